@@ -27,6 +27,8 @@ search_expr
   | search_expr and_op search_expr # and_search_expr
   | search_expr or_op search_expr # or_search_expr
   | search_expr implicit_and_op search_expr # implicit_and_search_expr
+  | search_key in_op LPAREN search_value+ RPAREN # in_search_expr
+  | search_key between_op search_value AND search_value # between_search_expr
   | search_key bin_op top_col_expr? # key_val_search_expr
   | search_key exists_op # exists_search_expr
   | top_col_expr # body_search_expr
@@ -53,28 +55,44 @@ exists_op
   | NOT EXISTS
   ;
 
+in_op
+  : IN
+  | NOT IN
+  ;
+
+between_op
+  : BETWEEN
+  | NOT BETWEEN
+  ;
+
 negation_op
   : NOT
   ;
 
 bin_op
   // BANG is not a valid operator, but don't want it to produce a parsing error.
-  : WS* (BANG | EQ | NEQ | GT | GTE | LT | LTE | COLON) WS*
+  : WS* (BANG | EQ | NEQ | REGEX_MATCH | NOT_REGEX_MATCH | GT | GTE | LT | LTE | COLON) WS*
   ;
 
 search_value
   : STRING
   | ID
   | VALUE
+  | IN
+  | BETWEEN
   ;
 
 AND : 'AND' ;
 OR : 'OR' ;
 NOT : 'NOT' ;
 EXISTS : 'EXISTS' ;
+IN : 'IN' ;
+BETWEEN : 'BETWEEN' ;
 BANG : '!' ;
 EQ : '=' ;
 NEQ : '!=' ;
+REGEX_MATCH : '=~' ;
+NOT_REGEX_MATCH : '!~' ;
 LT : '<' ;
 LTE : '<=' ;
 GT : '>' ;
@@ -84,6 +102,8 @@ RPAREN : ')' ;
 COLON : ':' ;
 ID : [A-Z_0-9.\-*]+ ;
 STRING : ('"' ( '\\"' | ~["] )* '"' | '\'' ( '\\\'' | ~['] )* '\'') | '`' ( '\\`' | ~[`] )* '`' ;
+// Commas are part of values so that free text such as `hello, world` is kept
+// intact. The values of an IN list are split on commas by the listeners.
 VALUE : ~[ \t\n\r\f=><:!)(]+ ;
 
 fragment WHITESPACE : [ \t\n\r\f] ;
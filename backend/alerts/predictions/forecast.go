@@ -0,0 +1,207 @@
+package predictions
+
+import (
+	"math"
+	"slices"
+)
+
+// seasonalPeriodsSeconds are the candidate seasonalities, checked from the longest to the shortest.
+var seasonalPeriodsSeconds = []int{7 * 24 * 60 * 60, 24 * 60 * 60, 60 * 60}
+
+const (
+	// scale of the median absolute deviation to estimate the standard deviation of normal residuals
+	madScale = 1.4826
+	// scale of the mean absolute deviation to estimate the standard deviation of normal residuals
+	meanAbsScale = 1.2533
+	// decomposition passes, each refining the trend with the previous pass's seasonality
+	decompositionPasses = 2
+)
+
+type forecastResult struct {
+	Yhat      []float64
+	YhatLower []float64
+	YhatUpper []float64
+}
+
+// forecast fits a robust seasonal decomposition to the series and returns the expected value
+// along with the uncertainty interval of each point. Missing values should be passed as NaN.
+// Medians are used throughout so that the anomalies being detected do not skew the fit.
+func forecast(y []float64, intervalSeconds int, changepointPriorScale float64, intervalWidth float64) forecastResult {
+	n := len(y)
+	result := forecastResult{
+		Yhat:      make([]float64, n),
+		YhatLower: make([]float64, n),
+		YhatUpper: make([]float64, n),
+	}
+	if n == 0 {
+		return result
+	}
+
+	period := seasonalPeriod(n, intervalSeconds)
+	window := trendWindow(n, period, changepointPriorScale)
+
+	seasonal := make([]float64, n)
+	var trend []float64
+	deseasonalized := make([]float64, n)
+	detrended := make([]float64, n)
+	for pass := 0; pass < decompositionPasses; pass++ {
+		for i := range y {
+			deseasonalized[i] = y[i] - seasonal[i]
+		}
+		trend = fillMissing(movingMedian(deseasonalized, window))
+		if period == 0 {
+			break
+		}
+		for i := range y {
+			detrended[i] = y[i] - trend[i]
+		}
+		seasonal = seasonalMedians(detrended, period)
+	}
+
+	residuals := make([]float64, 0, n)
+	for i := range y {
+		result.Yhat[i] = trend[i] + seasonal[i]
+		if !math.IsNaN(y[i]) {
+			residuals = append(residuals, y[i]-result.Yhat[i])
+		}
+	}
+
+	width := intervalZScore(intervalWidth) * residualScale(residuals)
+	for i := range y {
+		result.YhatLower[i] = result.Yhat[i] - width
+		result.YhatUpper[i] = result.Yhat[i] + width
+	}
+	return result
+}
+
+// seasonalPeriod returns the longest candidate seasonality, in buckets, that repeats at least twice in the series.
+// Returns 0 when the series is too short to have a seasonality.
+func seasonalPeriod(n int, intervalSeconds int) int {
+	if intervalSeconds <= 0 {
+		return 0
+	}
+	for _, seconds := range seasonalPeriodsSeconds {
+		period := seconds / intervalSeconds
+		if period >= 2 && n >= 2*period {
+			return period
+		}
+	}
+	return 0
+}
+
+// trendWindow returns the odd number of buckets used to smooth the trend. A larger
+// changepoint prior scale makes the trend more flexible by shortening the window.
+// The window spans at least one season so that the trend does not absorb the seasonality.
+func trendWindow(n int, period int, changepointPriorScale float64) int {
+	if changepointPriorScale < 0 {
+		changepointPriorScale = 0
+	}
+	window := int(math.Round(float64(n) * 0.5 / (1 + 10*changepointPriorScale)))
+	window = max(window, period, 3)
+	if window%2 == 0 {
+		window++
+	}
+	return window
+}
+
+// intervalZScore returns the number of standard deviations covering the interval width of a normal distribution.
+func intervalZScore(intervalWidth float64) float64 {
+	intervalWidth = min(max(intervalWidth, 0.01), 0.999)
+	return math.Sqrt2 * math.Erfinv(intervalWidth)
+}
+
+// residualScale estimates the standard deviation of the residuals, preferring the median absolute
+// deviation and falling back to the mean absolute deviation when most residuals are zero.
+func residualScale(residuals []float64) float64 {
+	if len(residuals) == 0 {
+		return 0
+	}
+	m := median(residuals)
+	deviations := make([]float64, len(residuals))
+	var sum float64
+	for i, r := range residuals {
+		deviations[i] = math.Abs(r - m)
+		sum += deviations[i]
+	}
+	if mad := median(deviations); mad > 0 {
+		return madScale * mad
+	}
+	return meanAbsScale * sum / float64(len(deviations))
+}
+
+// movingMedian returns the centered moving median of the series, narrowing the window at the edges.
+func movingMedian(y []float64, window int) []float64 {
+	half := window / 2
+	out := make([]float64, len(y))
+	values := make([]float64, 0, window)
+	for i := range y {
+		values = values[:0]
+		for j := max(0, i-half); j <= min(len(y)-1, i+half); j++ {
+			if !math.IsNaN(y[j]) {
+				values = append(values, y[j])
+			}
+		}
+		out[i] = median(values)
+	}
+	return out
+}
+
+// seasonalMedians returns the median of each phase of the season, centered around zero.
+func seasonalMedians(detrended []float64, period int) []float64 {
+	phases := make([]float64, period)
+	values := make([]float64, 0, len(detrended)/period+1)
+	for phase := 0; phase < period; phase++ {
+		values = values[:0]
+		for i := phase; i < len(detrended); i += period {
+			if !math.IsNaN(detrended[i]) {
+				values = append(values, detrended[i])
+			}
+		}
+		phases[phase] = median(values)
+	}
+	phases = fillMissing(phases)
+
+	var mean float64
+	for _, v := range phases {
+		mean += v
+	}
+	mean /= float64(period)
+
+	out := make([]float64, len(detrended))
+	for i := range out {
+		out[i] = phases[i%period] - mean
+	}
+	return out
+}
+
+// fillMissing replaces NaN values with the closest preceding value, or the first value for leading NaNs.
+// A series with no values is filled with zeros.
+func fillMissing(y []float64) []float64 {
+	first := slices.IndexFunc(y, func(v float64) bool { return !math.IsNaN(v) })
+	if first < 0 {
+		return make([]float64, len(y))
+	}
+	last := y[first]
+	for i := range y {
+		if math.IsNaN(y[i]) {
+			y[i] = last
+		} else {
+			last = y[i]
+		}
+	}
+	return y
+}
+
+// median returns the median of the values, or NaN when there are none. The values are not modified.
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
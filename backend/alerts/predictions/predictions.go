@@ -2,11 +2,14 @@ package predictions
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -36,65 +39,50 @@ type PredictionResult struct {
 	YHatUpper map[int]float64 `json:"yhat_upper"`
 }
 
+type Model string
+
+const (
+	ModelBuiltin Model = "builtin"
+	ModelRemote  Model = "remote"
+)
+
+// GetModel returns the model configured by PREDICTIONS_MODEL. When unset, the remote
+// predictions service is used if PREDICTIONS_ENDPOINT is configured.
+func GetModel() Model {
+	switch Model(env.Config.PredictionsModel) {
+	case ModelBuiltin:
+		return ModelBuiltin
+	case ModelRemote:
+		return ModelRemote
+	}
+	if env.Config.PredictionsEndpoint != "" {
+		return ModelRemote
+	}
+	return ModelBuiltin
+}
+
 func AddPredictions(ctx context.Context, metricBuckets []*modelInputs.MetricBucket, settings modelInputs.PredictionSettings) error {
+	model := GetModel()
+
 	// Partition all buckets by group, then get a prediction for each group
 	partitioned := lo.PartitionBy(metricBuckets, func(bucket *modelInputs.MetricBucket) string {
 		return strings.Join(bucket.Group, ",")
 	})
 
 	for _, buckets := range partitioned {
-		y := map[uint64]float64{}
-		ds := map[uint64]string{}
-		for _, b := range buckets {
-			var value, max, min float64
-			if b.MetricValue != nil && b.BucketMax != nil && b.BucketMin != nil {
-				value = *b.MetricValue
-				max = *b.BucketMax
-				min = *b.BucketMin
-			}
-			y[b.BucketID] = value
-			ds[b.BucketID] = time.Unix(int64((max+min)/2), 0).Format("2006-01-02T15:04:05")
-		}
-
-		marshaled, err := json.Marshal(PredictionInput{
-			ChangepointPriorScale: settings.ChangepointPriorScale,
-			IntervalWidth:         settings.IntervalWidth,
-			IntervalSeconds:       settings.IntervalSeconds,
-			Input: PredictionDataFrame{
-				DS: ds,
-				Y:  y,
-			},
-		})
-		if err != nil {
-			return err
+		var result *PredictionResult
+		var err error
+		if model == ModelRemote {
+			result, err = getRemotePredictions(ctx, buckets, settings)
+		} else {
+			result = getBuiltinPredictions(buckets, settings)
 		}
-
-		req, _ := http.NewRequest(http.MethodPost, env.Config.PredictionsEndpoint, bytes.NewReader(marshaled))
-		req = req.WithContext(ctx)
-		req.Header = http.Header{
-			"Content-Type": []string{"application/json"},
-		}
-
-		httpClient := http.Client{}
-		resp, err := httpClient.Do(req)
 		if err != nil {
 			return err
 		}
-		if resp.StatusCode != 200 {
-			return fmt.Errorf("prediction returned %d", resp.StatusCode)
-		}
-
-		b, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
-
-		var result PredictionResult
-		if err = json.Unmarshal(b, &result); err != nil {
-			return err
-		}
 
 		for idx, b := range buckets {
+			b.Yhat = pointy.Float64(result.YHat[idx])
 			if settings.ThresholdCondition != modelInputs.ThresholdConditionBelow {
 				b.YhatUpper = pointy.Float64(result.YHatUpper[idx])
 			}
@@ -106,3 +94,96 @@ func AddPredictions(ctx context.Context, metricBuckets []*modelInputs.MetricBuck
 
 	return nil
 }
+
+func getRemotePredictions(ctx context.Context, buckets []*modelInputs.MetricBucket, settings modelInputs.PredictionSettings) (*PredictionResult, error) {
+	y := map[uint64]float64{}
+	ds := map[uint64]string{}
+	for _, b := range buckets {
+		var value, max, min float64
+		if b.MetricValue != nil && b.BucketMax != nil && b.BucketMin != nil {
+			value = *b.MetricValue
+			max = *b.BucketMax
+			min = *b.BucketMin
+		}
+		y[b.BucketID] = value
+		ds[b.BucketID] = time.Unix(int64((max+min)/2), 0).Format("2006-01-02T15:04:05")
+	}
+
+	marshaled, err := json.Marshal(PredictionInput{
+		ChangepointPriorScale: settings.ChangepointPriorScale,
+		IntervalWidth:         settings.IntervalWidth,
+		IntervalSeconds:       settings.IntervalSeconds,
+		Input: PredictionDataFrame{
+			DS: ds,
+			Y:  y,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, env.Config.PredictionsEndpoint, bytes.NewReader(marshaled))
+	req = req.WithContext(ctx)
+	req.Header = http.Header{
+		"Content-Type": []string{"application/json"},
+	}
+
+	httpClient := http.Client{}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("prediction returned %d", resp.StatusCode)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result PredictionResult
+	if err = json.Unmarshal(b, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// getBuiltinPredictions forecasts the buckets in process, indexing the result by the position of each bucket.
+func getBuiltinPredictions(buckets []*modelInputs.MetricBucket, settings modelInputs.PredictionSettings) *PredictionResult {
+	// buckets are forecast in time order regardless of the order they were queried in
+	order := make([]int, len(buckets))
+	for idx := range order {
+		order[idx] = idx
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(buckets[a].BucketID, buckets[b].BucketID)
+	})
+
+	y := make([]float64, len(buckets))
+	for i, idx := range order {
+		y[i] = math.NaN()
+		if v := buckets[idx].MetricValue; v != nil {
+			y[i] = *v
+		}
+	}
+
+	forecasted := forecast(y, settings.IntervalSeconds, settings.ChangepointPriorScale, settings.IntervalWidth)
+
+	result := PredictionResult{
+		DS:        map[int]uint64{},
+		YHat:      map[int]float64{},
+		YHatLower: map[int]float64{},
+		YHatUpper: map[int]float64{},
+	}
+	for i, idx := range order {
+		b := buckets[idx]
+		if b.BucketMax != nil && b.BucketMin != nil {
+			result.DS[idx] = uint64((*b.BucketMax + *b.BucketMin) / 2)
+		}
+		result.YHat[idx] = forecasted.Yhat[i]
+		result.YHatLower[idx] = forecasted.YhatLower[i]
+		result.YHatUpper[idx] = forecasted.YhatUpper[i]
+	}
+	return &result
+}
@@ -0,0 +1,151 @@
+package predictions
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/env"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/stretchr/testify/assert"
+	"go.openly.dev/pointy"
+)
+
+const hourSeconds = 60 * 60
+
+// seasonalSeries returns an hourly series with a daily cycle, a slow trend and deterministic noise.
+func seasonalSeries(n int) []float64 {
+	y := make([]float64, n)
+	for i := range y {
+		daily := 20 * math.Sin(2*math.Pi*float64(i)/24)
+		noise := 2 * math.Sin(float64(i)*12.9898)
+		y[i] = 100 + 0.1*float64(i) + daily + noise
+	}
+	return y
+}
+
+func assertAnomalies(t *testing.T, y []float64, result forecastResult, anomalies map[int]bool) {
+	for i, v := range y {
+		outside := v > result.YhatUpper[i] || v < result.YhatLower[i]
+		assert.Equal(t, anomalies[i], outside, "bucket %d value %f band [%f, %f]", i, v, result.YhatLower[i], result.YhatUpper[i])
+	}
+}
+
+func TestForecastSeasonal(t *testing.T) {
+	y := seasonalSeries(100)
+	y[40] += 60
+	y[73] -= 60
+	y[99] += 60
+
+	result := forecast(y, hourSeconds, .25, .99)
+	assertAnomalies(t, y, result, map[int]bool{40: true, 73: true, 99: true})
+
+	// the fit follows the daily cycle rather than the spikes
+	assert.InDelta(t, 100+0.1*30+20*math.Sin(2*math.Pi*30/24), result.Yhat[30], 5)
+	assert.InDelta(t, 100+0.1*99+20*math.Sin(2*math.Pi*99/24), result.Yhat[99], 5)
+}
+
+func TestForecastLevelShift(t *testing.T) {
+	y := make([]float64, 100)
+	for i := range y {
+		y[i] = 50 + math.Sin(float64(i)*78.233)
+		if i >= 50 {
+			y[i] += 30
+		}
+	}
+	y[20] = 80
+
+	// minute buckets are too short for a seasonality, so only the trend is fit
+	result := forecast(y, 60, .25, .95)
+	assert.True(t, y[20] > result.YhatUpper[20])
+	assert.InDelta(t, 80, result.Yhat[90], 2)
+	assert.True(t, y[90] < result.YhatUpper[90] && y[90] > result.YhatLower[90])
+}
+
+func TestForecastMissingAndConstant(t *testing.T) {
+	y := make([]float64, 30)
+	for i := range y {
+		y[i] = 10
+	}
+	y[5] = math.NaN()
+	y[29] = 25
+
+	result := forecast(y, 60, .05, .8)
+	for i := range y {
+		assert.False(t, math.IsNaN(result.Yhat[i]))
+		assert.InDelta(t, 10, result.Yhat[i], 0.001)
+	}
+	assert.True(t, y[29] > result.YhatUpper[29])
+	assert.True(t, y[28] <= result.YhatUpper[28])
+
+	empty := forecast([]float64{math.NaN(), math.NaN()}, 60, .05, .8)
+	assert.Equal(t, []float64{0, 0}, empty.Yhat)
+}
+
+func TestSeasonalPeriod(t *testing.T) {
+	assert.Equal(t, 24, seasonalPeriod(100, hourSeconds))
+	assert.Equal(t, 12, seasonalPeriod(100, 5*60))
+	assert.Equal(t, 7, seasonalPeriod(100, 24*hourSeconds))
+	assert.Equal(t, 0, seasonalPeriod(10, hourSeconds))
+	assert.Equal(t, 0, seasonalPeriod(100, 0))
+}
+
+func TestAddPredictionsBuiltin(t *testing.T) {
+	env.Config.PredictionsModel = string(ModelBuiltin)
+	defer func() { env.Config.PredictionsModel = "" }()
+
+	y := seasonalSeries(100)
+	y[99] += 60
+
+	// buckets are returned out of order and for multiple groups
+	var buckets []*modelInputs.MetricBucket
+	for _, group := range []string{"a", "b"} {
+		for i := len(y) - 1; i >= 0; i-- {
+			value := y[i]
+			if group == "b" {
+				value = y[i] - 60*float64(i/99)
+			}
+			buckets = append(buckets, &modelInputs.MetricBucket{
+				BucketID:    uint64(i),
+				BucketMin:   pointy.Float64(float64(i * hourSeconds)),
+				BucketMax:   pointy.Float64(float64((i + 1) * hourSeconds)),
+				Group:       []string{group},
+				MetricValue: pointy.Float64(value),
+			})
+		}
+	}
+
+	err := AddPredictions(context.Background(), buckets, modelInputs.PredictionSettings{
+		ChangepointPriorScale: .25,
+		IntervalWidth:         .99,
+		ThresholdCondition:    modelInputs.ThresholdConditionAbove,
+		IntervalSeconds:       hourSeconds,
+	})
+	assert.NoError(t, err)
+
+	for _, b := range buckets {
+		assert.NotNil(t, b.Yhat)
+		assert.NotNil(t, b.YhatUpper)
+		assert.Nil(t, b.YhatLower)
+		if b.BucketID == 99 {
+			assert.Equal(t, b.Group[0] == "a", *b.MetricValue >= *b.YhatUpper)
+		}
+	}
+}
+
+func TestGetModel(t *testing.T) {
+	defer func() {
+		env.Config.PredictionsModel = ""
+		env.Config.PredictionsEndpoint = ""
+	}()
+
+	env.Config.PredictionsModel = ""
+	env.Config.PredictionsEndpoint = ""
+	assert.Equal(t, ModelBuiltin, GetModel())
+
+	env.Config.PredictionsEndpoint = "http://localhost:5001"
+	assert.Equal(t, ModelRemote, GetModel())
+
+	env.Config.PredictionsModel = string(ModelBuiltin)
+	assert.Equal(t, ModelBuiltin, GetModel())
+}
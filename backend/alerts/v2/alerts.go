@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/openlyinc/pointy"
 	e "github.com/pkg/errors"
 	"github.com/sendgrid/sendgrid-go"
 	log "github.com/sirupsen/logrus"
@@ -66,29 +67,34 @@ func SendAlerts(ctx context.Context, db *gorm.DB, mailClient *sendgrid.Client, l
 		ProjectName: *project.Name,
 	}
 
-	switch alert.ProductType {
-	case modelInputs.ProductTypeSessions:
-		sessionAlertInput, err := buildSessionAlertInput(ctx, db, &alertInput)
-		if err != nil {
-			return err
-		}
-		alertInput.SessionInput = sessionAlertInput
-	case modelInputs.ProductTypeErrors:
-		errorAlertInput, err := buildErrorAlertInput(ctx, db, &alertInput)
-		if err != nil {
-			return err
+	// release alerts are grouped by version rather than by a value of the product type
+	if alert.ReleaseMetric != nil {
+		alertInput.ReleaseInput = buildReleaseAlertInput(&alertInput)
+	} else {
+		switch alert.ProductType {
+		case modelInputs.ProductTypeSessions:
+			sessionAlertInput, err := buildSessionAlertInput(ctx, db, &alertInput)
+			if err != nil {
+				return err
+			}
+			alertInput.SessionInput = sessionAlertInput
+		case modelInputs.ProductTypeErrors:
+			errorAlertInput, err := buildErrorAlertInput(ctx, db, &alertInput)
+			if err != nil {
+				return err
+			}
+			alertInput.ErrorInput = errorAlertInput
+		case modelInputs.ProductTypeLogs:
+			alertInput.LogInput = buildLogAlertInput(ctx, db, &alertInput)
+		case modelInputs.ProductTypeTraces:
+			alertInput.TraceInput = buildTraceAlertInput(ctx, db, &alertInput)
+		case modelInputs.ProductTypeMetrics:
+			alertInput.MetricInput = buildMetricAlertInput(ctx, db, &alertInput)
+		case modelInputs.ProductTypeEvents:
+			// nothing extra needed
+		default:
+			return e.New("invalid product type")
 		}
-		alertInput.ErrorInput = errorAlertInput
-	case modelInputs.ProductTypeLogs:
-		alertInput.LogInput = buildLogAlertInput(ctx, db, &alertInput)
-	case modelInputs.ProductTypeTraces:
-		alertInput.TraceInput = buildTraceAlertInput(ctx, db, &alertInput)
-	case modelInputs.ProductTypeMetrics:
-		alertInput.MetricInput = buildMetricAlertInput(ctx, db, &alertInput)
-	case modelInputs.ProductTypeEvents:
-		// nothing extra needed
-	default:
-		return e.New("invalid product type")
 	}
 
 	for _, destinations := range destinationsByType {
@@ -177,6 +183,11 @@ func buildErrorAlertInput(ctx context.Context, db *gorm.DB, alertInput *destinat
 		stacktrace = *errorObject.StackTrace
 	}
 
+	owner, err := buildErrorGroupOwner(ctx, db, errorGroup)
+	if err != nil {
+		return nil, err
+	}
+
 	return &destinationsV2.ErrorInput{
 		Event:             errorObject.Event,
 		Stacktrace:        stacktrace,
@@ -188,9 +199,42 @@ func buildErrorAlertInput(ctx context.Context, db *gorm.DB, alertInput *destinat
 		SessionIdentifier: sessionIdentifier,
 		SessionLink:       sessionUrl,
 		SessionExcluded:   sessionExcluded,
+		Regressed:         alertInput.Alert.ThresholdType == modelInputs.ThresholdTypeRegression,
+		ServiceVersion:    errorObject.ServiceVersion,
+		Owner:             owner,
 	}, nil
 }
 
+func buildErrorGroupOwner(ctx context.Context, db *gorm.DB, errorGroup *model.ErrorGroup) (*destinationsV2.OwnerInput, error) {
+	if errorGroup.AssigneeAdminID != nil {
+		var admin model.Admin
+		if err := db.WithContext(ctx).Where(&model.Admin{Model: model.Model{ID: *errorGroup.AssigneeAdminID}}).Take(&admin).Error; err != nil {
+			return nil, err
+		}
+		owner := &destinationsV2.OwnerInput{Name: pointy.StringValue(admin.Name, pointy.StringValue(admin.Email, ""))}
+		if admin.Email != nil {
+			owner.Emails = append(owner.Emails, *admin.Email)
+		}
+		return owner, nil
+	}
+
+	if errorGroup.AssigneeTeamID != nil {
+		var team model.Team
+		if err := db.WithContext(ctx).Preload("Admins").Where(&model.Team{Model: model.Model{ID: *errorGroup.AssigneeTeamID}}).Take(&team).Error; err != nil {
+			return nil, err
+		}
+		owner := &destinationsV2.OwnerInput{Name: team.Name, SlackMentionID: pointy.StringValue(team.SlackMentionID, "")}
+		for _, admin := range team.Admins {
+			if admin.Email != nil {
+				owner.Emails = append(owner.Emails, *admin.Email)
+			}
+		}
+		return owner, nil
+	}
+
+	return nil, nil
+}
+
 func buildLogAlertInput(ctx context.Context, db *gorm.DB, alertInput *destinationsV2.AlertInput) *destinationsV2.LogInput {
 	frontendURL := env.Config.FrontendUri
 	queryStr := url.QueryEscape(*alertInput.Alert.Query)
@@ -235,6 +279,15 @@ func buildMetricAlertInput(ctx context.Context, db *gorm.DB, alertInput *destina
 	}
 }
 
+func buildReleaseAlertInput(alertInput *destinationsV2.AlertInput) *destinationsV2.ReleaseInput {
+	queryStr := url.QueryEscape(fmt.Sprintf("service_version=%s", alertInput.GroupValue))
+	return &destinationsV2.ReleaseInput{
+		Version:    alertInput.GroupValue,
+		Metric:     *alertInput.Alert.ReleaseMetric,
+		ErrorsLink: fmt.Sprintf("%s/%d/errors?query=%s", env.Config.FrontendUri, alertInput.Alert.ProjectID, queryStr),
+	}
+}
+
 func SendNotifications(ctx context.Context, db *gorm.DB, mailClient *sendgrid.Client, lambdaClient *lambda.Client, notificationInput destinationsV2.NotificationInput, destinations []*model.AlertDestination) {
 	destinationsByType := make(map[modelInputs.AlertDestinationType][]model.AlertDestination)
 	for _, destination := range destinations {
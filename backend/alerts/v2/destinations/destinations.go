@@ -1,6 +1,8 @@
 package destinationsV2
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/highlight-run/highlight/backend/model"
@@ -38,6 +40,7 @@ type AlertInput struct {
 	LogInput     *LogInput
 	TraceInput   *TraceInput
 	MetricInput  *MetricInput
+	ReleaseInput *ReleaseInput
 	WorkspaceID  int
 }
 
@@ -59,6 +62,44 @@ type ErrorInput struct {
 	SessionIdentifier string
 	SessionLink       string
 	SessionExcluded   bool
+	// set for regression alerts, sent when a resolved error group reopens
+	Regressed      bool
+	ServiceVersion string
+	// the admin or team assigned to the error group, if any
+	Owner *OwnerInput
+}
+
+type OwnerInput struct {
+	// the name of the admin or team
+	Name string
+	// the email of the admin, or of the admins of the team
+	Emails []string
+	// the Slack user or user group of a team
+	SlackMentionID string
+}
+
+// SlackMention mentions the owner in a Slack message, or returns its name if it has no Slack mention.
+func (o *OwnerInput) SlackMention() string {
+	switch {
+	case o.SlackMentionID == "":
+		return o.Name
+	case strings.HasPrefix(o.SlackMentionID, "S"):
+		// user group ids start with S
+		return fmt.Sprintf("<!subteam^%s>", o.SlackMentionID)
+	default:
+		return fmt.Sprintf("<@%s>", o.SlackMentionID)
+	}
+}
+
+// Title is the heading of error alert messages.
+func (i *ErrorInput) Title(alertValue float64) string {
+	if i.Regressed {
+		if i.ServiceVersion != "" {
+			return fmt.Sprintf("Regression Alert: Resolved Error Reopened in %s", i.ServiceVersion)
+		}
+		return "Regression Alert: Resolved Error Reopened"
+	}
+	return fmt.Sprintf("Error Alert: %d Recent Occurrences", int(alertValue))
 }
 
 type LogInput struct {
@@ -76,3 +117,32 @@ type TraceInput struct {
 type MetricInput struct {
 	DashboardLink string
 }
+
+// set instead of the product type input for alerts on the health of the latest release
+type ReleaseInput struct {
+	Version    string
+	Metric     modelInputs.ReleaseMetric
+	ErrorsLink string
+}
+
+// Title is the heading of release alert messages.
+func (i *ReleaseInput) Title() string {
+	return fmt.Sprintf("Release Alert: %s", i.Version)
+}
+
+// Description explains the release metric that crossed the threshold.
+func (i *ReleaseInput) Description(alertValue float64, threshold *float64) string {
+	var thresholdValue float64
+	if threshold != nil {
+		thresholdValue = *threshold
+	}
+	switch i.Metric {
+	case modelInputs.ReleaseMetricNewErrorGroups:
+		return fmt.Sprintf("%d new error groups were first seen in %s. Threshold: %d", int(alertValue), i.Version, int(thresholdValue))
+	case modelInputs.ReleaseMetricErrorRateChange:
+		return fmt.Sprintf("The error rate of %s changed by %.1f%% compared to the previous release. Threshold: %.1f%%", i.Version, alertValue, thresholdValue)
+	case modelInputs.ReleaseMetricCrashFreeSessionRate:
+		return fmt.Sprintf("%.1f%% of the sessions of %s were crash-free. Threshold: %.1f%%", alertValue, i.Version, thresholdValue)
+	}
+	return fmt.Sprintf("%s of %s was %f. Threshold: %f", i.Metric, i.Version, alertValue, thresholdValue)
+}
@@ -51,6 +51,11 @@ func SendAlerts(ctx context.Context, discordGuildId *string, alertInput *destina
 		return
 	}
 
+	if alertInput.ReleaseInput != nil {
+		sendReleaseAlert(ctx, *discordGuildId, alertInput, destinations)
+		return
+	}
+
 	switch alertInput.Alert.ProductType {
 	case modelInputs.ProductTypeSessions:
 		sendSessionAlert(ctx, *discordGuildId, alertInput, destinations)
@@ -143,7 +148,7 @@ func sendErrorAlert(ctx context.Context, discordGuildId string, alertInput *dest
 	embed.Color = RED_ALERT
 
 	// HEADER
-	embed.Title = fmt.Sprintf("**Error Alert: %d Recent Occurrences**", int(alertInput.AlertValue))
+	embed.Title = fmt.Sprintf("**%s**", alertInput.ErrorInput.Title(alertInput.AlertValue))
 
 	// BODY
 	// location
@@ -177,6 +182,9 @@ func sendErrorAlert(ctx context.Context, discordGuildId string, alertInput *dest
 	}
 
 	embed.Description = fmt.Sprintf("**[Error event in %s](%s)**\n```%s```\n%s", locationName, alertInput.ErrorInput.ErrorLink, errorEvent, sessionString)
+	if alertInput.ErrorInput.Owner != nil {
+		embed.Description += fmt.Sprintf("\n**Owner** %s", alertInput.ErrorInput.Owner.Name)
+	}
 
 	// action buttons
 	actionButtons := discordgo.ActionsRow{Components: []discordgo.MessageComponent{}}
@@ -479,6 +487,37 @@ func sendEventAlert(ctx context.Context, discordGuildId string, alertInput *dest
 	deliverAlerts(ctx, discordGuildId, &messageSend, destinations)
 }
 
+func sendReleaseAlert(ctx context.Context, discordGuildId string, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	embed := newMessageEmbed()
+	embed.Color = RED_ALERT
+
+	// HEADER
+	embed.Title = alertInput.ReleaseInput.Title()
+
+	// BODY
+	embed.Description = alertInput.ReleaseInput.Description(alertInput.AlertValue, alertInput.Alert.ThresholdValue)
+
+	// action buttons
+	actionButtons := discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.Button{
+				Emoji:    highlightEmoji,
+				Label:    "View Errors",
+				Style:    discordgo.LinkButton,
+				Disabled: false,
+				URL:      alertInput.ReleaseInput.ErrorsLink,
+			},
+		},
+	}
+
+	messageSend := discordgo.MessageSend{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: []discordgo.MessageComponent{actionButtons},
+	}
+
+	deliverAlerts(ctx, discordGuildId, &messageSend, destinations)
+}
+
 func SendNotifications(ctx context.Context, discordGuildId *string, notificationInput destinationsV2.NotificationInput, destinations []model.AlertDestination) {
 	if discordGuildId == nil {
 		log.WithContext(ctx).Error("discord access token is nil")
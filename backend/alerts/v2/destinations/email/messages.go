@@ -15,6 +15,7 @@ import (
 	"github.com/sendgrid/sendgrid-go"
 	log "github.com/sirupsen/logrus"
 	"regexp"
+	"strings"
 )
 
 type EmailData struct {
@@ -30,6 +31,11 @@ func SendAlerts(ctx context.Context, mailClient *sendgrid.Client, lambdaClient *
 	span.SetAttribute("product_type", alertInput.Alert.ProductType)
 	defer span.Finish()
 
+	if alertInput.ReleaseInput != nil {
+		sendReleaseAlert(ctx, mailClient, lambdaClient, alertInput, destinations)
+		return
+	}
+
 	switch alertInput.Alert.ProductType {
 	case modelInputs.ProductTypeSessions:
 		sendSessionAlert(ctx, mailClient, lambdaClient, alertInput, destinations)
@@ -88,8 +94,31 @@ func sendErrorAlert(ctx context.Context, mailClient *sendgrid.Client, lambdaClie
 			"serviceName":     alertInput.ErrorInput.ServiceName,
 			"sessionExcluded": alertInput.ErrorInput.SessionExcluded,
 			"sessionLink":     alertInput.ErrorInput.SessionLink,
+			"regressed":       alertInput.ErrorInput.Regressed,
+			"serviceVersion":  alertInput.ErrorInput.ServiceVersion,
 		},
 	}
+	if alertInput.ErrorInput.Regressed {
+		emailData.SubjectLine = fmt.Sprintf("%s Alert: %s", alertInput.Alert.Name, alertInput.ErrorInput.Title(alertInput.AlertValue))
+	}
+
+	// the owner of the error group is notified along with the alert's recipients
+	if owner := alertInput.ErrorInput.Owner; owner != nil {
+		emailData.TemplateData["owner"] = owner.Name
+		recipients := lo.SliceToMap(destinations, func(destination model.AlertDestination) (string, bool) {
+			return strings.ToLower(destination.TypeID), true
+		})
+		for _, email := range owner.Emails {
+			if !recipients[strings.ToLower(email)] {
+				recipients[strings.ToLower(email)] = true
+				destinations = append(destinations, model.AlertDestination{
+					DestinationType: modelInputs.AlertDestinationTypeEmail,
+					TypeID:          email,
+					TypeName:        email,
+				})
+			}
+		}
+	}
 
 	deliverAlerts(ctx, mailClient, lambdaClient, emailData, destinations)
 }
@@ -249,6 +278,32 @@ func sendEventAlert(ctx context.Context, mailClient *sendgrid.Client, lambdaClie
 	deliverAlerts(ctx, mailClient, lambdaClient, emailData, destinations)
 }
 
+// sendReleaseAlert reuses the metrics alert template, describing the release metric as the function.
+func sendReleaseAlert(ctx context.Context, mailClient *sendgrid.Client, lambdaClient *lambda.Client, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	var thresholdValue float64
+	if alertInput.Alert.ThresholdValue != nil {
+		thresholdValue = *alertInput.Alert.ThresholdValue
+	}
+
+	emailData := &EmailData{
+		SubjectLine: alertInput.ReleaseInput.Title(),
+		Template:    lambda.ReactEmailTemplateMetricsAlert,
+		TemplateData: map[string]interface{}{
+			"alertLink":      alertInput.AlertLink,
+			"alertName":      alertInput.Alert.Name,
+			"belowThreshold": alertInput.Alert.ThresholdCondition == modelInputs.ThresholdConditionBelow,
+			"functionValue":  alertInput.AlertValue,
+			"functionName":   alertInput.ReleaseInput.Metric.String(),
+			"dashboardsLink": alertInput.ReleaseInput.ErrorsLink,
+			"projectName":    alertInput.ProjectName,
+			"query":          fmt.Sprintf("service_version=%s", alertInput.ReleaseInput.Version),
+			"thresholdValue": thresholdValue,
+		},
+	}
+
+	deliverAlerts(ctx, mailClient, lambdaClient, emailData, destinations)
+}
+
 func SendNotifications(ctx context.Context, mailClient *sendgrid.Client, lambdaClient *lambda.Client, notificationInput destinationsV2.NotificationInput, destinations []model.AlertDestination) {
 	switch notificationInput.NotificationType {
 	case destinationsV2.NotificationTypeAlertCreated:
@@ -29,6 +29,11 @@ func SendAlerts(ctx context.Context, microsoftTeamsTenantId *string, alertInput
 		return
 	}
 
+	if alertInput.ReleaseInput != nil {
+		sendReleaseAlert(ctx, *microsoftTeamsTenantId, alertInput, destinations)
+		return
+	}
+
 	switch alertInput.Alert.ProductType {
 	case modelInputs.ProductTypeSessions:
 		sendSessionAlert(ctx, *microsoftTeamsTenantId, alertInput, destinations)
@@ -106,12 +111,19 @@ func sendErrorAlert(ctx context.Context, microsoftTeamsTenantId string, alertInp
 		)
 	}
 
+	owner := "Unassigned"
+	if alertInput.ErrorInput.Owner != nil {
+		owner = alertInput.ErrorInput.Owner.Name
+	}
+
 	messagePayload := microsoftteamsV2_templates.ErrorAlertPayload{
+		Title:           alertInput.ErrorInput.Title(alertInput.AlertValue),
 		ErrorCount:      int(alertInput.AlertValue),
 		Location:        locationName,
 		ErrorLink:       alertInput.ErrorInput.ErrorLink,
 		Event:           errorEvent,
 		SessionLinkText: sessionLinkText,
+		Owner:           owner,
 		ResolveLink:     routing.AttachQueryParam(ctx, alertInput.ErrorInput.ErrorLink, "action", "resolved"),
 		IgnoreLink:      routing.AttachQueryParam(ctx, alertInput.ErrorInput.ErrorLink, "action", "ignored"),
 		SnoozeLink:      routing.AttachQueryParam(ctx, alertInput.ErrorInput.ErrorLink, "action", "snooze"),
@@ -324,6 +336,16 @@ func sendEventAlert(ctx context.Context, microsoftTeamsTenantId string, alertInp
 	deliverAlerts(ctx, microsoftTeamsTenantId, microsoftteamsV2_templates.MetricAlertMessageTemplate, messagePayload, destinations)
 }
 
+func sendReleaseAlert(ctx context.Context, microsoftTeamsTenantId string, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	messagePayload := microsoftteamsV2_templates.ReleaseAlertPayload{
+		Title:       alertInput.ReleaseInput.Title(),
+		Description: alertInput.ReleaseInput.Description(alertInput.AlertValue, alertInput.Alert.ThresholdValue),
+		ErrorsLink:  alertInput.ReleaseInput.ErrorsLink,
+	}
+
+	deliverAlerts(ctx, microsoftTeamsTenantId, microsoftteamsV2_templates.ReleaseAlertMessageTemplate, messagePayload, destinations)
+}
+
 func SendNotifications(ctx context.Context, microsoftTeamsTenantId *string, notificationInput destinationsV2.NotificationInput, destinations []model.AlertDestination) {
 	if microsoftTeamsTenantId == nil {
 		log.WithContext(ctx).Error("microsoft teams access token is nil")
@@ -1,11 +1,13 @@
 package microsoftteamsV2_templates
 
 type ErrorAlertPayload struct {
+	Title           string
 	ErrorCount      int
 	Location        string
 	ErrorLink       string
 	Event           string
 	SessionLinkText string
+	Owner           string
 	ResolveLink     string
 	IgnoreLink      string
 	SnoozeLink      string
@@ -20,7 +22,7 @@ var ErrorAlertMessageTemplate = []byte(`{
 		"type": "TextBlock",
 		"size": "Large",
 		"weight": "Bolder",
-		"text": "Error Alert: {{.ErrorCount}} Recent Occurances"
+		"text": "{{.Title}}"
 		},
 		{
 		"type": "TextBlock",
@@ -35,6 +37,10 @@ var ErrorAlertMessageTemplate = []byte(`{
 		{
 		"type": "TextBlock",
 		"text": "**Session** {{.SessionLinkText}}"
+		},
+		{
+		"type": "TextBlock",
+		"text": "**Owner** {{.Owner}}"
 		}
 	],
 	"actions": [
@@ -0,0 +1,32 @@
+package microsoftteamsV2_templates
+
+type ReleaseAlertPayload struct {
+	Title       string
+	Description string
+	ErrorsLink  string
+}
+
+var ReleaseAlertMessageTemplate = []byte(`{
+	"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+	"type": "AdaptiveCard",
+	"version": "1.6",
+	"body": [
+		{
+			"type":   "TextBlock",
+			"size":   "Large",
+			"weight": "Bolder",
+			"text":   "{{.Title}}"
+		},
+		{
+			"type":   "TextBlock",
+			"text":   "{{.Description}}"
+		}
+	],
+	"actions": [
+		{
+			"type":  "Action.OpenUrl",
+			"title": "View Errors",
+			"url":   "{{.ErrorsLink}}"
+		}
+	]
+  }`)
@@ -41,6 +41,11 @@ func SendAlerts(ctx context.Context, slackAccessToken *string, alertInput *desti
 		return
 	}
 
+	if alertInput.ReleaseInput != nil {
+		sendReleaseAlert(ctx, *slackAccessToken, alertInput, destinations)
+		return
+	}
+
 	switch alertInput.Alert.ProductType {
 	case modelInputs.ProductTypeSessions:
 		sendSessionAlert(ctx, *slackAccessToken, alertInput, destinations)
@@ -144,7 +149,10 @@ func sendErrorAlert(ctx context.Context, slackAccessToken string, alertInput *de
 	var headerBlockSet []slack.Block
 
 	previewText := fmt.Sprintf("Error Alert: %s", alertInput.ErrorInput.Event)
-	headerBlock := slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("*Error Alert: %d Recent Occurrences*", int(alertInput.AlertValue)), false, false)
+	if alertInput.ErrorInput.Regressed {
+		previewText = fmt.Sprintf("Regression Alert: %s", alertInput.ErrorInput.Event)
+	}
+	headerBlock := slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("*%s*", alertInput.ErrorInput.Title(alertInput.AlertValue)), false, false)
 	headerBlockSet = append(headerBlockSet, slack.NewSectionBlock(headerBlock, nil, nil))
 
 	// BODY
@@ -180,6 +188,11 @@ func sendErrorAlert(ctx context.Context, slackAccessToken string, alertInput *de
 		sessionString = fmt.Sprintf("*Session* <%s|%s>", alertInput.ErrorInput.SessionLink, sessionText)
 	}
 
+	// owner
+	if alertInput.ErrorInput.Owner != nil {
+		sessionString += fmt.Sprintf("\n*Owner* %s", alertInput.ErrorInput.Owner.SlackMention())
+	}
+
 	eventBlock := slack.NewTextBlockObject(
 		slack.MarkdownType,
 		fmt.Sprintf("*<%s|Error event in %s>*\n```%s```\n%s", alertInput.ErrorInput.ErrorLink, locationName, errorEvent, sessionString),
@@ -578,6 +591,45 @@ func sendEventAlert(ctx context.Context, slackAccessToken string, alertInput *de
 	deliverAlerts(ctx, slackAccessToken, destinations, previewText, headerBlockSet, attachment)
 }
 
+func sendReleaseAlert(ctx context.Context, slackAccessToken string, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	previewText := alertInput.ReleaseInput.Title()
+
+	// HEADER
+	var headerBlockSet []slack.Block
+	headerBlock := slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("*%s* Alert for *%s*", alertInput.Alert.Name, alertInput.ReleaseInput.Version), false, false)
+	headerBlockSet = append(headerBlockSet, slack.NewSectionBlock(headerBlock, nil, nil))
+
+	// BODY
+	var bodyBlockSet []slack.Block
+
+	releaseBlock := slack.NewTextBlockObject(slack.MarkdownType, alertInput.ReleaseInput.Description(alertInput.AlertValue, alertInput.Alert.ThresholdValue), false, false)
+	bodyBlockSet = append(bodyBlockSet, slack.NewSectionBlock(releaseBlock, nil, nil))
+
+	// actions
+	var actionBlocks []slack.BlockElement
+	button := slack.NewButtonBlockElement(
+		"",
+		"click",
+		slack.NewTextBlockObject(
+			slack.PlainTextType,
+			"View Errors",
+			false,
+			false,
+		),
+	)
+	button.URL = alertInput.ReleaseInput.ErrorsLink
+	actionBlocks = append(actionBlocks, button)
+
+	bodyBlockSet = append(bodyBlockSet, slack.NewActionBlock("", actionBlocks...))
+
+	attachment := &slack.Attachment{
+		Color:  RED_ALERT,
+		Blocks: slack.Blocks{BlockSet: bodyBlockSet},
+	}
+
+	deliverAlerts(ctx, slackAccessToken, destinations, previewText, headerBlockSet, attachment)
+}
+
 func SendNotifications(ctx context.Context, slackAccessToken *string, notificationInput destinationsV2.NotificationInput, destinations []model.AlertDestination) {
 	if slackAccessToken == nil {
 		log.WithContext(ctx).Error("slack access token is nil")
@@ -25,6 +25,11 @@ func SendAlerts(ctx context.Context, alertInput *destinationsV2.AlertInput, dest
 	span.SetAttribute("product_type", alertInput.Alert.ProductType)
 	defer span.Finish()
 
+	if alertInput.ReleaseInput != nil {
+		sendReleaseAlert(ctx, alertInput, destinations)
+		return
+	}
+
 	switch alertInput.Alert.ProductType {
 	case modelInputs.ProductTypeSessions:
 		sendSessionAlert(ctx, alertInput, destinations)
@@ -88,6 +93,10 @@ type ErrorAlertPayload struct {
 	ErrorResolveURL string
 	ErrorIgnoreURL  string
 	ErrorSnoozeURL  string
+	Regressed       bool
+	ServiceVersion  string
+	Owner           string
+	OwnerEmails     []string
 }
 
 func sendErrorAlert(ctx context.Context, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
@@ -110,6 +119,12 @@ func sendErrorAlert(ctx context.Context, alertInput *destinationsV2.AlertInput,
 		ErrorResolveURL: routing.AttachQueryParam(ctx, alertInput.ErrorInput.ErrorLink, "action", "resolved"),
 		ErrorIgnoreURL:  routing.AttachQueryParam(ctx, alertInput.ErrorInput.ErrorLink, "action", "ignored"),
 		ErrorSnoozeURL:  routing.AttachQueryParam(ctx, alertInput.ErrorInput.ErrorLink, "action", "snooze"),
+		Regressed:       alertInput.ErrorInput.Regressed,
+		ServiceVersion:  alertInput.ErrorInput.ServiceVersion,
+	}
+	if alertInput.ErrorInput.Owner != nil {
+		messagePayload.Owner = alertInput.ErrorInput.Owner.Name
+		messagePayload.OwnerEmails = alertInput.ErrorInput.Owner.Emails
 	}
 
 	sendAlerts(ctx, messagePayload, destinations)
@@ -279,6 +294,37 @@ func sendEventAlert(ctx context.Context, alertInput *destinationsV2.AlertInput,
 	sendAlerts(ctx, messagePayload, destinations)
 }
 
+type ReleaseAlertPayload struct {
+	Event          string
+	AlertName      string
+	Version        string
+	Metric         modelInputs.ReleaseMetric
+	Value          float64
+	Threshold      float64
+	BelowThreshold bool
+	ErrorsURL      string
+}
+
+func sendReleaseAlert(ctx context.Context, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	var threshold float64
+	if alertInput.Alert.ThresholdValue != nil {
+		threshold = *alertInput.Alert.ThresholdValue
+	}
+
+	messagePayload := ReleaseAlertPayload{
+		Event:          model.AlertType.RELEASES,
+		AlertName:      alertInput.Alert.Name,
+		Version:        alertInput.ReleaseInput.Version,
+		Metric:         alertInput.ReleaseInput.Metric,
+		Value:          alertInput.AlertValue,
+		Threshold:      threshold,
+		BelowThreshold: alertInput.Alert.ThresholdCondition == modelInputs.ThresholdConditionBelow,
+		ErrorsURL:      alertInput.ReleaseInput.ErrorsLink,
+	}
+
+	sendAlerts(ctx, messagePayload, destinations)
+}
+
 func SendNotifications(ctx context.Context, notificationInput destinationsV2.NotificationInput, destinations []model.AlertDestination) {
 	switch notificationInput.NotificationType {
 	case destinationsV2.NotificationTypeAlertCreated:
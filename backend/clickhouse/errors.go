@@ -52,6 +52,7 @@ type ClickhouseErrorObject struct {
 }
 
 const ErrorGroupsTable = "error_groups"
+const ErrorGroupStatusMerged = "MERGED"
 const ErrorObjectsTable = "error_objects"
 const errorsTimeRangeField = "error-field_timestamp"
 
@@ -73,6 +74,10 @@ func (client *Client) WriteErrorGroups(ctx context.Context, groups []*model.Erro
 			Status:    string(group.State),
 			Type:      group.Type,
 		}
+		// merged groups are hidden from searches by status
+		if group.MergedIntoID != nil {
+			chEg.Status = ErrorGroupStatusMerged
+		}
 		if group.SnoozedUntil != nil {
 			chEg.SnoozedUntil = group.SnoozedUntil.UTC().UnixMicro()
 		} else {
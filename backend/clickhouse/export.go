@@ -0,0 +1,326 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	e "github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/highlight-run/highlight/backend/parser"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/util"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// ExportCursor is the position of a row in an export, ordered by timestamp and then id.
+type ExportCursor struct {
+	Timestamp time.Time
+	ID        string
+}
+
+// String encodes the cursor with nanosecond precision, the precision of trace timestamps.
+func (c ExportCursor) String() string {
+	return fmt.Sprintf("%d,%s", c.Timestamp.UnixNano(), c.ID)
+}
+
+func ParseExportCursor(cursor string) (*ExportCursor, error) {
+	timestamp, id, found := strings.Cut(cursor, ",")
+	if !found {
+		return nil, e.Errorf("invalid export cursor %s", cursor)
+	}
+	nanos, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, e.Wrapf(err, "invalid export cursor %s", cursor)
+	}
+	return &ExportCursor{Timestamp: time.Unix(0, nanos).UTC(), ID: id}, nil
+}
+
+// ExportRow is a log, span or error written by an export job.
+type ExportRow interface {
+	Cursor() ExportCursor
+}
+
+type LogExportRow struct {
+	Timestamp       time.Time         `json:"timestamp" parquet:"timestamp,timestamp(nanosecond)"`
+	ProjectID       uint32            `json:"project_id" parquet:"project_id"`
+	UUID            string            `json:"uuid" parquet:"uuid"`
+	TraceID         string            `json:"trace_id" parquet:"trace_id"`
+	SpanID          string            `json:"span_id" parquet:"span_id"`
+	SecureSessionID string            `json:"secure_session_id" parquet:"secure_session_id"`
+	Level           string            `json:"level" parquet:"level"`
+	Source          string            `json:"source" parquet:"source"`
+	ServiceName     string            `json:"service_name" parquet:"service_name"`
+	ServiceVersion  string            `json:"service_version" parquet:"service_version"`
+	Environment     string            `json:"environment" parquet:"environment"`
+	Message         string            `json:"message" parquet:"message"`
+	Attributes      map[string]string `json:"attributes" parquet:"attributes"`
+}
+
+func (r *LogExportRow) Cursor() ExportCursor {
+	return ExportCursor{Timestamp: r.Timestamp, ID: r.UUID}
+}
+
+type TraceEventExportRow struct {
+	Timestamp  time.Time         `json:"timestamp" parquet:"timestamp,timestamp(nanosecond)"`
+	Name       string            `json:"name" parquet:"name"`
+	Attributes map[string]string `json:"attributes" parquet:"attributes"`
+}
+
+type TraceExportRow struct {
+	Timestamp       time.Time             `json:"timestamp" parquet:"timestamp,timestamp(nanosecond)"`
+	ProjectID       uint32                `json:"project_id" parquet:"project_id"`
+	UUID            string                `json:"uuid" parquet:"uuid"`
+	TraceID         string                `json:"trace_id" parquet:"trace_id"`
+	SpanID          string                `json:"span_id" parquet:"span_id"`
+	ParentSpanID    string                `json:"parent_span_id" parquet:"parent_span_id"`
+	SecureSessionID string                `json:"secure_session_id" parquet:"secure_session_id"`
+	SpanName        string                `json:"span_name" parquet:"span_name"`
+	SpanKind        string                `json:"span_kind" parquet:"span_kind"`
+	Duration        int64                 `json:"duration" parquet:"duration"`
+	ServiceName     string                `json:"service_name" parquet:"service_name"`
+	ServiceVersion  string                `json:"service_version" parquet:"service_version"`
+	Environment     string                `json:"environment" parquet:"environment"`
+	StatusCode      string                `json:"status_code" parquet:"status_code"`
+	StatusMessage   string                `json:"status_message" parquet:"status_message"`
+	HasErrors       bool                  `json:"has_errors" parquet:"has_errors"`
+	Attributes      map[string]string     `json:"attributes" parquet:"attributes"`
+	Events          []TraceEventExportRow `json:"events" parquet:"events,list"`
+}
+
+func (r *TraceExportRow) Cursor() ExportCursor {
+	return ExportCursor{Timestamp: r.Timestamp, ID: r.UUID}
+}
+
+type ErrorExportRow struct {
+	Timestamp       time.Time `json:"timestamp" parquet:"timestamp,timestamp(nanosecond)"`
+	ProjectID       uint32    `json:"project_id" parquet:"project_id"`
+	ID              int64     `json:"id" parquet:"id"`
+	ErrorGroupID    int64     `json:"error_group_id" parquet:"error_group_id"`
+	SecureID        string    `json:"secure_id" parquet:"secure_id"`
+	Event           string    `json:"event" parquet:"event"`
+	Type            string    `json:"type" parquet:"type"`
+	Status          string    `json:"status" parquet:"status"`
+	Browser         string    `json:"browser" parquet:"browser"`
+	OSName          string    `json:"os_name" parquet:"os_name"`
+	Environment     string    `json:"environment" parquet:"environment"`
+	ServiceName     string    `json:"service_name" parquet:"service_name"`
+	ServiceVersion  string    `json:"service_version" parquet:"service_version"`
+	ClientID        string    `json:"client_id" parquet:"client_id"`
+	VisitedURL      string    `json:"visited_url" parquet:"visited_url"`
+	TraceID         string    `json:"trace_id" parquet:"trace_id"`
+	SecureSessionID string    `json:"secure_session_id" parquet:"secure_session_id"`
+}
+
+func (r *ErrorExportRow) Cursor() ExportCursor {
+	return ExportCursor{Timestamp: r.Timestamp, ID: strconv.FormatInt(r.ID, 10)}
+}
+
+type exportTable struct {
+	config  model.TableConfig
+	columns []string
+	// the expression ordering rows with the same timestamp, selected as `UUID`
+	idColumn string
+	scan     func(rows driver.Rows) (ExportRow, error)
+}
+
+var exportTables = map[modelInputs.ProductType]exportTable{
+	modelInputs.ProductTypeLogs: {
+		config:   LogsTableConfig,
+		columns:  LogsTableConfig.SelectColumns,
+		idColumn: "UUID",
+		scan: func(rows driver.Rows) (ExportRow, error) {
+			var result LogRow
+			if err := rows.ScanStruct(&result); err != nil {
+				return nil, err
+			}
+			return &LogExportRow{
+				Timestamp:       result.Timestamp,
+				ProjectID:       result.ProjectId,
+				UUID:            result.UUID,
+				TraceID:         result.TraceId,
+				SpanID:          result.SpanId,
+				SecureSessionID: result.SecureSessionId,
+				Level:           result.SeverityText,
+				Source:          string(result.Source),
+				ServiceName:     result.ServiceName,
+				ServiceVersion:  result.ServiceVersion,
+				Environment:     result.Environment,
+				Message:         result.Body,
+				Attributes:      result.LogAttributes,
+			}, nil
+		},
+	},
+	modelInputs.ProductTypeTraces: {
+		config:   TracesTableConfig,
+		columns:  TracesTableConfig.SelectColumns,
+		idColumn: "UUID",
+		scan: func(rows driver.Rows) (ExportRow, error) {
+			var result ClickhouseTraceRow
+			if err := rows.ScanStruct(&result); err != nil {
+				return nil, err
+			}
+			attributes := mergeAttributes(result)
+			for key, value := range map[string]string{
+				HttpUrlKey:          result.HttpUrl,
+				HttpRequestBodyKey:  result.HttpRequestBody,
+				HttpResponseBodyKey: result.HttpResponseBody,
+			} {
+				if value != "" {
+					attributes[key] = value
+				}
+			}
+			return &TraceExportRow{
+				Timestamp:       result.Timestamp,
+				ProjectID:       result.ProjectId,
+				UUID:            result.UUID,
+				TraceID:         result.TraceId,
+				SpanID:          result.SpanId,
+				ParentSpanID:    result.ParentSpanId,
+				SecureSessionID: result.SecureSessionId,
+				SpanName:        result.SpanName,
+				SpanKind:        result.SpanKind,
+				Duration:        result.Duration,
+				ServiceName:     result.ServiceName,
+				ServiceVersion:  result.ServiceVersion,
+				Environment:     result.Environment,
+				StatusCode:      result.StatusCode,
+				StatusMessage:   result.StatusMessage,
+				HasErrors:       result.HasErrors,
+				Attributes:      attributes,
+				Events: lo.Map(result.EventsTimestamp, func(t time.Time, idx int) TraceEventExportRow {
+					return TraceEventExportRow{
+						Timestamp:  t,
+						Name:       result.EventsName[idx],
+						Attributes: result.EventsAttributes[idx],
+					}
+				}),
+			}, nil
+		},
+	},
+	modelInputs.ProductTypeErrors: {
+		config: ErrorsJoinedTableConfig,
+		columns: []string{
+			"Timestamp",
+			"ProjectId",
+			"ID",
+			"ErrorGroupID",
+			"SecureID",
+			"Event",
+			"Type",
+			"Status",
+			"Browser",
+			"OSName",
+			"Environment",
+			"ServiceName",
+			"ServiceVersion",
+			"ClientID",
+			"VisitedURL",
+			"TraceID",
+			"SecureSessionID",
+		},
+		idColumn: "toString(ID)",
+		scan: func(rows driver.Rows) (ExportRow, error) {
+			var result struct {
+				Timestamp       time.Time
+				ProjectId       int32
+				ID              int64
+				ErrorGroupID    int64
+				SecureID        string
+				Event           string
+				Type            string
+				Status          string
+				Browser         string
+				OSName          string
+				Environment     string
+				ServiceName     string
+				ServiceVersion  string
+				ClientID        string
+				VisitedURL      string
+				TraceID         string
+				SecureSessionID string
+				UUID            string
+			}
+			if err := rows.ScanStruct(&result); err != nil {
+				return nil, err
+			}
+			return &ErrorExportRow{
+				Timestamp:       result.Timestamp,
+				ProjectID:       uint32(result.ProjectId),
+				ID:              result.ID,
+				ErrorGroupID:    result.ErrorGroupID,
+				SecureID:        result.SecureID,
+				Event:           result.Event,
+				Type:            result.Type,
+				Status:          result.Status,
+				Browser:         result.Browser,
+				OSName:          result.OSName,
+				Environment:     result.Environment,
+				ServiceName:     result.ServiceName,
+				ServiceVersion:  result.ServiceVersion,
+				ClientID:        result.ClientID,
+				VisitedURL:      result.VisitedURL,
+				TraceID:         result.TraceID,
+				SecureSessionID: result.SecureSessionID,
+			}, nil
+		},
+	},
+}
+
+// ReadExportRows returns up to `limit` rows of the product matching the query with a timestamp in
+// [start, end), ordered by timestamp and id, after the cursor.
+func (client *Client) ReadExportRows(ctx context.Context, product modelInputs.ProductType, projectID int, query string, start time.Time, end time.Time, after *ExportCursor, limit int) ([]ExportRow, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "clickhouse.ReadExportRows", util.Tag("projectID", projectID), util.Tag("product", product))
+	defer span.Finish()
+
+	table, ok := exportTables[product]
+	if !ok {
+		return nil, e.Errorf("product %s cannot be exported", product)
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	columns := table.columns
+	if table.idColumn != "UUID" {
+		columns = append(append([]string{}, columns...), fmt.Sprintf("%s AS UUID", table.idColumn))
+	}
+	sb.Select(columns...).
+		From(table.config.TableName).
+		Where(sb.Equal("ProjectId", projectID)).
+		Where(sb.GreaterEqualThan("Timestamp", start)).
+		Where(sb.LessThan("Timestamp", end))
+	if after != nil {
+		// positional time arguments are bound with second precision
+		timestamp := fmt.Sprintf("fromUnixTimestamp64Nano(%s)", sb.Var(after.Timestamp.UnixNano()))
+		sb.Where(sb.Or(
+			fmt.Sprintf("Timestamp > %s", timestamp),
+			sb.And(fmt.Sprintf("Timestamp = %s", timestamp), sb.GreaterThan("UUID", after.ID)),
+		))
+	}
+	parser.AssignSearchFilters(sb, query, table.config)
+	sb.OrderBy("Timestamp", "UUID").Limit(limit)
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	rows, err := client.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ExportRow
+	for rows.Next() {
+		row, err := table.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+	return results, nil
+}
@@ -0,0 +1,54 @@
+package clickhouse
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+)
+
+func TestParseExportCursor(t *testing.T) {
+	cursor := ExportCursor{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 123456789, time.UTC), ID: "a,b"}
+	parsed, err := ParseExportCursor(cursor.String())
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, *parsed)
+
+	_, err = ParseExportCursor("123")
+	assert.Error(t, err)
+	_, err = ParseExportCursor("abc,id")
+	assert.Error(t, err)
+}
+
+func TestReadExportRows(t *testing.T) {
+	ctx := context.Background()
+	client, teardown := setupTest(t)
+	defer teardown(t)
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Hour)
+	rows := []*LogRow{
+		NewLogRow(start, 1, WithBody(ctx, "first"), WithSeverityText("info")),
+		NewLogRow(start.Add(time.Minute), 1, WithBody(ctx, "second"), WithSeverityText("info")),
+		NewLogRow(start.Add(2*time.Minute), 1, WithBody(ctx, "third"), WithSeverityText("error")),
+		NewLogRow(start.Add(3*time.Minute), 1, WithBody(ctx, "fourth"), WithSeverityText("info")),
+		// rows of other hours and projects are not exported
+		NewLogRow(start.Add(time.Hour), 1, WithBody(ctx, "next hour"), WithSeverityText("info")),
+		NewLogRow(start, 2, WithBody(ctx, "other project"), WithSeverityText("info")),
+	}
+	assert.NoError(t, client.BatchWriteLogRows(ctx, rows))
+
+	results, err := client.ReadExportRows(ctx, modelInputs.ProductTypeLogs, 1, "level:info", start, start.Add(time.Hour), nil, 2)
+	assert.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "first", results[0].(*LogExportRow).Message)
+	assert.Equal(t, "second", results[1].(*LogExportRow).Message)
+
+	cursor := results[1].Cursor()
+	results, err = client.ReadExportRows(ctx, modelInputs.ProductTypeLogs, 1, "level:info", start, start.Add(time.Hour), &cursor, 2)
+	assert.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "fourth", results[0].(*LogExportRow).Message)
+}
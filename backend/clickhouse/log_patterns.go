@@ -0,0 +1,229 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	e "github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/util"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+const LogPatternsTable = "log_patterns"
+
+const (
+	LogPatternsLimit    = 50
+	LogPatternsMaxLimit = 500
+	// the number of buckets of the trend of a pattern
+	LogPatternTrendBuckets = 24
+)
+
+type LogPatternRow struct {
+	ProjectId   uint32
+	ServiceName string
+	PatternId   string
+	Template    string
+	FirstSeen   time.Time
+	LastSeen    time.Time
+}
+
+// BatchWriteLogPatterns stores the templates of the patterns of logs. Rows of the same pattern are
+// merged, keeping the latest template and the first and last time the pattern was seen.
+func (client *Client) BatchWriteLogPatterns(ctx context.Context, patternRows []*LogPatternRow) error {
+	if len(patternRows) == 0 {
+		return nil
+	}
+
+	batch, err := client.conn.PrepareBatch(ctx, fmt.Sprintf("INSERT INTO %s", LogPatternsTable))
+	if err != nil {
+		return e.Wrap(err, "failed to create log patterns batch")
+	}
+
+	for _, patternRow := range patternRows {
+		if err := batch.AppendStruct(patternRow); err != nil {
+			return err
+		}
+	}
+
+	return batch.Send()
+}
+
+// ReadLogPatterns returns the patterns with the most logs matching the query, with the count of
+// their logs over the date range.
+func (client *Client) ReadLogPatterns(ctx context.Context, projectID int, params modelInputs.QueryInput, limit int) ([]*modelInputs.LogPattern, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "clickhouse.ReadLogPatterns", util.Tag("projectID", projectID))
+	defer span.Finish()
+
+	if limit <= 0 {
+		limit = LogPatternsLimit
+	} else if limit > LogPatternsMaxLimit {
+		limit = LogPatternsMaxLimit
+	}
+
+	sb, _, err := makeSelectBuilder(
+		LogsTableConfig,
+		[]string{"PatternId", "ServiceName", "count() AS Count"},
+		[]int{projectID},
+		params,
+		Pagination{CountOnly: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+	sb.Where("PatternId != ''").
+		GroupBy("PatternId", "ServiceName").
+		OrderBy("Count DESC", "PatternId").
+		Limit(limit)
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	rows, err := client.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := []*modelInputs.LogPattern{}
+	patternsByID := map[string]*modelInputs.LogPattern{}
+	for rows.Next() {
+		var result struct {
+			PatternId   string
+			ServiceName string
+			Count       uint64
+		}
+		if err := rows.ScanStruct(&result); err != nil {
+			return nil, err
+		}
+
+		pattern := &modelInputs.LogPattern{
+			PatternID:   result.PatternId,
+			ServiceName: result.ServiceName,
+			Count:       result.Count,
+			Trend:       make([]*modelInputs.LogPatternBucket, LogPatternTrendBuckets),
+		}
+		for i := range pattern.Trend {
+			pattern.Trend[i] = &modelInputs.LogPatternBucket{Timestamp: logPatternBucketTimestamp(params.DateRange, i)}
+		}
+		patterns = append(patterns, pattern)
+		patternsByID[pattern.PatternID] = pattern
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if len(patterns) == 0 {
+		return patterns, nil
+	}
+	patternIDs := lo.Keys(patternsByID)
+
+	if err := client.readLogPatternTrends(ctx, projectID, params, patternIDs, patternsByID); err != nil {
+		return nil, err
+	}
+
+	if err := client.readLogPatternTemplates(ctx, projectID, patternIDs, patternsByID); err != nil {
+		return nil, err
+	}
+
+	return patterns, nil
+}
+
+func logPatternBucketTimestamp(dateRange *modelInputs.DateRangeRequiredInput, bucket int) time.Time {
+	bucketDuration := dateRange.EndDate.Sub(dateRange.StartDate) / LogPatternTrendBuckets
+	return dateRange.StartDate.Add(time.Duration(bucket) * bucketDuration)
+}
+
+func (client *Client) readLogPatternTrends(ctx context.Context, projectID int, params modelInputs.QueryInput, patternIDs []string, patternsByID map[string]*modelInputs.LogPattern) error {
+	startTimestamp := params.DateRange.StartDate.Unix()
+	endTimestamp := params.DateRange.EndDate.Unix()
+	if endTimestamp <= startTimestamp {
+		endTimestamp = startTimestamp + 1
+	}
+	bucketIdxExpr := fmt.Sprintf(
+		"least(toUInt64(intDiv(%d * (toRelativeSecondNum(Timestamp) - %d), (%d - %d))), %d) AS Bucket",
+		LogPatternTrendBuckets,
+		startTimestamp,
+		endTimestamp,
+		startTimestamp,
+		LogPatternTrendBuckets-1,
+	)
+
+	sb, _, err := makeSelectBuilder(
+		LogsTableConfig,
+		[]string{"PatternId", bucketIdxExpr, "count() AS Count"},
+		[]int{projectID},
+		params,
+		Pagination{CountOnly: true},
+	)
+	if err != nil {
+		return err
+	}
+	sb.Where(sb.In("PatternId", patternIDs)).
+		GroupBy("PatternId", "Bucket")
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	rows, err := client.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		var result struct {
+			PatternId string
+			Bucket    uint64
+			Count     uint64
+		}
+		if err := rows.ScanStruct(&result); err != nil {
+			return err
+		}
+		if pattern, ok := patternsByID[result.PatternId]; ok && result.Bucket < LogPatternTrendBuckets {
+			pattern.Trend[result.Bucket].Count += result.Count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+	return nil
+}
+
+func (client *Client) readLogPatternTemplates(ctx context.Context, projectID int, patternIDs []string, patternsByID map[string]*modelInputs.LogPattern) error {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		"PatternId",
+		"anyLast(Template) AS Template",
+		"min(FirstSeen) AS FirstSeen",
+	).
+		From(LogPatternsTable).
+		Where(sb.Equal("ProjectId", projectID)).
+		Where(sb.In("PatternId", patternIDs)).
+		GroupBy("PatternId")
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	rows, err := client.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		var result struct {
+			PatternId string
+			Template  string
+			FirstSeen time.Time
+		}
+		if err := rows.ScanStruct(&result); err != nil {
+			return err
+		}
+		if pattern, ok := patternsByID[result.PatternId]; ok {
+			pattern.Template = result.Template
+			pattern.FirstSeen = result.FirstSeen
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+	return nil
+}
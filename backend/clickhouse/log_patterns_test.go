@@ -0,0 +1,62 @@
+package clickhouse
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+)
+
+func TestReadLogPatterns(t *testing.T) {
+	ctx := context.Background()
+	client, teardown := setupTest(t)
+	defer teardown(t)
+
+	now := time.Now().Truncate(time.Second)
+	withPattern := func(timestamp time.Time, patternID string) *LogRow {
+		row := NewLogRow(timestamp, 1, WithServiceName("api"), WithBody(ctx, "user logged in"))
+		row.PatternId = patternID
+		return row
+	}
+	rows := []*LogRow{
+		withPattern(now, "login"),
+		withPattern(now.Add(-time.Minute), "login"),
+		withPattern(now.Add(-time.Minute), "login"),
+		withPattern(now, "logout"),
+		// logs without a pattern are ignored
+		withPattern(now, ""),
+	}
+	assert.NoError(t, client.BatchWriteLogRows(ctx, rows))
+	assert.NoError(t, client.BatchWriteLogPatterns(ctx, []*LogPatternRow{
+		{ProjectId: 1, ServiceName: "api", PatternId: "login", Template: "user <*> logged in", FirstSeen: now.Add(-time.Hour), LastSeen: now},
+		{ProjectId: 1, ServiceName: "api", PatternId: "login", Template: "user <*> logged <*>", FirstSeen: now.Add(-time.Minute), LastSeen: now},
+		{ProjectId: 1, ServiceName: "api", PatternId: "logout", Template: "user <*> logged out", FirstSeen: now, LastSeen: now},
+	}))
+
+	patterns, err := client.ReadLogPatterns(ctx, 1, modelInputs.QueryInput{DateRange: makeDateWithinRange(now)}, 0)
+	assert.NoError(t, err)
+	require.Len(t, patterns, 2)
+
+	login := patterns[0]
+	assert.Equal(t, "login", login.PatternID)
+	assert.Equal(t, "api", login.ServiceName)
+	assert.Equal(t, "user <*> logged <*>", login.Template)
+	assert.Equal(t, uint64(3), login.Count)
+	assert.True(t, now.Add(-time.Hour).Equal(login.FirstSeen))
+	assert.Len(t, login.Trend, LogPatternTrendBuckets)
+	assert.Equal(t, uint64(3), lo.SumBy(login.Trend, func(bucket *modelInputs.LogPatternBucket) uint64 {
+		return bucket.Count
+	}))
+	assert.Equal(t, "logout", patterns[1].PatternID)
+	assert.Equal(t, uint64(1), patterns[1].Count)
+
+	patterns, err = client.ReadLogPatterns(ctx, 1, modelInputs.QueryInput{Query: "pattern_id:logout", DateRange: makeDateWithinRange(now)}, 0)
+	assert.NoError(t, err)
+	require.Len(t, patterns, 1)
+	assert.Equal(t, "logout", patterns[0].PatternID)
+}
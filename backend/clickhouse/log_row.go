@@ -30,6 +30,7 @@ type LogRow struct {
 	Body            string
 	LogAttributes   map[string]string
 	Environment     string
+	PatternId       string
 }
 
 func NewLogRow(timestamp time.Time, projectID uint32, opts ...LogRowOption) *LogRow {
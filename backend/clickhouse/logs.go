@@ -37,6 +37,7 @@ var logKeysToColumns = map[string]string{
 	string(modelInputs.ReservedLogKeyServiceVersion):  "ServiceVersion",
 	string(modelInputs.ReservedLogKeyEnvironment):     "Environment",
 	string(modelInputs.ReservedLogKeyMessage):         "Body",
+	string(modelInputs.ReservedLogKeyPatternID):       "PatternId",
 	string(modelInputs.ReservedLogKeyTimestamp):       "Timestamp",
 }
 
@@ -46,6 +47,7 @@ var defaultLogKeys = []*modelInputs.QueryKey{
 	{Name: string(modelInputs.ReservedLogKeySpanID), Type: modelInputs.KeyTypeString},
 	{Name: string(modelInputs.ReservedLogKeyTraceID), Type: modelInputs.KeyTypeString},
 	{Name: string(modelInputs.ReservedLogKeyMessage), Type: modelInputs.KeyTypeString},
+	{Name: string(modelInputs.ReservedLogKeyPatternID), Type: modelInputs.KeyTypeString},
 	{Name: string(modelInputs.ReservedLogKeyTimestamp), Type: modelInputs.KeyTypeNumeric},
 }
 
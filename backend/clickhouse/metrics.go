@@ -211,7 +211,7 @@ func (client *Client) BatchWriteMetricRows(ctx context.Context, metricRows []Met
 		}),
 	} {
 		if len(rows) == 0 {
-			return nil
+			continue
 		}
 
 		span, _ := util.StartSpanFromContext(ctx, util.KafkaBatchWorkerOp, util.ResourceName("worker.kafka.batched.otelMetrics.write"))
@@ -0,0 +1,2 @@
+DROP VIEW IF EXISTS trace_service_metrics_mv;
+DROP TABLE IF EXISTS trace_service_metrics;
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS trace_service_metrics
+(
+    `ProjectId`         UInt32,
+    `Timestamp`         DateTime CODEC (Delta(4), ZSTD(1)),
+    `ServiceName`       LowCardinality(String),
+    `SpanName`          String,
+    `SpanKind`          LowCardinality(String),
+    `Environment`       LowCardinality(String),
+    `Count`             SimpleAggregateFunction(sum, UInt64),
+    `ErrorCount`        SimpleAggregateFunction(sum, UInt64),
+    `DurationSum`       SimpleAggregateFunction(sum, Float64),
+    `DurationMin`       SimpleAggregateFunction(min, Int64),
+    `DurationMax`       SimpleAggregateFunction(max, Int64),
+    `DurationQuantiles` AggregateFunction(quantilesTDigest(0.5, 0.9, 0.95, 0.99), Int64)
+)
+    ENGINE = AggregatingMergeTree()
+        PARTITION BY toStartOfDay(Timestamp)
+        ORDER BY (ProjectId, ServiceName, SpanName, SpanKind, Environment, Timestamp)
+        TTL Timestamp + toIntervalDay(30);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS trace_service_metrics_mv TO trace_service_metrics AS
+SELECT ProjectId,
+       toStartOfMinute(Timestamp)                                  as Timestamp,
+       ServiceName,
+       SpanName,
+       SpanKind,
+       Environment,
+       sumSimpleState(toUInt64(1))                                 as Count,
+       sumSimpleState(toUInt64(HasErrors))                         as ErrorCount,
+       sumSimpleState(toFloat64(Duration))                         as DurationSum,
+       minSimpleState(Duration)                                    as DurationMin,
+       maxSimpleState(Duration)                                    as DurationMax,
+       quantilesTDigestState(0.5, 0.9, 0.95, 0.99)(Duration)       as DurationQuantiles
+FROM traces
+WHERE SpanName != 'highlight-metric'
+  AND HighlightType != 'highlight.internal'
+GROUP BY ProjectId, Timestamp, ServiceName, SpanName, SpanKind, Environment;
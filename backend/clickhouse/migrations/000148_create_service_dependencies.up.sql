@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS service_dependencies
+(
+    `ProjectId`         UInt32,
+    `Timestamp`         DateTime CODEC (Delta(4), ZSTD(1)),
+    `Environment`       LowCardinality(String),
+    `Caller`            LowCardinality(String),
+    `Callee`            String,
+    `CalleeType`        LowCardinality(String),
+    `CallCount`         SimpleAggregateFunction(sum, UInt64),
+    `ErrorCount`        SimpleAggregateFunction(sum, UInt64),
+    `DurationQuantiles` AggregateFunction(quantilesTDigest(0.5, 0.95), Int64)
+)
+    ENGINE = AggregatingMergeTree()
+        PARTITION BY toStartOfDay(Timestamp)
+        ORDER BY (ProjectId, Environment, Caller, Callee, CalleeType, Timestamp)
+        TTL Timestamp + toIntervalDay(30);
@@ -0,0 +1,2 @@
+alter table logs_sampling
+    add column PatternId String;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS log_patterns;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS log_patterns
+(
+    `ProjectId`   UInt32,
+    `ServiceName` LowCardinality(String),
+    `PatternId`   String,
+    `Template`    SimpleAggregateFunction(anyLast, String),
+    `FirstSeen`   SimpleAggregateFunction(min, DateTime),
+    `LastSeen`    SimpleAggregateFunction(max, DateTime)
+)
+    ENGINE = AggregatingMergeTree()
+        ORDER BY (ProjectId, ServiceName, PatternId)
+        TTL LastSeen + toIntervalDay(30);
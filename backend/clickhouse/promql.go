@@ -0,0 +1,288 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/promql"
+	"github.com/highlight-run/highlight/backend/util"
+	"github.com/huandu/go-sqlbuilder"
+	e "github.com/pkg/errors"
+	"github.com/samber/lo"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	// the maximum number of rows read for the samples of a query
+	maxPrometheusRows = 1_000_000
+	// the maximum number of series returned by the labels and series endpoints
+	maxPrometheusSeries = 10_000
+)
+
+const prometheusJobLabel = "job"
+
+var prometheusSeriesSuffixes = []string{"_bucket", "_sum", "_count"}
+
+// histograms and summaries are exposed as their classic prometheus series, ie. a histogram
+// `latency` is read as `latency_bucket{le="..."}`, `latency_sum` and `latency_count`.
+func prometheusMetricNames(name string) []string {
+	names := []string{name}
+	for _, suffix := range prometheusSeriesSuffixes {
+		if base := strings.TrimSuffix(name, suffix); base != name && base != "" {
+			names = append(names, base)
+		}
+	}
+	return names
+}
+
+// prometheusSeriesBuilder selects the metrics of the project in the time range. Equality matchers
+// are applied to narrow the rows read; the returned series are filtered by all matchers by the engine.
+func prometheusSeriesBuilder(projectID int, matchers []*promql.Matcher, start time.Time, end time.Time) *sqlbuilder.SelectBuilder {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.From(MetricsTable).
+		Where(sb.Equal("ProjectId", projectID)).
+		Where(sb.GreaterEqualThan("Timestamp", start)).
+		Where(sb.LessEqualThan("Timestamp", end))
+	for _, m := range matchers {
+		if m.Type != promql.MatchEqual {
+			continue
+		}
+		switch m.Name {
+		case promql.MetricNameLabel:
+			sb.Where(sb.In("MetricName", lo.ToAnySlice(prometheusMetricNames(m.Value))...))
+		case promql.BucketLabel, promql.QuantileLabel:
+		case prometheusJobLabel:
+			if m.Value != "" {
+				sb.Where(sb.Or(sb.Equal("ServiceName", m.Value), sb.Equal("Attributes['job']", m.Value)))
+			}
+		default:
+			if m.Value != "" {
+				sb.Where(fmt.Sprintf("arrayExists((k, v) -> replaceRegexpAll(k, '[^a-zA-Z0-9_]', '_') = %s AND v = %s, mapKeys(Attributes), mapValues(Attributes))", sb.Var(m.Name), sb.Var(m.Value)))
+			}
+		}
+	}
+	return sb
+}
+
+func prometheusBaseLabels(name string, serviceName string, attributes map[string]string) promql.Labels {
+	labels := promql.Labels{promql.MetricNameLabel: name}
+	for k, v := range attributes {
+		if v != "" {
+			labels[promql.SanitizeLabelName(k)] = v
+		}
+	}
+	if _, ok := labels[prometheusJobLabel]; !ok && serviceName != "" {
+		labels[prometheusJobLabel] = serviceName
+	}
+	return labels
+}
+
+func withLabel(labels promql.Labels, name string, value string) promql.Labels {
+	result := labels.Without()
+	result[name] = value
+	return result
+}
+
+func formatPrometheusFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// mergeHistogramBuckets sums the bucket counts of histogram points aggregated in the same second
+// and returns the bounds with the cumulative counts of each bucket, ending with the +Inf bucket.
+func mergeHistogramBuckets(counts []uint64, bounds []float64) ([]float64, []float64) {
+	points := len(counts) - len(bounds)
+	if points <= 0 || len(bounds)%points != 0 {
+		return nil, nil
+	}
+	n := len(bounds) / points
+	merged := make([]float64, n+1)
+	for i, count := range counts {
+		merged[i%(n+1)] += float64(count)
+	}
+	for i := 1; i < len(merged); i++ {
+		merged[i] += merged[i-1]
+	}
+	return append(append([]float64{}, bounds[:n]...), math.Inf(1)), merged
+}
+
+type prometheusRow struct {
+	MetricName     string
+	ServiceName    string
+	MetricType     string
+	Attributes     map[string]string
+	Timestamp      time.Time
+	SumValue       float64
+	CountValue     uint64
+	BucketCounts   []uint64
+	ExplicitBounds []float64
+	Quantiles      []float64
+	QuantileValues []float64
+}
+
+// ReadPrometheusSeries reads the samples of the series of a project. Gauges and sums are read
+// as the value of each point, histograms and summaries as their classic prometheus series.
+func (client *Client) ReadPrometheusSeries(ctx context.Context, projectID int, matchers []*promql.Matcher, start time.Time, end time.Time) ([]*promql.Series, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "clickhouse.ReadPrometheusSeries", util.Tag("projectID", projectID))
+	defer span.Finish()
+
+	sb := prometheusSeriesBuilder(projectID, matchers, start, end)
+	sb.Select(
+		"MetricName",
+		"ServiceName",
+		"MetricType",
+		"Attributes",
+		"Timestamp",
+		"sum(Sum) AS SumValue",
+		"sum(Count) AS CountValue",
+		"groupArrayArray(BucketCounts) AS BucketCounts",
+		"groupArrayArray(ExplicitBounds) AS ExplicitBounds",
+		"groupArrayArray(`ValueAtQuantiles.Quantile`) AS Quantiles",
+		"groupArrayArray(`ValueAtQuantiles.Value`) AS QuantileValues",
+	).
+		GroupBy("MetricName", "ServiceName", "MetricType", "Attributes", "Timestamp").
+		OrderBy("Timestamp").
+		Limit(maxPrometheusRows + 1)
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	rows, err := client.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	series := map[string]*promql.Series{}
+	var keys []string
+	add := func(labels promql.Labels, t int64, v float64) {
+		key := labels.Key()
+		s, ok := series[key]
+		if !ok {
+			s = &promql.Series{Labels: labels}
+			series[key] = s
+			keys = append(keys, key)
+		}
+		s.Samples = append(s.Samples, promql.Sample{T: t, V: v})
+	}
+
+	var count int
+	for rows.Next() {
+		if count++; count > maxPrometheusRows {
+			return nil, e.New("query processing would load too many samples into memory")
+		}
+		var row prometheusRow
+		if err := rows.ScanStruct(&row); err != nil {
+			return nil, err
+		}
+
+		t := row.Timestamp.UnixMilli()
+		labels := prometheusBaseLabels(row.MetricName, row.ServiceName, row.Attributes)
+		switch row.MetricType {
+		case pmetric.MetricTypeHistogram.String():
+			bounds, cumulative := mergeHistogramBuckets(row.BucketCounts, row.ExplicitBounds)
+			for i, bound := range bounds {
+				add(withLabel(withLabel(labels, promql.MetricNameLabel, row.MetricName+"_bucket"), promql.BucketLabel, formatPrometheusFloat(bound)), t, cumulative[i])
+			}
+			add(withLabel(labels, promql.MetricNameLabel, row.MetricName+"_sum"), t, row.SumValue)
+			add(withLabel(labels, promql.MetricNameLabel, row.MetricName+"_count"), t, float64(row.CountValue))
+		case pmetric.MetricTypeSummary.String():
+			// points aggregated in the same second are averaged per quantile
+			values := map[float64][]float64{}
+			for i, q := range row.Quantiles {
+				if i < len(row.QuantileValues) {
+					values[q] = append(values[q], row.QuantileValues[i])
+				}
+			}
+			quantiles := lo.Keys(values)
+			sort.Float64s(quantiles)
+			for _, q := range quantiles {
+				add(withLabel(labels, promql.QuantileLabel, formatPrometheusFloat(q)), t, lo.Sum(values[q])/float64(len(values[q])))
+			}
+			add(withLabel(labels, promql.MetricNameLabel, row.MetricName+"_sum"), t, row.SumValue)
+			add(withLabel(labels, promql.MetricNameLabel, row.MetricName+"_count"), t, float64(row.CountValue))
+		default:
+			if row.CountValue == 0 {
+				continue
+			}
+			add(labels, t, row.SumValue/float64(row.CountValue))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]*promql.Series, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, series[key])
+	}
+	return result, nil
+}
+
+// ReadPrometheusSeriesLabels returns the label sets of the series of a project.
+func (client *Client) ReadPrometheusSeriesLabels(ctx context.Context, projectID int, matchers []*promql.Matcher, start time.Time, end time.Time) ([]promql.Labels, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "clickhouse.ReadPrometheusSeriesLabels", util.Tag("projectID", projectID))
+	defer span.Finish()
+
+	sb := prometheusSeriesBuilder(projectID, matchers, start, end)
+	sb.Select(
+		"MetricName",
+		"ServiceName",
+		"MetricType",
+		"Attributes",
+		"groupUniqArrayArray(ExplicitBounds) AS ExplicitBounds",
+		"groupUniqArrayArray(`ValueAtQuantiles.Quantile`) AS Quantiles",
+	).
+		GroupBy("MetricName", "ServiceName", "MetricType", "Attributes").
+		Limit(maxPrometheusSeries)
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	rows, err := client.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []promql.Labels
+	for rows.Next() {
+		var row struct {
+			MetricName     string
+			ServiceName    string
+			MetricType     string
+			Attributes     map[string]string
+			ExplicitBounds []float64
+			Quantiles      []float64
+		}
+		if err := rows.ScanStruct(&row); err != nil {
+			return nil, err
+		}
+
+		labels := prometheusBaseLabels(row.MetricName, row.ServiceName, row.Attributes)
+		switch row.MetricType {
+		case pmetric.MetricTypeHistogram.String():
+			sort.Float64s(row.ExplicitBounds)
+			for _, bound := range append(row.ExplicitBounds, math.Inf(1)) {
+				result = append(result, withLabel(withLabel(labels, promql.MetricNameLabel, row.MetricName+"_bucket"), promql.BucketLabel, formatPrometheusFloat(bound)))
+			}
+			result = append(result, withLabel(labels, promql.MetricNameLabel, row.MetricName+"_sum"), withLabel(labels, promql.MetricNameLabel, row.MetricName+"_count"))
+		case pmetric.MetricTypeSummary.String():
+			sort.Float64s(row.Quantiles)
+			for _, q := range row.Quantiles {
+				result = append(result, withLabel(labels, promql.QuantileLabel, formatPrometheusFloat(q)))
+			}
+			result = append(result, withLabel(labels, promql.MetricNameLabel, row.MetricName+"_sum"), withLabel(labels, promql.MetricNameLabel, row.MetricName+"_count"))
+		default:
+			result = append(result, labels)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
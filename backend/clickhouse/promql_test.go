@@ -0,0 +1,34 @@
+package clickhouse
+
+import (
+	"math"
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/promql"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_mergeHistogramBuckets(t *testing.T) {
+	bounds, cumulative := mergeHistogramBuckets([]uint64{3, 2, 1}, []float64{0.1, 0.5})
+	assert.Equal(t, []float64{0.1, 0.5, math.Inf(1)}, bounds)
+	assert.Equal(t, []float64{3, 5, 6}, cumulative)
+
+	// two points of the same series aggregated in one second
+	bounds, cumulative = mergeHistogramBuckets([]uint64{3, 2, 1, 1, 1, 1}, []float64{0.1, 0.5, 0.1, 0.5})
+	assert.Equal(t, []float64{0.1, 0.5, math.Inf(1)}, bounds)
+	assert.Equal(t, []float64{4, 7, 9}, cumulative)
+
+	bounds, _ = mergeHistogramBuckets(nil, nil)
+	assert.Empty(t, bounds)
+}
+
+func Test_prometheusLabels(t *testing.T) {
+	assert.Equal(t, []string{"latency_bucket", "latency"}, prometheusMetricNames("latency_bucket"))
+	assert.Equal(t, []string{"up"}, prometheusMetricNames("up"))
+
+	assert.Equal(t, promql.Labels{
+		promql.MetricNameLabel: "http_requests_total",
+		"http_method":          "GET",
+		"job":                  "api",
+	}, prometheusBaseLabels("http_requests_total", "api", map[string]string{"http.method": "GET", "empty": ""}))
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/nqd/flat"
 	e "github.com/pkg/errors"
 	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
 	"go.openly.dev/pointy"
 	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
 
@@ -1129,7 +1130,8 @@ func matchFilter[TObj interface{}](row *TObj, config model.TableConfig, filter *
 	if len(groups) > 0 {
 		key = groups[1]
 	}
-	bodyFilter := config.BodyColumn != "" && filter.Column == "" && key == config.BodyColumn
+	listFilter := filter.Operator == listener.OperatorIn || filter.Operator == listener.OperatorBetween
+	bodyFilter := config.BodyColumn != "" && filter.Column == "" && key == config.BodyColumn && !listFilter
 	v := reflect.ValueOf(*row)
 
 	rowBodyTerms := map[string]bool{}
@@ -1198,6 +1200,55 @@ func matchFilter[TObj interface{}](row *TObj, config model.TableConfig, filter *
 	} else {
 		return true, e.New(fmt.Sprintf("invalid filter %s", key))
 	}
+	switch filter.Operator {
+	case listener.OperatorIn:
+		for _, v := range filter.Values {
+			if v == rowValue {
+				return true, nil
+			}
+		}
+		return false, nil
+	case listener.OperatorBetween:
+		if len(filter.Values) != 2 {
+			return true, e.New(fmt.Sprintf("invalid between filter %s", key))
+		}
+		lower, err := strconv.ParseFloat(filter.Values[0], 64)
+		if err != nil {
+			return true, err
+		}
+		upper, err := strconv.ParseFloat(filter.Values[1], 64)
+		if err != nil {
+			return true, err
+		}
+		value, err := strconv.ParseFloat(rowValue, 64)
+		if err != nil {
+			return false, nil
+		}
+		return value >= lower && value <= upper, nil
+	case listener.OperatorGreaterThan, listener.OperatorGreaterThanOrEqualTo,
+		listener.OperatorLessThan, listener.OperatorLessThanOrEqualTo:
+		if len(filter.Values) != 1 {
+			return true, e.New(fmt.Sprintf("invalid comparison filter %s", key))
+		}
+		bound, err := strconv.ParseFloat(filter.Values[0], 64)
+		if err != nil {
+			return true, err
+		}
+		value, err := strconv.ParseFloat(rowValue, 64)
+		if err != nil {
+			return false, nil
+		}
+		switch filter.Operator {
+		case listener.OperatorGreaterThan:
+			return value > bound, nil
+		case listener.OperatorGreaterThanOrEqualTo:
+			return value >= bound, nil
+		case listener.OperatorLessThan:
+			return value < bound, nil
+		default:
+			return value <= bound, nil
+		}
+	}
 	for _, v := range filter.Values {
 		if filter.Operator == listener.OperatorRegExp {
 			pat, err := regexp.Compile(v)
@@ -1732,6 +1783,14 @@ func (client *Client) ReadMetrics(ctx context.Context, input ReadMetricsInput) (
 		}
 	}
 
+	if ServiceMetricsSupported(input) {
+		if covered, err := client.serviceMetricsCover(ctx, input.ProjectIDs, input.Params.DateRange.StartDate); err != nil {
+			log.WithContext(ctx).WithError(err).Error("failed to check trace service metrics coverage")
+		} else if covered {
+			return client.ReadServiceMetrics(ctx, input)
+		}
+	}
+
 	sampleRatio := 0.0
 	if input.SampleableConfig.sampleSizeRows != 0 {
 		originalTable := input.SampleableConfig.tableConfig.TableName
@@ -1949,9 +2008,15 @@ func (client *Client) ReadMetrics(ctx context.Context, input ReadMetricsInput) (
 		return nil, nil
 	}
 
+	return client.queryMetricBuckets(ctx, fromSb, input, bucketingInfo.BucketCount)
+}
+
+// queryMetricBuckets runs a metrics query selecting the bucket index, sample factor, bucket bounds,
+// one value per expression and the group by columns, interpolating buckets without results.
+func (client *Client) queryMetricBuckets(ctx context.Context, fromSb *sqlbuilder.SelectBuilder, input ReadMetricsInput, bucketCount int) (*modelInputs.MetricsBuckets, error) {
 	sql, args := fromSb.BuildWithFlavor(sqlbuilder.ClickHouse)
 
-	span, ctx = util.StartSpanFromContext(ctx, "readMetrics.query")
+	span, ctx := util.StartSpanFromContext(ctx, "readMetrics.query")
 	span.SetAttribute("sql", sql)
 	span.SetAttribute("args", args)
 	rows, err := client.conn.Query(
@@ -1998,7 +2063,7 @@ func (client *Client) ReadMetrics(ctx context.Context, input ReadMetricsInput) (
 		}
 
 		bucketId := groupKey
-		if bucketId >= uint64(bucketingInfo.BucketCount) {
+		if bucketId >= uint64(bucketCount) {
 			continue
 		}
 
@@ -2007,8 +2072,8 @@ func (client *Client) ReadMetrics(ctx context.Context, input ReadMetricsInput) (
 			for _, e := range input.Expressions {
 				metrics.Buckets = append(metrics.Buckets, &modelInputs.MetricBucket{
 					BucketID:    uint64(i),
-					BucketMin:   pointy.Float64(float64(i)*(max-min)/float64(bucketingInfo.BucketCount) + min),
-					BucketMax:   pointy.Float64(float64(i+1)*(max-min)/float64(bucketingInfo.BucketCount) + min),
+					BucketMin:   pointy.Float64(float64(i)*(max-min)/float64(bucketCount) + min),
+					BucketMax:   pointy.Float64(float64(i+1)*(max-min)/float64(bucketCount) + min),
 					Group:       append(make([]string, 0), groupByColResults...),
 					MetricType:  e.Aggregator,
 					Column:      e.Column,
@@ -2021,8 +2086,8 @@ func (client *Client) ReadMetrics(ctx context.Context, input ReadMetricsInput) (
 			result := metricResults[idx]
 			metrics.Buckets = append(metrics.Buckets, &modelInputs.MetricBucket{
 				BucketID:    bucketId,
-				BucketMin:   pointy.Float64(float64(bucketId)*(max-min)/float64(bucketingInfo.BucketCount) + min),
-				BucketMax:   pointy.Float64(float64(bucketId+1)*(max-min)/float64(bucketingInfo.BucketCount) + min),
+				BucketMin:   pointy.Float64(float64(bucketId)*(max-min)/float64(bucketCount) + min),
+				BucketMax:   pointy.Float64(float64(bucketId+1)*(max-min)/float64(bucketCount) + min),
 				Group:       append(make([]string, 0), groupByColResults...),
 				MetricType:  e.Aggregator,
 				Column:      e.Column,
@@ -2040,12 +2105,12 @@ func (client *Client) ReadMetrics(ctx context.Context, input ReadMetricsInput) (
 	}
 
 	// Interpolate any missing buckets
-	for i := lastBucketId + 1; i < bucketingInfo.BucketCount; i++ {
+	for i := lastBucketId + 1; i < bucketCount; i++ {
 		for _, e := range input.Expressions {
 			metrics.Buckets = append(metrics.Buckets, &modelInputs.MetricBucket{
 				BucketID:    uint64(i),
-				BucketMin:   pointy.Float64(float64(i)*(max-min)/float64(bucketingInfo.BucketCount) + min),
-				BucketMax:   pointy.Float64(float64(i+1)*(max-min)/float64(bucketingInfo.BucketCount) + min),
+				BucketMin:   pointy.Float64(float64(i)*(max-min)/float64(bucketCount) + min),
+				BucketMax:   pointy.Float64(float64(i+1)*(max-min)/float64(bucketCount) + min),
 				Group:       append(make([]string, 0), groupByColResults...),
 				MetricType:  e.Aggregator,
 				Column:      e.Column,
@@ -2055,7 +2120,7 @@ func (client *Client) ReadMetrics(ctx context.Context, input ReadMetricsInput) (
 	}
 
 	metrics.SampleFactor = sampleFactor
-	metrics.BucketCount = uint64(bucketingInfo.BucketCount)
+	metrics.BucketCount = uint64(bucketCount)
 
 	return metrics, err
 }
@@ -2137,6 +2202,12 @@ func repr(val reflect.Value) string {
 		return repr(val.Elem())
 	case reflect.Bool:
 		return fmt.Sprintf("%t", val.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(val.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(val.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(val.Float(), 'f', -1, 64)
 	default:
 		return val.String()
 	}
@@ -0,0 +1,171 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/util"
+)
+
+// the number of buckets of the adoption of a release
+const ReleaseAdoptionBuckets = 24
+
+type ReleaseStats struct {
+	Errors          uint64
+	Sessions        uint64
+	CrashedSessions uint64
+}
+
+// QueryReleaseStats counts the errors and sessions of each version over the date range. Errors are
+// matched by their service version and sessions by their app version, and sessions with errors
+// are counted as crashed.
+func (client *Client) QueryReleaseStats(ctx context.Context, projectID int, versions []string, startDate time.Time, endDate time.Time) (map[string]*ReleaseStats, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "clickhouse.QueryReleaseStats", util.Tag("projectID", projectID))
+	defer span.Finish()
+
+	stats := map[string]*ReleaseStats{}
+	for _, version := range versions {
+		stats[version] = &ReleaseStats{}
+	}
+	if len(versions) == 0 {
+		return stats, nil
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sql, args := sb.Select("ServiceVersion", "count() AS Errors").
+		From(fmt.Sprintf("%s FINAL", ErrorObjectsTable)).
+		Where(sb.Equal("ProjectID", projectID)).
+		Where(sb.In("ServiceVersion", versions)).
+		Where(sb.Between("Timestamp", startDate, endDate)).
+		GroupBy("ServiceVersion").
+		BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	rows, err := client.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var result struct {
+			ServiceVersion string
+			Errors         uint64
+		}
+		if err := rows.ScanStruct(&result); err != nil {
+			return nil, err
+		}
+		if s, ok := stats[result.ServiceVersion]; ok {
+			s.Errors = result.Errors
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	sb = sqlbuilder.NewSelectBuilder()
+	sql, args = sb.Select("AppVersion", "count() AS Sessions", "countIf(HasErrors) AS CrashedSessions").
+		From(fmt.Sprintf("%s FINAL", SessionsTable)).
+		Where(sb.Equal("ProjectID", projectID)).
+		Where(sb.In("AppVersion", versions)).
+		Where(sb.Between("CreatedAt", startDate, endDate)).
+		Where("NOT Excluded").
+		GroupBy("AppVersion").
+		BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	rows, err = client.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var result struct {
+			AppVersion      string
+			Sessions        uint64
+			CrashedSessions uint64
+		}
+		if err := rows.ScanStruct(&result); err != nil {
+			return nil, err
+		}
+		if s, ok := stats[result.AppVersion]; ok {
+			s.Sessions = result.Sessions
+			s.CrashedSessions = result.CrashedSessions
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	return stats, nil
+}
+
+// QueryReleaseAdoption returns the share of the sessions of the project on the version over the date range.
+func (client *Client) QueryReleaseAdoption(ctx context.Context, projectID int, version string, startDate time.Time, endDate time.Time) ([]*modelInputs.ReleaseAdoptionBucket, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "clickhouse.QueryReleaseAdoption", util.Tag("projectID", projectID))
+	defer span.Finish()
+
+	startTimestamp := startDate.Unix()
+	endTimestamp := endDate.Unix()
+	if endTimestamp <= startTimestamp {
+		endTimestamp = startTimestamp + 1
+	}
+	bucketDuration := time.Duration(endTimestamp-startTimestamp) * time.Second / ReleaseAdoptionBuckets
+
+	buckets := make([]*modelInputs.ReleaseAdoptionBucket, ReleaseAdoptionBuckets)
+	for i := range buckets {
+		buckets[i] = &modelInputs.ReleaseAdoptionBucket{Timestamp: startDate.Add(time.Duration(i) * bucketDuration)}
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sql, args := sb.Select(
+		fmt.Sprintf(
+			"least(toUInt64(intDiv(%d * (toRelativeSecondNum(CreatedAt) - %d), (%d - %d))), %d) AS Bucket",
+			ReleaseAdoptionBuckets,
+			startTimestamp,
+			endTimestamp,
+			startTimestamp,
+			ReleaseAdoptionBuckets-1,
+		),
+		fmt.Sprintf("countIf(AppVersion = %s) AS Sessions", sb.Var(version)),
+		"count() AS TotalSessions",
+	).
+		From(fmt.Sprintf("%s FINAL", SessionsTable)).
+		Where(sb.Equal("ProjectID", projectID)).
+		Where(sb.Between("CreatedAt", startDate, endDate)).
+		Where("NOT Excluded").
+		GroupBy("Bucket").
+		BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	rows, err := client.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var result struct {
+			Bucket        uint64
+			Sessions      uint64
+			TotalSessions uint64
+		}
+		if err := rows.ScanStruct(&result); err != nil {
+			return nil, err
+		}
+		if result.Bucket < ReleaseAdoptionBuckets {
+			bucket := buckets[result.Bucket]
+			bucket.Sessions += result.Sessions
+			bucket.TotalSessions += result.TotalSessions
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for _, bucket := range buckets {
+		if bucket.TotalSessions > 0 {
+			bucket.AdoptionPercent = 100 * float64(bucket.Sessions) / float64(bucket.TotalSessions)
+		}
+	}
+	return buckets, nil
+}
@@ -0,0 +1,68 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+)
+
+func TestQueryReleaseStats(t *testing.T) {
+	ctx := context.Background()
+	client, teardown := setupSessionsTest(t)
+	defer teardown(t)
+	defer func() {
+		assert.NoError(t, client.conn.Exec(ctx, fmt.Sprintf("TRUNCATE TABLE %s", ErrorObjectsTable)))
+	}()
+
+	now := time.Now()
+	newSession := func(id int, version string, hasErrors bool) *model.Session {
+		return &model.Session{
+			Model:          model.Model{ID: id, CreatedAt: now, UpdatedAt: now},
+			ProjectID:      1,
+			AppVersion:     ptr.String(version),
+			HasErrors:      ptr.Bool(hasErrors),
+			Fields:         []*model.Field{},
+			ViewedByAdmins: []model.Admin{},
+		}
+	}
+	sessions := []*model.Session{
+		newSession(1, "1.0.0", true),
+		newSession(2, "1.0.0", false),
+		newSession(3, "1.1.0", false),
+		newSession(4, "1.1.0", false),
+		newSession(5, "1.1.0", true),
+	}
+	require.NoError(t, client.WriteSessions(ctx, sessions))
+	require.NoError(t, client.WriteErrorObjects(ctx, []*model.ErrorObject{
+		{Model: model.Model{ID: 1}, ProjectID: 1, Timestamp: now, ServiceVersion: "1.0.0"},
+		{Model: model.Model{ID: 2}, ProjectID: 1, Timestamp: now, ServiceVersion: "1.0.0"},
+		{Model: model.Model{ID: 3}, ProjectID: 1, Timestamp: now, ServiceVersion: "1.1.0"},
+		{Model: model.Model{ID: 4}, ProjectID: 2, Timestamp: now, ServiceVersion: "1.1.0"},
+	}, sessions))
+
+	stats, err := client.QueryReleaseStats(ctx, 1, []string{"1.0.0", "1.1.0", "2.0.0"}, now.Add(-time.Hour), now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, ReleaseStats{Errors: 2, Sessions: 2, CrashedSessions: 1}, *stats["1.0.0"])
+	assert.Equal(t, ReleaseStats{Errors: 1, Sessions: 3, CrashedSessions: 1}, *stats["1.1.0"])
+	assert.Equal(t, ReleaseStats{}, *stats["2.0.0"])
+
+	adoption, err := client.QueryReleaseAdoption(ctx, 1, "1.1.0", now.Add(-time.Hour), now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, adoption, ReleaseAdoptionBuckets)
+	bucket, ok := lo.Find(adoption, func(bucket *modelInputs.ReleaseAdoptionBucket) bool {
+		return bucket.TotalSessions > 0
+	})
+	require.True(t, ok)
+	assert.Equal(t, uint64(3), bucket.Sessions)
+	assert.Equal(t, uint64(5), bucket.TotalSessions)
+	assert.Equal(t, 60., bucket.AdoptionPercent)
+}
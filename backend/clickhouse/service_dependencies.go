@@ -0,0 +1,244 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/util"
+	"github.com/highlight/highlight/sdk/highlight-go"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+const ServiceDependenciesTable = "service_dependencies"
+
+const (
+	// edges are aggregated per window
+	ServiceDependencyWindow = 5 * time.Minute
+	// windows are computed once late spans of the window are likely to have arrived
+	ServiceDependencyDelay = 5 * time.Minute
+	// the maximum time a job catches up on after downtime
+	ServiceDependencyMaxLookback = 24 * time.Hour
+	// how far before a span its parent span may have started
+	serviceDependencyParentLookback = time.Hour
+)
+
+const (
+	ServiceDependencyTypeService   = "service"
+	ServiceDependencyTypeDatabase  = "database"
+	ServiceDependencyTypeMessaging = "messaging"
+	ServiceDependencyTypeHttp      = "http"
+	ServiceDependencyTypeExternal  = "external"
+)
+
+// spans that are not part of the application's traces
+var serviceDependencySpanFilter = fmt.Sprintf("SpanName != '%s' AND HighlightType != '%s'", highlight.MetricSpanName, highlight.TraceTypeHighlightInternal)
+
+// calls between services are child spans whose parent span belongs to another service.
+// client and producer spans without an instrumented child are calls to an external dependency,
+// identified by the peer, database, messaging or http attributes of the span.
+var writeServiceDependenciesSql = fmt.Sprintf(`
+INSERT INTO %[1]s (ProjectId, Timestamp, Environment, Caller, Callee, CalleeType, CallCount, ErrorCount, DurationQuantiles)
+SELECT ProjectId,
+       toStartOfInterval(Timestamp, INTERVAL %[2]d SECOND) AS Window,
+       Environment,
+       Caller,
+       Callee,
+       CalleeType,
+       count(),
+       countIf(HasErrors),
+       quantilesTDigestState(0.5, 0.95)(Duration)
+FROM (
+    SELECT child.ProjectId    AS ProjectId,
+           child.Timestamp    AS Timestamp,
+           child.Environment  AS Environment,
+           parent.ServiceName AS Caller,
+           child.ServiceName  AS Callee,
+           '%[4]s'            AS CalleeType,
+           child.HasErrors    AS HasErrors,
+           child.Duration     AS Duration
+    FROM (
+        SELECT ProjectId, Timestamp, TraceId, ParentSpanId, ServiceName, Environment, HasErrors, Duration
+        FROM %[3]s
+        WHERE Timestamp >= ? AND Timestamp < ? AND ParentSpanId != '' AND %[9]s
+    ) child
+    INNER JOIN (
+        SELECT ProjectId, TraceId, SpanId, ServiceName
+        FROM %[3]s
+        WHERE Timestamp >= ? AND Timestamp < ? AND %[9]s
+    ) parent ON child.ProjectId = parent.ProjectId AND child.TraceId = parent.TraceId AND child.ParentSpanId = parent.SpanId
+    WHERE child.ServiceName != parent.ServiceName
+    UNION ALL
+    SELECT span.ProjectId, span.Timestamp, span.Environment, span.ServiceName, span.Callee, span.CalleeType, span.HasErrors, span.Duration
+    FROM (
+        SELECT ProjectId, Timestamp, TraceId, SpanId, ServiceName, Environment, HasErrors, Duration,
+               multiIf(
+                   DbAttributes['db.system'] != '', if(DbAttributes['db.name'] != '', concat(DbAttributes['db.system'], '/', DbAttributes['db.name']), DbAttributes['db.system']),
+                   TraceAttributes['messaging.system'] != '', if(TraceAttributes['messaging.destination.name'] != '', concat(TraceAttributes['messaging.system'], '/', TraceAttributes['messaging.destination.name']), TraceAttributes['messaging.system']),
+                   TraceAttributes['peer.service'] != '', TraceAttributes['peer.service'],
+                   HttpUrl != '', domain(HttpUrl),
+                   HttpAttributes['http.host'] != '', HttpAttributes['http.host'],
+                   TraceAttributes['server.address'] != '', TraceAttributes['server.address'],
+                   TraceAttributes['net.peer.name']
+               ) AS Callee,
+               multiIf(
+                   DbAttributes['db.system'] != '', '%[5]s',
+                   TraceAttributes['messaging.system'] != '', '%[6]s',
+                   TraceAttributes['peer.service'] != '', '%[4]s',
+                   HttpUrl != '' OR HttpAttributes['http.host'] != '', '%[7]s',
+                   '%[8]s'
+               ) AS CalleeType
+        FROM %[3]s
+        WHERE Timestamp >= ? AND Timestamp < ? AND SpanKind IN ('Client', 'Producer') AND %[9]s
+    ) span
+    LEFT ANTI JOIN (
+        SELECT ProjectId, TraceId, ParentSpanId
+        FROM %[3]s
+        WHERE Timestamp >= ? AND Timestamp < ? AND ParentSpanId != ''
+    ) children ON span.ProjectId = children.ProjectId AND span.TraceId = children.TraceId AND span.SpanId = children.ParentSpanId
+    WHERE span.Callee != ''
+)
+GROUP BY ProjectId, Window, Environment, Caller, Callee, CalleeType`,
+	ServiceDependenciesTable, int(ServiceDependencyWindow.Seconds()), TracesTable,
+	ServiceDependencyTypeService, ServiceDependencyTypeDatabase, ServiceDependencyTypeMessaging, ServiceDependencyTypeHttp, ServiceDependencyTypeExternal,
+	serviceDependencySpanFilter)
+
+// ServiceDependencyWindows returns the start of the windows that should be computed after the
+// last computed window, up to the latest window for which late spans have likely arrived.
+func ServiceDependencyWindows(lastWindow time.Time, now time.Time) []time.Time {
+	end := now.Add(-ServiceDependencyDelay).Truncate(ServiceDependencyWindow)
+	start := lastWindow.Add(ServiceDependencyWindow)
+	if earliest := end.Add(-ServiceDependencyMaxLookback); start.Before(earliest) {
+		start = earliest
+	}
+
+	var windows []time.Time
+	for window := start; window.Add(ServiceDependencyWindow).Compare(end) <= 0; window = window.Add(ServiceDependencyWindow) {
+		windows = append(windows, window)
+	}
+	return windows
+}
+
+// ReadLastServiceDependencyWindow returns the start of the latest window with stored edges.
+// The worker only uses it until it has stored the watermark of its computed windows.
+func (client *Client) ReadLastServiceDependencyWindow(ctx context.Context) (time.Time, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("max(Timestamp)").From(ServiceDependenciesTable)
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	var lastWindow time.Time
+	if err := client.conn.QueryRow(ctx, sql, args...).Scan(&lastWindow); err != nil {
+		return time.Time{}, err
+	}
+	return lastWindow, nil
+}
+
+// WriteServiceDependencies derives the caller to callee edges of spans starting in the window.
+func (client *Client) WriteServiceDependencies(ctx context.Context, window time.Time) error {
+	span, ctx := util.StartSpanFromContext(ctx, "clickhouse.WriteServiceDependencies", util.Tag("window", window))
+	defer span.Finish()
+
+	start, end := window, window.Add(ServiceDependencyWindow)
+	err := client.conn.Exec(ctx, writeServiceDependenciesSql,
+		// child spans
+		start, end,
+		// parent spans
+		start.Add(-serviceDependencyParentLookback), end,
+		// client and producer spans
+		start, end,
+		// children of client and producer spans may start after the window
+		start, end.Add(ServiceDependencyDelay),
+	)
+	span.Finish(err)
+	return err
+}
+
+// ReadServiceDependencies returns the services of a project, the dependencies they call and the
+// edges between them, aggregated over the date range.
+func (client *Client) ReadServiceDependencies(ctx context.Context, projectID int, environment *string, dateRange modelInputs.DateRangeRequiredInput) (*modelInputs.ServiceDependencyGraph, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "clickhouse.ReadServiceDependencies", util.Tag("projectID", projectID))
+	defer span.Finish()
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		"Caller",
+		"Callee",
+		"CalleeType",
+		"sum(CallCount) AS CallCount",
+		"sum(ErrorCount) AS ErrorCount",
+		"quantilesTDigestMerge(0.5, 0.95)(DurationQuantiles) AS Quantiles",
+	).
+		From(ServiceDependenciesTable).
+		Where(sb.Equal("ProjectId", projectID)).
+		Where(sb.GreaterEqualThan("Timestamp", dateRange.StartDate.Truncate(ServiceDependencyWindow))).
+		Where(sb.LessThan("Timestamp", dateRange.EndDate))
+	if environment != nil {
+		sb.Where(sb.Equal("Environment", *environment))
+	}
+	sb.GroupBy("Caller", "Callee", "CalleeType").
+		OrderBy("Caller", "Callee", "CalleeType")
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	rows, err := client.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &modelInputs.ServiceDependencyGraph{
+		Nodes: []*modelInputs.ServiceDependencyNode{},
+		Edges: []*modelInputs.ServiceDependencyEdge{},
+	}
+	nodes := map[string]string{}
+	for rows.Next() {
+		var result struct {
+			Caller     string
+			Callee     string
+			CalleeType string
+			CallCount  uint64
+			ErrorCount uint64
+			Quantiles  []float64
+		}
+		if err := rows.ScanStruct(&result); err != nil {
+			return nil, err
+		}
+
+		edge := &modelInputs.ServiceDependencyEdge{
+			Caller:     result.Caller,
+			Callee:     result.Callee,
+			CalleeType: result.CalleeType,
+			CallCount:  result.CallCount,
+			ErrorCount: result.ErrorCount,
+		}
+		if result.CallCount > 0 {
+			edge.ErrorRate = float64(result.ErrorCount) / float64(result.CallCount)
+		}
+		if len(result.Quantiles) == 2 {
+			edge.P50Duration = result.Quantiles[0]
+			edge.P95Duration = result.Quantiles[1]
+		}
+		graph.Edges = append(graph.Edges, edge)
+
+		if _, ok := nodes[result.Caller]; !ok {
+			nodes[result.Caller] = ServiceDependencyTypeService
+		}
+		// a peer service may also be instrumented
+		if _, ok := nodes[result.Callee]; !ok || result.CalleeType == ServiceDependencyTypeService {
+			nodes[result.Callee] = result.CalleeType
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for name, nodeType := range nodes {
+		graph.Nodes = append(graph.Nodes, &modelInputs.ServiceDependencyNode{Name: name, Type: nodeType})
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		return graph.Nodes[i].Name < graph.Nodes[j].Name
+	})
+
+	return graph, nil
+}
@@ -0,0 +1,80 @@
+package clickhouse
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceDependencyWindows(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 13, 0, 0, time.UTC)
+
+	// windows up to 5 minutes ago are complete
+	windows := ServiceDependencyWindows(time.Date(2024, 1, 1, 11, 50, 0, 0, time.UTC), now)
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 1, 1, 11, 55, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}, windows)
+
+	assert.Empty(t, ServiceDependencyWindows(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), now))
+
+	// catching up is limited
+	windows = ServiceDependencyWindows(time.Time{}, now)
+	assert.Len(t, windows, int(ServiceDependencyMaxLookback/ServiceDependencyWindow))
+	assert.Equal(t, time.Date(2023, 12, 31, 12, 5, 0, 0, time.UTC), windows[0])
+}
+
+func TestReadServiceDependencies(t *testing.T) {
+	ctx := context.Background()
+	client, teardown := setupTest(t)
+	defer teardown(t)
+
+	window := time.Now().Add(-time.Hour).Truncate(ServiceDependencyWindow)
+	now := window.Add(time.Minute)
+	var rows []*ClickhouseTraceRow
+	for i := 0; i < 4; i++ {
+		traceID := []string{"a", "b", "c", "d"}[i]
+		rows = append(rows,
+			// an instrumented call from the frontend to the api
+			NewTraceRow(now, 1).WithTraceId(traceID).WithSpanId(traceID+"1").WithServiceName("frontend").WithSpanKind("Client").WithEnvironment("production").WithTraceAttributes(map[string]string{"http.url": "https://api.example.com/graphql"}).AsClickhouseTraceRow(),
+			NewTraceRow(now, 1).WithTraceId(traceID).WithSpanId(traceID+"2").WithParentSpanId(traceID+"1").WithServiceName("api").WithSpanKind("Server").WithEnvironment("production").WithDuration(now, now.Add(time.Duration(i+1)*time.Millisecond)).WithHasErrors(i == 0).AsClickhouseTraceRow(),
+			// spans within a service are not edges
+			NewTraceRow(now, 1).WithTraceId(traceID).WithSpanId(traceID+"3").WithParentSpanId(traceID+"2").WithServiceName("api").WithSpanKind("Internal").WithEnvironment("production").AsClickhouseTraceRow(),
+			// calls to uninstrumented dependencies
+			NewTraceRow(now, 1).WithTraceId(traceID).WithSpanId(traceID+"4").WithParentSpanId(traceID+"3").WithServiceName("api").WithSpanKind("Client").WithEnvironment("production").WithTraceAttributes(map[string]string{"db.system": "postgresql", "db.name": "app"}).AsClickhouseTraceRow(),
+			NewTraceRow(now, 1).WithTraceId(traceID).WithSpanId(traceID+"5").WithParentSpanId(traceID+"3").WithServiceName("api").WithSpanKind("Client").WithEnvironment("production").WithTraceAttributes(map[string]string{"http.url": "https://api.stripe.com/v1/charges"}).AsClickhouseTraceRow(),
+		)
+	}
+	assert.NoError(t, client.BatchWriteTraceRows(ctx, rows))
+	assert.NoError(t, client.WriteServiceDependencies(ctx, window))
+
+	lastWindow, err := client.ReadLastServiceDependencyWindow(ctx)
+	assert.NoError(t, err)
+	assert.True(t, window.Equal(lastWindow))
+
+	graph, err := client.ReadServiceDependencies(ctx, 1, nil, *makeDateWithinRange(now))
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelInputs.ServiceDependencyNode{
+		{Name: "api", Type: ServiceDependencyTypeService},
+		{Name: "api.stripe.com", Type: ServiceDependencyTypeHttp},
+		{Name: "frontend", Type: ServiceDependencyTypeService},
+		{Name: "postgresql/app", Type: ServiceDependencyTypeDatabase},
+	}, graph.Nodes)
+	assert.Len(t, graph.Edges, 3)
+
+	edge := graph.Edges[2]
+	assert.Equal(t, "frontend", edge.Caller)
+	assert.Equal(t, "api", edge.Callee)
+	assert.Equal(t, uint64(4), edge.CallCount)
+	assert.Equal(t, uint64(1), edge.ErrorCount)
+	assert.Equal(t, 0.25, edge.ErrorRate)
+	assert.Greater(t, edge.P95Duration, edge.P50Duration)
+
+	graph, err = client.ReadServiceDependencies(ctx, 1, pointy.String("development"), *makeDateWithinRange(now))
+	assert.NoError(t, err)
+	assert.Empty(t, graph.Edges)
+}
@@ -0,0 +1,259 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/highlight-run/highlight/backend/parser"
+	"github.com/highlight-run/highlight/backend/parser/listener"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/util"
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/samber/lo"
+)
+
+// TraceServiceMetricsTable holds request count, error count and latency of spans per minute,
+// service, span name, span kind and environment. It is populated from traces on insert.
+const TraceServiceMetricsTable = "trace_service_metrics"
+
+// the minimum bucket size for which pre-aggregated rows are precise enough
+const serviceMetricsMinBucketSize = time.Minute
+
+var serviceMetricsKeysToColumns = map[string]string{
+	string(modelInputs.ReservedTraceKeyServiceName): "ServiceName",
+	string(modelInputs.ReservedTraceKeySpanName):    "SpanName",
+	string(modelInputs.ReservedTraceKeySpanKind):    "SpanKind",
+	string(modelInputs.ReservedTraceKeyEnvironment): "Environment",
+	string(modelInputs.ReservedTraceKeyTimestamp):   "Timestamp",
+}
+
+var serviceMetricsFilterColumns = map[string]bool{
+	"ServiceName": true,
+	"SpanName":    true,
+	"SpanKind":    true,
+	"Environment": true,
+}
+
+var serviceMetricsQuantiles = map[modelInputs.MetricAggregator]int{
+	modelInputs.MetricAggregatorP50: 1,
+	modelInputs.MetricAggregatorP90: 2,
+	modelInputs.MetricAggregatorP95: 3,
+	modelInputs.MetricAggregatorP99: 4,
+}
+
+var ServiceMetricsTableConfig = model.TableConfig{
+	TableName:     TraceServiceMetricsTable,
+	KeysToColumns: serviceMetricsKeysToColumns,
+	BodyColumn:    "SpanName",
+}
+
+var toStringColumn = regexp.MustCompile(`^toString\((\w+)\)$`)
+
+// serviceMetricsExpression returns the aggregation over trace_service_metrics equivalent to
+// the expression over traces. Returns false if the expression cannot be served from the table.
+func serviceMetricsExpression(e *modelInputs.MetricExpressionInput) (string, bool) {
+	if e.Aggregator == modelInputs.MetricAggregatorCount {
+		return "toFloat64(sum(Count))", true
+	}
+	switch strings.ToLower(e.Column) {
+	case string(modelInputs.ReservedTraceKeyDuration):
+		if idx, ok := serviceMetricsQuantiles[e.Aggregator]; ok {
+			return fmt.Sprintf("quantilesTDigestMerge(0.5, 0.9, 0.95, 0.99)(DurationQuantiles)[%d]", idx), true
+		}
+		switch e.Aggregator {
+		case modelInputs.MetricAggregatorAvg:
+			return "sum(DurationSum) / sum(Count)", true
+		case modelInputs.MetricAggregatorSum:
+			return "sum(DurationSum)", true
+		case modelInputs.MetricAggregatorMin:
+			return "toFloat64(min(DurationMin))", true
+		case modelInputs.MetricAggregatorMax:
+			return "toFloat64(max(DurationMax))", true
+		}
+	case string(modelInputs.ReservedTraceKeyHasErrors):
+		switch e.Aggregator {
+		case modelInputs.MetricAggregatorSum:
+			return "toFloat64(sum(ErrorCount))", true
+		case modelInputs.MetricAggregatorAvg:
+			// the error ratio
+			return "sum(ErrorCount) / sum(Count)", true
+		}
+	}
+	return "", false
+}
+
+func serviceMetricsFiltersSupported(filters listener.Filters) bool {
+	for _, filter := range filters {
+		if len(filter.Filters) > 0 {
+			if !serviceMetricsFiltersSupported(filter.Filters) {
+				return false
+			}
+			continue
+		}
+		key := filter.Key
+		if groups := toStringColumn.FindStringSubmatch(key); len(groups) > 0 {
+			key = groups[1]
+		}
+		if filter.Column != "" || !serviceMetricsFilterColumns[key] {
+			return false
+		}
+	}
+	return true
+}
+
+// ServiceMetricsSupported reports whether a metrics query over traces can be served from
+// the pre-aggregated trace_service_metrics table instead of scanning traces.
+func ServiceMetricsSupported(input ReadMetricsInput) bool {
+	if input.SampleableConfig.tableConfig.TableName != TracesTable {
+		return false
+	}
+	if input.Sql != nil || input.SavedMetricState != nil || len(input.Expressions) == 0 {
+		return false
+	}
+	if input.BucketBy != modelInputs.MetricBucketByTimestamp.String() && input.BucketBy != modelInputs.MetricBucketByNone.String() {
+		return false
+	}
+	if input.LimitAggregator != nil && (*input.LimitAggregator != modelInputs.MetricAggregatorCount || len(input.GroupBy) == 0) {
+		return false
+	}
+	if input.Params.DateRange != nil && input.BucketBy == modelInputs.MetricBucketByTimestamp.String() {
+		bucketSize := input.Params.DateRange.EndDate.Sub(input.Params.DateRange.StartDate)
+		if input.BucketWindow != nil {
+			bucketSize = time.Duration(*input.BucketWindow) * time.Second
+		} else if input.BucketCount != nil && *input.BucketCount > 0 {
+			bucketSize /= time.Duration(*input.BucketCount)
+		}
+		if bucketSize < serviceMetricsMinBucketSize {
+			return false
+		}
+	}
+	for _, e := range input.Expressions {
+		if _, ok := serviceMetricsExpression(e); !ok {
+			return false
+		}
+	}
+	for _, group := range input.GroupBy {
+		if col, ok := serviceMetricsKeysToColumns[group]; !ok || !serviceMetricsFilterColumns[col] {
+			return false
+		}
+	}
+	// the default traces filter is applied when the table is populated
+	if strings.Contains(input.Params.Query, string(modelInputs.ReservedTraceKeyMetricName)) {
+		return false
+	}
+	return serviceMetricsFiltersSupported(parser.Parse(input.Params.Query, TracesTableNoDefaultConfig))
+}
+
+// ReadServiceMetrics reads rate, errors and duration of spans from the pre-aggregated
+// trace_service_metrics table. Only inputs for which ServiceMetricsSupported is true can be read.
+func (client *Client) ReadServiceMetrics(ctx context.Context, input ReadMetricsInput) (*modelInputs.MetricsBuckets, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "clickhouse.readServiceMetrics")
+	span.SetAttribute("project_ids", input.ProjectIDs)
+	defer span.Finish()
+
+	if input.Params.DateRange == nil {
+		input.Params.DateRange = &modelInputs.DateRangeRequiredInput{
+			StartDate: time.Now().Add(-time.Hour * 24 * 30),
+			EndDate:   time.Now(),
+		}
+	}
+
+	// the table only has minute resolution, so include the minute of the start date
+	params := input.Params
+	params.DateRange = &modelInputs.DateRangeRequiredInput{
+		StartDate: input.Params.DateRange.StartDate.Truncate(time.Minute),
+		EndDate:   input.Params.DateRange.EndDate,
+	}
+	fromSb, _, err := makeSelectBuilder(
+		ServiceMetricsTableConfig,
+		nil,
+		input.ProjectIDs,
+		params,
+		Pagination{CountOnly: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	bucketingInfo := getBucketing(ServiceMetricsTableConfig, input, fromSb)
+	selectCols := append(bucketingInfo.NewSelectItems,
+		"Count", "ErrorCount", "DurationSum", "DurationMin", "DurationMax", "DurationQuantiles")
+
+	var groupAliases []string
+	for idx, group := range input.GroupBy {
+		groupAlias := fmt.Sprintf("g%d", idx)
+		groupAliases = append(groupAliases, groupAlias)
+		selectCols = append(selectCols, fromSb.As(fmt.Sprintf("toString(%s)", serviceMetricsKeysToColumns[group]), groupAlias))
+		fromSb.Where(fromSb.NotEqual(groupAlias, ""))
+	}
+
+	limitCount := 10
+	if input.Limit != nil {
+		limitCount = lo.Max([]int{*input.Limit, 1})
+	}
+	useLimit := input.LimitAggregator != nil && len(input.GroupBy) > 0 && limitCount != NoLimit
+	if useLimit {
+		selectCols = append(selectCols, fmt.Sprintf("sum(Count) OVER (PARTITION BY %s) as limit_metric", strings.Join(groupAliases, ", ")))
+	}
+	fromSb.Select(selectCols...)
+
+	innerSb := fromSb
+	fromSb = sqlbuilder.NewSelectBuilder()
+	outerSelect := []string{bucketIndexAlias, "1.0 as sample_factor", fmt.Sprintf("any(%s) as %s", minAlias, minAlias), fmt.Sprintf("any(%s) as %s", maxAlias, maxAlias)}
+	for idx, e := range input.Expressions {
+		expr, _ := serviceMetricsExpression(e)
+		outerSelect = append(outerSelect, fmt.Sprintf("%s as metric_value%d", expr, idx))
+	}
+	outerSelect = append(outerSelect, groupAliases...)
+	groupByCols := []string{bucketIndexAlias}
+	orderByCols := []string{bucketIndexAlias}
+	if useLimit {
+		outerSelect = append(outerSelect, fmt.Sprintf("dense_rank() OVER (ORDER BY limit_metric DESC, %s) as limit_rank", strings.Join(groupAliases, ", ")))
+		groupByCols = append(groupByCols, "limit_metric")
+		orderByCols = append(orderByCols, "limit_rank")
+	}
+	groupByCols = append(groupByCols, groupAliases...)
+	orderByCols = append(orderByCols, groupAliases...)
+	fromSb.Select(outerSelect...)
+	fromSb.From(fromSb.BuilderAs(innerSb, "inner"))
+	fromSb.GroupBy(groupByCols...)
+
+	if useLimit {
+		outerSelect := []string{bucketIndexAlias, "sample_factor", minAlias, maxAlias}
+		for idx := range input.Expressions {
+			outerSelect = append(outerSelect, fmt.Sprintf("metric_value%d", idx))
+		}
+		outerSelect = append(outerSelect, groupAliases...)
+
+		innerSb := fromSb
+		fromSb = sqlbuilder.NewSelectBuilder()
+		fromSb.Select(outerSelect...)
+		fromSb.From(fromSb.BuilderAs(innerSb, "outer"))
+		fromSb.Where(fromSb.LessEqualThan("limit_rank", limitCount))
+	}
+
+	fromSb.OrderBy(orderByCols...)
+	fromSb.Limit(10000)
+
+	return client.queryMetricBuckets(ctx, fromSb, input, bucketingInfo.BucketCount)
+}
+
+// serviceMetricsCover reports whether trace_service_metrics has rows for the projects
+// from the start of the date range, since spans ingested before the table existed are not aggregated.
+func (client *Client) serviceMetricsCover(ctx context.Context, projectIDs []int, startDate time.Time) (bool, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("min(Timestamp)").
+		From(TraceServiceMetricsTable).
+		Where(sb.In("ProjectId", lo.ToAnySlice(projectIDs)...))
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	var first time.Time
+	if err := client.conn.QueryRow(ctx, sql, args...).Scan(&first); err != nil {
+		return false, err
+	}
+	return !first.IsZero() && first.Unix() > 0 && !first.After(startDate.Truncate(time.Minute)), nil
+}
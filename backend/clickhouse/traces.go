@@ -655,6 +655,30 @@ func TraceMatchesQuery(trace *TraceRow, filters listener.Filters) bool {
 	return matchesQuery(trace, TracesTableConfig, filters, listener.OperatorAnd)
 }
 
+// ClickhouseTraceMatchesQuery matches a span that has already been converted for writing.
+// Attributes split into their prefixed columns are merged back so that queries match as on a TraceRow.
+func ClickhouseTraceMatchesQuery(trace *ClickhouseTraceRow, filters listener.Filters) bool {
+	row := *trace
+	row.TraceAttributes = lo.Assign(
+		trace.TraceAttributes,
+		trace.HttpAttributes,
+		trace.ProcessAttributes,
+		trace.OsAttributes,
+		trace.TelemetryAttributes,
+		trace.WsAttributes,
+		trace.EventAttributes,
+		trace.DbAttributes,
+		lo.OmitByValues(map[string]string{
+			HttpResponseBodyKey: trace.HttpResponseBody,
+			HttpRequestBodyKey:  trace.HttpRequestBody,
+			HttpUrlKey:          trace.HttpUrl,
+			HighlightKeyKey:     trace.HighlightKey,
+			HighlightTypeKey:    trace.HighlightType,
+		}, []string{""}),
+	)
+	return matchesQuery(&row, TracesTableConfig, filters, listener.OperatorAnd)
+}
+
 func (client *Client) TracesLogLines(ctx context.Context, projectID int, params modelInputs.QueryInput) ([]*modelInputs.LogLine, error) {
 	return logLines(ctx, client, TracesTableConfig, projectID, params)
 }
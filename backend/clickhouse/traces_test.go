@@ -9,6 +9,7 @@ import (
 	"github.com/highlight-run/highlight/backend/parser"
 
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/openlyinc/pointy"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -155,6 +156,62 @@ func Test_TraceMatchesQuery_v2(t *testing.T) {
 	assert.True(t, matches)
 }
 
+func Test_TraceMatchesQuery_Operators(t *testing.T) {
+	trace := TraceRow{
+		SpanName:        "gorm.Query",
+		Duration:        int64(150 * time.Millisecond),
+		ServiceName:     "public-worker-main",
+		TraceAttributes: map[string]string{"os.type": "linux", "http.status": "404"},
+	}
+
+	filters := parser.Parse("service_name IN (private-graph, public-worker-main)", TracesTableNoDefaultConfig)
+	assert.True(t, TraceMatchesQuery(&trace, filters))
+	filters = parser.Parse("service_name NOT IN (private-graph, public-worker-main)", TracesTableNoDefaultConfig)
+	assert.False(t, TraceMatchesQuery(&trace, filters))
+	filters = parser.Parse("os.type IN (darwin,windows)", TracesTableNoDefaultConfig)
+	assert.False(t, TraceMatchesQuery(&trace, filters))
+
+	filters = parser.Parse("duration BETWEEN 100ms AND 2s", TracesTableNoDefaultConfig)
+	assert.True(t, TraceMatchesQuery(&trace, filters))
+	filters = parser.Parse("duration BETWEEN 200ms AND 2s", TracesTableNoDefaultConfig)
+	assert.False(t, TraceMatchesQuery(&trace, filters))
+	filters = parser.Parse("http.status NOT BETWEEN 400 AND 499", TracesTableNoDefaultConfig)
+	assert.False(t, TraceMatchesQuery(&trace, filters))
+
+	filters = parser.Parse("span_name=~^gorm\\.", TracesTableNoDefaultConfig)
+	assert.True(t, TraceMatchesQuery(&trace, filters))
+	filters = parser.Parse("service_name!~worker", TracesTableNoDefaultConfig)
+	assert.False(t, TraceMatchesQuery(&trace, filters))
+}
+
+func Test_ClickhouseTraceMatchesQuery(t *testing.T) {
+	trace := ConvertTraceRow(&TraceRow{
+		SpanName:    "GET /api",
+		Duration:    int64(3 * time.Second),
+		ServiceName: "private-graph",
+		TraceAttributes: map[string]string{
+			"http.method": "GET",
+			"http.url":    "https://app.highlight.io/api",
+			"db.system":   "postgresql",
+			"user":        "vadim",
+		},
+	})
+
+	filters := parser.Parse("http.method=GET db.system=postgresql user=vadim", TracesTableNoDefaultConfig)
+	assert.True(t, ClickhouseTraceMatchesQuery(trace, filters))
+	filters = parser.Parse("http.url=*app.highlight.io*", TracesTableNoDefaultConfig)
+	assert.True(t, ClickhouseTraceMatchesQuery(trace, filters))
+	filters = parser.Parse("parent_span_id=\"\" duration>2s", TracesTableNoDefaultConfig)
+	assert.True(t, ClickhouseTraceMatchesQuery(trace, filters))
+	filters = parser.Parse("duration<2s", TracesTableNoDefaultConfig)
+	assert.False(t, ClickhouseTraceMatchesQuery(trace, filters))
+	filters = parser.Parse("has_errors=true", TracesTableNoDefaultConfig)
+	assert.False(t, ClickhouseTraceMatchesQuery(trace, filters))
+
+	// the converted row is not modified
+	assert.NotContains(t, trace.TraceAttributes, "http.method")
+}
+
 func TestReadTracesWithEnvironmentFilter(t *testing.T) {
 	ctx := context.Background()
 	client, teardown := setupTest(t)
@@ -246,3 +303,85 @@ func TestReadTracesWithSorting(t *testing.T) {
 	assert.Equal(t, "Span A", payload.Edges[1].Node.SpanName)
 	assert.Equal(t, "Span C", payload.Edges[2].Node.SpanName)
 }
+
+func Test_ServiceMetricsSupported(t *testing.T) {
+	now := time.Now()
+	newInput := func(query string, groupBy []string, expressions ...*modelInputs.MetricExpressionInput) ReadMetricsInput {
+		return ReadMetricsInput{
+			SampleableConfig: TracesSampleableTableConfig,
+			ProjectIDs:       []int{1},
+			Params:           modelInputs.QueryInput{Query: query, DateRange: makeDateWithinRange(now)},
+			GroupBy:          groupBy,
+			BucketCount:      pointy.Int(12),
+			BucketBy:         modelInputs.MetricBucketByTimestamp.String(),
+			Expressions:      expressions,
+		}
+	}
+	count := &modelInputs.MetricExpressionInput{Aggregator: modelInputs.MetricAggregatorCount}
+	p95 := &modelInputs.MetricExpressionInput{Aggregator: modelInputs.MetricAggregatorP95, Column: string(modelInputs.ReservedTraceKeyDuration)}
+	errorRatio := &modelInputs.MetricExpressionInput{Aggregator: modelInputs.MetricAggregatorAvg, Column: string(modelInputs.ReservedTraceKeyHasErrors)}
+
+	assert.True(t, ServiceMetricsSupported(newInput("", nil, count, p95, errorRatio)))
+	assert.True(t, ServiceMetricsSupported(newInput(`service_name=api environment=production span_kind!=Internal`, []string{"span_name"}, count)))
+	assert.True(t, ServiceMetricsSupported(newInput(`(service_name=api OR service_name=web) "GET /"`, []string{"service_name", "environment"}, p95)))
+
+	// attributes and unaggregated columns are only in traces
+	assert.False(t, ServiceMetricsSupported(newInput(`http.method=GET`, nil, count)))
+	assert.False(t, ServiceMetricsSupported(newInput(`duration>1s`, nil, count)))
+	assert.False(t, ServiceMetricsSupported(newInput("", []string{"trace_id"}, count)))
+	assert.False(t, ServiceMetricsSupported(newInput("", nil, &modelInputs.MetricExpressionInput{Aggregator: modelInputs.MetricAggregatorCountDistinct, Column: "trace_id"})))
+	assert.False(t, ServiceMetricsSupported(newInput("metric_name=cpu", nil, count)))
+
+	input := newInput("", nil, count)
+	input.BucketCount = pointy.Int(MaxBuckets)
+	assert.False(t, ServiceMetricsSupported(input), "buckets smaller than a minute")
+
+	input = newInput("", nil, count)
+	input.SampleableConfig = LogsSampleableTableConfig
+	assert.False(t, ServiceMetricsSupported(input))
+}
+
+func TestReadServiceMetrics(t *testing.T) {
+	ctx := context.Background()
+	client, teardown := setupTest(t)
+	defer teardown(t)
+
+	now := time.Now()
+	var rows []*ClickhouseTraceRow
+	for i := 0; i < 10; i++ {
+		rows = append(rows,
+			NewTraceRow(now, 1).WithServiceName("api").WithSpanName("GET /").WithSpanKind("Server").WithEnvironment("production").WithDuration(now, now.Add(time.Duration(i+1)*time.Millisecond)).WithHasErrors(i%5 == 0).AsClickhouseTraceRow(),
+			NewTraceRow(now, 1).WithServiceName("web").WithSpanName("render").WithSpanKind("Internal").WithEnvironment("production").WithDuration(now, now.Add(time.Millisecond)).AsClickhouseTraceRow(),
+		)
+	}
+	assert.NoError(t, client.BatchWriteTraceRows(ctx, rows))
+
+	input := ReadMetricsInput{
+		SampleableConfig: TracesSampleableTableConfig,
+		ProjectIDs:       []int{1},
+		Params:           modelInputs.QueryInput{Query: "environment=production", DateRange: makeDateWithinRange(now)},
+		GroupBy:          []string{string(modelInputs.ReservedTraceKeyServiceName)},
+		BucketBy:         modelInputs.MetricBucketByNone.String(),
+		Expressions: []*modelInputs.MetricExpressionInput{
+			{Aggregator: modelInputs.MetricAggregatorCount},
+			{Aggregator: modelInputs.MetricAggregatorAvg, Column: string(modelInputs.ReservedTraceKeyHasErrors)},
+			{Aggregator: modelInputs.MetricAggregatorMax, Column: string(modelInputs.ReservedTraceKeyDuration)},
+		},
+	}
+	assert.True(t, ServiceMetricsSupported(input))
+
+	metrics, err := client.ReadServiceMetrics(ctx, input)
+	assert.NoError(t, err)
+	values := map[string]float64{}
+	for _, bucket := range metrics.Buckets {
+		values[fmt.Sprintf("%s %s", bucket.Group[0], bucket.MetricType)] = *bucket.MetricValue
+	}
+	assert.Equal(t, map[string]float64{
+		"api Count": 10,
+		"api Avg":   0.2,
+		"api Max":   float64(10 * time.Millisecond),
+		"web Count": 10,
+		"web Avg":   0,
+		"web Max":   float64(time.Millisecond),
+	}, values)
+}
@@ -33,6 +33,7 @@ type Configuration struct {
 	AwsCloudfrontPublicKeyID    string `mapstructure:"AWS_CLOUDFRONT_PUBLIC_KEY_ID"`
 	AwsRoleArn                  string `mapstructure:"AWS_ROLE_ARN"`
 	AwsS3BucketName             string `mapstructure:"AWS_S3_BUCKET_NAME_NEW"`
+	AwsS3ExportBucketName       string `mapstructure:"AWS_S3_EXPORT_BUCKET_NAME"`
 	AwsS3GithubBucketName       string `mapstructure:"AWS_S3_GITHUB_BUCKET_NAME"`
 	AwsS3ResourcesBucketName    string `mapstructure:"AWS_S3_RESOURCES_BUCKET"`
 	AwsS3SourceMapBucketName    string `mapstructure:"AWS_S3_SOURCE_MAP_BUCKET_NAME_NEW"`
@@ -81,6 +82,8 @@ type Configuration struct {
 	JiraClientId                string `mapstructure:"JIRA_CLIENT_ID"`
 	JiraClientSecret            string `mapstructure:"JIRA_CLIENT_SECRET"`
 	KafkaEnvPrefix              string `mapstructure:"KAFKA_ENV_PREFIX"`
+	KafkaMessageCompression     string `mapstructure:"KAFKA_MESSAGE_COMPRESSION"` // codec for all topics, or comma separated topic=codec
+	KafkaMessageSizeBytes       string `mapstructure:"KAFKA_MESSAGE_SIZE_BYTES"`  // bytes for all topics, or comma separated topic=bytes
 	KafkaSASLPassword           string `mapstructure:"KAFKA_SASL_PASSWORD"`
 	KafkaSASLUsername           string `mapstructure:"KAFKA_SASL_USERNAME"`
 	KafkaServers                string `mapstructure:"KAFKA_SERVERS"`
@@ -98,10 +101,12 @@ type Configuration struct {
 	OAuthRedirectUrl            string `mapstructure:"OAUTH_REDIRECT_URL"`
 	OTLPDogfoodEndpoint         string `mapstructure:"OTLP_DOGFOOD_ENDPOINT"`
 	OTLPEndpoint                string `mapstructure:"OTLP_ENDPOINT"`
+	OTLPGRPCPort                string `mapstructure:"OTLP_GRPC_PORT"`
 	ObjectStorageFS             string `mapstructure:"OBJECT_STORAGE_FS"`
 	OnPrem                      string `mapstructure:"ON_PREM"`
 	OpenAIApiKey                string `mapstructure:"OPENAI_API_KEY"`
 	PredictionsEndpoint         string `mapstructure:"PREDICTIONS_ENDPOINT"`
+	PredictionsModel            string `mapstructure:"PREDICTIONS_MODEL"` // builtin or remote, defaults to remote when PREDICTIONS_ENDPOINT is set
 	PricingBasicPriceID         string `mapstructure:"BASIC_PLAN_PRICE_ID"`
 	PricingEnterprisePriceID    string `mapstructure:"ENTERPRISE_PLAN_PRICE_ID"`
 	PricingStartupPriceID       string `mapstructure:"STARTUP_PLAN_PRICE_ID"`
@@ -127,6 +132,12 @@ type Configuration struct {
 	StripeErrorsProductID       string `mapstructure:"STRIPE_ERRORS_PRODUCT_ID"`
 	StripeSessionsProductID     string `mapstructure:"STRIPE_SESSIONS_PRODUCT_ID"`
 	StripeWebhookSecret         string `mapstructure:"STRIPE_WEBHOOK_SECRET"`
+	SyslogProjectPorts          string `mapstructure:"SYSLOG_PROJECT_PORTS"` // comma separated port=project, listening on udp and tcp
+	SyslogTCPPort               string `mapstructure:"SYSLOG_TCP_PORT"`
+	SyslogTLSCertFile           string `mapstructure:"SYSLOG_TLS_CERT_FILE"`
+	SyslogTLSKeyFile            string `mapstructure:"SYSLOG_TLS_KEY_FILE"`
+	SyslogTLSPort               string `mapstructure:"SYSLOG_TLS_PORT"`
+	SyslogUDPPort               string `mapstructure:"SYSLOG_UDP_PORT"`
 	VercelClientId              string `mapstructure:"VERCEL_CLIENT_ID"`
 	VercelClientSecret          string `mapstructure:"VERCEL_CLIENT_SECRET"`
 	Version                     string `mapstructure:"REACT_APP_COMMIT_SHA"`
@@ -41,13 +41,17 @@ func GetFingerprints(projectID int, errorTraces []*privateModel.ErrorTrace) []*m
 	return fingerprints
 }
 
-func GetKey(projectID int, errorObj *model.ErrorObject, structuredStackTrace []*privateModel.ErrorTrace) string {
+// GetKey returns the key caching the error group of an error object, built from the result of the project's grouping rules.
+func GetKey(projectID int, errorObj *model.ErrorObject, grouping *GroupingResult) string {
+	if grouping.Fingerprint != "" {
+		return fmt.Sprintf("error-object-group-%d-rule-%s", projectID, grouping.FingerprintHash())
+	}
 	var fingerprintsStr string
-	for _, fp := range GetFingerprints(projectID, structuredStackTrace) {
+	for _, fp := range GetFingerprints(projectID, grouping.Frames) {
 		fingerprintsStr = fmt.Sprintf("%s%s%s%d ", fingerprintsStr, fp.Type, fp.Value, fp.Index)
 	}
 	stackBody := joinStringPtrs(errorObj.StackTrace, ptr.String(fingerprintsStr))
-	return fmt.Sprintf("error-object-group-%d-%s-%s", projectID, errorObj.Event, stackBody)
+	return fmt.Sprintf("error-object-group-%d-%s-%s", projectID, grouping.Event, stackBody)
 }
 
 // A key for deduping error objects with the same event and untransformed stack trace
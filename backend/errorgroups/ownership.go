@@ -0,0 +1,117 @@
+package errorgroups
+
+import (
+	"regexp"
+	"strings"
+
+	e "github.com/pkg/errors"
+
+	"github.com/highlight-run/highlight/backend/model"
+	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/util"
+)
+
+// compileCodeOwnersPattern converts a CODEOWNERS style glob to a regular expression. `*` matches
+// within a path segment, `**` across segments, and a pattern matching a directory matches the files
+// in it. As stack traces have absolute or bundler paths rather than paths relative to the
+// repository, patterns match at any directory of the path, so `/src/api/` matches `/app/src/api/x.ts`.
+func compileCodeOwnersPattern(pattern string) (*regexp.Regexp, error) {
+	glob := strings.Trim(strings.TrimSpace(pattern), "/")
+	if glob == "" {
+		return nil, e.New("empty file path pattern")
+	}
+
+	var expr strings.Builder
+	expr.WriteString(`(^|/)`)
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					// `**/` matches zero or more directories
+					i++
+					expr.WriteString(`(.*/)?`)
+				} else {
+					expr.WriteString(`.*`)
+				}
+			} else {
+				expr.WriteString(`[^/]*`)
+			}
+		case '?':
+			expr.WriteString(`[^/]`)
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString(`(/.*)?$`)
+
+	return util.CompileRegexp(expr.String())
+}
+
+// MatchesCodeOwnersPattern returns true if the file path matches a CODEOWNERS style glob.
+func MatchesCodeOwnersPattern(pattern string, filePath string) bool {
+	re, err := compileCodeOwnersPattern(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(strings.ReplaceAll(filePath, `\`, "/"))
+}
+
+// ValidateOwnershipRule returns an error if a rule is missing the fields required by its type
+// or does not assign exactly one admin or team.
+func ValidateOwnershipRule(rule *model.ErrorOwnershipRule) error {
+	if (rule.AdminID == nil) == (rule.TeamID == nil) {
+		return e.New("ownership rule requires either an admin or a team")
+	}
+	switch rule.Type {
+	case privateModel.ErrorOwnershipRuleTypeFilePath:
+		if _, err := compileCodeOwnersPattern(rule.Pattern); err != nil {
+			return e.Wrapf(err, "invalid file path pattern %s", rule.Pattern)
+		}
+		return nil
+	case privateModel.ErrorOwnershipRuleTypeServiceName:
+		if rule.Pattern == "" {
+			return e.New("service name ownership rule requires a pattern")
+		}
+	case privateModel.ErrorOwnershipRuleTypeAttribute:
+		if rule.Attribute == "" {
+			return e.New("attribute ownership rule requires an attribute")
+		}
+	default:
+		return e.Errorf("invalid ownership rule type %s", rule.Type)
+	}
+	if _, err := util.CompileRegexp(rule.Pattern); err != nil {
+		return e.Wrapf(err, "invalid ownership rule pattern %s", rule.Pattern)
+	}
+	return nil
+}
+
+// MatchOwnershipRule returns the first ownership rule matching an error, or nil. FilePath rules
+// match the file of the top in-app frame, ServiceName rules the service of the error, and
+// Attribute rules the value of an attribute of the error payload.
+func MatchOwnershipRule(rules []*model.ErrorOwnershipRule, errorObj *model.ErrorObject, frames []*privateModel.ErrorTrace) *model.ErrorOwnershipRule {
+	for _, rule := range rules {
+		switch rule.Type {
+		case privateModel.ErrorOwnershipRuleTypeFilePath:
+			frame := topInAppFrame(frames)
+			if frame != nil && frame.FileName != nil && MatchesCodeOwnersPattern(rule.Pattern, *frame.FileName) {
+				return rule
+			}
+		case privateModel.ErrorOwnershipRuleTypeServiceName:
+			re, err := util.CompileRegexp(rule.Pattern)
+			if err == nil && errorObj.ServiceName != "" && re.MatchString(errorObj.ServiceName) {
+				return rule
+			}
+		case privateModel.ErrorOwnershipRuleTypeAttribute:
+			re, err := util.CompileRegexp(rule.Pattern)
+			if err != nil {
+				continue
+			}
+			if value, ok := payloadAttribute(errorObj.Payload, rule.Attribute); ok && re.MatchString(value) {
+				return rule
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package errorgroups
+
+import (
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/highlight-run/highlight/backend/model"
+	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesCodeOwnersPattern(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		path    string
+		matches bool
+	}{
+		{"*.ts", "/app/src/orders.ts", true},
+		{"*.ts", "/app/src/orders.js", false},
+		{"/src/", "/app/src/orders.ts", true},
+		{"src/billing/", "/app/src/orders.ts", false},
+		{"src/*.ts", "/app/src/orders.ts", true},
+		{"src/*.ts", "/app/src/api/orders.ts", false},
+		{"src/**/orders.ts", "/app/src/api/v1/orders.ts", true},
+		{"src/**/orders.ts", "/app/src/orders.ts", true},
+		{"docs/**", "docs/a/b.md", true},
+		{"orders", "webpack:///./src/orders/index.ts", true},
+		{"order?.ts", "src/orders.ts", true},
+		{"rders.ts", "src/orders.ts", false},
+		{"src/api", `C:\app\src\api\handler.cs`, true},
+	} {
+		assert.Equal(t, tc.matches, MatchesCodeOwnersPattern(tc.pattern, tc.path), "%s %s", tc.pattern, tc.path)
+	}
+}
+
+func TestValidateOwnershipRule(t *testing.T) {
+	rule := &model.ErrorOwnershipRule{Type: privateModel.ErrorOwnershipRuleTypeFilePath, Pattern: "/src/api/"}
+	assert.Error(t, ValidateOwnershipRule(rule))
+	rule.AdminID = ptr.Int(1)
+	assert.NoError(t, ValidateOwnershipRule(rule))
+	rule.TeamID = ptr.Int(1)
+	assert.Error(t, ValidateOwnershipRule(rule))
+	rule.AdminID = nil
+
+	rule.Pattern = "/"
+	assert.Error(t, ValidateOwnershipRule(rule))
+
+	rule.Type = privateModel.ErrorOwnershipRuleTypeServiceName
+	rule.Pattern = "(checkout"
+	assert.Error(t, ValidateOwnershipRule(rule))
+
+	rule.Type = privateModel.ErrorOwnershipRuleTypeAttribute
+	rule.Pattern = ""
+	assert.Error(t, ValidateOwnershipRule(rule))
+	rule.Attribute = "tenant"
+	assert.NoError(t, ValidateOwnershipRule(rule))
+}
+
+func TestMatchOwnershipRule(t *testing.T) {
+	rules := []*model.ErrorOwnershipRule{
+		{Model: model.Model{ID: 1}, Type: privateModel.ErrorOwnershipRuleTypeFilePath, Pattern: "/src/billing/", TeamID: ptr.Int(1)},
+		{Model: model.Model{ID: 2}, Type: privateModel.ErrorOwnershipRuleTypeFilePath, Pattern: "src/orders.ts", AdminID: ptr.Int(1)},
+		{Model: model.Model{ID: 3}, Type: privateModel.ErrorOwnershipRuleTypeServiceName, Pattern: "^checkout-", TeamID: ptr.Int(2)},
+		{Model: model.Model{ID: 4}, Type: privateModel.ErrorOwnershipRuleTypeAttribute, Attribute: "tenant", Pattern: "^enterprise$", TeamID: ptr.Int(3)},
+	}
+
+	// the top frame is in a library, so the first in-app frame decides the owner
+	rule := MatchOwnershipRule(rules, &model.ErrorObject{ServiceName: "checkout-api"}, groupingTrace)
+	assert.Equal(t, 2, rule.ID)
+
+	rule = MatchOwnershipRule(rules, &model.ErrorObject{ServiceName: "checkout-api"}, nil)
+	assert.Equal(t, 3, rule.ID)
+
+	rule = MatchOwnershipRule(rules, &model.ErrorObject{Payload: ptr.String(`{"tenant":"enterprise"}`)}, nil)
+	assert.Equal(t, 4, rule.ID)
+
+	assert.Nil(t, MatchOwnershipRule(rules, &model.ErrorObject{Payload: ptr.String(`{"tenant":"free"}`)}, nil))
+}
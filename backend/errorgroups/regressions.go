@@ -0,0 +1,100 @@
+package errorgroups
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/highlight-run/highlight/backend/model"
+	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+)
+
+// CompareVersions compares two release versions, returning -1, 0 or 1. Numeric parts are compared
+// as numbers so that `1.10.0` is after `1.9.2`, and a leading `v` is ignored.
+func CompareVersions(a, b string) int {
+	left, right := versionParts(a), versionParts(b)
+	for i := 0; i < len(left) || i < len(right); i++ {
+		// a trailing pre-release like `-beta` sorts before the release, trailing numbers after
+		if i >= len(left) {
+			if isNumeric(right[i]) {
+				return -1
+			}
+			return 1
+		}
+		if i >= len(right) {
+			if isNumeric(left[i]) {
+				return 1
+			}
+			return -1
+		}
+		l, lErr := strconv.ParseUint(left[i], 10, 64)
+		r, rErr := strconv.ParseUint(right[i], 10, 64)
+		switch {
+		case lErr == nil && rErr == nil:
+			if l != r {
+				if l < r {
+					return -1
+				}
+				return 1
+			}
+		case lErr == nil:
+			// numbers sort before text, so that `1.0.0` is after `1.0.0-beta`
+			return 1
+		case rErr == nil:
+			return -1
+		default:
+			if c := strings.Compare(left[i], right[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+func isNumeric(part string) bool {
+	_, err := strconv.ParseUint(part, 10, 64)
+	return err == nil
+}
+
+func versionParts(version string) []string {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	var parts []string
+	var current strings.Builder
+	var digits bool
+	for _, r := range version {
+		if r == '.' || r == '-' || r == '+' || r == '_' {
+			if current.Len() > 0 {
+				parts = append(parts, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		if current.Len() > 0 && unicode.IsDigit(r) != digits {
+			parts = append(parts, current.String())
+			current.Reset()
+		}
+		digits = unicode.IsDigit(r)
+		current.WriteRune(r)
+	}
+	if current.Len() > 0 {
+		parts = append(parts, current.String())
+	}
+	return parts
+}
+
+// IsRegression returns true if an error seen in the version reopens the resolved group. A group
+// resolved in a version only reopens when the error is seen in that version or a later one, and a
+// group resolved in the next release only reopens when it is seen in a version after the one at
+// the time it was resolved. Errors without a version do not reopen groups resolved in a version.
+func IsRegression(group *model.ErrorGroup, version string) bool {
+	if group.State != privateModel.ErrorStateResolved {
+		return false
+	}
+	if group.ResolvedInVersion != nil {
+		return version != "" && CompareVersions(version, *group.ResolvedInVersion) >= 0
+	}
+	if group.ResolvedAfterVersion != nil {
+		return version != "" && CompareVersions(version, *group.ResolvedAfterVersion) > 0
+	}
+	return true
+}
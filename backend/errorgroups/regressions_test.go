@@ -0,0 +1,41 @@
+package errorgroups
+
+import (
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/highlight-run/highlight/backend/model"
+	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 0, CompareVersions("1.2.3", "v1.2.3"))
+	assert.Equal(t, 1, CompareVersions("1.10.0", "1.9.2"))
+	assert.Equal(t, -1, CompareVersions("1.9", "1.9.1"))
+	assert.Equal(t, 1, CompareVersions("1.0.0", "1.0.0-beta"))
+	assert.Equal(t, -1, CompareVersions("1.0.0-beta", "1.0.0-rc1"))
+	assert.Equal(t, -1, CompareVersions("1.0.0-rc2", "1.0.0-rc10"))
+	assert.Equal(t, 1, CompareVersions("2024.02.01", "2024.1.30"))
+}
+
+func TestIsRegression(t *testing.T) {
+	group := &model.ErrorGroup{State: privateModel.ErrorStateOpen}
+	assert.False(t, IsRegression(group, "1.0.0"))
+
+	group.State = privateModel.ErrorStateResolved
+	assert.True(t, IsRegression(group, ""))
+	assert.True(t, IsRegression(group, "1.0.0"))
+
+	group.ResolvedInVersion = ptr.String("1.2.0")
+	assert.False(t, IsRegression(group, ""))
+	assert.False(t, IsRegression(group, "1.1.9"))
+	assert.True(t, IsRegression(group, "1.2.0"))
+	assert.True(t, IsRegression(group, "1.10.0"))
+
+	group.ResolvedInVersion = nil
+	group.ResolvedAfterVersion = ptr.String("1.2.0")
+	assert.False(t, IsRegression(group, ""))
+	assert.False(t, IsRegression(group, "1.2.0"))
+	assert.True(t, IsRegression(group, "1.2.1"))
+}
@@ -0,0 +1,224 @@
+package errorgroups
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	e "github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	"github.com/highlight-run/highlight/backend/model"
+	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/util"
+)
+
+// frames of third party code, skipped when looking for the top in-app frame
+var libraryFramePattern = regexp.MustCompile(`node_modules/|/vendor/|site-packages/|dist-packages/|/gems/|/usr/local/go/|/go/pkg/mod/|/rustc/|\.cargo/registry/|^(java|javax|jdk|sun|kotlin|kotlinx|scala)\.|^(node|internal)[:/]`)
+
+var exceptionTypePattern = regexp.MustCompile(`^((?:[\w$]+(?:\.|::|\\))*[A-Z][\w$]*):\s`)
+
+// ValidateGroupingRule returns an error if a rule is missing the fields required by its type.
+func ValidateGroupingRule(rule *model.ErrorGroupingRule) error {
+	switch rule.Type {
+	case privateModel.ErrorGroupingRuleTypeNormalizeMessage, privateModel.ErrorGroupingRuleTypeIgnoreFrames:
+		if rule.Pattern == "" {
+			return e.Errorf("grouping rule %s requires a pattern", rule.Name)
+		}
+	case privateModel.ErrorGroupingRuleTypeCustomFingerprint:
+		if rule.Attribute == "" {
+			return e.Errorf("grouping rule %s requires an attribute", rule.Name)
+		}
+	case privateModel.ErrorGroupingRuleTypeExceptionTypeTopFrame:
+	default:
+		return e.Errorf("invalid grouping rule type %s", rule.Type)
+	}
+	if _, err := util.CompileRegexp(rule.Pattern); err != nil {
+		return e.Wrapf(err, "invalid pattern for grouping rule %s", rule.Name)
+	}
+	return nil
+}
+
+// GroupingResult is the input of grouping for an error object after the project's grouping rules are applied.
+type GroupingResult struct {
+	// Event is the error message with the NormalizeMessage rules applied.
+	Event string
+	// Frames are the frames of the stack trace not ignored by IgnoreFrames rules.
+	Frames []*privateModel.ErrorTrace
+	// Fingerprint is set when a rule decides the group of the error. Errors with a fingerprint
+	// are grouped with the error group having the same fingerprint rather than by similarity.
+	Fingerprint string
+}
+
+// FingerprintHash is the value of the RULE error fingerprint.
+func (g *GroupingResult) FingerprintHash() string {
+	hash := sha256.Sum256([]byte(g.Fingerprint))
+	return hex.EncodeToString(hash[:])
+}
+
+// GetRuleFingerprint returns the fingerprint stored for an error group created from a grouping rule.
+func GetRuleFingerprint(projectID int, grouping *GroupingResult) *model.ErrorFingerprint {
+	if grouping.Fingerprint == "" {
+		return nil
+	}
+	return &model.ErrorFingerprint{
+		ProjectID: projectID,
+		Type:      model.Fingerprint.GroupingRule,
+		Value:     grouping.FingerprintHash(),
+	}
+}
+
+// ExceptionType returns the type of the exception of an error object, either prefixing its message
+// as in `TypeError: foo` or as reported by backend sdks.
+func ExceptionType(errorObj *model.ErrorObject) string {
+	if matches := exceptionTypePattern.FindStringSubmatch(errorObj.Event); matches != nil {
+		return matches[1]
+	}
+	return errorObj.Type
+}
+
+func isLibraryFrame(frame *privateModel.ErrorTrace) bool {
+	if frame.FileName != nil && libraryFramePattern.MatchString(*frame.FileName) {
+		return true
+	}
+	return frame.FunctionName != nil && libraryFramePattern.MatchString(*frame.FunctionName)
+}
+
+func topInAppFrame(frames []*privateModel.ErrorTrace) *privateModel.ErrorTrace {
+	for _, frame := range frames {
+		if !isLibraryFrame(frame) {
+			return frame
+		}
+	}
+	if len(frames) > 0 {
+		return frames[0]
+	}
+	return nil
+}
+
+func payloadAttribute(payload *string, attribute string) (string, bool) {
+	if payload == nil {
+		return "", false
+	}
+	var attributes map[string]interface{}
+	if err := json.Unmarshal([]byte(*payload), &attributes); err != nil {
+		// frontend payloads are json encoded twice
+		var str string
+		if err := json.Unmarshal([]byte(*payload), &str); err != nil {
+			return "", false
+		}
+		if err := json.Unmarshal([]byte(str), &attributes); err != nil {
+			return "", false
+		}
+	}
+	value, ok := attributes[attribute]
+	if !ok || value == nil || value == "" {
+		return "", false
+	}
+	if str, ok := value.(string); ok {
+		return str, true
+	}
+	b, _ := json.Marshal(value)
+	return string(b), true
+}
+
+// ApplyGroupingRules evaluates the grouping rules of a project in order for an error object.
+// NormalizeMessage and IgnoreFrames rules always apply. The first ExceptionTypeTopFrame or
+// CustomFingerprint rule that matches the error decides its fingerprint; an error with a
+// normalized message is otherwise fingerprinted by that message.
+func ApplyGroupingRules(rules []*model.ErrorGroupingRule, errorObj *model.ErrorObject, frames []*privateModel.ErrorTrace) *GroupingResult {
+	result := &GroupingResult{Event: errorObj.Event, Frames: frames}
+	for _, rule := range rules {
+		re, err := util.CompileRegexp(rule.Pattern)
+		if err != nil {
+			continue
+		}
+		switch rule.Type {
+		case privateModel.ErrorGroupingRuleTypeNormalizeMessage:
+			result.Event = re.ReplaceAllString(result.Event, rule.Replacement)
+		case privateModel.ErrorGroupingRuleTypeIgnoreFrames:
+			result.Frames = lo.Filter(result.Frames, func(frame *privateModel.ErrorTrace, _ int) bool {
+				return frame.FileName == nil || !re.MatchString(*frame.FileName)
+			})
+		}
+	}
+
+	for _, rule := range rules {
+		re, err := util.CompileRegexp(rule.Pattern)
+		if err != nil {
+			continue
+		}
+		switch rule.Type {
+		case privateModel.ErrorGroupingRuleTypeExceptionTypeTopFrame:
+			exceptionType := ExceptionType(errorObj)
+			if rule.Pattern != "" && !re.MatchString(exceptionType) {
+				continue
+			}
+			var frameKey string
+			if frame := topInAppFrame(result.Frames); frame != nil {
+				frameKey = joinStringPtrs(frame.FileName, frame.FunctionName)
+			}
+			result.Fingerprint = fmt.Sprintf("type:%s;frame:%s", exceptionType, frameKey)
+			return result
+		case privateModel.ErrorGroupingRuleTypeCustomFingerprint:
+			value, ok := payloadAttribute(errorObj.Payload, rule.Attribute)
+			if !ok || (rule.Pattern != "" && !re.MatchString(value)) {
+				continue
+			}
+			result.Fingerprint = fmt.Sprintf("attribute:%s=%s", rule.Attribute, value)
+			return result
+		}
+	}
+
+	if result.Event != errorObj.Event {
+		result.Fingerprint = fmt.Sprintf("event:%s", result.Event)
+	}
+	return result
+}
+
+// DryRunErrorObject is a recent error object with its structured stack trace for a grouping dry run.
+type DryRunErrorObject struct {
+	ErrorObject *model.ErrorObject
+	Frames      []*privateModel.ErrorTrace
+}
+
+// DryRunGroup is an error group that would result from applying grouping rules.
+type DryRunGroup struct {
+	Fingerprint      string
+	Event            string
+	ErrorObjectCount int
+	ErrorGroupIDs    []int
+}
+
+// DryRunGroupingRules regroups error objects with a set of grouping rules. Objects fingerprinted
+// by a rule are grouped by fingerprint, others keep their current error group.
+// Groups are returned by descending number of error objects.
+func DryRunGroupingRules(rules []*model.ErrorGroupingRule, errorObjects []*DryRunErrorObject) (currentGroupCount int, groups []*DryRunGroup) {
+	currentGroups := map[int]bool{}
+	byKey := map[string]*DryRunGroup{}
+	for _, obj := range errorObjects {
+		currentGroups[obj.ErrorObject.ErrorGroupID] = true
+		grouping := ApplyGroupingRules(rules, obj.ErrorObject, obj.Frames)
+		key := grouping.Fingerprint
+		if key == "" {
+			key = fmt.Sprintf("error-group-%d", obj.ErrorObject.ErrorGroupID)
+		}
+		group, ok := byKey[key]
+		if !ok {
+			group = &DryRunGroup{Fingerprint: grouping.Fingerprint, Event: grouping.Event}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.ErrorObjectCount++
+		if !lo.Contains(group.ErrorGroupIDs, obj.ErrorObject.ErrorGroupID) {
+			group.ErrorGroupIDs = append(group.ErrorGroupIDs, obj.ErrorObject.ErrorGroupID)
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].ErrorObjectCount > groups[j].ErrorObjectCount
+	})
+	return len(currentGroups), groups
+}
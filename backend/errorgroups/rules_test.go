@@ -0,0 +1,108 @@
+package errorgroups
+
+import (
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/highlight-run/highlight/backend/model"
+	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var groupingTrace = []*privateModel.ErrorTrace{
+	{FileName: ptr.String("/app/node_modules/pg/lib/client.js"), FunctionName: ptr.String("Client.query"), LineNumber: ptr.Int(12)},
+	{FileName: ptr.String("/app/src/orders.ts"), FunctionName: ptr.String("createOrder"), LineNumber: ptr.Int(40)},
+	{FileName: ptr.String("/app/src/server.ts"), FunctionName: ptr.String("handler"), LineNumber: ptr.Int(8)},
+}
+
+func TestApplyGroupingRulesNormalizeMessage(t *testing.T) {
+	rules := []*model.ErrorGroupingRule{
+		{Type: privateModel.ErrorGroupingRuleTypeNormalizeMessage, Pattern: `[\w.+-]+@[\w-]+\.[\w.]+`, Replacement: "<email>"},
+		{Type: privateModel.ErrorGroupingRuleTypeNormalizeMessage, Pattern: `request [0-9a-f-]{36}`, Replacement: "request <id>"},
+	}
+
+	first := ApplyGroupingRules(rules, &model.ErrorObject{Event: "user jay@highlight.io not found for request 1b4e28ba-2fa1-11d2-883f-0016d3cca427"}, groupingTrace)
+	second := ApplyGroupingRules(rules, &model.ErrorObject{Event: "user vadim@highlight.io not found for request 6fa459ea-ee8a-3ca4-894e-db77e160355e"}, groupingTrace)
+	assert.Equal(t, "user <email> not found for request <id>", first.Event)
+	assert.Equal(t, first.Fingerprint, second.Fingerprint)
+	assert.Equal(t, GetKey(1, &model.ErrorObject{}, first), GetKey(1, &model.ErrorObject{}, second))
+
+	unchanged := ApplyGroupingRules(rules, &model.ErrorObject{Event: "connection refused"}, groupingTrace)
+	assert.Empty(t, unchanged.Fingerprint)
+	assert.Nil(t, GetRuleFingerprint(1, unchanged))
+}
+
+func TestApplyGroupingRulesIgnoreFrames(t *testing.T) {
+	rules := []*model.ErrorGroupingRule{
+		{Type: privateModel.ErrorGroupingRuleTypeIgnoreFrames, Pattern: `node_modules|vendor/`},
+	}
+
+	result := ApplyGroupingRules(rules, &model.ErrorObject{Event: "boom"}, groupingTrace)
+	assert.Len(t, result.Frames, 2)
+	assert.Equal(t, "createOrder", *result.Frames[0].FunctionName)
+	assert.Empty(t, result.Fingerprint)
+	assert.Len(t, GetFingerprints(1, result.Frames), 2)
+}
+
+func TestApplyGroupingRulesExceptionTypeTopFrame(t *testing.T) {
+	rules := []*model.ErrorGroupingRule{
+		{Type: privateModel.ErrorGroupingRuleTypeExceptionTypeTopFrame, Pattern: `^TypeError$`},
+	}
+
+	first := ApplyGroupingRules(rules, &model.ErrorObject{Event: "TypeError: cannot read properties of undefined (reading 'id')"}, groupingTrace)
+	second := ApplyGroupingRules(rules, &model.ErrorObject{Event: "TypeError: cannot read properties of null (reading 'name')"}, groupingTrace)
+	assert.Equal(t, "type:TypeError;frame:/app/src/orders.ts;createOrder;", first.Fingerprint)
+	assert.Equal(t, first.Fingerprint, second.Fingerprint)
+
+	other := ApplyGroupingRules(rules, &model.ErrorObject{Event: "RangeError: invalid array length"}, groupingTrace)
+	assert.Empty(t, other.Fingerprint)
+
+	backend := ApplyGroupingRules([]*model.ErrorGroupingRule{{Type: privateModel.ErrorGroupingRuleTypeExceptionTypeTopFrame}}, &model.ErrorObject{Event: "duplicate key value", Type: "psycopg2.IntegrityError"}, groupingTrace)
+	assert.Equal(t, "type:psycopg2.IntegrityError;frame:/app/src/orders.ts;createOrder;", backend.Fingerprint)
+}
+
+func TestApplyGroupingRulesCustomFingerprint(t *testing.T) {
+	rules := []*model.ErrorGroupingRule{
+		{Type: privateModel.ErrorGroupingRuleTypeCustomFingerprint, Attribute: "error.fingerprint"},
+		{Type: privateModel.ErrorGroupingRuleTypeExceptionTypeTopFrame},
+	}
+
+	result := ApplyGroupingRules(rules, &model.ErrorObject{Event: "Error: payment failed", Payload: ptr.String(`{"error.fingerprint":"stripe-card-declined"}`)}, groupingTrace)
+	assert.Equal(t, "attribute:error.fingerprint=stripe-card-declined", result.Fingerprint)
+	assert.Equal(t, model.Fingerprint.GroupingRule, GetRuleFingerprint(1, result).Type)
+	assert.Equal(t, result.FingerprintHash(), GetRuleFingerprint(1, result).Value)
+
+	// the next rule applies when the attribute is missing
+	result = ApplyGroupingRules(rules, &model.ErrorObject{Event: "Error: payment failed", Payload: ptr.String(`"{\"user\":\"1\"}"`)}, groupingTrace)
+	assert.Equal(t, "type:Error;frame:/app/src/orders.ts;createOrder;", result.Fingerprint)
+}
+
+func TestValidateGroupingRule(t *testing.T) {
+	assert.NoError(t, ValidateGroupingRule(&model.ErrorGroupingRule{Type: privateModel.ErrorGroupingRuleTypeExceptionTypeTopFrame}))
+	assert.NoError(t, ValidateGroupingRule(&model.ErrorGroupingRule{Type: privateModel.ErrorGroupingRuleTypeNormalizeMessage, Pattern: `\d+`}))
+	assert.Error(t, ValidateGroupingRule(&model.ErrorGroupingRule{Type: privateModel.ErrorGroupingRuleTypeNormalizeMessage}))
+	assert.Error(t, ValidateGroupingRule(&model.ErrorGroupingRule{Type: privateModel.ErrorGroupingRuleTypeIgnoreFrames, Pattern: `(`}))
+	assert.Error(t, ValidateGroupingRule(&model.ErrorGroupingRule{Type: privateModel.ErrorGroupingRuleTypeCustomFingerprint}))
+	assert.Error(t, ValidateGroupingRule(&model.ErrorGroupingRule{Type: "Unknown"}))
+}
+
+func TestDryRunGroupingRules(t *testing.T) {
+	rules := []*model.ErrorGroupingRule{
+		{Type: privateModel.ErrorGroupingRuleTypeNormalizeMessage, Pattern: `order \d+`, Replacement: "order <id>"},
+	}
+	objects := []*DryRunErrorObject{
+		{ErrorObject: &model.ErrorObject{ErrorGroupID: 1, Event: "failed to process order 1"}, Frames: groupingTrace},
+		{ErrorObject: &model.ErrorObject{ErrorGroupID: 2, Event: "failed to process order 2"}, Frames: groupingTrace},
+		{ErrorObject: &model.ErrorObject{ErrorGroupID: 2, Event: "failed to process order 2"}, Frames: groupingTrace},
+		{ErrorObject: &model.ErrorObject{ErrorGroupID: 3, Event: "connection refused"}, Frames: groupingTrace},
+	}
+
+	currentGroupCount, groups := DryRunGroupingRules(rules, objects)
+	assert.Equal(t, 3, currentGroupCount)
+	assert.Len(t, groups, 2)
+	assert.Equal(t, "failed to process order <id>", groups[0].Event)
+	assert.Equal(t, 3, groups[0].ErrorObjectCount)
+	assert.Equal(t, []int{1, 2}, groups[0].ErrorGroupIDs)
+	assert.Empty(t, groups[1].Fingerprint)
+	assert.Equal(t, []int{3}, groups[1].ErrorGroupIDs)
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/highlight-run/highlight/backend/model"
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/redaction"
 	"github.com/highlight-run/highlight/backend/store"
 	"github.com/highlight-run/highlight/backend/util"
 	"github.com/lukasbob/srcset"
@@ -330,6 +331,59 @@ func escapeNodeWithJSAttrs(ctx context.Context, node map[string]interface{}) {
 	}
 }
 
+// Redact redacts the text nodes and input values of the snapshot with the redaction rules of the project.
+func (s *Snapshot) Redact(redactor *redaction.Redactor) {
+	if redactor.Empty() {
+		return
+	}
+	redactTree(redactor, s.data)
+}
+
+func redactTree(redactor *redaction.Redactor, root map[string]interface{}) {
+	redactNode(redactor, root)
+
+	for _, v := range root {
+		switch typedVal := v.(type) {
+		case []interface{}:
+			for _, item := range typedVal {
+				if typedItem, ok := item.(map[string]interface{}); ok {
+					redactTree(redactor, typedItem)
+				}
+			}
+		case map[string]interface{}:
+			redactTree(redactor, typedVal)
+		}
+	}
+}
+
+func redactNode(redactor *redaction.Redactor, node map[string]interface{}) {
+	// text nodes, other than the contents of style tags
+	if text, ok := node["textContent"].(string); ok && node["isStyle"] != true {
+		node["textContent"] = redactor.Redact(text)
+	}
+
+	// the value attribute of inputs, in full snapshots and attribute mutations
+	if attributes, ok := node["attributes"].(map[string]interface{}); ok {
+		if value, ok := attributes["value"].(string); ok {
+			attributes["value"] = redactor.Redact(value)
+		}
+	}
+
+	// text mutations are {id, value}
+	if _, ok := node["id"].(float64); ok {
+		if value, ok := node["value"].(string); ok {
+			node["value"] = redactor.Redact(value)
+		}
+	}
+
+	// input events
+	if source, ok := node["source"].(float64); ok && EventSource(source) == Input {
+		if text, ok := node["text"].(string); ok {
+			node["text"] = redactor.Redact(text)
+		}
+	}
+}
+
 // InjectStylesheets injects custom stylesheets into a given snapshot event.
 func (s *Snapshot) InjectStylesheets(ctx context.Context) error {
 	node, ok := s.data["node"].(map[string]interface{})
@@ -14,6 +14,7 @@ import (
 	"github.com/go-test/deep"
 	"github.com/highlight-run/highlight/backend/model"
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/redaction"
 	"github.com/highlight-run/highlight/backend/redis"
 	"github.com/highlight-run/highlight/backend/storage"
 	"github.com/highlight-run/highlight/backend/store"
@@ -248,6 +249,57 @@ func TestEscapeJavascript(t *testing.T) {
 	}
 }
 
+func TestSnapshot_Redact(t *testing.T) {
+	detector := modelInputs.RedactionDetectorEmail
+	redactor := redaction.New(1, []*model.RedactionRule{
+		{Name: "emails", Detector: &detector, Action: modelInputs.RedactionActionMask},
+	})
+
+	var data map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"type": 2,
+		"data": {"node": {"type": 0, "childNodes": [
+			{"type": 3, "id": 1, "textContent": "signed in as jane.doe@example.com"},
+			{"type": 2, "id": 2, "tagName": "input", "attributes": {"type": "email", "value": "jane.doe@example.com"}},
+			{"type": 3, "id": 3, "textContent": ".jane.doe@example.com { color: red; }", "isStyle": true},
+			{"type": 2, "id": 4, "tagName": "div", "attributes": {"class": "profile"}}
+		]}}
+	}`), &data)
+	if err != nil {
+		t.Fatalf("error unmarshaling: %v", err)
+	}
+
+	snapshot, err := NewSnapshot(data, nil)
+	if err != nil {
+		t.Fatalf("error parsing: %v", err)
+	}
+	snapshot.Redact(redactor)
+
+	nodes := data["data"].(map[string]interface{})["node"].(map[string]interface{})["childNodes"].([]interface{})
+	assert.Equal(t, "signed in as [REDACTED]", nodes[0].(map[string]interface{})["textContent"])
+	assert.Equal(t, "[REDACTED]", nodes[1].(map[string]interface{})["attributes"].(map[string]interface{})["value"])
+	assert.Equal(t, ".jane.doe@example.com { color: red; }", nodes[2].(map[string]interface{})["textContent"])
+	assert.Equal(t, "profile", nodes[3].(map[string]interface{})["attributes"].(map[string]interface{})["class"])
+
+	// incremental input events and text mutations
+	err = json.Unmarshal([]byte(`{
+		"type": 3,
+		"data": {"source": 5, "id": 2, "text": "jane.doe@example.com", "texts": [{"id": 1, "value": "hello jane.doe@example.com"}]}
+	}`), &data)
+	if err != nil {
+		t.Fatalf("error unmarshaling: %v", err)
+	}
+	snapshot, err = NewSnapshot(data, nil)
+	if err != nil {
+		t.Fatalf("error parsing: %v", err)
+	}
+	snapshot.Redact(redactor)
+
+	input := data["data"].(map[string]interface{})
+	assert.Equal(t, "[REDACTED]", input["text"])
+	assert.Equal(t, "hello [REDACTED]", input["texts"].([]interface{})[0].(map[string]interface{})["value"])
+}
+
 func TestSnapshot_ReplaceAssets(t *testing.T) {
 	defer teardown(t)
 	ctx := context.TODO()
@@ -0,0 +1,92 @@
+package exports
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/openlyinc/pointy"
+	e "github.com/pkg/errors"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/storage"
+)
+
+// the region of an S3Compatible destination without a region
+const defaultRegion = "us-east-1"
+
+// Destination stores the files written by an export job.
+type Destination interface {
+	PushFile(ctx context.Context, key string, data []byte) error
+}
+
+// storageDestination writes the files of a job with the storage.Client of the deployment.
+type storageDestination struct {
+	client    storage.Client
+	projectID int
+	prefix    string
+}
+
+func (d *storageDestination) PushFile(ctx context.Context, key string, data []byte) error {
+	return d.client.PushExportFile(ctx, d.projectID, path.Join(d.prefix, key), data)
+}
+
+// s3Destination writes the files of a job to a bucket of an S3 compatible object storage.
+type s3Destination struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+func newS3Destination(job *model.ExportJob) *s3Destination {
+	region := pointy.StringValue(job.Region, "")
+	if region == "" {
+		region = defaultRegion
+	}
+	endpoint := pointy.StringValue(job.Endpoint, "")
+	client := s3.NewFromConfig(aws.Config{
+		Region:      region,
+		Credentials: credentials.NewStaticCredentialsProvider(pointy.StringValue(job.AccessKeyID, ""), pointy.StringValue(job.SecretAccessKey, ""), ""),
+	}, func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+			// most S3 compatible storages do not support virtual hosted buckets
+			o.UsePathStyle = true
+		}
+	})
+	return &s3Destination{
+		client: client,
+		bucket: pointy.StringValue(job.Bucket, ""),
+		prefix: strings.Trim(pointy.StringValue(job.Prefix, ""), "/"),
+	}
+}
+
+func (d *s3Destination) PushFile(ctx context.Context, key string, data []byte) error {
+	if d.prefix != "" {
+		key = path.Join(d.prefix, key)
+	}
+	if _, err := d.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(d.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	}); err != nil {
+		return e.Wrapf(err, "error 'put'ing export file in bucket %s", d.bucket)
+	}
+	return nil
+}
+
+func (ex *Exporter) getDestination(_ context.Context, job *model.ExportJob) (Destination, error) {
+	switch job.Destination {
+	case modelInputs.ExportDestinationTypeStorage:
+		return &storageDestination{client: ex.storage, projectID: job.ProjectID, prefix: strconv.Itoa(job.ID)}, nil
+	case modelInputs.ExportDestinationTypeS3Compatible:
+		return newS3Destination(job), nil
+	}
+	return nil, e.Errorf("invalid export destination %s", job.Destination)
+}
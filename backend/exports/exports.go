@@ -0,0 +1,184 @@
+package exports
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openlyinc/pointy"
+	e "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/storage"
+	"github.com/highlight-run/highlight/backend/util"
+)
+
+const (
+	// how often export jobs are run
+	Interval = 5 * time.Minute
+	// hours are exported once late rows are likely to have arrived
+	Delay = 30 * time.Minute
+	// the hours a job exports per run, so that a job catching up does not hold up the other jobs
+	MaxHoursPerRun = 24
+	// the maximum number of rows written per file
+	RowsPerFile = 100_000
+)
+
+// ValidateJob returns an error if a job cannot be run.
+func ValidateJob(job *model.ExportJob) error {
+	if job.Name == "" {
+		return e.New("export job requires a name")
+	}
+	switch job.Product {
+	case modelInputs.ProductTypeLogs, modelInputs.ProductTypeTraces, modelInputs.ProductTypeErrors:
+	default:
+		return e.Errorf("%s cannot be exported", job.Product)
+	}
+	if !job.Format.IsValid() {
+		return e.Errorf("invalid export format %s", job.Format)
+	}
+	switch job.Destination {
+	case modelInputs.ExportDestinationTypeStorage:
+	case modelInputs.ExportDestinationTypeS3Compatible:
+		if job.Bucket == nil || *job.Bucket == "" {
+			return e.Errorf("export job %s requires a bucket", job.Name)
+		}
+		if job.AccessKeyID == nil || *job.AccessKeyID == "" || job.SecretAccessKey == nil || *job.SecretAccessKey == "" {
+			return e.Errorf("export job %s requires an access key", job.Name)
+		}
+	default:
+		return e.Errorf("invalid export destination %s", job.Destination)
+	}
+	return nil
+}
+
+// PartitionKey returns the key of a file written for an hour, partitioned by date and hour.
+func PartitionKey(product modelInputs.ProductType, format modelInputs.ExportFormat, hour time.Time, part int) string {
+	hour = hour.UTC()
+	return fmt.Sprintf("%s/dt=%s/hour=%s/part-%05d.%s", strings.ToLower(string(product)), hour.Format(time.DateOnly), hour.Format("15"), part, extension(format))
+}
+
+type jobStore interface {
+	GetActiveExportJobs(ctx context.Context) ([]*model.ExportJob, error)
+	UpdateExportJobCheckpoint(ctx context.Context, job *model.ExportJob) error
+}
+
+type rowReader interface {
+	ReadExportRows(ctx context.Context, product modelInputs.ProductType, projectID int, query string, start time.Time, end time.Time, after *clickhouse.ExportCursor, limit int) ([]clickhouse.ExportRow, error)
+}
+
+// Exporter runs the export jobs of all projects.
+type Exporter struct {
+	store       jobStore
+	reader      rowReader
+	storage     storage.Client
+	rowsPerFile int
+}
+
+func NewExporter(store jobStore, reader rowReader, storageClient storage.Client) *Exporter {
+	return &Exporter{
+		store:       store,
+		reader:      reader,
+		storage:     storageClient,
+		rowsPerFile: RowsPerFile,
+	}
+}
+
+// Run exports the completed hours of each active job.
+func (ex *Exporter) Run(ctx context.Context) {
+	span, ctx := util.StartSpanFromContext(ctx, "exports.Run")
+	defer span.Finish()
+
+	jobs, err := ex.store.GetActiveExportJobs(ctx)
+	if err != nil {
+		log.WithContext(ctx).WithError(err).Error("failed to get export jobs")
+		return
+	}
+	for _, job := range jobs {
+		if err := ex.RunJob(ctx, job, time.Now()); err != nil {
+			log.WithContext(ctx).WithError(err).WithField("project_id", job.ProjectID).WithField("export_job_id", job.ID).Error("failed to run export job")
+		}
+	}
+}
+
+// RunJob exports the hours of a job completed before `now`, resuming from its checkpoint.
+// The checkpoint is saved after each file is written, so a failed run at most rewrites the
+// last file with the same rows.
+func (ex *Exporter) RunJob(ctx context.Context, job *model.ExportJob, now time.Time) error {
+	span, ctx := util.StartSpanFromContext(ctx, "exports.RunJob", util.Tag("project_id", job.ProjectID), util.Tag("export_job_id", job.ID))
+	defer span.Finish()
+
+	err := ex.runJob(ctx, job, now)
+	job.LastRunAt = &now
+	job.LastError = nil
+	if err != nil {
+		job.LastError = pointy.String(err.Error())
+	}
+	if saveErr := ex.store.UpdateExportJobCheckpoint(ctx, job); saveErr != nil && err == nil {
+		err = saveErr
+	}
+	return err
+}
+
+func (ex *Exporter) runJob(ctx context.Context, job *model.ExportJob, now time.Time) error {
+	destination, err := ex.getDestination(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	end := now.Add(-Delay).Truncate(time.Hour)
+	for hours := 0; hours < MaxHoursPerRun && !job.ExportedUntil.Add(time.Hour).After(end); hours++ {
+		if err := ex.exportHour(ctx, job, destination); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ex *Exporter) exportHour(ctx context.Context, job *model.ExportJob, destination Destination) error {
+	start := job.ExportedUntil.UTC()
+	var after *clickhouse.ExportCursor
+	if job.CheckpointCursor != nil {
+		cursor, err := clickhouse.ParseExportCursor(*job.CheckpointCursor)
+		if err != nil {
+			return err
+		}
+		after = cursor
+	}
+
+	for {
+		rows, err := ex.reader.ReadExportRows(ctx, job.Product, job.ProjectID, job.Query, start, start.Add(time.Hour), after, ex.rowsPerFile)
+		if err != nil {
+			return e.Wrapf(err, "failed to read %s to export", job.Product)
+		}
+		if len(rows) > 0 {
+			data, err := Encode(job.Format, rows)
+			if err != nil {
+				return err
+			}
+			if err := destination.PushFile(ctx, PartitionKey(job.Product, job.Format, start, job.CheckpointPart), data); err != nil {
+				return e.Wrap(err, "failed to write export file")
+			}
+
+			cursor := rows[len(rows)-1].Cursor()
+			after = &cursor
+			job.CheckpointCursor = pointy.String(cursor.String())
+			job.CheckpointPart++
+			if err := ex.store.UpdateExportJobCheckpoint(ctx, job); err != nil {
+				return err
+			}
+		}
+		if len(rows) < ex.rowsPerFile {
+			break
+		}
+	}
+
+	job.ExportedUntil = start.Add(time.Hour)
+	job.CheckpointCursor = nil
+	job.CheckpointPart = 0
+	return ex.store.UpdateExportJobCheckpoint(ctx, job)
+}
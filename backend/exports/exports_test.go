@@ -0,0 +1,235 @@
+package exports
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/openlyinc/pointy"
+	"github.com/parquet-go/parquet-go"
+	e "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/storage"
+)
+
+var testTime = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+type memoryStore struct {
+	jobs  []*model.ExportJob
+	saves []model.ExportJob
+}
+
+func (s *memoryStore) GetActiveExportJobs(_ context.Context) ([]*model.ExportJob, error) {
+	return s.jobs, nil
+}
+
+func (s *memoryStore) UpdateExportJobCheckpoint(_ context.Context, job *model.ExportJob) error {
+	s.saves = append(s.saves, *job)
+	return nil
+}
+
+// memoryReader returns the rows of the logs table, failing once after `failAfter` reads.
+type memoryReader struct {
+	rows      []*clickhouse.LogExportRow
+	reads     int
+	failAfter int
+}
+
+func (r *memoryReader) ReadExportRows(_ context.Context, _ modelInputs.ProductType, _ int, _ string, start time.Time, end time.Time, after *clickhouse.ExportCursor, limit int) ([]clickhouse.ExportRow, error) {
+	r.reads++
+	if r.failAfter > 0 && r.reads > r.failAfter {
+		r.failAfter = 0
+		return nil, e.New("clickhouse is unavailable")
+	}
+
+	var results []clickhouse.ExportRow
+	for _, row := range r.rows {
+		if row.Timestamp.Before(start) || !row.Timestamp.Before(end) {
+			continue
+		}
+		if after != nil && (row.Timestamp.Before(after.Timestamp) || row.Timestamp.Equal(after.Timestamp) && row.UUID <= after.ID) {
+			continue
+		}
+		results = append(results, row)
+		if len(results) == limit {
+			break
+		}
+	}
+	return results, nil
+}
+
+func logRows(count int, start time.Time, interval time.Duration) []*clickhouse.LogExportRow {
+	var rows []*clickhouse.LogExportRow
+	for i := 0; i < count; i++ {
+		rows = append(rows, &clickhouse.LogExportRow{
+			Timestamp:  start.Add(time.Duration(i) * interval),
+			ProjectID:  1,
+			UUID:       fmt.Sprintf("%04d", i),
+			Level:      "info",
+			Message:    fmt.Sprintf("log %d", i),
+			Attributes: map[string]string{"index": fmt.Sprint(i)},
+		})
+	}
+	return rows
+}
+
+func readNDJSON(t *testing.T, data []byte) []clickhouse.LogExportRow {
+	var rows []clickhouse.LogExportRow
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var row clickhouse.LogExportRow
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+		rows = append(rows, row)
+	}
+	require.NoError(t, scanner.Err())
+	return rows
+}
+
+func TestPartitionKey(t *testing.T) {
+	hour := time.Date(2024, 3, 9, 7, 0, 0, 0, time.FixedZone("PST", -8*60*60))
+	assert.Equal(t, "logs/dt=2024-03-09/hour=15/part-00000.ndjson", PartitionKey(modelInputs.ProductTypeLogs, modelInputs.ExportFormatNdjson, hour, 0))
+	assert.Equal(t, "traces/dt=2024-03-09/hour=15/part-00012.parquet", PartitionKey(modelInputs.ProductTypeTraces, modelInputs.ExportFormatParquet, hour, 12))
+}
+
+func TestValidateJob(t *testing.T) {
+	job := &model.ExportJob{
+		Name:        "archive",
+		Product:     modelInputs.ProductTypeLogs,
+		Format:      modelInputs.ExportFormatNdjson,
+		Destination: modelInputs.ExportDestinationTypeStorage,
+	}
+	assert.NoError(t, ValidateJob(job))
+
+	job.Product = modelInputs.ProductTypeSessions
+	assert.Error(t, ValidateJob(job))
+	job.Product = modelInputs.ProductTypeErrors
+
+	job.Destination = modelInputs.ExportDestinationTypeS3Compatible
+	assert.Error(t, ValidateJob(job))
+	job.Bucket = pointy.String("warehouse")
+	job.AccessKeyID = pointy.String("key")
+	assert.Error(t, ValidateJob(job))
+	job.SecretAccessKey = pointy.String("secret")
+	assert.NoError(t, ValidateJob(job))
+
+	job.Name = ""
+	assert.Error(t, ValidateJob(job))
+}
+
+func TestEncode(t *testing.T) {
+	rows := logRows(3, testTime, time.Nanosecond)
+	rows[0].Message = "<a & b>"
+	var exportRows []clickhouse.ExportRow
+	for _, row := range rows {
+		exportRows = append(exportRows, row)
+	}
+
+	data, err := Encode(modelInputs.ExportFormatNdjson, exportRows)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"message":"<a & b>"`)
+	decoded := readNDJSON(t, data)
+	require.Len(t, decoded, 3)
+	for i, row := range decoded {
+		assert.True(t, rows[i].Timestamp.Equal(row.Timestamp))
+		assert.Equal(t, rows[i].Message, row.Message)
+		assert.Equal(t, rows[i].Attributes, row.Attributes)
+	}
+
+	data, err = Encode(modelInputs.ExportFormatParquet, exportRows)
+	require.NoError(t, err)
+	parquetRows, err := parquet.Read[clickhouse.LogExportRow](bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	require.Len(t, parquetRows, 3)
+	for i, row := range parquetRows {
+		assert.True(t, rows[i].Timestamp.Equal(row.Timestamp))
+		assert.Equal(t, rows[i].UUID, row.UUID)
+		assert.Equal(t, rows[i].Attributes, row.Attributes)
+	}
+
+	_, err = Encode(modelInputs.ExportFormatParquet, nil)
+	assert.Error(t, err)
+}
+
+func TestRunJobResumesFromCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	fsRoot := t.TempDir()
+	storageClient, err := storage.NewFSClient(ctx, "", fsRoot)
+	require.NoError(t, err)
+
+	// two complete hours of 25 rows each, and rows of the current hour which are not exported yet
+	rows := logRows(60, testTime, 144*time.Second)
+	reader := &memoryReader{rows: rows, failAfter: 4}
+	store := &memoryStore{}
+	exporter := NewExporter(store, reader, storageClient)
+	exporter.rowsPerFile = 10
+
+	job := &model.ExportJob{
+		Model:         model.Model{ID: 7},
+		ProjectID:     1,
+		Name:          "archive",
+		Product:       modelInputs.ProductTypeLogs,
+		Format:        modelInputs.ExportFormatNdjson,
+		Destination:   modelInputs.ExportDestinationTypeStorage,
+		ExportedUntil: testTime,
+	}
+	now := testTime.Add(2*time.Hour + Delay)
+
+	// the first hour is exported in 3 files, then the read of the second file of the second hour fails
+	err = exporter.RunJob(ctx, job, now)
+	require.Error(t, err)
+	assert.Equal(t, testTime.Add(time.Hour), job.ExportedUntil)
+	assert.Equal(t, 1, job.CheckpointPart)
+	assert.Equal(t, pointy.String(rows[34].Cursor().String()), job.CheckpointCursor)
+	assert.NotNil(t, job.LastError)
+	assert.Equal(t, now, *job.LastRunAt)
+
+	require.NoError(t, exporter.RunJob(ctx, job, now))
+	assert.Equal(t, testTime.Add(2*time.Hour), job.ExportedUntil)
+	assert.Nil(t, job.CheckpointCursor)
+	assert.Equal(t, 0, job.CheckpointPart)
+	assert.Nil(t, job.LastError)
+
+	// the saved checkpoint matches the job
+	assert.Equal(t, *job, store.saves[len(store.saves)-1])
+
+	var exported []string
+	for _, hour := range []time.Time{testTime, testTime.Add(time.Hour)} {
+		for part := 0; ; part++ {
+			data, err := os.ReadFile(path.Join(fsRoot, "exports", "1", "7", PartitionKey(job.Product, job.Format, hour, part)))
+			if os.IsNotExist(err) {
+				assert.Equal(t, 3, part)
+				break
+			}
+			require.NoError(t, err)
+			for _, row := range readNDJSON(t, data) {
+				assert.False(t, row.Timestamp.Before(hour))
+				assert.True(t, row.Timestamp.Before(hour.Add(time.Hour)))
+				exported = append(exported, row.UUID)
+			}
+		}
+	}
+
+	var expected []string
+	for _, row := range rows[:50] {
+		expected = append(expected, row.UUID)
+	}
+	sort.Strings(exported)
+	assert.Equal(t, expected, exported)
+
+	// the job is up to date until the next hour completes
+	reads := reader.reads
+	require.NoError(t, exporter.RunJob(ctx, job, now.Add(30*time.Minute)))
+	assert.Equal(t, reads, reader.reads)
+}
@@ -0,0 +1,76 @@
+package exports
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/parquet-go/parquet-go"
+	e "github.com/pkg/errors"
+
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+)
+
+func extension(format modelInputs.ExportFormat) string {
+	switch format {
+	case modelInputs.ExportFormatParquet:
+		return "parquet"
+	default:
+		return "ndjson"
+	}
+}
+
+// Encode writes rows of the same product as newline delimited JSON or as a Parquet file.
+func Encode(format modelInputs.ExportFormat, rows []clickhouse.ExportRow) ([]byte, error) {
+	switch format {
+	case modelInputs.ExportFormatNdjson:
+		return encodeNDJSON(rows)
+	case modelInputs.ExportFormatParquet:
+		if len(rows) == 0 {
+			return nil, e.New("cannot write an empty parquet file")
+		}
+		switch rows[0].(type) {
+		case *clickhouse.LogExportRow:
+			return encodeParquet[clickhouse.LogExportRow](rows)
+		case *clickhouse.TraceExportRow:
+			return encodeParquet[clickhouse.TraceExportRow](rows)
+		case *clickhouse.ErrorExportRow:
+			return encodeParquet[clickhouse.ErrorExportRow](rows)
+		}
+		return nil, e.Errorf("cannot write %T as parquet", rows[0])
+	}
+	return nil, e.Errorf("invalid export format %s", format)
+}
+
+func encodeNDJSON(rows []clickhouse.ExportRow) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	for _, row := range rows {
+		if err := encoder.Encode(row); err != nil {
+			return nil, e.Wrap(err, "failed to encode export row")
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeParquet[T any](rows []clickhouse.ExportRow) ([]byte, error) {
+	records := make([]T, 0, len(rows))
+	for _, row := range rows {
+		record, ok := any(row).(*T)
+		if !ok {
+			return nil, e.Errorf("cannot write %T in a parquet file of %T", row, record)
+		}
+		records = append(records, *record)
+	}
+
+	buf := &bytes.Buffer{}
+	writer := parquet.NewGenericWriter[T](buf, parquet.Compression(&parquet.Snappy))
+	if _, err := writer.Write(records); err != nil {
+		return nil, e.Wrap(err, "failed to write parquet rows")
+	}
+	if err := writer.Close(); err != nil {
+		return nil, e.Wrap(err, "failed to close parquet file")
+	}
+	return buf.Bytes(), nil
+}
@@ -28,6 +28,13 @@ const (
 	LogDrainServiceQueryParam = "service"
 	LogDrainProjectHeader     = "x-highlight-project"
 	LogDrainServiceHeader     = "x-highlight-service"
+
+	IngestKeyHeader     = "x-highlight-ingest-key"
+	IngestKeyQueryParam = "ingest_key"
+	// firehose sends the access key configured on the http endpoint destination in this header
+	FirehoseAccessKeyHeader = "X-Amz-Firehose-Access-Key"
+	// IngestKeyAttribute carries the ingest key on otel resources, it is never stored
+	IngestKeyAttribute = "highlight.ingest_key"
 )
 
 func GetBody(ctx context.Context, r *http.Request) ([]byte, error) {
@@ -79,6 +86,41 @@ func getJSONLogs(r *http.Request) (logs [][]byte, err error) {
 	return
 }
 
+// IngestKeyValidator returns an error if the key may not push the product for the project.
+type IngestKeyValidator func(ctx context.Context, projectID int, key string, product model.ProductType, source model.LogSource) error
+
+var ingestKeyValidator IngestKeyValidator
+
+func getIngestKey(r *http.Request) string {
+	if key := r.Header.Get(IngestKeyHeader); key != "" {
+		return key
+	}
+	if key := r.Header.Get(FirehoseAccessKeyHeader); key != "" {
+		return key
+	}
+	return r.URL.Query().Get(IngestKeyQueryParam)
+}
+
+// authorizeLogs validates the ingest key of the request for the project.
+// The key is returned so that it can be forwarded on the logs submitted to the otel ingest.
+func authorizeLogs(r *http.Request, projectID int) (string, error) {
+	key := getIngestKey(r)
+	if ingestKeyValidator == nil {
+		return key, nil
+	}
+	if err := ingestKeyValidator(r.Context(), projectID, key, model.ProductTypeLogs, model.LogSourceBackend); err != nil {
+		log.WithContext(r.Context()).WithError(err).WithField("projectID", projectID).Warn("rejected http logs request")
+		return "", err
+	}
+	return key, nil
+}
+
+func setIngestKey(lg *hlog.Log, key string) {
+	if key != "" {
+		lg.Attributes[IngestKeyAttribute] = key
+	}
+}
+
 func getQueryStringParams(r *http.Request) (int, string, error) {
 	qs := r.URL.Query()
 	projectVerboseID := qs.Get(LogDrainProjectQueryParam)
@@ -126,6 +168,12 @@ func HandleFirehoseLog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ingestKey, err := authorizeLogs(r, projectID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
 	for _, rec := range rawRecords {
 		for _, payload := range []Payload{&CloudFrontJsonPayload{}, &FireLensFluentBitPayload{}, &FireLensPinoPayload{}, &FireLensPayload{}, &CloudWatchPayload{}, &JsonPayload{}} {
 			if payload.Parse(rec) {
@@ -141,6 +189,7 @@ func HandleFirehoseLog(w http.ResponseWriter, r *http.Request) {
 					ctx := p.SetLogAttributes(r.Context(), &hl, rec)
 					hl.Message = p.GetMessage()
 					hl.Timestamp = t.UTC().Format(hlog.TimestampFormat)
+					setIngestKey(&hl, ingestKey)
 					if err := hlog.SubmitHTTPLog(ctx, tracer, projectID, hl); err != nil {
 						log.WithContext(r.Context()).WithError(err).Error("failed to submit log")
 						http.Error(w, err.Error(), http.StatusBadRequest)
@@ -170,6 +219,12 @@ func HandlePinoLogs(w http.ResponseWriter, r *http.Request, lgJson []byte, logs
 		http.Error(w, "no project query string parameter provided", http.StatusBadRequest)
 	}
 
+	ingestKey, err := authorizeLogs(r, projectID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
 	// parse the logs as a list of maps to get other structured attributes (from the top level)
 	var lgAttrs struct {
 		Logs []map[string]interface{} `json:"logs"`
@@ -187,6 +242,7 @@ func HandlePinoLogs(w http.ResponseWriter, r *http.Request, lgJson []byte, logs
 		lg.Timestamp = time.UnixMilli(pinoLog.Time).UTC().Format(hlog.TimestampFormat)
 		lg.Message = pinoLog.Message
 		lg.Level = parsePinoLevel(pinoLog.Level)
+		setIngestKey(&lg, ingestKey)
 
 		for k, v := range lgAttrs.Logs[idx] {
 			// skip the keys that are part of the message
@@ -260,6 +316,13 @@ func HandleJSONLog(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
+		ingestKey, err := authorizeLogs(r, projectID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		setIngestKey(&lg, ingestKey)
+
 		lg.Attributes[string(semconv.ServiceNameKey)] = serviceName
 		if err := hlog.SubmitHTTPLog(r.Context(), tracer, projectID, lg); err != nil {
 			log.WithContext(r.Context()).WithError(err).Error("failed to submit log")
@@ -278,6 +341,12 @@ func HandleRawLog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ingestKey, err := authorizeLogs(r, projectID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
 	body, err := GetBody(r.Context(), r)
 	if err != nil {
 		log.WithContext(r.Context()).WithError(err).Error("invalid http firehose body")
@@ -295,6 +364,7 @@ func HandleRawLog(w http.ResponseWriter, r *http.Request) {
 	if serviceName != "" {
 		lg.Attributes[string(semconv.ServiceNameKey)] = serviceName
 	}
+	setIngestKey(&lg, ingestKey)
 	if err := hlog.SubmitHTTPLog(r.Context(), tracer, projectID, lg); err != nil {
 		log.WithContext(r.Context()).WithError(err).Error("failed to submit log")
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -306,8 +376,9 @@ func HandleRawLog(w http.ResponseWriter, r *http.Request) {
 
 var tracer trace.Tracer
 
-func Listen(r *chi.Mux, t trace.Tracer) {
+func Listen(r *chi.Mux, t trace.Tracer, validator IngestKeyValidator) {
 	tracer = t
+	ingestKeyValidator = validator
 	r.Route("/v1", func(r chi.Router) {
 		r.Use(highlightChi.Middleware)
 		r.HandleFunc("/logs/raw", HandleRawLog)
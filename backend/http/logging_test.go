@@ -3,8 +3,11 @@ package http
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"github.com/highlight-run/highlight/backend/private-graph/graph/model"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/otel/attribute"
@@ -129,6 +132,34 @@ func TestHandleRawLog(t *testing.T) {
 	assert.Equal(t, 200, w.statusCode)
 }
 
+func TestHandleRawLogIngestKey(t *testing.T) {
+	ingestKeyValidator = func(ctx context.Context, projectID int, key string, product model.ProductType, source model.LogSource) error {
+		assert.Equal(t, model.ProductTypeLogs, product)
+		if key != "hik_valid" {
+			return errors.New("invalid ingest key")
+		}
+		return nil
+	}
+	defer func() {
+		ingestKeyValidator = nil
+	}()
+
+	r, _ := http.NewRequest("POST", fmt.Sprintf("/v1/logs/raw?%s=1jdkoe52", LogDrainProjectQueryParam), strings.NewReader("unauthorized message"))
+	w := &MockResponseWriter{}
+	HandleRawLog(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.statusCode)
+
+	spanRecorder.Reset()
+	r, _ = http.NewRequest("POST", fmt.Sprintf("/v1/logs/raw?%s=1jdkoe52&%s=hik_valid", LogDrainProjectQueryParam, IngestKeyQueryParam), strings.NewReader("authorized message"))
+	w = &MockResponseWriter{}
+	HandleRawLog(w, r)
+	assert.Equal(t, 200, w.statusCode)
+
+	spans := spanRecorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Events()[0].Attributes, attribute.String(IngestKeyAttribute, "hik_valid"))
+}
+
 func TestHandleFlyJSONLog(t *testing.T) {
 	r, _ := http.NewRequest("POST", "/v1/logs/json", strings.NewReader(FlyNDJson))
 	r.Header.Set("Content-Type", "application/x-ndjson")
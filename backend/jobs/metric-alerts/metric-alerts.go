@@ -11,6 +11,7 @@ import (
 	"github.com/highlight-run/highlight/backend/clickhouse"
 	"github.com/highlight-run/highlight/backend/lambda"
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/store"
 	"github.com/highlight-run/highlight/backend/util"
 	"github.com/highlight-run/workerpool"
 	"github.com/openlyinc/pointy"
@@ -37,7 +38,7 @@ func applyDefaultFilters(productType modelInputs.ProductType) string {
 	return ""
 }
 
-func WatchMetricAlerts(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.Client, ccClient *clickhouse.Client, lambdaClient *lambda.Client) {
+func WatchMetricAlerts(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.Client, ccClient *clickhouse.Client, lambdaClient *lambda.Client, store *store.Store) {
 	log.WithContext(ctx).Info("Starting to watch metric alerts")
 
 	alertWorkerpool := workerpool.New(maxWorkers)
@@ -53,7 +54,7 @@ func WatchMetricAlerts(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.Cl
 				func() {
 					ctx := context.Background()
 
-					err := processMetricAlert(ctx, DB, MailClient, alert, ccClient, lambdaClient)
+					err := processMetricAlert(ctx, DB, MailClient, alert, ccClient, lambdaClient, store)
 					if err != nil {
 						log.WithContext(ctx).Error(err)
 					}
@@ -69,7 +70,8 @@ func WatchMetricAlerts(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.Cl
 
 func getMetricAlerts(ctx context.Context, DB *gorm.DB) []*model.Alert {
 	var alerts []*model.Alert
-	if err := DB.Model(&model.Alert{}).Where("disabled = ?", false).Find(&alerts).Error; err != nil {
+	// regression alerts are sent when an error group reopens rather than evaluated on a schedule
+	if err := DB.Model(&model.Alert{}).Where("disabled = ?", false).Where("threshold_type IS DISTINCT FROM ?", modelInputs.ThresholdTypeRegression).Find(&alerts).Error; err != nil {
 		log.WithContext(ctx).Error("Error querying for metric alerts")
 	}
 
@@ -78,7 +80,7 @@ func getMetricAlerts(ctx context.Context, DB *gorm.DB) []*model.Alert {
 
 const timeFormatSecondsNoTz = "2006-01-02T15:04:05"
 
-func processMetricAlert(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.Client, alert *model.Alert, ccClient *clickhouse.Client, lambdaClient *lambda.Client) error {
+func processMetricAlert(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.Client, alert *model.Alert, ccClient *clickhouse.Client, lambdaClient *lambda.Client, store *store.Store) error {
 	span, ctx := util.StartSpanFromContext(ctx, "WatchMetricAlerts.processMetricAlert")
 	span.SetAttribute("alert_id", alert.ID)
 	span.SetAttribute("project_id", alert.ProjectID)
@@ -125,6 +127,10 @@ func processMetricAlert(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.C
 		cooldown = time.Duration(*alert.ThresholdCooldown) * time.Second
 	}
 
+	if alert.ReleaseMetric != nil {
+		return processReleaseAlert(ctx, store, MailClient, alert, lambdaClient, curDate, thresholdWindow, cooldown)
+	}
+
 	alertingStates, err := ccClient.GetLastAlertingStates(ctx, alert.ProjectID, alert.ID, curDate.Add(-1*cooldown), curDate)
 	if err != nil {
 		return err
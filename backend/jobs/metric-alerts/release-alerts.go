@@ -0,0 +1,97 @@
+package metric_alerts
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/sendgrid/sendgrid-go"
+	log "github.com/sirupsen/logrus"
+
+	alertsV2 "github.com/highlight-run/highlight/backend/alerts/v2"
+	"github.com/highlight-run/highlight/backend/lambda"
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/store"
+)
+
+// the group by key of release alerts, matching the key used to search errors by version
+const releaseAlertGroupByKey = "service_version"
+
+// processReleaseAlert evaluates the health of the latest release of the project over the threshold window,
+// starting no earlier than when the release was first seen.
+func processReleaseAlert(ctx context.Context, store *store.Store, MailClient *sendgrid.Client, alert *model.Alert, lambdaClient *lambda.Client, curDate time.Time, thresholdWindow time.Duration, cooldown time.Duration) error {
+	release, err := store.GetLatestRelease(ctx, alert.ProjectID)
+	if err != nil {
+		return err
+	}
+	if release == nil {
+		return nil
+	}
+
+	startDate := curDate.Add(-1 * thresholdWindow)
+	if release.FirstSeenAt.After(startDate) {
+		startDate = release.FirstSeenAt
+	}
+	health, err := store.GetReleaseHealth(ctx, release, startDate, curDate)
+	if err != nil {
+		return err
+	}
+
+	value, ok := releaseMetricValue(health, *alert.ReleaseMetric)
+
+	alertingStates, err := store.ClickhouseClient.GetLastAlertingStates(ctx, alert.ProjectID, alert.ID, curDate.Add(-1*cooldown), curDate)
+	if err != nil {
+		return err
+	}
+	lastAlerts := lo.SliceToMap(alertingStates, func(alertingState modelInputs.AlertStateChange) (string, time.Time) {
+		return alertingState.GroupByKey, alertingState.Timestamp
+	})
+
+	var thresholdValue float64
+	if alert.ThresholdValue != nil {
+		thresholdValue = *alert.ThresholdValue
+	}
+
+	alertCondition := false
+	if ok {
+		if alert.ThresholdCondition == modelInputs.ThresholdConditionBelow {
+			alertCondition = value <= thresholdValue
+		} else {
+			alertCondition = value >= thresholdValue
+		}
+	}
+
+	alertStateChange := getAlertStateChange(curDate, alertCondition, alert.ID, release.Version, lastAlerts, cooldown)
+	if alertStateChange.State == modelInputs.AlertStateAlerting {
+		log.WithContext(ctx).WithFields(
+			log.Fields{
+				"alertID": alert.ID,
+				"version": release.Version,
+			}).Info("alerting release alert")
+
+		if err := alertsV2.SendAlerts(ctx, store.DB, MailClient, lambdaClient, alert, releaseAlertGroupByKey, release.Version, value); err != nil {
+			log.WithContext(ctx).WithFields(
+				log.Fields{
+					"alertID": alert.ID,
+					"version": release.Version,
+				}).Error(err)
+		}
+	}
+
+	return store.ClickhouseClient.WriteAlertStateChanges(ctx, alert.ProjectID, []modelInputs.AlertStateChange{alertStateChange})
+}
+
+// releaseMetricValue returns the value of the metric for the release, or false if it cannot be computed,
+// such as the error rate change of the first release.
+func releaseMetricValue(health *model.ReleaseHealth, metric modelInputs.ReleaseMetric) (float64, bool) {
+	switch metric {
+	case modelInputs.ReleaseMetricNewErrorGroups:
+		return float64(health.NewErrorGroupCount), true
+	case modelInputs.ReleaseMetricErrorRateChange:
+		return lo.FromPtr(health.ErrorRateChangePercent), health.ErrorRateChangePercent != nil
+	case modelInputs.ReleaseMetricCrashFreeSessionRate:
+		return lo.FromPtr(health.CrashFreeSessionPercent), health.CrashFreeSessionPercent != nil
+	}
+	return 0, false
+}
@@ -0,0 +1,70 @@
+package kafka_queue
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/highlight-run/highlight/backend/env"
+	"github.com/pkg/errors"
+)
+
+// getTopicSetting reads a comma separated setting where `value` applies to all topics
+// and `topic=value` applies to a single topic type, ie. `268435456,batched=536870912`.
+// The topic specific value takes precedence. Returns an empty string when the setting is unset.
+func getTopicSetting(setting string, topicType TopicType) (string, error) {
+	var value, override string
+	for _, part := range strings.Split(setting, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		topic, topicValue, ok := strings.Cut(part, "=")
+		if !ok {
+			if value == "" {
+				value = part
+			}
+			continue
+		}
+		topic, topicValue = strings.TrimSpace(topic), strings.TrimSpace(topicValue)
+		if topic == "" || topicValue == "" {
+			return "", errors.Errorf("invalid kafka topic setting %s", part)
+		}
+		if TopicType(topic) == topicType {
+			override = topicValue
+		}
+	}
+	if override != "" {
+		return override, nil
+	}
+	return value, nil
+}
+
+// GetTopicConfig returns the overrides that every producer and consumer of a topic type should apply,
+// so that workers, producers and the dead-letter replay agree on the message size limit and compression.
+func GetTopicConfig(topicType TopicType) (*ConfigOverride, error) {
+	cfg := &ConfigOverride{}
+
+	size, err := getTopicSetting(env.Config.KafkaMessageSizeBytes, topicType)
+	if err != nil {
+		return nil, err
+	}
+	if size != "" {
+		sizeBytes, err := strconv.ParseInt(size, 10, 64)
+		if err != nil || sizeBytes <= 0 {
+			return nil, errors.Errorf("invalid kafka message size %s for topic %s", size, topicType)
+		}
+		cfg.MessageSizeBytes = &sizeBytes
+	}
+
+	codec, err := getTopicSetting(env.Config.KafkaMessageCompression, topicType)
+	if err != nil {
+		return nil, err
+	}
+	compression, err := ParseCompression(codec)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Compression = &compression
+
+	return cfg, nil
+}
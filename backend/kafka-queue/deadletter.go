@@ -0,0 +1,266 @@
+package kafka_queue
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	hmetric "github.com/highlight/highlight/sdk/highlight-go/metric"
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+	log "github.com/sirupsen/logrus"
+)
+
+const deadLetterTopicSuffix = "dead_letter"
+
+// Dead-letter metadata is written as headers so that the original message is stored as-is
+// and a message that fit in its topic also fits in the dead-letter topic.
+const (
+	deadLetterHeaderPrefix    = "x-dead-letter-"
+	deadLetterHeaderTopic     = deadLetterHeaderPrefix + "topic"
+	deadLetterHeaderPartition = deadLetterHeaderPrefix + "partition"
+	deadLetterHeaderOffset    = deadLetterHeaderPrefix + "offset"
+	deadLetterHeaderTime      = deadLetterHeaderPrefix + "time"
+	deadLetterHeaderType      = deadLetterHeaderPrefix + "type"
+	deadLetterHeaderReason    = deadLetterHeaderPrefix + "reason"
+	deadLetterHeaderError     = deadLetterHeaderPrefix + "error"
+	deadLetterHeaderFailures  = deadLetterHeaderPrefix + "failures"
+	deadLetterHeaderFailedAt  = deadLetterHeaderPrefix + "failed-at"
+	deadLetterHeaderTruncated = deadLetterHeaderPrefix + "truncated"
+)
+
+const (
+	// maxDeadLetterErrorLength bounds the size of the error header
+	maxDeadLetterErrorLength = 4 * 1024
+	// deadLetterHeaderBytes is reserved in the dead-letter writer for the metadata headers
+	deadLetterHeaderBytes = 64 * 1024
+)
+
+type DeadLetterReason string
+
+const (
+	DeadLetterReasonRetriesExhausted  DeadLetterReason = "retries_exhausted"
+	DeadLetterReasonDeserializeFailed DeadLetterReason = "deserialize_failed"
+)
+
+// DeadLetterMessage wraps a message that could not be processed along with where it came from,
+// so that it can be inspected and replayed to the original topic once a fix ships.
+type DeadLetterMessage struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Headers   []kafka.Header
+	Time      time.Time
+	// Value is the original message as it was read from the topic
+	Value []byte
+	// Truncated is set when the original message was too large to be dead-lettered,
+	// in which case Value is empty and the message can only be recovered from the original topic.
+	Truncated bool
+
+	Type     *PayloadType
+	Reason   DeadLetterReason
+	Error    string
+	Failures int
+	FailedAt time.Time
+}
+
+// GetDeadLetterTopic returns the topic that exhausted messages of the provided topic are written to.
+func GetDeadLetterTopic(topic string) string {
+	return topic + "_" + deadLetterTopicSuffix
+}
+
+func newDeadLetterMessage(topic string, m *kafka.Message, reason DeadLetterReason, err error) *DeadLetterMessage {
+	msg := &DeadLetterMessage{
+		Topic:     topic,
+		Partition: m.Partition,
+		Offset:    m.Offset,
+		Key:       m.Key,
+		Headers:   m.Headers,
+		Time:      m.Time,
+		Value:     m.Value,
+		Reason:    reason,
+		FailedAt:  time.Now(),
+	}
+	if err != nil {
+		msg.Error = err.Error()
+		if len(msg.Error) > maxDeadLetterErrorLength {
+			msg.Error = msg.Error[:maxDeadLetterErrorLength]
+		}
+	}
+	return msg
+}
+
+// toKafkaMessage stores the original message as the value with the dead-letter metadata in headers.
+func (msg *DeadLetterMessage) toKafkaMessage() kafka.Message {
+	headers := make([]kafka.Header, 0, len(msg.Headers)+10)
+	headers = append(headers, msg.Headers...)
+	header := func(key, value string) {
+		headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+	}
+	header(deadLetterHeaderTopic, msg.Topic)
+	header(deadLetterHeaderPartition, strconv.Itoa(msg.Partition))
+	header(deadLetterHeaderOffset, strconv.FormatInt(msg.Offset, 10))
+	header(deadLetterHeaderTime, msg.Time.Format(time.RFC3339Nano))
+	if msg.Type != nil {
+		header(deadLetterHeaderType, strconv.Itoa(int(*msg.Type)))
+	}
+	header(deadLetterHeaderReason, string(msg.Reason))
+	header(deadLetterHeaderError, msg.Error)
+	header(deadLetterHeaderFailures, strconv.Itoa(msg.Failures))
+	header(deadLetterHeaderFailedAt, msg.FailedAt.Format(time.RFC3339Nano))
+	if msg.Truncated {
+		header(deadLetterHeaderTruncated, "true")
+	}
+	return kafka.Message{
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	}
+}
+
+// parseDeadLetterMessage reads a message written by toKafkaMessage.
+func parseDeadLetterMessage(m *kafka.Message) (*DeadLetterMessage, error) {
+	msg := &DeadLetterMessage{
+		Key:   m.Key,
+		Value: m.Value,
+	}
+	var err error
+	for _, h := range m.Headers {
+		if !strings.HasPrefix(h.Key, deadLetterHeaderPrefix) {
+			msg.Headers = append(msg.Headers, h)
+			continue
+		}
+		value := string(h.Value)
+		switch h.Key {
+		case deadLetterHeaderTopic:
+			msg.Topic = value
+		case deadLetterHeaderPartition:
+			msg.Partition, err = strconv.Atoi(value)
+		case deadLetterHeaderOffset:
+			msg.Offset, err = strconv.ParseInt(value, 10, 64)
+		case deadLetterHeaderTime:
+			msg.Time, err = time.Parse(time.RFC3339Nano, value)
+		case deadLetterHeaderType:
+			var t int
+			t, err = strconv.Atoi(value)
+			payloadType := PayloadType(t)
+			msg.Type = &payloadType
+		case deadLetterHeaderReason:
+			msg.Reason = DeadLetterReason(value)
+		case deadLetterHeaderError:
+			msg.Error = value
+		case deadLetterHeaderFailures:
+			msg.Failures, err = strconv.Atoi(value)
+		case deadLetterHeaderFailedAt:
+			msg.FailedAt, err = time.Parse(time.RFC3339Nano, value)
+		case deadLetterHeaderTruncated:
+			msg.Truncated = value == "true"
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid dead-letter header %s", h.Key)
+		}
+	}
+	if msg.Topic == "" {
+		return nil, errors.New("dead-letter message is missing its original topic")
+	}
+	return msg, nil
+}
+
+// SubmitDeadLetter writes a message that exhausted its retries to the dead-letter topic.
+func (p *Queue) SubmitDeadLetter(ctx context.Context, task RetryableMessage, err error) error {
+	m := task.GetKafkaMessage()
+	if m == nil {
+		return errors.New("cannot dead-letter a message that was not read from kafka")
+	}
+	msg := newDeadLetterMessage(p.Topic, m, DeadLetterReasonRetriesExhausted, err)
+	msgType := task.GetType()
+	msg.Type = &msgType
+	msg.Failures = task.GetFailures()
+	return p.submitDeadLetter(ctx, msg)
+}
+
+func (p *Queue) submitDeadLetter(ctx context.Context, msg *DeadLetterMessage) error {
+	if p.kafkaDLQ == nil {
+		return errors.New("dead-letter producer is not configured for this queue")
+	}
+	// keep the metadata of messages too large for the dead-letter topic so they can be found in the original topic
+	if int64(len(msg.Value))+deadLetterHeaderBytes > p.kafkaDLQ.BatchBytes {
+		log.WithContext(ctx).
+			WithField("topic", p.Topic).
+			WithField("partition", msg.Partition).
+			WithField("offset", msg.Offset).
+			WithField("msgBytes", len(msg.Value)).
+			Error("dead-letter message is too large, writing metadata only")
+		msg.Value = nil
+		msg.Truncated = true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, KafkaOperationTimeout)
+	defer cancel()
+	if err := p.kafkaDLQ.WriteMessages(ctx, msg.toKafkaMessage()); err != nil {
+		log.WithContext(ctx).WithError(err).WithField("topic", p.Topic).Error("failed to write dead-letter message")
+		return err
+	}
+	hmetric.Incr(ctx, p.metricPrefix()+"deadletter.count", nil, 1)
+	return nil
+}
+
+// ReceiveDeadLetter reads the next message from a queue consuming a dead-letter topic.
+// Returns nil when no message is available within the kafka operation timeout.
+func (p *Queue) ReceiveDeadLetter(ctx context.Context) (*DeadLetterMessage, *kafka.Message, error) {
+	rxCtx, cancel := context.WithTimeout(ctx, KafkaOperationTimeout)
+	defer cancel()
+
+	m, err := p.kafkaC.FetchMessage(rxCtx)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, nil, nil
+		}
+		return nil, nil, errors.Wrap(err, "failed to receive dead-letter message")
+	}
+
+	msg, err := parseDeadLetterMessage(&m)
+	if err != nil {
+		return nil, &m, err
+	}
+	return msg, &m, nil
+}
+
+// Replay re-submits the original dead-lettered message to this queue's topic.
+func (p *Queue) Replay(ctx context.Context, msg *DeadLetterMessage) error {
+	if msg.Topic != p.Topic {
+		return errors.Errorf("dead-letter message from topic %s cannot be replayed to %s", msg.Topic, p.Topic)
+	}
+	if msg.Truncated {
+		return errors.Errorf("dead-letter message at %s/%d/%d was too large to be stored and must be recovered from the original topic", msg.Topic, msg.Partition, msg.Offset)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, KafkaOperationTimeout)
+	defer cancel()
+	if err := p.kafkaP.WriteMessages(ctx, kafka.Message{
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: msg.Headers,
+	}); err != nil {
+		return errors.Wrap(err, "failed to replay dead-letter message")
+	}
+	hmetric.Incr(ctx, p.metricPrefix()+"deadletter.replay.count", nil, 1)
+	return nil
+}
+
+// Requeue writes a dead-letter message back to this queue's dead-letter topic,
+// ie. to preserve messages skipped while replaying.
+func (p *Queue) Requeue(ctx context.Context, msg *DeadLetterMessage) error {
+	if GetDeadLetterTopic(msg.Topic) != p.Topic {
+		return errors.Errorf("dead-letter message from topic %s cannot be requeued to %s", msg.Topic, p.Topic)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, KafkaOperationTimeout)
+	defer cancel()
+	if err := p.kafkaP.WriteMessages(ctx, msg.toKafkaMessage()); err != nil {
+		return errors.Wrap(err, "failed to requeue dead-letter message")
+	}
+	return nil
+}
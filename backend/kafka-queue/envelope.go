@@ -0,0 +1,154 @@
+package kafka_queue
+
+import (
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+type Compression string
+
+const (
+	CompressionNone   Compression = ""
+	CompressionZstd   Compression = "zstd"
+	CompressionSnappy Compression = "snappy"
+)
+
+// Messages written with an envelope start with envelopeMagic, which can never start a legacy JSON message.
+// The envelope layout is: magic byte, envelope version, codec, followed by the encoded JSON message.
+const (
+	envelopeMagic      byte = 0x00
+	envelopeVersion1   byte = 1
+	envelopeHeaderSize      = 3
+)
+
+const (
+	codecNone byte = iota
+	codecZstd
+	codecSnappy
+)
+
+// DecompressionRatioLimit bounds the decompressed size of a message relative to the topic's
+// message size limit, which applies to the size on the wire. Protects consumers from decompression bombs.
+const DecompressionRatioLimit = 8
+
+var ErrMessageTooLarge = errors.New("message too large")
+
+var getZstdEncoder = sync.OnceValues(func() (*zstd.Encoder, error) {
+	return zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+})
+
+// zstdDecoders caches a decoder per decompressed size limit since the limit is set when the decoder is created.
+var zstdDecoders sync.Map
+
+func getZstdDecoder(maxDecompressedSize int64) (*zstd.Decoder, error) {
+	if decoder, ok := zstdDecoders.Load(maxDecompressedSize); ok {
+		return decoder.(*zstd.Decoder), nil
+	}
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(0), zstd.WithDecoderMaxMemory(uint64(maxDecompressedSize)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create zstd decoder")
+	}
+	actual, loaded := zstdDecoders.LoadOrStore(maxDecompressedSize, decoder)
+	if loaded {
+		decoder.Close()
+	}
+	return actual.(*zstd.Decoder), nil
+}
+
+func ParseCompression(value string) (Compression, error) {
+	switch c := Compression(value); c {
+	case CompressionNone, CompressionZstd, CompressionSnappy:
+		return c, nil
+	default:
+		return CompressionNone, errors.Errorf("unsupported kafka message compression %s", value)
+	}
+}
+
+// encodeEnvelope wraps the JSON message in a versioned envelope, compressed with the provided codec.
+// CompressionNone writes legacy JSON so that producers can be rolled out before consumers read envelopes.
+func encodeEnvelope(data []byte, compression Compression) ([]byte, error) {
+	var codec byte
+	var body []byte
+	switch compression {
+	case CompressionNone:
+		return data, nil
+	case CompressionZstd:
+		encoder, err := getZstdEncoder()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create zstd encoder")
+		}
+		codec = codecZstd
+		body = encoder.EncodeAll(data, make([]byte, 0, len(data)/4))
+	case CompressionSnappy:
+		codec = codecSnappy
+		body = snappy.Encode(nil, data)
+	default:
+		return nil, errors.Errorf("unsupported kafka message compression %s", compression)
+	}
+
+	out := make([]byte, 0, envelopeHeaderSize+len(body))
+	out = append(out, envelopeMagic, envelopeVersion1, codec)
+	return append(out, body...), nil
+}
+
+// decodeEnvelope returns the JSON message from either an envelope or a legacy uncompressed message.
+// Messages larger than maxSize bytes on the wire, or that decompress to more than maxDecompressedSize bytes,
+// are rejected with ErrMessageTooLarge.
+func decodeEnvelope(data []byte, maxSize int64, maxDecompressedSize int64) ([]byte, error) {
+	if int64(len(data)) >= maxSize {
+		return nil, ErrMessageTooLarge
+	}
+	if len(data) == 0 || data[0] != envelopeMagic {
+		return data, nil
+	}
+	if len(data) < envelopeHeaderSize {
+		return nil, errors.New("truncated message envelope")
+	}
+	if data[1] != envelopeVersion1 {
+		return nil, errors.Errorf("unsupported message envelope version %d", data[1])
+	}
+
+	body := data[envelopeHeaderSize:]
+	switch data[2] {
+	case codecNone:
+		return body, nil
+	case codecZstd:
+		header := zstd.Header{}
+		if err := header.Decode(body); err == nil && header.HasFCS && int64(header.FrameContentSize) >= maxDecompressedSize {
+			return nil, ErrMessageTooLarge
+		}
+		decoder, err := getZstdDecoder(maxDecompressedSize)
+		if err != nil {
+			return nil, err
+		}
+		out, err := decoder.DecodeAll(body, nil)
+		if err != nil {
+			if errors.Is(err, zstd.ErrDecoderSizeExceeded) || errors.Is(err, zstd.ErrWindowSizeExceeded) {
+				return nil, ErrMessageTooLarge
+			}
+			return nil, errors.Wrap(err, "failed to decompress zstd message")
+		}
+		if int64(len(out)) >= maxDecompressedSize {
+			return nil, ErrMessageTooLarge
+		}
+		return out, nil
+	case codecSnappy:
+		size, err := snappy.DecodedLen(body)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read snappy message length")
+		}
+		if int64(size) >= maxDecompressedSize {
+			return nil, ErrMessageTooLarge
+		}
+		out, err := snappy.Decode(nil, body)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decompress snappy message")
+		}
+		return out, nil
+	default:
+		return nil, errors.Errorf("unsupported message envelope codec %d", data[2])
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/samber/lo"
 	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/compress"
 	"github.com/segmentio/kafka-go/sasl"
 	"github.com/segmentio/kafka-go/sasl/scram"
 	log "github.com/sirupsen/logrus"
@@ -55,9 +56,11 @@ type Queue struct {
 	Topic            string
 	ConsumerGroup    string
 	MessageSizeBytes int64
+	Compression      Compression
 	Client           *kafka.Client
 	kafkaP           *kafka.Writer
 	kafkaC           *kafka.Reader
+	kafkaDLQ         *kafka.Writer
 }
 
 type MessageQueue interface {
@@ -102,6 +105,7 @@ type ConfigOverride struct {
 	MinBytes         *int
 	MaxWait          *time.Duration
 	MessageSizeBytes *int64
+	Compression      *Compression
 	OnAssignGroups   func()
 }
 
@@ -195,16 +199,28 @@ func New(ctx context.Context, topic string, mode Mode, configOverride *ConfigOve
 		rebalanceTimeout = time.Second
 		// create per-profile consumer and topic to avoid collisions between dev envs
 		groupID = fmt.Sprintf("%s_%s", EnvironmentPrefix, groupID)
-		_, err := client.CreateTopics(ctx, &kafka.CreateTopicsRequest{
-			Topics: []kafka.TopicConfig{{
-				Topic:             topic,
-				NumPartitions:     8,
+		topics := []kafka.TopicConfig{{
+			Topic:             topic,
+			NumPartitions:     8,
+			ReplicationFactor: 1,
+		}}
+		if usesDeadLetter(topic, mode) {
+			topics = append(topics, kafka.TopicConfig{
+				Topic:             GetDeadLetterTopic(topic),
+				NumPartitions:     1,
 				ReplicationFactor: 1,
-			}},
+			})
+		}
+		_, err := client.CreateTopics(ctx, &kafka.CreateTopicsRequest{
+			Topics: topics,
 		})
 		if err != nil {
 			log.WithContext(ctx).Error(errors.Wrap(err, "failed to create dev topic"))
 		}
+	} else if usesDeadLetter(topic, mode) {
+		if err := createDeadLetterTopic(ctx, client, topic); err != nil {
+			log.WithContext(ctx).WithError(err).WithField("topic", topic).Error("failed to create dead-letter topic")
+		}
 	}
 
 	pool := &Queue{Topic: topic, ConsumerGroup: groupID, Client: client, MessageSizeBytes: MaxMessageSizeBytes}
@@ -241,6 +257,16 @@ func New(ctx context.Context, topic string, mode Mode, configOverride *ConfigOve
 				pool.kafkaP.BatchBytes = *deref.MessageSizeBytes
 				pool.MessageSizeBytes = *deref.MessageSizeBytes
 			}
+			if deref.Compression != nil {
+				pool.Compression = *deref.Compression
+			}
+		}
+		if isDeadLetterTopic(topic) {
+			pool.kafkaP.BatchBytes += deadLetterHeaderBytes
+		}
+		// enveloped messages are already compressed, so avoid compressing them again in the batch
+		if pool.Compression != CompressionNone {
+			pool.kafkaP.Compression = compress.None
 		}
 
 		if !env.IsDevOrTestEnv() {
@@ -293,6 +319,9 @@ func New(ctx context.Context, topic string, mode Mode, configOverride *ConfigOve
 				pool.MessageSizeBytes = *deref.MessageSizeBytes
 			}
 		}
+		if isDeadLetterTopic(topic) {
+			config.MaxBytes += deadLetterHeaderBytes
+		}
 
 		if !env.IsDevOrTestEnv() {
 			log.WithContext(ctx).
@@ -303,6 +332,24 @@ func New(ctx context.Context, topic string, mode Mode, configOverride *ConfigOve
 
 		pool.kafkaC = kafka.NewReader(config)
 	}
+	if usesDeadLetter(topic, mode) {
+		// messages that exhaust their retries are written to a per-topic dead-letter topic.
+		// the reader may return a message larger than its MaxBytes, so allow up to the largest supported message.
+		pool.kafkaDLQ = &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Transport:    transport,
+			Topic:        GetDeadLetterTopic(topic),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireOne,
+			Compression:  kafka.Zstd,
+			BatchSize:    1,
+			BatchBytes:   max(pool.MessageSizeBytes, MaxMessageSizeBytes) + deadLetterHeaderBytes,
+			ReadTimeout:  KafkaOperationTimeout,
+			WriteTimeout: KafkaOperationTimeout,
+			Logger:       getLogger("dead-letter producer", topic, log.InfoLevel),
+			ErrorLogger:  getLogger("dead-letter producer", topic, log.ErrorLevel),
+		}
+	}
 
 	go func() {
 		for {
@@ -314,6 +361,38 @@ func New(ctx context.Context, topic string, mode Mode, configOverride *ConfigOve
 	return pool
 }
 
+// usesDeadLetter is true for consumers of regular topics. Dead-letter topics themselves are not dead-lettered.
+func usesDeadLetter(topic string, mode Mode) bool {
+	return (mode>>1)&1 == 1 && !isDeadLetterTopic(topic)
+}
+
+func isDeadLetterTopic(topic string) bool {
+	return strings.HasSuffix(topic, "_"+deadLetterTopicSuffix)
+}
+
+// createDeadLetterTopic provisions the dead-letter topic of a consumed topic with the broker's
+// default partitions and replication, so that dead-letters can be written without manual setup.
+func createDeadLetterTopic(ctx context.Context, client *kafka.Client, topic string) error {
+	ctx, cancel := context.WithTimeout(ctx, KafkaOperationTimeout)
+	defer cancel()
+
+	deadLetterTopic := GetDeadLetterTopic(topic)
+	resp, err := client.CreateTopics(ctx, &kafka.CreateTopicsRequest{
+		Topics: []kafka.TopicConfig{{
+			Topic:             deadLetterTopic,
+			NumPartitions:     -1,
+			ReplicationFactor: -1,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if err := resp.Errors[deadLetterTopic]; err != nil && !errors.Is(err, kafka.TopicAlreadyExists) {
+		return err
+	}
+	return nil
+}
+
 func (p *Queue) metricPrefix() string {
 	return fmt.Sprintf("worker.kafka.%s.", p.Topic)
 }
@@ -331,6 +410,12 @@ func (p *Queue) Stop(ctx context.Context) {
 		}
 		p.kafkaP = nil
 	}
+	if p.kafkaDLQ != nil {
+		if err := p.kafkaDLQ.Close(); err != nil {
+			log.WithContext(ctx).Error(errors.Wrap(err, "failed to close dead-letter writer"))
+		}
+		p.kafkaDLQ = nil
+	}
 }
 
 func (p *Queue) Submit(ctx context.Context, partitionKey string, messages ...RetryableMessage) error {
@@ -358,7 +443,7 @@ func (p *Queue) Submit(ctx context.Context, partitionKey string, messages ...Ret
 	var kMessages []kafka.Message
 	for _, msg := range messages {
 		msg.SetMaxRetries(TaskRetries)
-		msgBytes, err := p.serializeMessage(msg)
+		msgBytes, err := p.serializeMessage(ctx, msg)
 		if err != nil {
 			log.WithContext(ctx).Error(errors.Wrap(err, "failed to serialize message"))
 			return err
@@ -406,9 +491,12 @@ func (p *Queue) Receive(ctx context.Context) (context.Context, RetryableMessage)
 	propagator := otel.GetTextMapPropagator()
 	ctx = propagator.Extract(ctx, &carrier)
 
-	msg, err := p.deserializeMessage(m.Value)
+	msg, err := p.deserializeMessage(ctx, m.Value)
 	if err != nil {
 		log.WithContext(ctx).WithField("topic", p.Topic).WithField("partition", m.Partition).WithField("msgBytes", len(m.Value)).Error(errors.Wrap(err, "failed to deserialize message"))
+		if dlqErr := p.submitDeadLetter(ctx, newDeadLetterMessage(p.Topic, &m, DeadLetterReasonDeserializeFailed, err)); dlqErr != nil {
+			log.WithContext(ctx).WithError(dlqErr).WithField("topic", p.Topic).Error("failed to dead-letter undeserializable message")
+		}
 		return ctx, nil
 	}
 	msg.SetKafkaMessage(&m)
@@ -505,22 +593,33 @@ func (p *Queue) LogStats() {
 	}
 }
 
-func (p *Queue) serializeMessage(msg RetryableMessage) (compressed []byte, err error) {
-	compressed, err = json.Marshal(&msg)
+func (p *Queue) serializeMessage(ctx context.Context, msg RetryableMessage) (compressed []byte, err error) {
+	data, err := json.Marshal(&msg)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshall json")
 	}
+	compressed, err = encodeEnvelope(data, p.Compression)
+	if err != nil {
+		return nil, err
+	}
+	if p.Compression != CompressionNone && len(compressed) > 0 {
+		hmetric.Histogram(ctx, p.metricPrefix()+"compression.ratio", float64(len(data))/float64(len(compressed)), []attribute.KeyValue{attribute.String("compression", string(p.Compression))}, 1)
+	}
 	return
 }
 
-func (p *Queue) deserializeMessage(compressed []byte) (RetryableMessage, error) {
-	if int64(len(compressed)) >= p.MessageSizeBytes {
-		return nil, errors.New("message too large")
+func (p *Queue) deserializeMessage(ctx context.Context, compressed []byte) (RetryableMessage, error) {
+	data, err := decodeEnvelope(compressed, p.MessageSizeBytes, p.MessageSizeBytes*DecompressionRatioLimit)
+	if err != nil {
+		if errors.Is(err, ErrMessageTooLarge) {
+			hmetric.Incr(ctx, p.metricPrefix()+"oversized.count", nil, 1)
+		}
+		return nil, err
 	}
 	var msgType struct {
 		Type PayloadType
 	}
-	if err := json.Unmarshal(compressed, &msgType); err != nil {
+	if err := json.Unmarshal(data, &msgType); err != nil {
 		return nil, errors.Wrap(err, "failed to unmarshall message type")
 	}
 
@@ -541,7 +640,7 @@ func (p *Queue) deserializeMessage(compressed []byte) (RetryableMessage, error)
 		msg = &Message{}
 	}
 
-	if err := json.Unmarshal(compressed, &msg); err != nil {
+	if err := json.Unmarshal(data, &msg); err != nil {
 		return nil, errors.Wrap(err, "failed to unmarshall message")
 	}
 
@@ -3,12 +3,15 @@ package kafka_queue
 import (
 	"context"
 	cryptorand "crypto/rand"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/highlight-run/highlight/backend/env"
 	"github.com/highlight-run/highlight/backend/util"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"math/rand"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -157,3 +160,148 @@ func TestPartitionKey(t *testing.T) {
 		t.Fatalf("unexpected partition %d", partitionID)
 	}
 }
+
+func TestDeadLetterMessage(t *testing.T) {
+	q := &Queue{Topic: "dev_topic", MessageSizeBytes: MaxMessageSizeBytes}
+	msg := &Message{Type: PushBackendPayload}
+	value, err := q.serializeMessage(context.TODO(), msg)
+	assert.NoError(t, err)
+
+	headers := []kafka.Header{{Key: "traceparent", Value: []byte("00-abc-def-01")}}
+	m := kafka.Message{Partition: 3, Offset: 42, Key: []byte("key"), Value: value, Headers: headers, Time: time.Now().Truncate(time.Millisecond)}
+	dl := newDeadLetterMessage(q.Topic, &m, DeadLetterReasonRetriesExhausted, errors.New("clickhouse unavailable"))
+	msgType := PushBackendPayload
+	dl.Type = &msgType
+	dl.Failures = 1
+	assert.Equal(t, "dev_topic", dl.Topic)
+	assert.Equal(t, 3, dl.Partition)
+	assert.Equal(t, int64(42), dl.Offset)
+	assert.Equal(t, "clickhouse unavailable", dl.Error)
+
+	// the original message is stored as-is, with the metadata in headers
+	encoded := dl.toKafkaMessage()
+	assert.Equal(t, value, encoded.Value)
+	assert.Equal(t, []byte("key"), encoded.Key)
+
+	decoded, err := parseDeadLetterMessage(&encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, dl.Topic, decoded.Topic)
+	assert.Equal(t, dl.Partition, decoded.Partition)
+	assert.Equal(t, dl.Offset, decoded.Offset)
+	assert.Equal(t, dl.Reason, decoded.Reason)
+	assert.Equal(t, dl.Error, decoded.Error)
+	assert.Equal(t, dl.Failures, decoded.Failures)
+	assert.Equal(t, PushBackendPayload, *decoded.Type)
+	assert.True(t, dl.Time.Equal(decoded.Time))
+	assert.True(t, dl.FailedAt.Equal(decoded.FailedAt))
+	assert.Equal(t, headers, decoded.Headers)
+	assert.False(t, decoded.Truncated)
+
+	replayed, err := q.deserializeMessage(context.TODO(), decoded.Value)
+	assert.NoError(t, err)
+	assert.Equal(t, PushBackendPayload, replayed.GetType())
+
+	_, err = parseDeadLetterMessage(&kafka.Message{Value: value})
+	assert.Error(t, err)
+
+	long := newDeadLetterMessage(q.Topic, &m, DeadLetterReasonDeserializeFailed, errors.New(strings.Repeat("e", 2*maxDeadLetterErrorLength)))
+	assert.Len(t, long.Error, maxDeadLetterErrorLength)
+
+	assert.Equal(t, "dev_topic_dead_letter", GetDeadLetterTopic(q.Topic))
+	assert.True(t, usesDeadLetter(q.Topic, Consumer))
+	assert.False(t, usesDeadLetter(q.Topic, Producer))
+	assert.False(t, usesDeadLetter(GetDeadLetterTopic(q.Topic), Consumer))
+}
+
+func TestGetTopicConfig(t *testing.T) {
+	defer func() { env.Config.KafkaMessageSizeBytes = "" }()
+
+	env.Config.KafkaMessageSizeBytes = ""
+	cfg, err := GetTopicConfig(TopicTypeBatched)
+	assert.NoError(t, err)
+	assert.Nil(t, cfg.MessageSizeBytes)
+
+	env.Config.KafkaMessageSizeBytes = "1024, batched=4096"
+	cfg, err = GetTopicConfig(TopicTypeBatched)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4096), *cfg.MessageSizeBytes)
+	cfg, err = GetTopicConfig(TopicTypeTraces)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1024), *cfg.MessageSizeBytes)
+
+	env.Config.KafkaMessageSizeBytes = "batched=big"
+	_, err = GetTopicConfig(TopicTypeBatched)
+	assert.Error(t, err)
+	_, err = GetTopicConfig(TopicTypeDefault)
+	assert.NoError(t, err)
+}
+
+func TestMessageEnvelope(t *testing.T) {
+	ctx := context.TODO()
+	msg := &Message{
+		Type: PushPayload,
+		PushPayload: &PushPayloadArgs{
+			SessionSecureID: "abc123",
+			Messages:        strings.Repeat("{\"message\":\"hello, world\"}", 1000),
+		},
+	}
+	legacy, err := json.Marshal(msg)
+	assert.NoError(t, err)
+
+	for _, compression := range []Compression{CompressionNone, CompressionZstd, CompressionSnappy} {
+		q := &Queue{Topic: "dev_topic", MessageSizeBytes: MaxMessageSizeBytes, Compression: compression}
+		value, err := q.serializeMessage(ctx, msg)
+		assert.NoError(t, err)
+		if compression == CompressionNone {
+			assert.Equal(t, legacy, value)
+		} else {
+			assert.Equal(t, envelopeMagic, value[0])
+			assert.Less(t, len(value), len(legacy))
+		}
+
+		decoded, err := q.deserializeMessage(ctx, value)
+		assert.NoError(t, err)
+		assert.Equal(t, "abc123", decoded.(*Message).PushPayload.SessionSecureID)
+		assert.Equal(t, msg.PushPayload.Messages, decoded.(*Message).PushPayload.Messages)
+
+		// consumers continue to read legacy JSON regardless of the producer compression
+		decoded, err = q.deserializeMessage(ctx, legacy)
+		assert.NoError(t, err)
+		assert.Equal(t, PushPayload, decoded.GetType())
+
+		// the message size limit applies to the size on the wire, so compressed messages may decompress past it
+		small := &Queue{Topic: "dev_topic", MessageSizeBytes: int64(len(legacy) / 2), Compression: compression}
+		_, err = small.deserializeMessage(ctx, value)
+		if compression == CompressionNone {
+			assert.ErrorIs(t, err, ErrMessageTooLarge)
+		} else {
+			assert.NoError(t, err)
+		}
+
+		// the decompressed size is limited separately to protect against decompression bombs
+		_, err = decodeEnvelope(value, MaxMessageSizeBytes, int64(len(legacy)/2))
+		if compression == CompressionNone {
+			assert.NoError(t, err)
+		} else {
+			assert.ErrorIs(t, err, ErrMessageTooLarge)
+		}
+	}
+
+	_, err = ParseCompression("gzip")
+	assert.Error(t, err)
+
+	defer func() { env.Config.KafkaMessageCompression = "" }()
+	env.Config.KafkaMessageCompression = "zstd,traces=snappy,metric_sum="
+	_, err = GetTopicConfig(TopicTypeTraces)
+	assert.Error(t, err)
+	env.Config.KafkaMessageCompression = "zstd,traces=snappy"
+	cfg, err := GetTopicConfig(TopicTypeTraces)
+	assert.NoError(t, err)
+	assert.Equal(t, CompressionSnappy, *cfg.Compression)
+	cfg, err = GetTopicConfig(TopicTypeMetricSum)
+	assert.NoError(t, err)
+	assert.Equal(t, CompressionZstd, *cfg.Compression)
+	env.Config.KafkaMessageCompression = "traces=gzip"
+	_, err = GetTopicConfig(TopicTypeTraces)
+	assert.Error(t, err)
+}
@@ -0,0 +1,317 @@
+package logmetrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	e "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/highlight-run/highlight/backend/parser"
+	"github.com/highlight-run/highlight/backend/parser/listener"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/redis"
+	"github.com/highlight-run/highlight/backend/util"
+)
+
+const (
+	// matching logs are aggregated into one metric row per rule, group and resolution interval
+	Resolution = time.Minute
+	// groups of a rule beyond this count in a batch are aggregated into the OtherGroup
+	MaxGroupsPerRule = 1_000
+	OtherGroup       = "__other__"
+	MaxGroupBy       = 10
+	filterCacheSize  = 1_000
+)
+
+// DefaultBuckets are the histogram bounds of a Histogram rule without buckets.
+var DefaultBuckets = []float64{1, 5, 10, 25, 50, 100, 250, 500, 1_000, 2_500, 5_000, 10_000}
+
+// ValidateRule returns an error if a rule cannot be evaluated.
+func ValidateRule(rule *model.LogMetricRule) error {
+	if rule.Name == "" {
+		return e.New("log metric rule requires a metric name")
+	}
+	if strings.TrimSpace(rule.Query) == "" {
+		return e.Errorf("log metric rule %s requires a query", rule.Name)
+	}
+	if len(rule.GroupBy) > MaxGroupBy {
+		return e.Errorf("log metric rule %s can group by at most %d attributes", rule.Name, MaxGroupBy)
+	}
+	switch rule.Type {
+	case modelInputs.LogMetricRuleTypeCount:
+	case modelInputs.LogMetricRuleTypeSum, modelInputs.LogMetricRuleTypeHistogram:
+		if (rule.ValueAttribute == nil || *rule.ValueAttribute == "") == (rule.ValuePattern == nil || *rule.ValuePattern == "") {
+			return e.Errorf("log metric rule %s requires either a value attribute or a value pattern", rule.Name)
+		}
+	default:
+		return e.Errorf("invalid log metric rule type %s", rule.Type)
+	}
+	if rule.ValuePattern != nil && *rule.ValuePattern != "" {
+		re, err := util.CompileRegexp(*rule.ValuePattern)
+		if err != nil {
+			return e.Wrapf(err, "invalid value pattern for log metric rule %s", rule.Name)
+		}
+		if re.NumSubexp() > 1 {
+			return e.Errorf("value pattern for log metric rule %s has more than one capture group", rule.Name)
+		}
+	}
+	for i, bound := range rule.Buckets {
+		if math.IsNaN(bound) || math.IsInf(bound, 0) || (i > 0 && bound <= rule.Buckets[i-1]) {
+			return e.Errorf("buckets of log metric rule %s must be finite and increasing", rule.Name)
+		}
+	}
+	return nil
+}
+
+// LogValue returns the value of a reserved log key or of a log attribute.
+func LogValue(row *clickhouse.LogRow, key string) (string, bool) {
+	switch modelInputs.ReservedLogKey(key) {
+	case modelInputs.ReservedLogKeyEnvironment:
+		return row.Environment, true
+	case modelInputs.ReservedLogKeyLevel:
+		return row.SeverityText, true
+	case modelInputs.ReservedLogKeyMessage:
+		return row.Body, true
+	case modelInputs.ReservedLogKeyPatternID:
+		return row.PatternId, true
+	case modelInputs.ReservedLogKeySecureSessionID:
+		return row.SecureSessionId, true
+	case modelInputs.ReservedLogKeySpanID:
+		return row.SpanId, true
+	case modelInputs.ReservedLogKeyTraceID:
+		return row.TraceId, true
+	case modelInputs.ReservedLogKeySource:
+		return string(row.Source), true
+	case modelInputs.ReservedLogKeyServiceName:
+		return row.ServiceName, true
+	case modelInputs.ReservedLogKeyServiceVersion:
+		return row.ServiceVersion, true
+	}
+	value, ok := row.LogAttributes[key]
+	return value, ok
+}
+
+// ExtractValue returns the numeric value of a log for a Sum or Histogram rule.
+func ExtractValue(rule *model.LogMetricRule, row *clickhouse.LogRow) (float64, bool) {
+	var str string
+	if rule.ValueAttribute != nil && *rule.ValueAttribute != "" {
+		value, ok := LogValue(row, *rule.ValueAttribute)
+		if !ok {
+			return 0, false
+		}
+		str = value
+	} else if rule.ValuePattern != nil && *rule.ValuePattern != "" {
+		re, err := util.CompileRegexp(*rule.ValuePattern)
+		if err != nil {
+			return 0, false
+		}
+		matches := re.FindStringSubmatch(row.Body)
+		if matches == nil {
+			return 0, false
+		}
+		str = matches[len(matches)-1]
+	} else {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false
+	}
+	return value, true
+}
+
+type ruleStore interface {
+	GetLogMetricRules(ctx context.Context, projectID int, opts ...redis.Option) ([]*model.LogMetricRule, error)
+}
+
+type aggregateKey struct {
+	ruleID    int
+	timestamp int64
+	group     string
+}
+
+type aggregate struct {
+	rule       *model.LogMetricRule
+	projectID  uint32
+	timestamp  time.Time
+	attributes map[string]string
+
+	count  uint64
+	sum    float64
+	min    float64
+	max    float64
+	counts []uint64
+}
+
+// Evaluator derives metrics from batches of logs with the model.LogMetricRule of their project.
+type Evaluator struct {
+	store     ruleStore
+	retention func(ctx context.Context, projectID int) uint8
+	filters   *lru.Cache[string, listener.Filters]
+}
+
+// NewEvaluator creates an Evaluator. `retention` returns the metric retention of a project in days.
+func NewEvaluator(store ruleStore, retention func(ctx context.Context, projectID int) uint8) *Evaluator {
+	filters, _ := lru.New[string, listener.Filters](filterCacheSize)
+	return &Evaluator{
+		store:     store,
+		retention: retention,
+		filters:   filters,
+	}
+}
+
+func (ev *Evaluator) getFilters(query string) listener.Filters {
+	filters, ok := ev.filters.Get(query)
+	if !ok {
+		filters = parser.Parse(query, clickhouse.LogsTableConfig)
+		ev.filters.Add(query, filters)
+	}
+	return filters
+}
+
+// Evaluate aggregates the logs matching each rule per Resolution interval and per group. Count and Sum
+// rules emit a delta MetricSumRow, Histogram rules emit a delta MetricHistogramRow.
+func (ev *Evaluator) Evaluate(ctx context.Context, rows []*clickhouse.LogRow) []clickhouse.MetricRow {
+	rulesByProject := map[uint32][]*model.LogMetricRule{}
+	aggregates := map[aggregateKey]*aggregate{}
+	groupsByRule := map[int]map[string]bool{}
+	var keys []aggregateKey
+	for _, row := range rows {
+		rules, ok := rulesByProject[row.ProjectId]
+		if !ok {
+			var err error
+			rules, err = ev.store.GetLogMetricRules(ctx, int(row.ProjectId))
+			if err != nil {
+				log.WithContext(ctx).WithError(err).WithField("project_id", row.ProjectId).Error("failed to get log metric rules")
+			}
+			rulesByProject[row.ProjectId] = rules
+		}
+		for _, rule := range rules {
+			if !clickhouse.LogMatchesQuery(row, ev.getFilters(rule.Query)) {
+				continue
+			}
+			var value float64
+			if rule.Type != modelInputs.LogMetricRuleTypeCount {
+				if value, ok = ExtractValue(rule, row); !ok {
+					continue
+				}
+			}
+
+			attributes := map[string]string{}
+			var groupParts []string
+			for _, key := range rule.GroupBy {
+				attributes[key], _ = LogValue(row, key)
+				groupParts = append(groupParts, fmt.Sprintf("%s=%s", key, attributes[key]))
+			}
+			group := strings.Join(groupParts, "\x00")
+			groups, ok := groupsByRule[rule.ID]
+			if !ok {
+				groups = map[string]bool{}
+				groupsByRule[rule.ID] = groups
+			}
+			if !groups[group] && len(groups) >= MaxGroupsPerRule {
+				group = OtherGroup
+				for _, key := range rule.GroupBy {
+					attributes[key] = OtherGroup
+				}
+			}
+			groups[group] = true
+
+			timestamp := row.Timestamp.Truncate(Resolution)
+			key := aggregateKey{ruleID: rule.ID, timestamp: timestamp.Unix(), group: group}
+			agg, ok := aggregates[key]
+			if !ok {
+				agg = &aggregate{rule: rule, projectID: row.ProjectId, timestamp: timestamp, attributes: attributes, min: value, max: value}
+				if rule.Type == modelInputs.LogMetricRuleTypeHistogram {
+					agg.counts = make([]uint64, len(bounds(rule))+1)
+				}
+				aggregates[key] = agg
+				keys = append(keys, key)
+			}
+			agg.add(value)
+		}
+	}
+
+	retention := map[uint32]uint8{}
+	var metricRows []clickhouse.MetricRow
+	for _, key := range keys {
+		agg := aggregates[key]
+		days, ok := retention[agg.projectID]
+		if !ok {
+			days = ev.retention(ctx, int(agg.projectID))
+			retention[agg.projectID] = days
+		}
+		metricRows = append(metricRows, agg.toMetricRow(days))
+	}
+	return metricRows
+}
+
+func bounds(rule *model.LogMetricRule) []float64 {
+	if len(rule.Buckets) > 0 {
+		return rule.Buckets
+	}
+	return DefaultBuckets
+}
+
+func (a *aggregate) add(value float64) {
+	a.count++
+	a.sum += value
+	a.min = math.Min(a.min, value)
+	a.max = math.Max(a.max, value)
+	if a.counts != nil {
+		// the bucket i counts values in (bounds[i-1], bounds[i]]
+		a.counts[sort.SearchFloat64s(bounds(a.rule), value)]++
+	}
+}
+
+func (a *aggregate) toMetricRow(retentionDays uint8) clickhouse.MetricRow {
+	base := clickhouse.MetricBaseRow{
+		ProjectId:      a.projectID,
+		ServiceName:    a.attributes[string(modelInputs.ReservedLogKeyServiceName)],
+		ServiceVersion: a.attributes[string(modelInputs.ReservedLogKeyServiceVersion)],
+		MetricName:     a.rule.Name,
+		Attributes:     a.attributes,
+		Timestamp:      a.timestamp,
+		StartTimestamp: a.timestamp,
+		RetentionDays:  retentionDays,
+	}
+	switch a.rule.Type {
+	case modelInputs.LogMetricRuleTypeHistogram:
+		base.MetricType = pmetric.MetricTypeHistogram
+		return &clickhouse.MetricHistogramRow{
+			MetricBaseRow:          base,
+			Count:                  a.count,
+			Sum:                    a.sum,
+			BucketCounts:           a.counts,
+			ExplicitBounds:         bounds(a.rule),
+			Min:                    a.min,
+			Max:                    a.max,
+			AggregationTemporality: int32(pmetric.AggregationTemporalityDelta),
+		}
+	case modelInputs.LogMetricRuleTypeSum:
+		base.MetricType = pmetric.MetricTypeSum
+		return &clickhouse.MetricSumRow{
+			MetricBaseRow:          base,
+			Value:                  a.sum,
+			AggregationTemporality: int32(pmetric.AggregationTemporalityDelta),
+		}
+	default:
+		base.MetricType = pmetric.MetricTypeSum
+		return &clickhouse.MetricSumRow{
+			MetricBaseRow:          base,
+			Value:                  float64(a.count),
+			AggregationTemporality: int32(pmetric.AggregationTemporalityDelta),
+			IsMonotonic:            true,
+		}
+	}
+}
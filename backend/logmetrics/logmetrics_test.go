@@ -0,0 +1,141 @@
+package logmetrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/redis"
+)
+
+type memoryStore map[int][]*model.LogMetricRule
+
+func (s memoryStore) GetLogMetricRules(_ context.Context, projectID int, _ ...redis.Option) ([]*model.LogMetricRule, error) {
+	return s[projectID], nil
+}
+
+var testTime = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func logRow(projectID uint32, offset time.Duration, body string, attributes map[string]string) *clickhouse.LogRow {
+	return &clickhouse.LogRow{
+		Timestamp:     testTime.Add(offset),
+		ProjectId:     projectID,
+		SeverityText:  "error",
+		ServiceName:   "payments",
+		Body:          body,
+		LogAttributes: attributes,
+	}
+}
+
+func newTestEvaluator(rules memoryStore) *Evaluator {
+	return NewEvaluator(rules, func(context.Context, int) uint8 {
+		return 30
+	})
+}
+
+func TestEvaluateCount(t *testing.T) {
+	evaluator := newTestEvaluator(memoryStore{
+		1: {{Model: model.Model{ID: 1}, Name: "payments.declined", Query: `"payment declined"`, Type: modelInputs.LogMetricRuleTypeCount, GroupBy: pq.StringArray{"merchant", "service_name"}}},
+	})
+
+	rows := evaluator.Evaluate(context.Background(), []*clickhouse.LogRow{
+		logRow(1, 0, "payment declined: insufficient funds", map[string]string{"merchant": "acme"}),
+		logRow(1, 10*time.Second, "payment declined: card expired", map[string]string{"merchant": "acme"}),
+		logRow(1, 20*time.Second, "payment declined: fraud", map[string]string{"merchant": "globex"}),
+		logRow(1, 70*time.Second, "payment declined: fraud", map[string]string{"merchant": "acme"}),
+		logRow(1, 0, "payment accepted", map[string]string{"merchant": "acme"}),
+		// projects without rules are ignored
+		logRow(2, 0, "payment declined", map[string]string{"merchant": "acme"}),
+	})
+	require.Len(t, rows, 3)
+
+	first := rows[0].(*clickhouse.MetricSumRow)
+	assert.Equal(t, "payments.declined", first.MetricName)
+	assert.Equal(t, uint32(1), first.ProjectId)
+	assert.Equal(t, map[string]string{"merchant": "acme", "service_name": "payments"}, first.Attributes)
+	assert.Equal(t, "payments", first.ServiceName)
+	assert.Equal(t, testTime, first.Timestamp)
+	assert.Equal(t, 2., first.Value)
+	assert.True(t, first.IsMonotonic)
+	assert.Equal(t, pmetric.MetricTypeSum, first.MetricType)
+	assert.Equal(t, int32(pmetric.AggregationTemporalityDelta), first.AggregationTemporality)
+	assert.Equal(t, uint8(30), first.RetentionDays)
+
+	assert.Equal(t, "globex", rows[1].(*clickhouse.MetricSumRow).Attributes["merchant"])
+	assert.Equal(t, 1., rows[1].(*clickhouse.MetricSumRow).Value)
+
+	// logs of the next minute are aggregated separately
+	assert.Equal(t, testTime.Add(time.Minute), rows[2].GetTimestamp())
+	assert.Equal(t, 1., rows[2].(*clickhouse.MetricSumRow).Value)
+}
+
+func TestEvaluateSumAndHistogram(t *testing.T) {
+	evaluator := newTestEvaluator(memoryStore{
+		1: {
+			{Model: model.Model{ID: 1}, Name: "payments.declined.amount", Query: `"payment declined"`, Type: modelInputs.LogMetricRuleTypeSum, ValueAttribute: pointy.String("amount")},
+			{Model: model.Model{ID: 2}, Name: "payments.latency", Query: `level=error`, Type: modelInputs.LogMetricRuleTypeHistogram, ValuePattern: pointy.String(`took (\d+(?:\.\d+)?)ms`), Buckets: pq.Float64Array{10, 100}},
+		},
+	})
+
+	rows := evaluator.Evaluate(context.Background(), []*clickhouse.LogRow{
+		logRow(1, 0, "payment declined, took 5ms", map[string]string{"amount": "12.5"}),
+		logRow(1, 0, "payment declined, took 50ms", map[string]string{"amount": "7.5"}),
+		logRow(1, 0, "payment declined, took 500ms", map[string]string{"amount": "not a number"}),
+		logRow(1, 0, "payment declined", map[string]string{}),
+	})
+	require.Len(t, rows, 2)
+
+	sum := rows[0].(*clickhouse.MetricSumRow)
+	assert.Equal(t, 20., sum.Value)
+	assert.False(t, sum.IsMonotonic)
+
+	histogram := rows[1].(*clickhouse.MetricHistogramRow)
+	assert.Equal(t, "payments.latency", histogram.MetricName)
+	assert.Equal(t, pmetric.MetricTypeHistogram, histogram.MetricType)
+	assert.Equal(t, uint64(3), histogram.Count)
+	assert.Equal(t, 555., histogram.Sum)
+	assert.Equal(t, 5., histogram.Min)
+	assert.Equal(t, 500., histogram.Max)
+	assert.Equal(t, []float64{10, 100}, histogram.ExplicitBounds)
+	assert.Equal(t, []uint64{1, 1, 1}, histogram.BucketCounts)
+}
+
+func TestEvaluateMaxGroups(t *testing.T) {
+	evaluator := newTestEvaluator(memoryStore{
+		1: {{Model: model.Model{ID: 1}, Name: "requests", Query: `user_id=*`, Type: modelInputs.LogMetricRuleTypeCount, GroupBy: pq.StringArray{"user_id"}}},
+	})
+
+	var logRows []*clickhouse.LogRow
+	for i := 0; i < MaxGroupsPerRule+10; i++ {
+		logRows = append(logRows, logRow(1, 0, "request", map[string]string{"user_id": time.Duration(i).String()}))
+	}
+	rows := evaluator.Evaluate(context.Background(), logRows)
+	require.Len(t, rows, MaxGroupsPerRule+1)
+	other := rows[len(rows)-1].(*clickhouse.MetricSumRow)
+	assert.Equal(t, OtherGroup, other.Attributes["user_id"])
+	assert.Equal(t, 10., other.Value)
+}
+
+func TestValidateRule(t *testing.T) {
+	assert.NoError(t, ValidateRule(&model.LogMetricRule{Name: "count", Query: "level=error", Type: modelInputs.LogMetricRuleTypeCount}))
+	assert.NoError(t, ValidateRule(&model.LogMetricRule{Name: "sum", Query: "level=error", Type: modelInputs.LogMetricRuleTypeSum, ValueAttribute: pointy.String("amount")}))
+	assert.NoError(t, ValidateRule(&model.LogMetricRule{Name: "histogram", Query: "level=error", Type: modelInputs.LogMetricRuleTypeHistogram, ValuePattern: pointy.String(`(\d+)ms`), Buckets: pq.Float64Array{1, 2}}))
+
+	assert.Error(t, ValidateRule(&model.LogMetricRule{Query: "level=error", Type: modelInputs.LogMetricRuleTypeCount}))
+	assert.Error(t, ValidateRule(&model.LogMetricRule{Name: "count", Type: modelInputs.LogMetricRuleTypeCount}))
+	assert.Error(t, ValidateRule(&model.LogMetricRule{Name: "sum", Query: "level=error", Type: modelInputs.LogMetricRuleTypeSum}))
+	assert.Error(t, ValidateRule(&model.LogMetricRule{Name: "sum", Query: "level=error", Type: modelInputs.LogMetricRuleTypeSum, ValueAttribute: pointy.String("amount"), ValuePattern: pointy.String(`(\d+)`)}))
+	assert.Error(t, ValidateRule(&model.LogMetricRule{Name: "sum", Query: "level=error", Type: modelInputs.LogMetricRuleTypeSum, ValuePattern: pointy.String(`(\d+`)}))
+	assert.Error(t, ValidateRule(&model.LogMetricRule{Name: "sum", Query: "level=error", Type: modelInputs.LogMetricRuleTypeSum, ValuePattern: pointy.String(`(\d+)\.(\d+)`)}))
+	assert.Error(t, ValidateRule(&model.LogMetricRule{Name: "histogram", Query: "level=error", Type: modelInputs.LogMetricRuleTypeHistogram, ValueAttribute: pointy.String("amount"), Buckets: pq.Float64Array{2, 1}}))
+	assert.Error(t, ValidateRule(&model.LogMetricRule{Name: "unknown", Query: "level=error", Type: "Unknown"}))
+}
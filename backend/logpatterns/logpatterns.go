@@ -0,0 +1,185 @@
+package logpatterns
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+const (
+	// Wildcard replaces the tokens that differ between the logs of a pattern
+	Wildcard = "<*>"
+	// only the beginning of a long log body is mined
+	MaxBodyLength = 4_096
+	// tokens beyond this count are collapsed into a trailing Wildcard
+	MaxTokens = 64
+	// the number of leading tokens routing a log to its pattern
+	prefixDepth = 3
+	// patterns of a service beyond this count are not stored
+	MaxPatternsPerService = 1_000
+	// the number of services whose patterns are kept in memory
+	maxServices = 10_000
+)
+
+type mask struct {
+	pattern     *regexp.Regexp
+	placeholder string
+}
+
+// masks are applied in order, so that the digits of an id are not masked as numbers first
+var masks = []mask{
+	{regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`), "<UUID>"},
+	{regexp.MustCompile(`[\w.+-]+@[\w-]+(?:\.[\w-]+)+`), "<EMAIL>"},
+	{regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}(?::\d{1,5})?\b`), "<IP>"},
+	{regexp.MustCompile(`\b0x[0-9a-fA-F]+\b|\b[0-9a-fA-F]{8,}\b`), "<HEX>"},
+	{regexp.MustCompile(`\d+(?:\.\d+)?`), "<NUM>"},
+}
+
+// Mask replaces the variable parts of a log body, such as numbers, ids and ip addresses, with a placeholder.
+func Mask(body string) string {
+	for _, m := range masks {
+		body = m.pattern.ReplaceAllStringFunc(body, func(match string) string {
+			// hex strings without digits are more likely words
+			if m.placeholder == "<HEX>" && strings.IndexFunc(match, unicode.IsDigit) < 0 {
+				return match
+			}
+			return m.placeholder
+		})
+	}
+	return body
+}
+
+// Tokenize splits a masked log body into at most MaxTokens tokens.
+func Tokenize(body string) []string {
+	if len(body) > MaxBodyLength {
+		body = strings.ToValidUTF8(body[:MaxBodyLength], "")
+	}
+	tokens := strings.Fields(body)
+	if len(tokens) > MaxTokens {
+		tokens = append(tokens[:MaxTokens-1], Wildcard)
+	}
+	return tokens
+}
+
+// routingKey returns the token count and the leading tokens of a log, with variable tokens
+// replaced by Wildcard. Logs with the same routing key belong to the same pattern.
+func routingKey(tokens []string) []string {
+	key := []string{strconv.Itoa(len(tokens))}
+	for i := 0; i < prefixDepth && i < len(tokens); i++ {
+		token := tokens[i]
+		if isVariable(token) {
+			token = Wildcard
+		}
+		key = append(key, token)
+	}
+	return key
+}
+
+// PatternID returns the id of a pattern, derived from its service and the routing key of its template.
+// The id only depends on the logs of the pattern, not on the order they are mined in, so that
+// all miners assign the same id to the same pattern.
+func PatternID(serviceName string, template string) string {
+	return patternID(serviceName, Tokenize(template))
+}
+
+func patternID(serviceName string, tokens []string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(serviceName))
+	for _, token := range routingKey(tokens) {
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(token))
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// Pattern is the template shared by similar logs of a service.
+type Pattern struct {
+	ID       string
+	Template string
+}
+
+type cluster struct {
+	tokens []string
+}
+
+func (c *cluster) template() string {
+	return strings.Join(c.tokens, " ")
+}
+
+// merge replaces the tokens of the cluster that differ from the log with a Wildcard.
+func (c *cluster) merge(tokens []string) {
+	for i, token := range c.tokens {
+		if token != tokens[i] {
+			c.tokens[i] = Wildcard
+		}
+	}
+}
+
+func isVariable(token string) bool {
+	return strings.ContainsRune(token, '<') || strings.IndexFunc(token, unicode.IsDigit) >= 0
+}
+
+// tree holds the clusters of a service. As in Drain, logs are routed to a cluster by their
+// token count and their leading tokens, and the differing tokens of the logs of a cluster
+// are generalized into a Wildcard.
+type tree struct {
+	serviceName string
+	clusters    map[string]*cluster
+}
+
+func (t *tree) add(tokens []string) Pattern {
+	id := patternID(t.serviceName, tokens)
+	c, ok := t.clusters[id]
+	if ok {
+		c.merge(tokens)
+		return Pattern{ID: id, Template: c.template()}
+	}
+
+	if len(t.clusters) < MaxPatternsPerService {
+		t.clusters[id] = &cluster{tokens: append([]string{}, tokens...)}
+	}
+	return Pattern{ID: id, Template: strings.Join(tokens, " ")}
+}
+
+type treeKey struct {
+	projectID   uint32
+	serviceName string
+}
+
+// Miner clusters the logs of each service into patterns using a Drain-style online log parsing algorithm.
+// The id of a pattern is kept when the pattern is generalized by later logs.
+type Miner struct {
+	mu    sync.Mutex
+	trees *lru.Cache[treeKey, *tree]
+}
+
+func NewMiner() *Miner {
+	trees, _ := lru.New[treeKey, *tree](maxServices)
+	return &Miner{trees: trees}
+}
+
+// Add assigns a log body to a pattern of its service. Returns nil if the body has no tokens.
+func (m *Miner) Add(projectID uint32, serviceName string, body string) *Pattern {
+	tokens := Tokenize(Mask(body))
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := treeKey{projectID: projectID, serviceName: serviceName}
+	t, ok := m.trees.Get(key)
+	if !ok {
+		t = &tree{serviceName: serviceName, clusters: map[string]*cluster{}}
+		m.trees.Add(key, t)
+	}
+	pattern := t.add(tokens)
+	return &pattern
+}
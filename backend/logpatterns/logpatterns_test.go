@@ -0,0 +1,103 @@
+package logpatterns
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMask(t *testing.T) {
+	for body, expected := range map[string]string{
+		"user 42 logged in from 10.0.0.1:8080":                     "user <NUM> logged in from <IP>",
+		"request 9f1c2a3b-1d2e-4f5a-8b9c-0d1e2f3a4b5c took 12.5ms": "request <UUID> took <NUM>ms",
+		"sent email to jane.doe@example.com":                       "sent email to <EMAIL>",
+		"pointer 0xc000123abc, commit 4e5f6a7b8c9d":                "pointer <HEX>, commit <HEX>",
+		"cache miss for key facade":                                "cache miss for key facade",
+		"retry attempt 3 of 5":                                     "retry attempt <NUM> of <NUM>",
+	} {
+		assert.Equal(t, expected, Mask(body))
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	assert.Empty(t, Tokenize("  \n "))
+	assert.Equal(t, []string{"a", "b", "c"}, Tokenize(" a  b\tc\n"))
+
+	tokens := Tokenize(strings.Repeat("word ", MaxTokens+10))
+	require.Len(t, tokens, MaxTokens)
+	assert.Equal(t, Wildcard, tokens[MaxTokens-1])
+}
+
+func TestMinerAdd(t *testing.T) {
+	miner := NewMiner()
+	assert.Nil(t, miner.Add(1, "api", ""))
+
+	first := miner.Add(1, "api", "user 42 logged in from 10.0.0.1")
+	require.NotNil(t, first)
+	assert.Equal(t, "user <NUM> logged in from <IP>", first.Template)
+	assert.Equal(t, PatternID("api", "user <NUM> logged in from <IP>"), first.ID)
+
+	// differing tokens are replaced by a wildcard, the pattern keeps its id
+	second := miner.Add(1, "api", "user 7 logged out from 10.0.0.2")
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, "user <NUM> logged <*> from <IP>", second.Template)
+	assert.Equal(t, first.ID, miner.Add(1, "api", "user 8 logged in from 10.0.0.3").ID)
+
+	// logs with a different token count or prefix belong to another pattern
+	assert.NotEqual(t, first.ID, miner.Add(1, "api", "user 42 logged in").ID)
+	assert.NotEqual(t, first.ID, miner.Add(1, "api", "payment 42 declined for card ending 1234").ID)
+
+	// logs with different constant leading tokens belong to another pattern
+	assert.NotEqual(t, first.ID, miner.Add(1, "api", "user cache evicted by the janitor").ID)
+
+	// patterns are mined per project and service
+	other := miner.Add(1, "worker", "user 42 logged in from 10.0.0.1")
+	assert.NotEqual(t, first.ID, other.ID)
+	assert.Equal(t, "user <NUM> logged in from <IP>", other.Template)
+	assert.Equal(t, first.ID, miner.Add(2, "api", "user 42 logged in from 10.0.0.1").ID)
+	assert.Equal(t, "user <NUM> logged in from <IP>", miner.Add(2, "api", "user 42 logged in from 10.0.0.1").Template)
+}
+
+func TestMinerOrder(t *testing.T) {
+	logs := []string{
+		"user 42 logged in from 10.0.0.1",
+		"user 7 logged out from 10.0.0.2",
+		"payment 42 declined for card 1234",
+		"payment 43 declined for insufficient funds",
+	}
+
+	// miners of different workers assign the same ids regardless of the order of the logs
+	forward, backward := NewMiner(), NewMiner()
+	ids := map[string]string{}
+	for _, body := range logs {
+		ids[body] = forward.Add(1, "api", body).ID
+	}
+	for i := len(logs) - 1; i >= 0; i-- {
+		assert.Equal(t, ids[logs[i]], backward.Add(1, "api", logs[i]).ID)
+	}
+	assert.Equal(t, ids[logs[0]], ids[logs[1]])
+	assert.NotEqual(t, ids[logs[0]], ids[logs[2]])
+
+	// the id of a generalized template is the id of its logs
+	pattern := forward.Add(1, "api", logs[3])
+	assert.Equal(t, "payment <NUM> declined for <*> <*>", pattern.Template)
+	assert.Equal(t, pattern.ID, PatternID("api", pattern.Template))
+}
+
+func TestMinerMaxPatterns(t *testing.T) {
+	miner := NewMiner()
+	for i := 0; i < MaxPatternsPerService; i++ {
+		miner.Add(1, "api", fmt.Sprintf("event%c%c%c", 'a'+i%26, 'a'+i/26%26, 'a'+i/676))
+	}
+
+	// logs that do not match a stored pattern still get an id, but do not create a pattern
+	pattern := miner.Add(1, "api", "a completely new kind of log")
+	assert.Equal(t, PatternID("api", "a completely new kind of log"), pattern.ID)
+	assert.Equal(t, PatternID("api", "a completely new kind of bug"), miner.Add(1, "api", "a completely new kind of bug").ID)
+
+	// stored patterns are still matched
+	assert.Equal(t, PatternID("api", "eventaaa"), miner.Add(1, "api", "eventaaa").ID)
+}
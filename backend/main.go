@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"math/rand"
@@ -172,6 +173,52 @@ var PRIVATE_GRAPH_CORS_OPTIONS = cors.Options{
 	AllowedHeaders:             []string{"*"},
 }
 
+// producerConfig applies the message size and compression configured for the topic to a producer's config.
+func producerConfig(ctx context.Context, topicType kafkaqueue.TopicType, cfg kafkaqueue.ConfigOverride) *kafkaqueue.ConfigOverride {
+	topicConfig, err := kafkaqueue.GetTopicConfig(topicType)
+	if err != nil {
+		log.WithContext(ctx).Fatalf("error reading kafka %s topic config: %v", topicType, err)
+	}
+	cfg.MessageSizeBytes = topicConfig.MessageSizeBytes
+	cfg.Compression = topicConfig.Compression
+	return &cfg
+}
+
+// getSyslogListeners returns the syslog ports configured for the deployment.
+func getSyslogListeners(ctx context.Context) []otel.SyslogListener {
+	var listeners []otel.SyslogListener
+	if env.Config.SyslogUDPPort != "" {
+		listeners = append(listeners, otel.SyslogListener{Transport: otel.SyslogTransportUDP, Port: env.Config.SyslogUDPPort})
+	}
+	if env.Config.SyslogTCPPort != "" {
+		listeners = append(listeners, otel.SyslogListener{Transport: otel.SyslogTransportTCP, Port: env.Config.SyslogTCPPort})
+	}
+	if env.Config.SyslogTLSPort != "" {
+		cert, err := tls.LoadX509KeyPair(env.Config.SyslogTLSCertFile, env.Config.SyslogTLSKeyFile)
+		if err != nil {
+			log.WithContext(ctx).WithError(err).Error("failed to load syslog tls certificate")
+		} else {
+			listeners = append(listeners, otel.SyslogListener{
+				Transport: otel.SyslogTransportTLS,
+				Port:      env.Config.SyslogTLSPort,
+				TLSConfig: &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12},
+			})
+		}
+	}
+
+	projectPorts, err := otel.ParseSyslogProjectPorts(env.Config.SyslogProjectPorts)
+	if err != nil {
+		log.WithContext(ctx).WithError(err).Error("failed to parse syslog project ports")
+	}
+	for port, project := range projectPorts {
+		listeners = append(listeners,
+			otel.SyslogListener{Transport: otel.SyslogTransportUDP, Port: port, ProjectID: project},
+			otel.SyslogListener{Transport: otel.SyslogTransportTCP, Port: port, ProjectID: project},
+		)
+	}
+	return listeners
+}
+
 func validateOrigin(_ *http.Request, origin string) (bool, []string) {
 	if env.Config.DisableCors == "true" {
 		return true, []string{"*"}
@@ -308,23 +355,23 @@ func main() {
 	}
 
 	// sync writes with batching per-partition
-	kafkaProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeDefault}), kafkaqueue.Producer, nil)
+	kafkaProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeDefault}), kafkaqueue.Producer, producerConfig(ctx, kafkaqueue.TopicTypeDefault, kafkaqueue.ConfigOverride{}))
 	// sync writes without batching
-	kafkaDataSyncProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeDataSync}), kafkaqueue.Producer, &kafkaqueue.ConfigOverride{BatchSize: ptr.Int(1)})
+	kafkaDataSyncProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeDataSync}), kafkaqueue.Producer, producerConfig(ctx, kafkaqueue.TopicTypeDataSync, kafkaqueue.ConfigOverride{BatchSize: ptr.Int(1)}))
 
 	// async writes for workers (where order of write between workers does not matter)
-	kCfg := &kafkaqueue.ConfigOverride{Async: ptr.Bool(true)}
-	kafkaAsyncProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeDefault}), kafkaqueue.Producer, kCfg)
+	kCfg := kafkaqueue.ConfigOverride{Async: ptr.Bool(true)}
+	kafkaAsyncProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeDefault}), kafkaqueue.Producer, producerConfig(ctx, kafkaqueue.TopicTypeDefault, kCfg))
 	defer kafkaAsyncProducer.Stop(ctx)
-	kafkaBatchedProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeBatched}), kafkaqueue.Producer, kCfg)
+	kafkaBatchedProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeBatched}), kafkaqueue.Producer, producerConfig(ctx, kafkaqueue.TopicTypeBatched, kCfg))
 	defer kafkaBatchedProducer.Stop(ctx)
-	kafkaTracesProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeTraces}), kafkaqueue.Producer, kCfg)
+	kafkaTracesProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeTraces}), kafkaqueue.Producer, producerConfig(ctx, kafkaqueue.TopicTypeTraces, kCfg))
 	defer kafkaTracesProducer.Stop(ctx)
-	kafkaMetricSumProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeMetricSum}), kafkaqueue.Producer, kCfg)
+	kafkaMetricSumProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeMetricSum}), kafkaqueue.Producer, producerConfig(ctx, kafkaqueue.TopicTypeMetricSum, kCfg))
 	defer kafkaMetricSumProducer.Stop(ctx)
-	kafkaMetricHistogramProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeMetricHistogram}), kafkaqueue.Producer, kCfg)
+	kafkaMetricHistogramProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeMetricHistogram}), kafkaqueue.Producer, producerConfig(ctx, kafkaqueue.TopicTypeMetricHistogram, kCfg))
 	defer kafkaMetricHistogramProducer.Stop(ctx)
-	kafkaMetricSummaryProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeMetricSummary}), kafkaqueue.Producer, kCfg)
+	kafkaMetricSummaryProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeMetricSummary}), kafkaqueue.Producer, producerConfig(ctx, kafkaqueue.TopicTypeMetricSummary, kCfg))
 	defer kafkaMetricSummaryProducer.Stop(ctx)
 
 	var lambdaClient *lambda.Client
@@ -466,6 +513,7 @@ func main() {
 			}
 			r.Get("/assets/{project_id}/{hash_val}", privateResolver.AssetHandler)
 			r.Get("/project-token/{project_id}", privateResolver.ProjectJWTHandler)
+			r.Route("/prometheus/{project_id}/api/v1", privateResolver.PrometheusRoutes)
 
 			log.WithContext(ctx).WithField("private", privateEndpoint).Info("setting up private graph auth listeners")
 			private.AuthClient.SetupListeners(r)
@@ -570,8 +618,21 @@ func main() {
 		})
 		otelHandler := otel.New(publicResolver)
 		otelHandler.Listen(r)
+		// the OTLP gRPC port (4317) is usually served by the collector, so only listen when explicitly configured
+		if env.Config.OTLPGRPCPort != "" {
+			go func() {
+				if err := otelHandler.ListenGRPC(ctx, env.Config.OTLPGRPCPort); err != nil {
+					log.WithContext(ctx).WithError(err).Error("otel grpc listener stopped")
+				}
+			}()
+		}
 		vercel.Listen(r, tracerNoResources)
-		highlightHttp.Listen(r, tracerNoResources)
+		highlightHttp.Listen(r, tracerNoResources, publicResolver.Store.ValidateIngestKey)
+		if listeners := getSyslogListeners(ctx); len(listeners) > 0 {
+			if err := otelHandler.NewSyslogServer().Listen(ctx, listeners); err != nil {
+				log.WithContext(ctx).WithError(err).Error("failed to start syslog listeners")
+			}
+		}
 	}
 
 	/*
@@ -66,6 +66,7 @@ var AlertType = struct {
 	LOGS     string
 	TRACES   string
 	METRICS  string
+	RELEASES string
 }{
 	// deprecated alerts
 	ERROR:            "ERROR_ALERT",
@@ -82,6 +83,7 @@ var AlertType = struct {
 	LOGS:     "LOGS_ALERT",
 	TRACES:   "TRACES_ALERT",
 	METRICS:  "METRICS_ALERT",
+	RELEASES: "RELEASES_ALERT",
 }
 
 var AdminRole = struct {
@@ -117,6 +119,7 @@ var ContextKeys = struct {
 	ZapierProject  contextString
 	SessionId      contextString
 	SSOClientID    contextString
+	IngestKey      contextString
 }{
 	IP:             "ip",
 	UserAgent:      "userAgent",
@@ -128,6 +131,7 @@ var ContextKeys = struct {
 	ZapierProject:  "project",
 	SessionId:      "sessionId",
 	SSOClientID:    "clientID",
+	IngestKey:      "ingestKey",
 }
 
 var Models = []interface{}{
@@ -200,6 +204,15 @@ var Models = []interface{}{
 	&Alert{},
 	&AlertDestination{},
 	&SSOClient{},
+	&IngestKey{},
+	&TraceSamplingPolicy{},
+	&ErrorGroupingRule{},
+	&LogMetricRule{},
+	&ExportJob{},
+	&Team{},
+	&ErrorOwnershipRule{},
+	&Release{},
+	&RedactionRule{},
 }
 
 func init() {
@@ -416,6 +429,28 @@ type Project struct {
 	FilterChromeExtension *bool `gorm:"default:false"`
 }
 
+// IngestKey authorizes pushing telemetry for a project. Once a project has created a key,
+// logs, traces, metrics and backend errors without a valid key for the project are rejected.
+type IngestKey struct {
+	Model
+	ProjectID int                        `gorm:"not null;index"`
+	Name      string                     `gorm:"not null"`
+	Scope     modelInputs.IngestKeyScope `gorm:"not null;default:Backend"`
+	// Products the key may push. Empty allows all products.
+	Products pq.StringArray `gorm:"type:text[]"`
+	// The key itself is only returned when created or rotated, we store its sha256.
+	KeyHash   string `gorm:"not null;uniqueIndex"`
+	KeyPrefix string `gorm:"not null"`
+	RotatedAt *time.Time
+	RevokedAt *time.Time
+}
+
+// IngestKeyWithSecret is returned when a key is created or rotated, the only time the key is readable.
+type IngestKeyWithSecret struct {
+	IngestKey *IngestKey
+	Key       string
+}
+
 type MarkBackendSetupType = string
 
 const (
@@ -456,6 +491,21 @@ type ProjectFilterSettings struct {
 	LogExclusionQuery                 *string
 	TraceExclusionQuery               *string
 	MetricExclusionQuery              *string
+	// Rate at which complete traces matching none of the project's TraceSamplingPolicy are kept.
+	TraceTailSamplingRate float64 `gorm:"default:1"`
+	// How long spans of a trace are buffered before the tail sampling decision is made.
+	TraceTailSamplingWindowSeconds int `gorm:"default:10"`
+}
+
+// TraceSamplingPolicy keeps a fraction of the complete traces where any span matches the query.
+// Policies are evaluated in order, the first matching policy decides. Once a project has a policy,
+// traces are tail sampled: spans are buffered per trace until the decision window has passed.
+type TraceSamplingPolicy struct {
+	Model
+	ProjectID    int     `gorm:"not null;index"`
+	Name         string  `gorm:"not null"`
+	Query        string  `gorm:"not null"`
+	SamplingRate float64 `gorm:"not null;default:1"`
 }
 
 type AllWorkspaceSettings struct {
@@ -1021,6 +1071,9 @@ type ErrorObject struct {
 	IsBeacon                bool    `gorm:"default:false"`
 	ServiceName             string
 	ServiceVersion          string
+
+	// the group of the object before it was moved by a merge
+	MergedFromErrorGroupID *int `gorm:"type:integer"`
 }
 
 type ErrorObjectEmbeddings struct {
@@ -1043,16 +1096,31 @@ type ErrorGroup struct {
 	MappedStackTrace *string
 	State            modelInputs.ErrorState `json:"state" gorm:"default:OPEN"`
 	SnoozedUntil     *time.Time             `json:"snoozed_until"`
-	Fingerprints     []*ErrorFingerprint
-	FieldGroup       *string
-	Environments     string
-	IsPublic         bool                                 `gorm:"default:false"`
-	ErrorFrequency   []int64                              `gorm:"-"`
-	ErrorMetrics     []*modelInputs.ErrorDistributionItem `gorm:"-"`
-	FirstOccurrence  *time.Time                           `gorm:"-"`
-	LastOccurrence   *time.Time                           `gorm:"-"`
-	ErrorObjects     []ErrorObject
-	ServiceName      string
+	// a resolved group only regresses when an error is seen in this version or later
+	ResolvedInVersion *string
+	// a group resolved in the next release only regresses when an error is seen in a version after this one
+	ResolvedAfterVersion *string
+	// the admin or team responsible for the group, at most one is set
+	AssigneeAdminID *int
+	AssigneeTeamID  *int
+	// set when the group was merged into another group, which new errors of the group are grouped into
+	MergedIntoID    *int `gorm:"index"`
+	Fingerprints    []*ErrorFingerprint
+	FieldGroup      *string
+	Environments    string
+	IsPublic        bool                                 `gorm:"default:false"`
+	ErrorFrequency  []int64                              `gorm:"-"`
+	ErrorMetrics    []*modelInputs.ErrorDistributionItem `gorm:"-"`
+	FirstOccurrence *time.Time                           `gorm:"-"`
+	LastOccurrence  *time.Time                           `gorm:"-"`
+	ErrorObjects    []ErrorObject
+	ServiceName     string
+	// set when the group was created or reopened by the error being grouped
+	Created   bool `gorm:"-"`
+	Regressed bool `gorm:"-"`
+
+	// the version of the first error of the group, used to report the groups that are new in a release
+	FirstSeenVersion *string `gorm:"index"`
 
 	// manually migrate as gorm wants to make this have a default value otherwise
 	ErrorTagID *int      `gorm:"-:migration"`
@@ -1078,9 +1146,14 @@ type MatchedErrorObject struct {
 type ErrorGroupEventType string
 
 const (
-	ErrorGroupResolvedEvent ErrorGroupEventType = "ErrorGroupResolved"
-	ErrorGroupIgnoredEvent  ErrorGroupEventType = "ErrorGroupIgnored"
-	ErrorGroupOpenedEvent   ErrorGroupEventType = "ErrorGroupOpened"
+	ErrorGroupResolvedEvent   ErrorGroupEventType = "ErrorGroupResolved"
+	ErrorGroupIgnoredEvent    ErrorGroupEventType = "ErrorGroupIgnored"
+	ErrorGroupOpenedEvent     ErrorGroupEventType = "ErrorGroupOpened"
+	ErrorGroupRegressedEvent  ErrorGroupEventType = "ErrorGroupRegressed"
+	ErrorGroupAssignedEvent   ErrorGroupEventType = "ErrorGroupAssigned"
+	ErrorGroupUnassignedEvent ErrorGroupEventType = "ErrorGroupUnassigned"
+	ErrorGroupMergedEvent     ErrorGroupEventType = "ErrorGroupMerged"
+	ErrorGroupUnmergedEvent   ErrorGroupEventType = "ErrorGroupUnmerged"
 )
 
 type ErrorGroupActivityLog struct {
@@ -1124,10 +1197,12 @@ var Fingerprint = struct {
 	StackFrameCode     FingerprintType
 	StackFrameMetadata FingerprintType
 	JsonResult         FingerprintType
+	GroupingRule       FingerprintType
 }{
 	StackFrameCode:     "CODE",
 	StackFrameMetadata: "META",
 	JsonResult:         "JSON",
+	GroupingRule:       "RULE",
 }
 
 type ErrorFingerprint struct {
@@ -1137,6 +1212,133 @@ type ErrorFingerprint struct {
 	Type         FingerprintType
 	Value        string
 	Index        int
+	// the group of the fingerprint before it was moved by a merge
+	MergedFromErrorGroupID *int
+}
+
+// ErrorGroupingRule customizes how the errors of a project are grouped. Rules are evaluated in order
+// before the grouping key is built; errors fingerprinted by a rule are grouped by exact fingerprint match.
+type ErrorGroupingRule struct {
+	Model
+	ProjectID   int                               `gorm:"not null;index"`
+	Name        string                            `gorm:"not null"`
+	Type        modelInputs.ErrorGroupingRuleType `gorm:"not null"`
+	Pattern     string                            `gorm:"not null;default:''"`
+	Replacement string                            `gorm:"not null;default:''"`
+	Attribute   string                            `gorm:"not null;default:''"`
+}
+
+// Team is a named group of workspace admins that error groups can be assigned to.
+type Team struct {
+	Model
+	WorkspaceID int     `gorm:"not null;index"`
+	Name        string  `gorm:"not null"`
+	Admins      []Admin `gorm:"many2many:team_admins;"`
+	// The Slack user or user group mentioned in alerts for error groups owned by the team.
+	SlackMentionID *string
+}
+
+// ErrorOwnershipRule assigns new error groups of a project to an admin or team. Rules are evaluated
+// in order and the first rule matching the error decides the owner.
+type ErrorOwnershipRule struct {
+	Model
+	ProjectID int                                `gorm:"not null;index"`
+	Type      modelInputs.ErrorOwnershipRuleType `gorm:"not null"`
+	// A CODEOWNERS style glob for FilePath rules, otherwise a regular expression.
+	Pattern   string `gorm:"not null;default:''"`
+	Attribute string `gorm:"not null;default:''"`
+	AdminID   *int
+	TeamID    *int
+}
+
+// Release is a version of the services of a project. Releases are created when a version is first
+// seen on an error, session or trace, or explicitly when the version is deployed.
+type Release struct {
+	Model
+	ProjectID   int       `gorm:"not null;uniqueIndex:idx_releases_project_id_version"`
+	Version     string    `gorm:"not null;uniqueIndex:idx_releases_project_id_version"`
+	FirstSeenAt time.Time `gorm:"not null"`
+	// set when the release is created explicitly, e.g. when uploading its sourcemaps
+	ReleasedAt *time.Time
+	CommitSha  *string
+}
+
+// ReleaseHealth reports the errors and sessions of a release over a date range, compared to the
+// release before it.
+type ReleaseHealth struct {
+	Release             *Release
+	PreviousRelease     *Release
+	NewErrorGroupCount  int
+	NewErrorGroups      []*ErrorGroup
+	ErrorCount          uint64
+	SessionCount        uint64
+	CrashedSessionCount uint64
+	// errors per session, or per hour for releases without sessions
+	ErrorRate               float64
+	PreviousErrorRate       *float64
+	ErrorRateChangePercent  *float64
+	CrashFreeSessionPercent *float64
+	Adoption                []*modelInputs.ReleaseAdoptionBucket
+}
+
+// RedactionRule redacts personal data from the logs, traces, errors and session replays of a project
+// as they are ingested. A rule matches a pattern, a built-in detector or the attributes with a key in
+// its deny-list, and masks, hashes or drops what it matches.
+type RedactionRule struct {
+	Model
+	ProjectID     int    `gorm:"not null;index"`
+	Name          string `gorm:"not null"`
+	Pattern       *string
+	Detector      *modelInputs.RedactionDetector
+	AttributeKeys pq.StringArray              `gorm:"type:text[]"`
+	Action        modelInputs.RedactionAction `gorm:"not null"`
+
+	// the number of values redacted by the rule, kept in redis rather than in the database
+	RedactedCount int64 `gorm:"-"`
+}
+
+// LogMetricRule derives a metric from the logs of a project matching the query as they are ingested.
+// Matching logs are counted, or their extracted value summed or recorded in a histogram, per minute
+// and per value of the group by attributes. The metric is kept after the logs age out of retention.
+type LogMetricRule struct {
+	Model
+	ProjectID int                           `gorm:"not null;index"`
+	Name      string                        `gorm:"not null"`
+	Query     string                        `gorm:"not null"`
+	Type      modelInputs.LogMetricRuleType `gorm:"not null"`
+	GroupBy   pq.StringArray                `gorm:"type:text[]"`
+	// The value of a Sum or Histogram rule is read from a log attribute,
+	// or from the first capture group of a pattern matching the log body.
+	ValueAttribute *string
+	ValuePattern   *string
+	Buckets        pq.Float64Array `gorm:"type:double precision[]"`
+}
+
+// ExportJob periodically writes the logs, traces or errors of a project matching the query to a
+// storage destination. Each hour is exported once it is complete, in files of up to a page of rows.
+type ExportJob struct {
+	Model
+	ProjectID   int                               `gorm:"not null;index"`
+	Name        string                            `gorm:"not null"`
+	Product     modelInputs.ProductType           `gorm:"not null"`
+	Query       string                            `gorm:"not null;default:''"`
+	Format      modelInputs.ExportFormat          `gorm:"not null;default:NDJSON"`
+	Destination modelInputs.ExportDestinationType `gorm:"not null;default:Storage"`
+	// The bucket of an S3Compatible destination. Files are written under the prefix.
+	Endpoint        *string
+	Region          *string
+	Bucket          *string
+	Prefix          *string
+	AccessKeyID     *string
+	SecretAccessKey *string `json:"-"`
+	Disabled        bool
+	// Hours before ExportedUntil are exported. The checkpoint is the last row written of the
+	// hour starting at ExportedUntil and the number of files written for that hour.
+	ExportedUntil    time.Time `gorm:"not null"`
+	CheckpointCursor *string
+	CheckpointPart   int
+	LastRunAt        *time.Time
+	LastError        *string
 }
 
 type ExternalAttachment struct {
@@ -1945,6 +2147,9 @@ type Alert struct {
 	ThresholdType      modelInputs.ThresholdType
 	ThresholdCondition modelInputs.ThresholdCondition
 	Sql                *string
+
+	// set for alerts on the health of the latest release rather than on a query
+	ReleaseMetric *modelInputs.ReleaseMetric
 }
 
 type AlertDestination struct {
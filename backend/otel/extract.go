@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/highlight-run/highlight/backend/env"
+	highlightHttp "github.com/highlight-run/highlight/backend/http"
 	"github.com/highlight-run/highlight/backend/model"
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
 	"github.com/highlight-run/highlight/backend/public-graph/graph"
@@ -30,6 +31,7 @@ var fluentProjectPattern = regexp.MustCompile(fmt.Sprintf(`%s=([\S]+)`, highligh
 type extractedFields struct {
 	projectID      string
 	projectIDInt   int
+	ingestKey      string
 	sessionID      string
 	requestID      string
 	environment    string
@@ -85,6 +87,25 @@ type extractFieldsParams struct {
 	curTime          time.Time
 
 	herokuProjectExtractor func(context.Context, string) (string, int)
+	ingestKeyValidator     func(context.Context, int, string, modelInputs.ProductType, modelInputs.LogSource) error
+}
+
+// product is the type of telemetry the params describe, used to authorize the ingest key.
+func (params extractFieldsParams) product() modelInputs.ProductType {
+	switch {
+	case params.logRecord != nil:
+		return modelInputs.ProductTypeLogs
+	case params.metric != nil || params.metricAttributes != nil:
+		return modelInputs.ProductTypeMetrics
+	case params.event != nil && params.event.Name() == semconv.ExceptionEventName:
+		return modelInputs.ProductTypeErrors
+	case params.event != nil && params.event.Name() == highlight.LogEvent:
+		return modelInputs.ProductTypeLogs
+	case params.event != nil && params.event.Name() == highlight.MetricEvent:
+		return modelInputs.ProductTypeMetrics
+	default:
+		return modelInputs.ProductTypeTraces
+	}
 }
 
 func extractFields(ctx context.Context, params extractFieldsParams) (*extractedFields, error) {
@@ -240,6 +261,15 @@ func extractFields(ctx context.Context, params extractFieldsParams) (*extractedF
 		fields.projectID = val
 	}
 
+	if val, ok := fields.attrs[highlightHttp.IngestKeyAttribute]; ok {
+		fields.ingestKey = val
+		delete(fields.attrs, highlightHttp.IngestKeyAttribute)
+	}
+
+	if val := params.headers.Get(highlightHttp.IngestKeyHeader); val != "" {
+		fields.ingestKey = val
+	}
+
 	if val, ok := fields.attrs[highlight.DeprecatedSessionIDAttribute]; ok {
 		fields.sessionID = val
 		delete(fields.attrs, highlight.DeprecatedSessionIDAttribute)
@@ -349,6 +379,9 @@ func extractFields(ctx context.Context, params extractFieldsParams) (*extractedF
 
 	var err error
 	fields.projectIDInt, err = projectToInt(fields.projectID)
+	if err == nil && params.ingestKeyValidator != nil {
+		err = params.ingestKeyValidator(ctx, fields.projectIDInt, fields.ingestKey, params.product(), fields.source)
+	}
 
 	if fields.projectIDInt == 1 && env.IsProduction() {
 		if fields.serviceName == "all" || fields.serviceName == "" {
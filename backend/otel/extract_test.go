@@ -5,7 +5,9 @@ import (
 	"testing"
 	"time"
 
+	highlightHttp "github.com/highlight-run/highlight/backend/http"
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/store"
 	"github.com/highlight/highlight/sdk/highlight-go"
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -382,3 +384,31 @@ func TestExtractFields_HandleInvalidTimestamp(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, curTime, fields.timestamp)
 }
+
+func TestExtractFields_IngestKey(t *testing.T) {
+	var validated []modelInputs.ProductType
+	validator := func(ctx context.Context, projectID int, key string, product modelInputs.ProductType, source modelInputs.LogSource) error {
+		assert.Equal(t, 1, projectID)
+		assert.Equal(t, "hik_resource", key)
+		validated = append(validated, product)
+		if product == modelInputs.ProductTypeTraces {
+			return store.ErrIngestKeyScope
+		}
+		return nil
+	}
+
+	resource := newResource(t, map[string]any{
+		highlightHttp.IngestKeyAttribute: "hik_resource",
+	})
+	span := newSpan(map[string]string{})
+	fields, err := extractFields(ctx, extractFieldsParams{resource: &resource, span: &span, ingestKeyValidator: validator})
+	assert.Equal(t, store.ErrIngestKeyScope, err)
+	assert.NotContains(t, fields.attrs, highlightHttp.IngestKeyAttribute)
+
+	event := newEvent(map[string]string{})
+	event.SetName(highlight.LogEvent)
+	fields, err = extractFields(ctx, extractFieldsParams{resource: &resource, span: &span, event: &event, ingestKeyValidator: validator})
+	assert.NoError(t, err)
+	assert.Equal(t, "hik_resource", fields.ingestKey)
+	assert.Equal(t, []modelInputs.ProductType{modelInputs.ProductTypeTraces, modelInputs.ProductTypeLogs}, validated)
+}
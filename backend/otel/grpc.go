@@ -0,0 +1,118 @@
+package otel
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/highlight/highlight/sdk/highlight-go"
+	e "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// maxGRPCMessageSize matches the upper bound of what the collector will send in a single batch.
+const maxGRPCMessageSize = 64 * 1024 * 1024
+
+type traceServer struct {
+	ptraceotlp.UnimplementedGRPCServer
+	handler *Handler
+}
+
+func (s *traceServer) Export(ctx context.Context, req ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	span, ctx := highlight.StartTrace(ctx, "otel.grpc.traces")
+	defer highlight.EndTrace(span)
+	resp := ptraceotlp.NewExportResponse()
+	rejected, err := s.handler.exportTraces(ctx, headersFromMetadata(ctx), req)
+	if err != nil {
+		return resp, status.Error(codes.Unavailable, err.Error())
+	}
+	if rejected > 0 {
+		resp.PartialSuccess().SetRejectedSpans(rejected)
+		resp.PartialSuccess().SetErrorMessage(rejectedMessage(rejected, "spans"))
+	}
+	return resp, nil
+}
+
+type logServer struct {
+	plogotlp.UnimplementedGRPCServer
+	handler *Handler
+}
+
+func (s *logServer) Export(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	span, ctx := highlight.StartTrace(ctx, "otel.grpc.logs")
+	defer highlight.EndTrace(span)
+	resp := plogotlp.NewExportResponse()
+	rejected, err := s.handler.exportLogs(ctx, headersFromMetadata(ctx), req)
+	if err != nil {
+		return resp, status.Error(codes.Unavailable, err.Error())
+	}
+	if rejected > 0 {
+		resp.PartialSuccess().SetRejectedLogRecords(rejected)
+		resp.PartialSuccess().SetErrorMessage(rejectedMessage(rejected, "log records"))
+	}
+	return resp, nil
+}
+
+type metricServer struct {
+	pmetricotlp.UnimplementedGRPCServer
+	handler *Handler
+}
+
+func (s *metricServer) Export(ctx context.Context, req pmetricotlp.ExportRequest) (pmetricotlp.ExportResponse, error) {
+	span, ctx := highlight.StartTrace(ctx, "otel.grpc.metrics")
+	defer highlight.EndTrace(span)
+	resp := pmetricotlp.NewExportResponse()
+	rejected, err := s.handler.exportMetrics(ctx, headersFromMetadata(ctx), req)
+	if err != nil {
+		return resp, status.Error(codes.Unavailable, err.Error())
+	}
+	if rejected > 0 {
+		resp.PartialSuccess().SetRejectedDataPoints(rejected)
+		resp.PartialSuccess().SetErrorMessage(rejectedMessage(rejected, "data points"))
+	}
+	return resp, nil
+}
+
+// headersFromMetadata converts incoming gRPC metadata to http headers so that
+// project resolution in extractFields (ie. the x-highlight-project header) behaves the same as over HTTP.
+func headersFromMetadata(ctx context.Context) http.Header {
+	headers := http.Header{}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return headers
+	}
+	for key, values := range md {
+		for _, value := range values {
+			headers.Add(key, value)
+		}
+	}
+	return headers
+}
+
+// NewGRPCServer returns a gRPC server implementing the OTLP trace, log and metric export services.
+func (o *Handler) NewGRPCServer() *grpc.Server {
+	server := grpc.NewServer(grpc.MaxRecvMsgSize(maxGRPCMessageSize))
+	ptraceotlp.RegisterGRPCServer(server, &traceServer{handler: o})
+	plogotlp.RegisterGRPCServer(server, &logServer{handler: o})
+	pmetricotlp.RegisterGRPCServer(server, &metricServer{handler: o})
+	return server
+}
+
+// ListenGRPC serves the OTLP gRPC receiver on the provided port.
+func (o *Handler) ListenGRPC(ctx context.Context, port string) error {
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return e.Wrap(err, "failed to listen for otel grpc")
+	}
+
+	log.WithContext(ctx).WithField("port", port).Info("running OTLP gRPC listener")
+	return o.NewGRPCServer().Serve(lis)
+}
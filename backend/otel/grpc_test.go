@@ -0,0 +1,92 @@
+package otel
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/integrations"
+	kafkaqueue "github.com/highlight-run/highlight/backend/kafka-queue"
+	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	public "github.com/highlight-run/highlight/backend/public-graph/graph"
+	"github.com/highlight-run/highlight/backend/storage"
+	"github.com/highlight-run/highlight/backend/store"
+	"github.com/highlight/highlight/sdk/highlight-go"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestHeadersFromMetadata(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.TODO(), metadata.Pairs(highlight.ProjectIDHeader, "abc123"))
+	assert.Equal(t, "abc123", headersFromMetadata(ctx).Get(highlight.ProjectIDHeader))
+	assert.Equal(t, "", headersFromMetadata(context.TODO()).Get(highlight.ProjectIDHeader))
+}
+
+func TestHandler_GRPCLogExport(t *testing.T) {
+	inputBytes, err := os.ReadFile("./samples/log.json")
+	if err != nil {
+		t.Fatalf("error reading: %v", err)
+	}
+
+	req := plogotlp.NewExportRequest()
+	if err := req.UnmarshalJSON(inputBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	producer := MockKafkaProducer{}
+	resolver := &public.Resolver{
+		Redis:                red,
+		Store:                store.NewStore(db, red, integrations.NewIntegrationsClient(db), &storage.FilesystemClient{}, &producer, nil),
+		AsyncProducerQueue:   &producer,
+		ProducerQueue:        &producer,
+		BatchedQueue:         &producer,
+		TracesQueue:          &producer,
+		MetricSumQueue:       &producer,
+		MetricSummaryQueue:   &producer,
+		MetricHistogramQueue: &producer,
+		DB:                   db,
+		Clickhouse:           chClient,
+	}
+	h := Handler{
+		resolver: resolver,
+	}
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := h.NewGRPCServer()
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx := metadata.AppendToOutgoingContext(context.TODO(), highlight.ProjectIDHeader, "123")
+	if _, err := plogotlp.NewGRPCClient(conn).Export(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+
+	var numLogs int
+	for _, message := range producer.messages {
+		if message.GetType() == kafkaqueue.PushLogsFlattened {
+			logRowMessage := message.(*kafkaqueue.LogRowMessage)
+			assert.Equal(t, privateModel.LogSourceBackend, logRowMessage.Source)
+			assert.Equal(t, uint32(123), logRowMessage.ProjectId)
+			numLogs++
+		}
+	}
+	assert.Greater(t, numLogs, 0)
+}
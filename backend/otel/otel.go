@@ -13,8 +13,10 @@ import (
 	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
 	"github.com/highlight-run/highlight/backend/public-graph/graph"
 	"github.com/highlight-run/highlight/backend/public-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/redaction"
 	"github.com/highlight-run/highlight/backend/redis"
 	"github.com/highlight-run/highlight/backend/stacktraces"
+	"github.com/highlight-run/highlight/backend/store"
 	"github.com/highlight/highlight/sdk/highlight-go"
 	highlightChi "github.com/highlight/highlight/sdk/highlight-go/middleware/chi"
 	"github.com/openlyinc/pointy"
@@ -28,7 +30,12 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.opentelemetry.io/otel/trace"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	"gorm.io/gorm"
+	"mime"
 	"net/http"
 	"strconv"
 	"strings"
@@ -124,6 +131,86 @@ func (o *Handler) getMetric(ctx context.Context, ts time.Time, fields *extracted
 	}, nil
 }
 
+const (
+	contentTypeJSON     = "application/json"
+	contentTypeProtobuf = "application/x-protobuf"
+)
+
+// otlpMessage is implemented by the OTLP export requests and responses of each signal.
+type otlpMessage interface {
+	MarshalProto() ([]byte, error)
+	UnmarshalProto(data []byte) error
+	MarshalJSON() ([]byte, error)
+	UnmarshalJSON(data []byte) error
+}
+
+// isJSONRequest reports whether the request uses the OTLP/HTTP JSON encoding.
+// Any other content type is treated as binary protobuf, the OTLP default.
+func isJSONRequest(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == contentTypeJSON
+}
+
+func unmarshalExportRequest(r *http.Request, body []byte, req otlpMessage) error {
+	if isJSONRequest(r) {
+		return req.UnmarshalJSON(body)
+	}
+	return req.UnmarshalProto(body)
+}
+
+// writeExportResponse responds with the same encoding as the request, as required by the OTLP/HTTP spec.
+func writeExportResponse(ctx context.Context, w http.ResponseWriter, r *http.Request, resp otlpMessage) {
+	var body []byte
+	var err error
+	contentType := contentTypeProtobuf
+	if isJSONRequest(r) {
+		contentType = contentTypeJSON
+		body, err = resp.MarshalJSON()
+	} else {
+		body, err = resp.MarshalProto()
+	}
+	if err != nil {
+		log.WithContext(ctx).WithError(err).Error("failed to marshal otel export response")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		log.WithContext(ctx).WithError(err).Error("failed to write otel export response")
+	}
+}
+
+// writeExportError responds with an OTLP Status message in the same encoding as the request.
+func writeExportError(ctx context.Context, w http.ResponseWriter, r *http.Request, statusCode int, code codes.Code, err error) {
+	st := &spb.Status{Code: int32(code), Message: err.Error()}
+	var body []byte
+	var marshalErr error
+	contentType := contentTypeProtobuf
+	if isJSONRequest(r) {
+		contentType = contentTypeJSON
+		body, marshalErr = protojson.Marshal(st)
+	} else {
+		body, marshalErr = proto.Marshal(st)
+	}
+	if marshalErr != nil {
+		log.WithContext(ctx).WithError(marshalErr).Error("failed to marshal otel export status")
+		w.WriteHeader(statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(body); err != nil {
+		log.WithContext(ctx).WithError(err).Error("failed to write otel export status")
+	}
+}
+
+func rejectedMessage(rejected int64, kind string) string {
+	return fmt.Sprintf("%d %s could not be ingested", rejected, kind)
+}
+
 func (o *Handler) HandleTrace(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -136,15 +223,32 @@ func (o *Handler) HandleTrace(w http.ResponseWriter, r *http.Request) {
 
 	span, _ := highlight.StartTrace(ctx, "otel.proto")
 	req := ptraceotlp.NewExportRequest()
-	err = req.UnmarshalProto(output)
+	err = unmarshalExportRequest(r, output, req)
 	span.RecordError(err)
 	highlight.EndTrace(span)
 	if err != nil {
-		log.WithContext(ctx).WithError(err).Error("invalid trace protobuf")
+		log.WithContext(ctx).WithError(err).Error("invalid trace payload")
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	rejected, err := o.exportTraces(ctx, r.Header, req)
+	if err != nil {
+		writeExportError(ctx, w, r, http.StatusServiceUnavailable, codes.Unavailable, err)
+		return
+	}
+
+	resp := ptraceotlp.NewExportResponse()
+	if rejected > 0 {
+		resp.PartialSuccess().SetRejectedSpans(rejected)
+		resp.PartialSuccess().SetErrorMessage(rejectedMessage(rejected, "spans"))
+	}
+	writeExportResponse(ctx, w, r, resp)
+}
+
+// exportTraces converts and submits an OTLP trace export request. Shared by the HTTP and gRPC receivers.
+func (o *Handler) exportTraces(ctx context.Context, headers http.Header, req ptraceotlp.ExportRequest) (int64, error) {
+	var rejected int64
 	var projectSessionErrors = make(map[string]map[string][]*model.BackendErrorObjectInput)
 	var projectLogs = make(map[string][]*clickhouse.LogRow)
 
@@ -174,16 +278,21 @@ func (o *Handler) HandleTrace(w http.ResponseWriter, r *http.Request) {
 				}
 
 				fields, err := extractFields(ctx, extractFieldsParams{
-					headers:  r.Header,
-					resource: &resource,
-					span:     &span,
-					curTime:  curTime,
+					headers:            headers,
+					resource:           &resource,
+					span:               &span,
+					curTime:            curTime,
+					ingestKeyValidator: o.resolver.Store.ValidateIngestKey,
 				})
-				if err != nil {
+				// span events are authorized on their own: a key restricted to logs or errors, or a key
+				// only set on the event (ie. logs forwarded by the http log drains), may still push them
+				spanRejected := store.IsIngestKeyRejection(err)
+				if err != nil && !spanRejected {
 					lg(ctx, fields).
 						WithError(err).
 						WithField("traceID", span.TraceID().String()).
 						Debug("failed to extract fields from span")
+					rejected++
 					continue
 				}
 				traceID := cast(fields.requestID, span.TraceID().String())
@@ -196,12 +305,13 @@ func (o *Handler) HandleTrace(w http.ResponseWriter, r *http.Request) {
 					}
 					event := events.At(l)
 					fields, err := extractFields(ctx, extractFieldsParams{
-						headers:  r.Header,
-						resource: &resource,
-						scope:    &scope,
-						span:     &span,
-						event:    &event,
-						curTime:  curTime,
+						headers:            headers,
+						resource:           &resource,
+						scope:              &scope,
+						span:               &span,
+						event:              &event,
+						curTime:            curTime,
+						ingestKeyValidator: o.resolver.Store.ValidateIngestKey,
 					})
 					if err != nil {
 						lg(ctx, fields).
@@ -311,6 +421,11 @@ func (o *Handler) HandleTrace(w http.ResponseWriter, r *http.Request) {
 					}
 				}
 
+				if spanRejected {
+					rejected++
+					continue
+				}
+
 				timestamp := graph.ClampTime(span.StartTimestamp().AsTime(), curTime)
 				traceRow := clickhouse.NewTraceRow(timestamp, fields.projectIDInt).
 					WithSecureSessionId(fields.sessionID).
@@ -344,34 +459,32 @@ func (o *Handler) HandleTrace(w http.ResponseWriter, r *http.Request) {
 					MetricSumRow: metric,
 				})
 			}
-			err = o.resolver.MetricSumQueue.Submit(ctx, sessionID, messages...)
-			if err != nil {
+			if err := o.resolver.MetricSumQueue.Submit(ctx, sessionID, messages...); err != nil {
 				log.WithContext(ctx).WithError(err).Error("failed to submit otel project metrics to public worker queue")
-				w.WriteHeader(http.StatusServiceUnavailable)
-				return
+				return rejected, err
 			}
 		}
 	}
 
 	if err := o.submitProjectSessionErrors(ctx, projectSessionErrors); err != nil {
 		log.WithContext(ctx).WithError(err).Error("failed to submit otel project session errors")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		return
+		return rejected, err
 	}
 
-	if err := o.submitTraceSpans(ctx, traceSpans); err != nil {
+	quotaExceeded, err := o.submitTraceSpans(ctx, traceSpans)
+	rejected += quotaExceeded
+	if err != nil {
 		log.WithContext(ctx).WithError(err).Error("failed to submit otel project spans")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		return
+		return rejected, err
 	}
 
-	if err := o.submitProjectLogs(ctx, projectLogs); err != nil {
+	// logs from span events are not counted since the partial success only reports spans
+	if _, err := o.submitProjectLogs(ctx, projectLogs); err != nil {
 		log.WithContext(ctx).WithError(err).Error("failed to submit otel project logs")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		return
+		return rejected, err
 	}
 
-	w.WriteHeader(http.StatusOK)
+	return rejected, nil
 }
 
 func (o *Handler) HandleLog(w http.ResponseWriter, r *http.Request) {
@@ -386,15 +499,32 @@ func (o *Handler) HandleLog(w http.ResponseWriter, r *http.Request) {
 
 	span, _ := highlight.StartTrace(ctx, "otel.proto")
 	req := plogotlp.NewExportRequest()
-	err = req.UnmarshalProto(output)
+	err = unmarshalExportRequest(r, output, req)
 	span.RecordError(err)
 	highlight.EndTrace(span)
 	if err != nil {
-		log.WithContext(ctx).WithError(err).Error("invalid log protobuf")
+		log.WithContext(ctx).WithError(err).Error("invalid log payload")
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	rejected, err := o.exportLogs(ctx, r.Header, req)
+	if err != nil {
+		writeExportError(ctx, w, r, http.StatusServiceUnavailable, codes.Unavailable, err)
+		return
+	}
+
+	resp := plogotlp.NewExportResponse()
+	if rejected > 0 {
+		resp.PartialSuccess().SetRejectedLogRecords(rejected)
+		resp.PartialSuccess().SetErrorMessage(rejectedMessage(rejected, "log records"))
+	}
+	writeExportResponse(ctx, w, r, resp)
+}
+
+// exportLogs converts and submits an OTLP log export request. Shared by the HTTP and gRPC receivers.
+func (o *Handler) exportLogs(ctx context.Context, headers http.Header, req plogotlp.ExportRequest) (int64, error) {
+	var rejected int64
 	var projectLogs = make(map[string][]*clickhouse.LogRow)
 	var projectSessionErrors = make(map[string]map[string][]*model.BackendErrorObjectInput)
 
@@ -412,12 +542,13 @@ func (o *Handler) HandleLog(w http.ResponseWriter, r *http.Request) {
 				logRecord := logRecords.At(k)
 
 				fields, err := extractFields(ctx, extractFieldsParams{
-					headers:                r.Header,
+					headers:                headers,
 					resource:               &resource,
 					scope:                  &scope,
 					logRecord:              &logRecord,
 					curTime:                curTime,
 					herokuProjectExtractor: o.matchHerokuDrain,
+					ingestKeyValidator:     o.resolver.Store.ValidateIngestKey,
 				})
 				if err != nil {
 					lg(ctx, fields).
@@ -425,6 +556,7 @@ func (o *Handler) HandleLog(w http.ResponseWriter, r *http.Request) {
 						WithField("traceID", logRecord.TraceID().String()).
 						WithField("body", logRecord.Body().AsRaw()).
 						Debug("failed to extract fields from log")
+					rejected++
 					continue
 				}
 
@@ -449,6 +581,7 @@ func (o *Handler) HandleLog(w http.ResponseWriter, r *http.Request) {
 					projectLogs[fields.projectID] = append(projectLogs[fields.projectID], logRow)
 				} else {
 					lg(ctx, fields).Errorf("otel log got no project")
+					rejected++
 					continue
 				}
 
@@ -463,19 +596,19 @@ func (o *Handler) HandleLog(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if err := o.submitProjectLogs(ctx, projectLogs); err != nil {
+	quotaExceeded, err := o.submitProjectLogs(ctx, projectLogs)
+	rejected += quotaExceeded
+	if err != nil {
 		log.WithContext(ctx).WithError(err).Error("failed to submit otel project logs")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		return
+		return rejected, err
 	}
 
 	if err := o.submitProjectSessionErrors(ctx, projectSessionErrors); err != nil {
 		log.WithContext(ctx).WithError(err).Error("failed to submit otel log project session errors")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		return
+		return rejected, err
 	}
 
-	w.WriteHeader(http.StatusOK)
+	return rejected, nil
 }
 
 func (o *Handler) HandleMetric(w http.ResponseWriter, r *http.Request) {
@@ -490,15 +623,32 @@ func (o *Handler) HandleMetric(w http.ResponseWriter, r *http.Request) {
 
 	span, _ := highlight.StartTrace(ctx, "otel.proto")
 	req := pmetricotlp.NewExportRequest()
-	err = req.UnmarshalProto(output)
+	err = unmarshalExportRequest(r, output, req)
 	span.RecordError(err)
 	highlight.EndTrace(span)
 	if err != nil {
-		log.WithContext(ctx).WithError(err).Error("invalid metric protobuf")
+		log.WithContext(ctx).WithError(err).Error("invalid metric payload")
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	rejected, err := o.exportMetrics(ctx, r.Header, req)
+	if err != nil {
+		writeExportError(ctx, w, r, http.StatusServiceUnavailable, codes.Unavailable, err)
+		return
+	}
+
+	resp := pmetricotlp.NewExportResponse()
+	if rejected > 0 {
+		resp.PartialSuccess().SetRejectedDataPoints(rejected)
+		resp.PartialSuccess().SetErrorMessage(rejectedMessage(rejected, "data points"))
+	}
+	writeExportResponse(ctx, w, r, resp)
+}
+
+// exportMetrics converts and submits an OTLP metric export request. Shared by the HTTP and gRPC receivers.
+func (o *Handler) exportMetrics(ctx context.Context, headers http.Header, req pmetricotlp.ExportRequest) (int64, error) {
+	var rejected int64
 	var projectMetrics = make(map[int][]clickhouse.MetricRow)
 	var projectRetentions = make(map[int]uint8)
 
@@ -536,19 +686,21 @@ func (o *Handler) HandleMetric(w http.ResponseWriter, r *http.Request) {
 					}
 				}
 				for _, dp := range dps {
-					fields, err := extractFields(r.Context(), extractFieldsParams{
-						headers:          r.Header,
-						resource:         &resource,
-						scope:            &scope,
-						metric:           &metric,
-						metricAttributes: dp.ExtractAttributes(),
-						curTime:          curTime,
+					fields, err := extractFields(ctx, extractFieldsParams{
+						headers:            headers,
+						resource:           &resource,
+						scope:              &scope,
+						metric:             &metric,
+						metricAttributes:   dp.ExtractAttributes(),
+						curTime:            curTime,
+						ingestKeyValidator: o.resolver.Store.ValidateIngestKey,
 					})
 					if err != nil {
 						lg(ctx, fields).
 							WithError(err).
 							WithField("name", metric.Name()).
 							Debug("failed to extract fields from metric")
+						rejected++
 						continue
 					}
 					if _, ok := projectRetentions[fields.projectIDInt]; !ok {
@@ -557,19 +709,25 @@ func (o *Handler) HandleMetric(w http.ResponseWriter, r *http.Request) {
 					if _, ok := projectMetrics[fields.projectIDInt]; !ok {
 						projectMetrics[fields.projectIDInt] = []clickhouse.MetricRow{}
 					}
-					projectMetrics[fields.projectIDInt] = append(projectMetrics[fields.projectIDInt], dp.ToMetricRow(ctx, projectRetentions[fields.projectIDInt], metric.Type(), fields))
+					row := dp.ToMetricRow(ctx, projectRetentions[fields.projectIDInt], metric.Type(), fields)
+					if row == nil {
+						rejected++
+						continue
+					}
+					projectMetrics[fields.projectIDInt] = append(projectMetrics[fields.projectIDInt], row)
 				}
 			}
 		}
 	}
 
-	if err := o.submitProjectMetrics(ctx, projectMetrics); err != nil {
+	quotaExceeded, err := o.submitProjectMetrics(ctx, projectMetrics)
+	rejected += quotaExceeded
+	if err != nil {
 		log.WithContext(ctx).WithError(err).Error("failed to submit otel project metrics")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		return
+		return rejected, err
 	}
 
-	w.WriteHeader(http.StatusOK)
+	return rejected, nil
 }
 
 func (o *Handler) getQuotaExceededByProject(ctx context.Context, projectIds map[uint32]struct{}, productType model2.PricingProductType) (map[uint32]bool, error) {
@@ -628,7 +786,29 @@ func (o *Handler) getQuotaExceededByProject(ctx context.Context, projectIds map[
 	return quotaExceededByProject, nil
 }
 
-func (o *Handler) submitProjectLogs(ctx context.Context, projectLogs map[string][]*clickhouse.LogRow) error {
+// getRedactor returns the redactor of the project, loading it once per batch.
+func (o *Handler) getRedactor(ctx context.Context, redactors map[uint32]*redaction.Redactor, projectId uint32) (*redaction.Redactor, error) {
+	if redactor, ok := redactors[projectId]; ok {
+		return redactor, nil
+	}
+	redactor, err := o.resolver.Store.GetRedactor(ctx, int(projectId))
+	if err != nil {
+		return nil, e.Wrap(err, "failed to get redaction rules")
+	}
+	redactors[projectId] = redactor
+	return redactor, nil
+}
+
+func (o *Handler) recordRedactions(ctx context.Context, redactors map[uint32]*redaction.Redactor, path redaction.Path) {
+	for _, redactor := range redactors {
+		if err := o.resolver.Store.RecordRedactions(ctx, redactor, path); err != nil {
+			log.WithContext(ctx).WithError(err).Error("failed to record redactions")
+		}
+	}
+}
+
+// submitProjectLogs writes the logs to the public worker queue, returning the number of logs dropped for exceeding the billing quota.
+func (o *Handler) submitProjectLogs(ctx context.Context, projectLogs map[string][]*clickhouse.LogRow) (int64, error) {
 	span, ctx := highlight.StartTrace(ctx, "otel.submitProjectLogs")
 	defer highlight.EndTrace(span)
 
@@ -647,21 +827,36 @@ func (o *Handler) submitProjectLogs(ctx context.Context, projectLogs map[string]
 	}
 
 	sp, c := highlight.StartTrace(ctx, "otel.upsertServices")
+	var quotaExceeded int64
 	var markBackendSetupProjectIds []uint32
 	var filteredRows []*clickhouse.LogRow
+	redactors := map[uint32]*redaction.Redactor{}
 	for _, logRows := range projectLogs {
 		for _, logRow := range logRows {
 			// Filter out any log rows for projects where the log quota has been exceeded
 			if quotaExceededByProject[logRow.ProjectId] {
+				quotaExceeded++
 				continue
 			}
 
+			// redact personal data before the log is used or written
+			redactor, err := o.getRedactor(c, redactors, logRow.ProjectId)
+			if err != nil {
+				return quotaExceeded, err
+			}
+			redactor.RedactLogRow(logRow)
+
 			// create service record for any services found in ingested logs
 			if logRow.ServiceName != "" {
 				if _, err = o.resolver.Store.UpsertService(c, int(logRow.ProjectId), logRow.ServiceName, logRow.LogAttributes); err != nil {
 					log.WithContext(c).Error(e.Wrap(err, "failed to upsert service from log"))
 				}
 			}
+			if logRow.ServiceVersion != "" {
+				if _, err = o.resolver.Store.UpsertRelease(c, int(logRow.ProjectId), logRow.ServiceVersion); err != nil {
+					log.WithContext(c).Error(e.Wrap(err, "failed to upsert release from log"))
+				}
+			}
 
 			if logRow.Source == privateModel.LogSourceBackend {
 				markBackendSetupProjectIds = append(markBackendSetupProjectIds, logRow.ProjectId)
@@ -671,6 +866,7 @@ func (o *Handler) submitProjectLogs(ctx context.Context, projectLogs map[string]
 		}
 	}
 	highlight.EndTrace(sp)
+	o.recordRedactions(ctx, redactors, redaction.PathLogs)
 
 	sp, c = highlight.StartTrace(ctx, "otel.markBackendSetupImpl")
 	for _, projectId := range markBackendSetupProjectIds {
@@ -696,9 +892,9 @@ func (o *Handler) submitProjectLogs(ctx context.Context, projectLogs map[string]
 
 	err = o.resolver.BatchedQueue.Submit(ctx, "", messages...)
 	if err != nil {
-		return e.Wrap(err, "failed to submit otel project logs to public worker queue")
+		return quotaExceeded, e.Wrap(err, "failed to submit otel project logs to public worker queue")
 	}
-	return nil
+	return quotaExceeded, nil
 }
 
 func (o *Handler) submitProjectSessionErrors(ctx context.Context, projectSessionErrors map[string]map[string][]*model.BackendErrorObjectInput) error {
@@ -733,7 +929,8 @@ func (o *Handler) submitProjectSessionErrors(ctx context.Context, projectSession
 	return nil
 }
 
-func (o *Handler) submitTraceSpans(ctx context.Context, traceRows map[string][]*clickhouse.TraceRow) error {
+// submitTraceSpans writes the spans to the traces queue, returning the number of spans dropped for exceeding the billing quota.
+func (o *Handler) submitTraceSpans(ctx context.Context, traceRows map[string][]*clickhouse.TraceRow) (int64, error) {
 	markBackendSetupProjectIds := map[uint32]struct{}{}
 	projectIds := map[uint32]struct{}{}
 	for _, traceRows := range traceRows {
@@ -752,12 +949,23 @@ func (o *Handler) submitTraceSpans(ctx context.Context, traceRows map[string][]*
 		quotaExceededByProject = map[uint32]bool{}
 	}
 
+	var quotaExceeded int64
+	redactors := map[uint32]*redaction.Redactor{}
+	defer o.recordRedactions(ctx, redactors, redaction.PathTraces)
 	for traceID, traceRows := range traceRows {
 		var messages []kafkaqueue.RetryableMessage
 		for _, traceRow := range traceRows {
 			if quotaExceededByProject[traceRow.ProjectId] {
+				quotaExceeded++
 				continue
 			}
+
+			// redact personal data before the span is used or written
+			redactor, err := o.getRedactor(ctx, redactors, traceRow.ProjectId)
+			if err != nil {
+				return quotaExceeded, err
+			}
+			redactor.RedactTraceRow(traceRow)
 			if !o.resolver.IsTraceIngested(ctx, traceRow) {
 				continue
 			}
@@ -768,6 +976,11 @@ func (o *Handler) submitTraceSpans(ctx context.Context, traceRows map[string][]*
 					log.WithContext(ctx).Error(e.Wrap(err, "failed to upsert service from trace"))
 				}
 			}
+			if traceRow.ServiceVersion != "" {
+				if _, err = o.resolver.Store.UpsertRelease(ctx, int(traceRow.ProjectId), traceRow.ServiceVersion); err != nil {
+					log.WithContext(ctx).Error(e.Wrap(err, "failed to upsert release from trace"))
+				}
+			}
 			messages = append(messages, &kafkaqueue.TraceRowMessage{
 				Type:               kafkaqueue.PushTracesFlattened,
 				ClickhouseTraceRow: clickhouse.ConvertTraceRow(traceRow),
@@ -776,7 +989,7 @@ func (o *Handler) submitTraceSpans(ctx context.Context, traceRows map[string][]*
 
 		err := o.resolver.TracesQueue.Submit(ctx, traceID, messages...)
 		if err != nil {
-			return e.Wrap(err, "failed to submit otel project traces to public worker queue")
+			return quotaExceeded, e.Wrap(err, "failed to submit otel project traces to public worker queue")
 		}
 	}
 
@@ -787,10 +1000,11 @@ func (o *Handler) submitTraceSpans(ctx context.Context, traceRows map[string][]*
 		}
 	}
 
-	return nil
+	return quotaExceeded, nil
 }
 
-func (o *Handler) submitProjectMetrics(ctx context.Context, projectMetricRows map[int][]clickhouse.MetricRow) error {
+// submitProjectMetrics writes the metric rows to the metric queues, returning the number of rows dropped for exceeding the billing quota.
+func (o *Handler) submitProjectMetrics(ctx context.Context, projectMetricRows map[int][]clickhouse.MetricRow) (int64, error) {
 	projectIds := lo.MapEntries(projectMetricRows, func(p int, _ []clickhouse.MetricRow) (uint32, struct{}) {
 		return uint32(p), struct{}{}
 	})
@@ -800,6 +1014,7 @@ func (o *Handler) submitProjectMetrics(ctx context.Context, projectMetricRows ma
 		quotaExceededByProject = map[uint32]bool{}
 	}
 
+	var quotaExceeded int64
 	var sumMessages, histogramMessages, summaryMessages []kafkaqueue.RetryableMessage
 	for projectID, metricRows := range projectMetricRows {
 		for _, metricRow := range metricRows {
@@ -807,6 +1022,7 @@ func (o *Handler) submitProjectMetrics(ctx context.Context, projectMetricRows ma
 				continue
 			}
 			if quotaExceededByProject[uint32(projectID)] {
+				quotaExceeded++
 				continue
 			}
 			if !o.resolver.IsMetricIngested(ctx, metricRow) {
@@ -853,15 +1069,15 @@ func (o *Handler) submitProjectMetrics(ctx context.Context, projectMetricRows ma
 		// no ordering for metrics data
 		err := o.resolver.MetricSumQueue.Submit(ctx, "", sumMessages...)
 		if err != nil {
-			return e.Wrap(err, "failed to submit otel project sum metrics to public worker queue")
+			return quotaExceeded, e.Wrap(err, "failed to submit otel project sum metrics to public worker queue")
 		}
 		err = o.resolver.MetricHistogramQueue.Submit(ctx, "", histogramMessages...)
 		if err != nil {
-			return e.Wrap(err, "failed to submit otel project histogram metrics to public worker queue")
+			return quotaExceeded, e.Wrap(err, "failed to submit otel project histogram metrics to public worker queue")
 		}
 		err = o.resolver.MetricSummaryQueue.Submit(ctx, "", summaryMessages...)
 		if err != nil {
-			return e.Wrap(err, "failed to submit otel project summary metrics to public worker queue")
+			return quotaExceeded, e.Wrap(err, "failed to submit otel project summary metrics to public worker queue")
 		}
 	}
 
@@ -872,7 +1088,7 @@ func (o *Handler) submitProjectMetrics(ctx context.Context, projectMetricRows ma
 		}
 	}
 
-	return nil
+	return quotaExceeded, nil
 }
 
 func (o *Handler) matchHerokuDrain(ctx context.Context, herokuDrainToken string) (string, int) {
@@ -904,6 +1120,10 @@ func (o *Handler) Listen(r *chi.Mux) {
 		r.HandleFunc("/logs", o.HandleLog)
 		r.HandleFunc("/metrics", o.HandleMetric)
 	})
+	r.Route("/prometheus/api/v1", func(r chi.Router) {
+		r.Use(highlightChi.UseMiddleware(trace.WithSpanKind(trace.SpanKindConsumer)))
+		r.Post("/write", o.HandlePrometheusWrite)
+	})
 }
 
 func New(resolver *graph.Resolver) *Handler {
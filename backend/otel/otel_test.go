@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -15,6 +17,10 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	"gorm.io/gorm"
 
 	"github.com/highlight-run/highlight/backend/clickhouse"
@@ -350,6 +356,82 @@ func TestHandler_HandleMetric(t *testing.T) {
 
 }
 
+func TestHandler_HandleLogJSON(t *testing.T) {
+	inputBytes, err := os.ReadFile("./samples/log.json")
+	if err != nil {
+		t.Fatalf("error reading: %v", err)
+	}
+
+	producer := MockKafkaProducer{}
+	resolver := &public.Resolver{
+		Redis:                red,
+		Store:                store.NewStore(db, red, integrations.NewIntegrationsClient(db), &storage.FilesystemClient{}, &producer, nil),
+		AsyncProducerQueue:   &producer,
+		ProducerQueue:        &producer,
+		BatchedQueue:         &producer,
+		TracesQueue:          &producer,
+		MetricSumQueue:       &producer,
+		MetricSummaryQueue:   &producer,
+		MetricHistogramQueue: &producer,
+		DB:                   db,
+		Clickhouse:           chClient,
+	}
+	h := Handler{
+		resolver: resolver,
+	}
+
+	for _, tc := range []struct {
+		projectID        string
+		expectedRejected int64
+	}{
+		{projectID: "123"},
+		{projectID: "", expectedRejected: 1},
+	} {
+		producer.messages = []kafkaqueue.RetryableMessage{}
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "", bytes.NewReader(inputBytes))
+		r.Header.Set("Content-Type", "application/json; charset=utf-8")
+		if tc.projectID != "" {
+			r.Header.Set(highlight.ProjectIDHeader, tc.projectID)
+		}
+		h.HandleLog(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+		resp := plogotlp.NewExportResponse()
+		assert.NoError(t, resp.UnmarshalJSON(w.Body.Bytes()))
+		assert.Equal(t, tc.expectedRejected, resp.PartialSuccess().RejectedLogRecords())
+
+		for _, message := range producer.messages {
+			if message.GetType() == kafkaqueue.PushLogsFlattened {
+				assert.Equal(t, uint32(123), message.(*kafka_queue.LogRowMessage).ProjectId)
+			}
+		}
+	}
+}
+
+func TestWriteExportError(t *testing.T) {
+	for _, contentType := range []string{contentTypeJSON, contentTypeProtobuf} {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "", nil)
+		r.Header.Set("Content-Type", contentType)
+		writeExportError(context.Background(), w, r, http.StatusServiceUnavailable, codes.Unavailable, errors.New("queue unavailable"))
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, contentType, w.Header().Get("Content-Type"))
+
+		st := &spb.Status{}
+		if contentType == contentTypeJSON {
+			assert.NoError(t, protojson.Unmarshal(w.Body.Bytes(), st))
+		} else {
+			assert.NoError(t, proto.Unmarshal(w.Body.Bytes(), st))
+		}
+		assert.Equal(t, int32(codes.Unavailable), st.Code)
+		assert.Equal(t, "queue unavailable", st.Message)
+	}
+}
+
 func TestExtractFields_Syslog(t *testing.T) {
 	resolver := &public.Resolver{
 		Redis:      red,
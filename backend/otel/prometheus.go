@@ -0,0 +1,493 @@
+package otel
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/highlight/highlight/sdk/highlight-go"
+	e "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// maxPrometheusWriteSize bounds both the compressed and decompressed size of a remote write request.
+const maxPrometheusWriteSize = 32 * 1024 * 1024
+
+const (
+	prometheusNameLabel     = "__name__"
+	prometheusJobLabel      = "job"
+	prometheusInstanceLabel = "instance"
+	prometheusBucketLabel   = "le"
+	prometheusQuantileLabel = "quantile"
+
+	prometheusBucketSuffix = "_bucket"
+	prometheusSumSuffix    = "_sum"
+	prometheusCountSuffix  = "_count"
+	prometheusTotalSuffix  = "_total"
+)
+
+// prometheus.MetricMetadata_MetricType of the remote write protocol
+type prometheusMetricType int32
+
+const (
+	prometheusMetricTypeUnknown   prometheusMetricType = 0
+	prometheusMetricTypeCounter   prometheusMetricType = 1
+	prometheusMetricTypeGauge     prometheusMetricType = 2
+	prometheusMetricTypeHistogram prometheusMetricType = 3
+	prometheusMetricTypeSummary   prometheusMetricType = 5
+)
+
+type prometheusLabel struct {
+	Name  string
+	Value string
+}
+
+type prometheusSample struct {
+	Value     float64
+	Timestamp int64
+}
+
+type prometheusTimeSeries struct {
+	Labels  []prometheusLabel
+	Samples []prometheusSample
+}
+
+type prometheusMetadata struct {
+	Type prometheusMetricType
+	Help string
+	Unit string
+}
+
+// prometheusWriteRequest is the remote write v1 prometheus.WriteRequest.
+type prometheusWriteRequest struct {
+	Timeseries []prometheusTimeSeries
+	Metadata   map[string]prometheusMetadata
+}
+
+// HandlePrometheusWrite accepts snappy compressed Prometheus remote write v1 requests.
+// The project is set by the x-highlight-project header or a `highlight_project_id` label.
+func (o *Handler) HandlePrometheusWrite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	compressed, err := io.ReadAll(io.LimitReader(r.Body, maxPrometheusWriteSize+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(compressed) > maxPrometheusWriteSize {
+		http.Error(w, "remote write request too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	span, _ := highlight.StartTrace(ctx, "otel.prometheus")
+	req, err := decodePrometheusWriteRequest(compressed)
+	span.RecordError(err)
+	highlight.EndTrace(span)
+	if err != nil {
+		log.WithContext(ctx).WithError(err).Error("invalid prometheus remote write payload")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rejected, err := o.exportMetrics(ctx, r.Header, prometheusToOTLP(req))
+	if err != nil {
+		// prometheus retries requests failing with a server error
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if rejected > 0 {
+		log.WithContext(ctx).WithField("rejected", rejected).Debug("prometheus remote write samples were rejected")
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodePrometheusWriteRequest(compressed []byte) (*prometheusWriteRequest, error) {
+	size, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, e.Wrap(err, "invalid snappy payload")
+	}
+	if size > maxPrometheusWriteSize {
+		return nil, e.Errorf("decompressed remote write request of %d bytes is too large", size)
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, e.Wrap(err, "invalid snappy payload")
+	}
+	return parsePrometheusWriteRequest(data)
+}
+
+// forEachField calls fn with each field of a protobuf message. Unknown fields are skipped.
+func forEachField(data []byte, fn func(num protowire.Number, typ protowire.Type, value []byte, scalar uint64) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		var value []byte
+		var scalar uint64
+		switch typ {
+		case protowire.VarintType:
+			scalar, n = protowire.ConsumeVarint(data)
+		case protowire.Fixed64Type:
+			scalar, n = protowire.ConsumeFixed64(data)
+		case protowire.Fixed32Type:
+			var v uint32
+			v, n = protowire.ConsumeFixed32(data)
+			scalar = uint64(v)
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(data)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		if err := fn(num, typ, value, scalar); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parsePrometheusWriteRequest(data []byte) (*prometheusWriteRequest, error) {
+	req := &prometheusWriteRequest{Metadata: map[string]prometheusMetadata{}}
+	err := forEachField(data, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			ts, err := parsePrometheusTimeSeries(value)
+			if err != nil {
+				return err
+			}
+			req.Timeseries = append(req.Timeseries, ts)
+		case 3:
+			var name string
+			var metadata prometheusMetadata
+			if err := forEachField(value, func(num protowire.Number, _ protowire.Type, value []byte, scalar uint64) error {
+				switch num {
+				case 1:
+					metadata.Type = prometheusMetricType(scalar)
+				case 2:
+					name = string(value)
+				case 4:
+					metadata.Help = string(value)
+				case 5:
+					metadata.Unit = string(value)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			req.Metadata[name] = metadata
+		}
+		return nil
+	})
+	return req, err
+}
+
+func parsePrometheusTimeSeries(data []byte) (prometheusTimeSeries, error) {
+	var ts prometheusTimeSeries
+	err := forEachField(data, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			var label prometheusLabel
+			if err := forEachField(value, func(num protowire.Number, _ protowire.Type, value []byte, _ uint64) error {
+				switch num {
+				case 1:
+					label.Name = string(value)
+				case 2:
+					label.Value = string(value)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			ts.Labels = append(ts.Labels, label)
+		case 2:
+			var sample prometheusSample
+			if err := forEachField(value, func(num protowire.Number, _ protowire.Type, _ []byte, scalar uint64) error {
+				switch num {
+				case 1:
+					sample.Value = math.Float64frombits(scalar)
+				case 2:
+					sample.Timestamp = int64(scalar)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			ts.Samples = append(ts.Samples, sample)
+		}
+		return nil
+	})
+	return ts, err
+}
+
+// prometheusSeriesKey identifies the series of one histogram or summary
+type prometheusSeriesKey struct {
+	name   string
+	labels string
+}
+
+type prometheusDistribution struct {
+	labels    map[string]string
+	timestamp int64
+	sum       float64
+	count     float64
+	hasCount  bool
+	buckets   map[float64]float64
+	quantiles map[float64]float64
+}
+
+// prometheusToOTLP converts remote write samples to otel metrics so that they are ingested
+// like OTLP metrics. `_bucket`, `_sum` and `_count` series become histograms, and series with a
+// quantile label become summaries. Counters are cumulative sums, anything else is a gauge.
+func prometheusToOTLP(req *prometheusWriteRequest) pmetricotlp.ExportRequest {
+	metrics := pmetric.NewMetrics()
+	// series of a job and instance share a resource
+	resources := map[[2]string]pmetric.MetricSlice{}
+	getMetrics := func(labels map[string]string) pmetric.MetricSlice {
+		key := [2]string{labels[prometheusJobLabel], labels[prometheusInstanceLabel]}
+		slice, ok := resources[key]
+		if !ok {
+			rm := metrics.ResourceMetrics().AppendEmpty()
+			if key[0] != "" {
+				rm.Resource().Attributes().PutStr(string(semconv.ServiceNameKey), key[0])
+			}
+			if key[1] != "" {
+				rm.Resource().Attributes().PutStr(string(semconv.ServiceInstanceIDKey), key[1])
+			}
+			slice = rm.ScopeMetrics().AppendEmpty().Metrics()
+			resources[key] = slice
+		}
+		return slice
+	}
+
+	distributions := map[prometheusSeriesKey]map[int64]*prometheusDistribution{}
+	var distributionKeys []prometheusSeriesKey
+	for _, ts := range req.Timeseries {
+		labels := map[string]string{}
+		for _, label := range ts.Labels {
+			labels[label.Name] = label.Value
+		}
+		name := labels[prometheusNameLabel]
+		if name == "" {
+			continue
+		}
+
+		baseName, isDistribution := prometheusDistributionName(name, labels, req.Metadata)
+		if !isDistribution {
+			metadata := req.Metadata[name]
+			metric := getMetrics(labels).AppendEmpty()
+			metric.SetName(name)
+			metric.SetDescription(metadata.Help)
+			metric.SetUnit(metadata.Unit)
+			var dps pmetric.NumberDataPointSlice
+			if metadata.Type == prometheusMetricTypeCounter || (metadata.Type == prometheusMetricTypeUnknown && strings.HasSuffix(name, prometheusTotalSuffix)) {
+				sum := metric.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dps = sum.DataPoints()
+			} else {
+				dps = metric.SetEmptyGauge().DataPoints()
+			}
+			for _, sample := range ts.Samples {
+				if math.IsNaN(sample.Value) {
+					// staleness marker
+					continue
+				}
+				dp := dps.AppendEmpty()
+				dp.SetTimestamp(prometheusTimestamp(sample.Timestamp))
+				dp.SetDoubleValue(sample.Value)
+				putPrometheusAttributes(dp.Attributes(), labels)
+			}
+			continue
+		}
+
+		seriesLabels := map[string]string{}
+		for k, v := range labels {
+			if k != prometheusNameLabel && k != prometheusBucketLabel && k != prometheusQuantileLabel {
+				seriesLabels[k] = v
+			}
+		}
+		key := prometheusSeriesKey{name: baseName, labels: prometheusLabelsKey(seriesLabels)}
+		if _, ok := distributions[key]; !ok {
+			distributions[key] = map[int64]*prometheusDistribution{}
+			distributionKeys = append(distributionKeys, key)
+		}
+		for _, sample := range ts.Samples {
+			if math.IsNaN(sample.Value) {
+				continue
+			}
+			d, ok := distributions[key][sample.Timestamp]
+			if !ok {
+				d = &prometheusDistribution{
+					labels:    seriesLabels,
+					timestamp: sample.Timestamp,
+					buckets:   map[float64]float64{},
+					quantiles: map[float64]float64{},
+				}
+				distributions[key][sample.Timestamp] = d
+			}
+			switch {
+			case strings.HasSuffix(name, prometheusBucketSuffix):
+				if le, err := strconv.ParseFloat(labels[prometheusBucketLabel], 64); err == nil {
+					d.buckets[le] = sample.Value
+				}
+			case strings.HasSuffix(name, prometheusSumSuffix):
+				d.sum = sample.Value
+			case strings.HasSuffix(name, prometheusCountSuffix):
+				d.count = sample.Value
+				d.hasCount = true
+			default:
+				if q, err := strconv.ParseFloat(labels[prometheusQuantileLabel], 64); err == nil {
+					d.quantiles[q] = sample.Value
+				}
+			}
+		}
+	}
+
+	for _, key := range distributionKeys {
+		byTimestamp := distributions[key]
+		timestamps := make([]int64, 0, len(byTimestamp))
+		for ts := range byTimestamp {
+			timestamps = append(timestamps, ts)
+		}
+		sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+
+		first := byTimestamp[timestamps[0]]
+		metadata := req.Metadata[key.name]
+		metric := getMetrics(first.labels).AppendEmpty()
+		metric.SetName(key.name)
+		metric.SetDescription(metadata.Help)
+		metric.SetUnit(metadata.Unit)
+
+		if len(first.quantiles) > 0 || metadata.Type == prometheusMetricTypeSummary {
+			dps := metric.SetEmptySummary().DataPoints()
+			for _, ts := range timestamps {
+				d := byTimestamp[ts]
+				dp := dps.AppendEmpty()
+				dp.SetTimestamp(prometheusTimestamp(d.timestamp))
+				dp.SetCount(uint64(d.count))
+				dp.SetSum(d.sum)
+				for _, q := range sortedKeys(d.quantiles) {
+					qv := dp.QuantileValues().AppendEmpty()
+					qv.SetQuantile(q)
+					qv.SetValue(d.quantiles[q])
+				}
+				putPrometheusAttributes(dp.Attributes(), d.labels)
+			}
+			continue
+		}
+
+		histogram := metric.SetEmptyHistogram()
+		histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for _, ts := range timestamps {
+			d := byTimestamp[ts]
+			dp := histogram.DataPoints().AppendEmpty()
+			dp.SetTimestamp(prometheusTimestamp(d.timestamp))
+			dp.SetSum(d.sum)
+
+			// prometheus buckets are cumulative and end with +Inf, otel buckets count the values in each bound
+			var previous float64
+			for _, le := range sortedKeys(d.buckets) {
+				cumulative := d.buckets[le]
+				if !math.IsInf(le, 1) {
+					dp.ExplicitBounds().Append(le)
+				}
+				dp.BucketCounts().Append(uint64(math.Max(cumulative-previous, 0)))
+				previous = cumulative
+			}
+			if _, ok := d.buckets[math.Inf(1)]; !ok && len(d.buckets) > 0 {
+				dp.BucketCounts().Append(uint64(math.Max(d.count-previous, 0)))
+			}
+			if d.hasCount {
+				dp.SetCount(uint64(d.count))
+			} else {
+				dp.SetCount(uint64(previous))
+			}
+			putPrometheusAttributes(dp.Attributes(), d.labels)
+		}
+	}
+
+	return pmetricotlp.NewExportRequestFromMetrics(metrics)
+}
+
+// prometheusDistributionName returns the name of the histogram or summary the series belongs to.
+func prometheusDistributionName(name string, labels map[string]string, metadata map[string]prometheusMetadata) (string, bool) {
+	if _, ok := labels[prometheusQuantileLabel]; ok {
+		return name, true
+	}
+	for _, suffix := range []string{prometheusBucketSuffix, prometheusSumSuffix, prometheusCountSuffix} {
+		if !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		baseName := strings.TrimSuffix(name, suffix)
+		if suffix == prometheusBucketSuffix {
+			_, ok := labels[prometheusBucketLabel]
+			return baseName, ok
+		}
+		// without metadata, `_sum` and `_count` are assumed to be part of a histogram
+		if m, ok := metadata[baseName]; ok {
+			return baseName, m.Type == prometheusMetricTypeHistogram || m.Type == prometheusMetricTypeSummary
+		}
+		if m, ok := metadata[name]; ok && m.Type != prometheusMetricTypeUnknown {
+			return name, false
+		}
+		return baseName, true
+	}
+	return name, false
+}
+
+func putPrometheusAttributes(attributes pcommon.Map, labels map[string]string) {
+	for k, v := range labels {
+		switch k {
+		case prometheusNameLabel, prometheusBucketLabel, prometheusQuantileLabel, prometheusJobLabel, prometheusInstanceLabel:
+			continue
+		}
+		attributes.PutStr(k, v)
+	}
+}
+
+func prometheusLabelsKey(labels map[string]string) string {
+	var b strings.Builder
+	for _, k := range sortedKeys(labels) {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(labels[k])
+		b.WriteByte(',')
+	}
+	return b.String()
+}
+
+func prometheusTimestamp(ms int64) pcommon.Timestamp {
+	return pcommon.NewTimestampFromTime(time.UnixMilli(ms))
+}
+
+func sortedKeys[K string | float64, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
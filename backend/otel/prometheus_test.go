@@ -0,0 +1,138 @@
+package otel
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func encodePrometheusTimeSeries(labels []prometheusLabel, samples []prometheusSample) []byte {
+	var ts []byte
+	for _, label := range labels {
+		var l []byte
+		l = protowire.AppendTag(l, 1, protowire.BytesType)
+		l = protowire.AppendString(l, label.Name)
+		l = protowire.AppendTag(l, 2, protowire.BytesType)
+		l = protowire.AppendString(l, label.Value)
+		ts = protowire.AppendTag(ts, 1, protowire.BytesType)
+		ts = protowire.AppendBytes(ts, l)
+	}
+	for _, sample := range samples {
+		var s []byte
+		s = protowire.AppendTag(s, 1, protowire.Fixed64Type)
+		s = protowire.AppendFixed64(s, math.Float64bits(sample.Value))
+		s = protowire.AppendTag(s, 2, protowire.VarintType)
+		s = protowire.AppendVarint(s, uint64(sample.Timestamp))
+		ts = protowire.AppendTag(ts, 2, protowire.BytesType)
+		ts = protowire.AppendBytes(ts, s)
+	}
+	var req []byte
+	req = protowire.AppendTag(req, 1, protowire.BytesType)
+	return protowire.AppendBytes(req, ts)
+}
+
+func encodePrometheusMetadata(name string, metricType prometheusMetricType, help string) []byte {
+	var m []byte
+	m = protowire.AppendTag(m, 1, protowire.VarintType)
+	m = protowire.AppendVarint(m, uint64(metricType))
+	m = protowire.AppendTag(m, 2, protowire.BytesType)
+	m = protowire.AppendString(m, name)
+	m = protowire.AppendTag(m, 4, protowire.BytesType)
+	m = protowire.AppendString(m, help)
+	var req []byte
+	req = protowire.AppendTag(req, 3, protowire.BytesType)
+	return protowire.AppendBytes(req, m)
+}
+
+func prometheusTestSeries(name string, labels ...string) []prometheusLabel {
+	result := []prometheusLabel{{Name: prometheusNameLabel, Value: name}, {Name: "job", Value: "api"}, {Name: "instance", Value: "10.0.0.1:9090"}}
+	for i := 0; i+1 < len(labels); i += 2 {
+		result = append(result, prometheusLabel{Name: labels[i], Value: labels[i+1]})
+	}
+	return result
+}
+
+func TestDecodePrometheusWriteRequest(t *testing.T) {
+	const ts = 1700000000000
+	var payload []byte
+	payload = append(payload, encodePrometheusMetadata("http_requests_total", prometheusMetricTypeCounter, "requests")...)
+	payload = append(payload, encodePrometheusTimeSeries(prometheusTestSeries("http_requests_total", "highlight_project_id", "1", "method", "GET"), []prometheusSample{{Value: 10, Timestamp: ts}, {Value: 12, Timestamp: ts + 15000}})...)
+	payload = append(payload, encodePrometheusTimeSeries(prometheusTestSeries("go_goroutines"), []prometheusSample{{Value: 42, Timestamp: ts}, {Value: math.NaN(), Timestamp: ts + 15000}})...)
+	for _, bucket := range []struct {
+		le    string
+		value float64
+	}{{"0.1", 3}, {"0.5", 5}, {"+Inf", 6}} {
+		payload = append(payload, encodePrometheusTimeSeries(prometheusTestSeries("request_duration_seconds_bucket", "le", bucket.le), []prometheusSample{{Value: bucket.value, Timestamp: ts}})...)
+	}
+	payload = append(payload, encodePrometheusTimeSeries(prometheusTestSeries("request_duration_seconds_sum"), []prometheusSample{{Value: 1.5, Timestamp: ts}})...)
+	payload = append(payload, encodePrometheusTimeSeries(prometheusTestSeries("request_duration_seconds_count"), []prometheusSample{{Value: 6, Timestamp: ts}})...)
+	payload = append(payload, encodePrometheusTimeSeries(prometheusTestSeries("rpc_latency", "quantile", "0.5"), []prometheusSample{{Value: 0.2, Timestamp: ts}})...)
+	payload = append(payload, encodePrometheusTimeSeries(prometheusTestSeries("rpc_latency", "quantile", "0.99"), []prometheusSample{{Value: 0.9, Timestamp: ts}})...)
+	payload = append(payload, encodePrometheusTimeSeries(prometheusTestSeries("rpc_latency_sum"), []prometheusSample{{Value: 4, Timestamp: ts}})...)
+	payload = append(payload, encodePrometheusTimeSeries(prometheusTestSeries("rpc_latency_count"), []prometheusSample{{Value: 10, Timestamp: ts}})...)
+
+	req, err := decodePrometheusWriteRequest(snappy.Encode(nil, payload))
+	require.NoError(t, err)
+	assert.Len(t, req.Timeseries, 11)
+	assert.Equal(t, prometheusMetricTypeCounter, req.Metadata["http_requests_total"].Type)
+
+	metrics := map[string]pmetric.Metric{}
+	rms := prometheusToOTLP(req).Metrics().ResourceMetrics()
+	require.Equal(t, 1, rms.Len())
+	serviceName, _ := rms.At(0).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "api", serviceName.Str())
+	instance, _ := rms.At(0).Resource().Attributes().Get("service.instance.id")
+	assert.Equal(t, "10.0.0.1:9090", instance.Str())
+	ms := rms.At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		metrics[ms.At(i).Name()] = ms.At(i)
+	}
+	assert.Len(t, metrics, 4)
+
+	counter := metrics["http_requests_total"]
+	require.Equal(t, pmetric.MetricTypeSum, counter.Type())
+	assert.True(t, counter.Sum().IsMonotonic())
+	assert.Equal(t, "requests", counter.Description())
+	require.Equal(t, 2, counter.Sum().DataPoints().Len())
+	assert.Equal(t, 12., counter.Sum().DataPoints().At(1).DoubleValue())
+	project, _ := counter.Sum().DataPoints().At(0).Attributes().Get("highlight_project_id")
+	assert.Equal(t, "1", project.Str())
+	_, hasJob := counter.Sum().DataPoints().At(0).Attributes().Get("job")
+	assert.False(t, hasJob)
+
+	gauge := metrics["go_goroutines"]
+	require.Equal(t, pmetric.MetricTypeGauge, gauge.Type())
+	// the staleness marker is dropped
+	assert.Equal(t, 1, gauge.Gauge().DataPoints().Len())
+
+	histogram := metrics["request_duration_seconds"]
+	require.Equal(t, pmetric.MetricTypeHistogram, histogram.Type())
+	require.Equal(t, 1, histogram.Histogram().DataPoints().Len())
+	dp := histogram.Histogram().DataPoints().At(0)
+	assert.Equal(t, []float64{0.1, 0.5}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{3, 2, 1}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(6), dp.Count())
+	assert.Equal(t, 1.5, dp.Sum())
+
+	summary := metrics["rpc_latency"]
+	require.Equal(t, pmetric.MetricTypeSummary, summary.Type())
+	sdp := summary.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(10), sdp.Count())
+	assert.Equal(t, 4., sdp.Sum())
+	require.Equal(t, 2, sdp.QuantileValues().Len())
+	assert.Equal(t, 0.99, sdp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 0.9, sdp.QuantileValues().At(1).Value())
+}
+
+func TestDecodePrometheusWriteRequestInvalid(t *testing.T) {
+	_, err := decodePrometheusWriteRequest([]byte("not snappy"))
+	assert.Error(t, err)
+
+	_, err = decodePrometheusWriteRequest(snappy.Encode(nil, []byte{0x0a, 0xff}))
+	assert.Error(t, err)
+}
@@ -3,6 +3,8 @@ package otel
 import (
 	"strconv"
 
+	"github.com/influxdata/go-syslog/v3"
+	"github.com/influxdata/go-syslog/v3/rfc3164"
 	"github.com/influxdata/go-syslog/v3/rfc5424"
 	"go.opentelemetry.io/collector/pdata/plog"
 )
@@ -11,33 +13,7 @@ func extractSyslog(fields *extractedFields) {
 	p := rfc5424.NewParser(rfc5424.WithBestEffort())
 	message, err := p.Parse([]byte(fields.logBody))
 	if msg, ok := message.(*rfc5424.SyslogMessage); err == nil && ok {
-		if msg.Message != nil {
-			fields.logBody = *msg.Message
-		}
-		if msg.Facility != nil {
-			fields.attrs["facility"] = strconv.Itoa(int(*msg.Facility))
-		}
-		if msg.Severity != nil {
-			fields.logSeverity = plog.SeverityNumber(*msg.Severity).String()
-		}
-		if msg.Priority != nil {
-			fields.attrs["priority"] = strconv.Itoa(int(*msg.Priority))
-		}
-		if msg.Timestamp != nil {
-			fields.timestamp = *msg.Timestamp
-		}
-		if msg.Hostname != nil {
-			fields.attrs["hostname"] = *msg.Hostname
-		}
-		if msg.Appname != nil {
-			fields.attrs["app_name"] = *msg.Appname
-		}
-		if msg.ProcID != nil {
-			fields.attrs["proc_id"] = *msg.ProcID
-		}
-		if msg.MsgID != nil {
-			fields.attrs["msg_id"] = *msg.MsgID
-		}
+		extractSyslogBase(fields, &msg.Base)
 		if msg.StructuredData != nil {
 
 			for topLevelKey, vMap := range *msg.StructuredData {
@@ -46,5 +22,45 @@ func extractSyslog(fields *extractedFields) {
 				}
 			}
 		}
+		return
+	}
+
+	// legacy BSD syslog, ie. from network devices
+	p = rfc3164.NewParser(rfc3164.WithBestEffort(), rfc3164.WithYear(rfc3164.CurrentYear{}), rfc3164.WithRFC3339())
+	message, err = p.Parse([]byte(fields.logBody))
+	if msg, ok := message.(*rfc3164.SyslogMessage); err == nil && ok {
+		extractSyslogBase(fields, &msg.Base)
+	}
+}
+
+func extractSyslogBase(fields *extractedFields, msg *syslog.Base) {
+	if msg.Message != nil {
+		fields.logBody = *msg.Message
+	}
+	if msg.Facility != nil {
+		fields.attrs["facility"] = strconv.Itoa(int(*msg.Facility))
+	}
+	if msg.Severity != nil {
+		fields.logSeverity = plog.SeverityNumber(*msg.Severity).String()
+		// `severity` is read as the log level, so the syslog severity is kept separately
+		fields.attrs["severity_code"] = strconv.Itoa(int(*msg.Severity))
+	}
+	if msg.Priority != nil {
+		fields.attrs["priority"] = strconv.Itoa(int(*msg.Priority))
+	}
+	if msg.Timestamp != nil {
+		fields.timestamp = *msg.Timestamp
+	}
+	if msg.Hostname != nil {
+		fields.attrs["hostname"] = *msg.Hostname
+	}
+	if msg.Appname != nil {
+		fields.attrs["app_name"] = *msg.Appname
+	}
+	if msg.ProcID != nil {
+		fields.attrs["proc_id"] = *msg.ProcID
+	}
+	if msg.MsgID != nil {
+		fields.attrs["msg_id"] = *msg.MsgID
 	}
 }
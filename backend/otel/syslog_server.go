@@ -0,0 +1,360 @@
+package otel
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/highlight/highlight/sdk/highlight-go"
+	hmetric "github.com/highlight/highlight/sdk/highlight-go/metric"
+	e "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// larger messages are truncated, matching the limit of most syslog relays
+	syslogMaxMessageSize = 64 * 1024
+	// the longest project token or octet count prefixed to a framed message
+	syslogMaxTokenLength = 64
+	syslogBatchSize      = 1000
+	syslogFlushInterval  = time.Second
+	syslogQueueSize      = 10_000
+)
+
+type SyslogTransport string
+
+const (
+	SyslogTransportUDP SyslogTransport = "udp"
+	SyslogTransportTCP SyslogTransport = "tcp"
+	SyslogTransportTLS SyslogTransport = "tls"
+)
+
+// SyslogListener is a port receiving syslog messages. Messages on a port with a ProjectID belong
+// to that project, otherwise the project is read from the `[highlight project_id="..."]` structured data
+// or from a project token preceding the message, as sent by syslog drains.
+type SyslogListener struct {
+	Transport SyslogTransport
+	Port      string
+	ProjectID string
+	TLSConfig *tls.Config
+}
+
+type syslogMessage struct {
+	projectID string
+	transport SyslogTransport
+	body      string
+}
+
+type syslogExporter func(ctx context.Context, headers http.Header, req plogotlp.ExportRequest) (int64, error)
+
+// SyslogServer accepts RFC 5424 and RFC 3164 messages over UDP, TCP and TLS and ingests them as logs.
+type SyslogServer struct {
+	export   syslogExporter
+	messages chan syslogMessage
+}
+
+func (o *Handler) NewSyslogServer() *SyslogServer {
+	return newSyslogServer(o.exportLogs)
+}
+
+func newSyslogServer(export syslogExporter) *SyslogServer {
+	return &SyslogServer{
+		export:   export,
+		messages: make(chan syslogMessage, syslogQueueSize),
+	}
+}
+
+// ParseSyslogProjectPorts parses a comma separated list of `port=project` mappings.
+func ParseSyslogProjectPorts(value string) (map[string]string, error) {
+	ports := map[string]string{}
+	for _, mapping := range strings.Split(value, ",") {
+		if strings.TrimSpace(mapping) == "" {
+			continue
+		}
+		port, project, found := strings.Cut(strings.TrimSpace(mapping), "=")
+		if !found || port == "" || project == "" {
+			return nil, e.Errorf("invalid syslog port mapping %s", mapping)
+		}
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return nil, e.Wrapf(err, "invalid syslog port %s", port)
+		}
+		ports[port] = project
+	}
+	return ports, nil
+}
+
+// Listen starts the listeners and ingests received messages until the context is done.
+func (s *SyslogServer) Listen(ctx context.Context, listeners []SyslogListener) error {
+	for _, listener := range listeners {
+		switch listener.Transport {
+		case SyslogTransportUDP:
+			conn, err := net.ListenPacket("udp", ":"+listener.Port)
+			if err != nil {
+				return e.Wrapf(err, "failed to listen for syslog on udp port %s", listener.Port)
+			}
+			go s.serveUDP(ctx, conn, listener)
+		case SyslogTransportTCP, SyslogTransportTLS:
+			var lis net.Listener
+			var err error
+			if listener.Transport == SyslogTransportTLS {
+				lis, err = tls.Listen("tcp", ":"+listener.Port, listener.TLSConfig)
+			} else {
+				lis, err = net.Listen("tcp", ":"+listener.Port)
+			}
+			if err != nil {
+				return e.Wrapf(err, "failed to listen for syslog on %s port %s", listener.Transport, listener.Port)
+			}
+			go s.serveTCP(ctx, lis, listener)
+		default:
+			return e.Errorf("unsupported syslog transport %s", listener.Transport)
+		}
+		log.WithContext(ctx).WithField("port", listener.Port).WithField("transport", listener.Transport).Info("running syslog listener")
+	}
+
+	go s.flushMessages(ctx)
+	return nil
+}
+
+func (s *SyslogServer) serveUDP(ctx context.Context, conn net.PacketConn, listener SyslogListener) {
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	buf := make([]byte, syslogMaxMessageSize)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.WithContext(ctx).WithError(err).Error("failed to read syslog udp datagram")
+			}
+			return
+		}
+		// each datagram is one message, see RFC 5426
+		s.enqueue(ctx, listener, strings.TrimRight(string(buf[:n]), "\r\n\x00"))
+	}
+}
+
+func (s *SyslogServer) serveTCP(ctx context.Context, lis net.Listener, listener SyslogListener) {
+	go func() {
+		<-ctx.Done()
+		_ = lis.Close()
+	}()
+
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.WithContext(ctx).WithError(err).Error("failed to accept syslog connection")
+			}
+			return
+		}
+		go func() {
+			defer conn.Close()
+			reader := bufio.NewReaderSize(conn, syslogMaxMessageSize)
+			for {
+				message, err := readSyslogFrame(reader)
+				if err != nil {
+					if err != io.EOF {
+						log.WithContext(ctx).WithError(err).WithField("transport", listener.Transport).Warn("closing syslog connection")
+					}
+					return
+				}
+				s.enqueue(ctx, listener, message)
+			}
+		}()
+	}
+}
+
+// readSyslogFrame reads the next message of a stream using either octet counting
+// (`<length> <message>`) or newline framing, as described by RFC 6587.
+// The reader must be sized to syslogMaxMessageSize so that reads stay bounded.
+func readSyslogFrame(reader *bufio.Reader) (string, error) {
+	for {
+		if header, length, ok := peekSyslogOctetCount(reader); ok {
+			if length > syslogMaxMessageSize {
+				return "", e.Errorf("syslog message of %d bytes exceeds the maximum size", length)
+			}
+			if _, err := reader.Discard(header); err != nil {
+				return "", err
+			}
+			message := make([]byte, length)
+			if _, err := io.ReadFull(reader, message); err != nil {
+				return "", err
+			}
+			return strings.TrimRight(string(message), "\r\n"), nil
+		}
+
+		line, err := readSyslogLine(reader)
+		line = strings.TrimRight(line, "\r\n\x00")
+		if line != "" {
+			return line, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// readSyslogLine reads a newline framed message. Lines longer than the buffer of the
+// reader are truncated and the rest of the line is discarded.
+func readSyslogLine(reader *bufio.Reader) (string, error) {
+	slice, err := reader.ReadSlice('\n')
+	line := string(slice)
+	for err == bufio.ErrBufferFull {
+		_, err = reader.ReadSlice('\n')
+	}
+	if len(line) > syslogMaxMessageSize {
+		line = line[:syslogMaxMessageSize]
+	}
+	return line, err
+}
+
+// peekSyslogOctetCount returns the header and message lengths of an octet counted frame
+// without consuming it. Only buffered data is inspected so that the peek does not block.
+// A frame starting with a project token (ie. `1jdkoe52 <1>1 ...` or `1 <1>1 ...`) is
+// newline framed, which is told apart by the message not fitting the counted length.
+func peekSyslogOctetCount(reader *bufio.Reader) (int, int, bool) {
+	if _, err := reader.Peek(1); err != nil {
+		return 0, 0, false
+	}
+	buffered, _ := reader.Peek(reader.Buffered())
+
+	header, length, ok := parseSyslogOctetCount(buffered)
+	if !ok {
+		return 0, 0, false
+	}
+	frame := buffered[header:]
+	// the message starts with a priority, ie. `<165>`
+	pri := strings.IndexByte(string(frame[:min(len(frame), length)]), '>')
+	if pri < 2 || pri > 4 {
+		return 0, 0, false
+	}
+	if _, err := strconv.Atoi(string(frame[1:pri])); err != nil {
+		return 0, 0, false
+	}
+	if len(frame) < length {
+		// a newline terminated line shorter than the counted length is newline framed
+		return header, length, frame[len(frame)-1] != '\n'
+	}
+	// the counted message is followed by the end of the data, a newline or the next frame
+	return header, length, isSyslogFrameBoundary(frame[length:])
+}
+
+// isSyslogFrameBoundary returns whether data can start a frame, ie. `<34>...`,
+// `72 <34>...` or `1jdkoe52 <34>...`. Data too short to tell is accepted.
+func isSyslogFrameBoundary(data []byte) bool {
+	if len(data) == 0 || data[0] == '<' || data[0] == '\n' || data[0] == '\r' {
+		return true
+	}
+	prefix := data[:min(len(data), syslogMaxTokenLength)]
+	idx := strings.IndexByte(string(prefix), ' ')
+	if idx < 0 {
+		return len(data) < syslogMaxTokenLength
+	}
+	return idx > 0 && (idx+1 == len(data) || data[idx+1] == '<')
+}
+
+// parseSyslogOctetCount parses an octet count header of at most 5 digits followed by a
+// space and the start of the priority of the message, ie. `72 <`.
+func parseSyslogOctetCount(data []byte) (int, int, bool) {
+	idx := strings.IndexByte(string(data[:min(len(data), 6)]), ' ')
+	if idx <= 0 || idx+1 >= len(data) || data[idx+1] != '<' || data[0] == '0' {
+		return 0, 0, false
+	}
+	length, err := strconv.Atoi(string(data[:idx]))
+	if err != nil || length <= 0 {
+		return 0, 0, false
+	}
+	return idx + 1, length, true
+}
+
+func (s *SyslogServer) enqueue(ctx context.Context, listener SyslogListener, body string) {
+	if body == "" {
+		return
+	}
+	tags := []attribute.KeyValue{attribute.String("transport", string(listener.Transport))}
+	select {
+	case s.messages <- syslogMessage{projectID: listener.ProjectID, transport: listener.Transport, body: body}:
+		hmetric.Incr(ctx, "syslog.messages", tags, 1)
+	default:
+		hmetric.Incr(ctx, "syslog.dropped", tags, 1)
+	}
+}
+
+func (s *SyslogServer) flushMessages(ctx context.Context) {
+	ticker := time.NewTicker(syslogFlushInterval)
+	defer ticker.Stop()
+
+	var batch []syslogMessage
+	for {
+		select {
+		case <-ctx.Done():
+			s.flush(context.Background(), batch)
+			return
+		case message := <-s.messages:
+			batch = append(batch, message)
+			if len(batch) >= syslogBatchSize {
+				s.flush(ctx, batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			s.flush(ctx, batch)
+			batch = nil
+		}
+	}
+}
+
+func (s *SyslogServer) flush(ctx context.Context, batch []syslogMessage) {
+	if len(batch) == 0 {
+		return
+	}
+	span, ctx := highlight.StartTrace(ctx, "otel.syslog.flush")
+	defer highlight.EndTrace(span)
+
+	rejected, err := s.export(ctx, http.Header{}, newSyslogExportRequest(batch, time.Now()))
+	if err != nil {
+		log.WithContext(ctx).WithError(err).WithField("messages", len(batch)).Error("failed to export syslog messages")
+		return
+	}
+	if rejected > 0 {
+		log.WithContext(ctx).WithField("rejected", rejected).Warn("syslog messages were rejected")
+	}
+}
+
+// newSyslogExportRequest wraps messages in otel log records so that they are parsed by extractSyslog.
+func newSyslogExportRequest(messages []syslogMessage, observed time.Time) plogotlp.ExportRequest {
+	req := plogotlp.NewExportRequest()
+	resourceLogs := req.Logs().ResourceLogs()
+	scopes := map[syslogMessage]plog.LogRecordSlice{}
+	for _, message := range messages {
+		key := syslogMessage{projectID: message.projectID, transport: message.transport}
+		scope, ok := scopes[key]
+		if !ok {
+			rl := resourceLogs.AppendEmpty()
+			if message.projectID != "" {
+				rl.Resource().Attributes().PutStr(highlight.ProjectIDAttribute, message.projectID)
+			}
+			rl.Resource().Attributes().PutStr("syslog.transport", string(message.transport))
+			scope = rl.ScopeLogs().AppendEmpty().LogRecords()
+			scopes[key] = scope
+		}
+
+		record := scope.AppendEmpty()
+		// replaced by the timestamp of the message when it has one
+		record.SetTimestamp(pcommon.NewTimestampFromTime(observed))
+		record.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed))
+		record.Body().SetStr(message.body)
+	}
+	return req
+}
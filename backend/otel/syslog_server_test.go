@@ -0,0 +1,171 @@
+package otel
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/highlight/highlight/sdk/highlight-go"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+)
+
+func TestReadSyslogFrame(t *testing.T) {
+	stream := strings.Join([]string{
+		// octet counted
+		"72 <165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - - hello",
+		// newline framed with a project token
+		"1jdkoe52 <1>1 2023-07-27T05:43:22.401882Z render render-log-endpoint-test 1 render-log-endpoint-test - Render test log\r\n",
+		"\n",
+		"<34>Oct 11 22:14:15 mymachine su: 'su root' failed\n",
+		"<34>Oct 11 22:14:15 mymachine su: unterminated",
+	}, "")
+	reader := bufio.NewReader(strings.NewReader(stream))
+
+	var messages []string
+	for {
+		message, err := readSyslogFrame(reader)
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		messages = append(messages, message)
+	}
+	assert.Equal(t, []string{
+		"<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - - hello",
+		"1jdkoe52 <1>1 2023-07-27T05:43:22.401882Z render render-log-endpoint-test 1 render-log-endpoint-test - Render test log",
+		"<34>Oct 11 22:14:15 mymachine su: 'su root' failed",
+		"<34>Oct 11 22:14:15 mymachine su: unterminated",
+	}, messages)
+
+	_, err := readSyslogFrame(bufio.NewReader(strings.NewReader("99999 <1>1 too long")))
+	assert.Error(t, err)
+}
+
+func TestReadSyslogFrameNumericProjectToken(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader(strings.Join([]string{
+		"1 <34>Oct 11 22:14:15 mymachine su: 'su root' failed\n",
+		"12 <34>Oct 11 22:14:15 mymachine su: 'su root' failed\n",
+		"12 <34>1 - - -\n",
+	}, "")))
+
+	for _, expected := range []string{
+		"1 <34>Oct 11 22:14:15 mymachine su: 'su root' failed",
+		"12 <34>Oct 11 22:14:15 mymachine su: 'su root' failed",
+		"<34>1 - - -",
+	} {
+		message, err := readSyslogFrame(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, message)
+	}
+}
+
+func TestReadSyslogFrameUnterminated(t *testing.T) {
+	stream := strings.Repeat("a", 3*syslogMaxMessageSize) + "\n<34>Oct 11 22:14:15 mymachine su: next\n"
+	reader := bufio.NewReaderSize(strings.NewReader(stream), syslogMaxMessageSize)
+
+	message, err := readSyslogFrame(reader)
+	assert.NoError(t, err)
+	assert.Len(t, message, syslogMaxMessageSize)
+
+	message, err = readSyslogFrame(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "<34>Oct 11 22:14:15 mymachine su: next", message)
+}
+
+func TestParseSyslogProjectPorts(t *testing.T) {
+	ports, err := ParseSyslogProjectPorts("5514=1, 5515=abc123,")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"5514": "1", "5515": "abc123"}, ports)
+
+	ports, err = ParseSyslogProjectPorts("")
+	assert.NoError(t, err)
+	assert.Empty(t, ports)
+
+	_, err = ParseSyslogProjectPorts("5514")
+	assert.Error(t, err)
+	_, err = ParseSyslogProjectPorts("syslog=1")
+	assert.Error(t, err)
+}
+
+func TestSyslogExportRequest(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	req := newSyslogExportRequest([]syslogMessage{
+		{projectID: "1", transport: SyslogTransportUDP, body: "<34>Oct 11 22:14:15 mymachine su: 'su root' failed"},
+		{transport: SyslogTransportTCP, body: `<165>1 2003-10-11T22:14:15.003Z host app - - [highlight project_id="2"] structured`},
+		{projectID: "1", transport: SyslogTransportUDP, body: "<13>1 - host app - - - second"},
+	}, now)
+
+	resourceLogs := req.Logs().ResourceLogs()
+	assert.Equal(t, 2, resourceLogs.Len())
+
+	var projects, bodies []string
+	for i := 0; i < resourceLogs.Len(); i++ {
+		resource := resourceLogs.At(i).Resource()
+		records := resourceLogs.At(i).ScopeLogs().At(0).LogRecords()
+		for j := 0; j < records.Len(); j++ {
+			record := records.At(j)
+			fields, err := extractFields(ctx, extractFieldsParams{
+				headers:   http.Header{},
+				resource:  &resource,
+				logRecord: &record,
+				curTime:   now,
+			})
+			assert.NoError(t, err)
+			projects = append(projects, fields.projectID)
+			bodies = append(bodies, fields.logBody)
+			assert.NotEmpty(t, fields.attrs["hostname"])
+			assert.NotEmpty(t, fields.attrs["app_name"])
+			assert.NotEmpty(t, fields.attrs["facility"])
+			assert.NotEmpty(t, fields.attrs["severity_code"])
+		}
+	}
+	assert.Equal(t, []string{"1", "1", "2"}, projects)
+	assert.Equal(t, []string{"'su root' failed", "second", "structured"}, bodies)
+}
+
+func TestSyslogServerTCP(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var lock sync.Mutex
+	var received []string
+	server := newSyslogServer(func(ctx context.Context, headers http.Header, req plogotlp.ExportRequest) (int64, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		resourceLogs := req.Logs().ResourceLogs()
+		for i := 0; i < resourceLogs.Len(); i++ {
+			project, _ := resourceLogs.At(i).Resource().Attributes().Get(highlight.ProjectIDAttribute)
+			records := resourceLogs.At(i).ScopeLogs().At(0).LogRecords()
+			for j := 0; j < records.Len(); j++ {
+				received = append(received, project.Str()+" "+records.At(j).Body().Str())
+			}
+		}
+		return 0, nil
+	})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go server.serveTCP(ctx, lis, SyslogListener{Transport: SyslogTransportTCP, ProjectID: "7"})
+	go server.flushMessages(ctx)
+
+	conn, err := net.Dial("tcp", lis.Addr().String())
+	assert.NoError(t, err)
+	_, err = conn.Write([]byte("<34>Oct 11 22:14:15 mymachine su: first\n11 <13>1 - - -"))
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Close())
+
+	assert.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(received) == 2
+	}, 5*time.Second, 100*time.Millisecond)
+	assert.Equal(t, []string{"7 <34>Oct 11 22:14:15 mymachine su: first", "7 <13>1 - - -"}, received)
+}
@@ -26,3 +26,26 @@ func Test_extractSyslogWithStructuredData(t *testing.T) {
 	assert.Equal(t, "Application", fields.attrs["exampleSDID@32473.eventSource"])
 	assert.Equal(t, "1011", fields.attrs["exampleSDID@32473.eventID"])
 }
+
+func Test_extractSyslogRFC3164(t *testing.T) {
+	fields := newExtractedFields()
+
+	fields.logBody = "<34>Oct 11 22:14:15 mymachine su[123]: 'su root' failed for lonvick on /dev/pts/8"
+	extractSyslog(fields)
+	assert.Equal(t, "mymachine", fields.attrs["hostname"])
+	assert.Equal(t, "su", fields.attrs["app_name"])
+	assert.Equal(t, "123", fields.attrs["proc_id"])
+	assert.Equal(t, "4", fields.attrs["facility"])
+	assert.Equal(t, "2", fields.attrs["severity_code"])
+	assert.Equal(t, "'su root' failed for lonvick on /dev/pts/8", fields.logBody)
+	assert.Equal(t, 10, int(fields.timestamp.Month()))
+}
+
+func Test_extractSyslogNotSyslog(t *testing.T) {
+	fields := newExtractedFields()
+
+	fields.logBody = "<div>not a syslog message</div>"
+	extractSyslog(fields)
+	assert.Equal(t, "<div>not a syslog message</div>", fields.logBody)
+	assert.Empty(t, fields.attrs)
+}
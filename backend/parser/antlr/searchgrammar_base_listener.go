@@ -93,6 +93,12 @@ func (s *BaseSearchGrammarListener) EnterAnd_search_expr(ctx *And_search_exprCon
 // ExitAnd_search_expr is called when production and_search_expr is exited.
 func (s *BaseSearchGrammarListener) ExitAnd_search_expr(ctx *And_search_exprContext) {}
 
+// EnterIn_search_expr is called when production in_search_expr is entered.
+func (s *BaseSearchGrammarListener) EnterIn_search_expr(ctx *In_search_exprContext) {}
+
+// ExitIn_search_expr is called when production in_search_expr is exited.
+func (s *BaseSearchGrammarListener) ExitIn_search_expr(ctx *In_search_exprContext) {}
+
 // EnterOr_search_expr is called when production or_search_expr is entered.
 func (s *BaseSearchGrammarListener) EnterOr_search_expr(ctx *Or_search_exprContext) {}
 
@@ -113,6 +119,12 @@ func (s *BaseSearchGrammarListener) EnterExists_search_expr(ctx *Exists_search_e
 // ExitExists_search_expr is called when production exists_search_expr is exited.
 func (s *BaseSearchGrammarListener) ExitExists_search_expr(ctx *Exists_search_exprContext) {}
 
+// EnterBetween_search_expr is called when production between_search_expr is entered.
+func (s *BaseSearchGrammarListener) EnterBetween_search_expr(ctx *Between_search_exprContext) {}
+
+// ExitBetween_search_expr is called when production between_search_expr is exited.
+func (s *BaseSearchGrammarListener) ExitBetween_search_expr(ctx *Between_search_exprContext) {}
+
 // EnterKey_val_search_expr is called when production key_val_search_expr is entered.
 func (s *BaseSearchGrammarListener) EnterKey_val_search_expr(ctx *Key_val_search_exprContext) {}
 
@@ -155,6 +167,18 @@ func (s *BaseSearchGrammarListener) EnterExists_op(ctx *Exists_opContext) {}
 // ExitExists_op is called when production exists_op is exited.
 func (s *BaseSearchGrammarListener) ExitExists_op(ctx *Exists_opContext) {}
 
+// EnterIn_op is called when production in_op is entered.
+func (s *BaseSearchGrammarListener) EnterIn_op(ctx *In_opContext) {}
+
+// ExitIn_op is called when production in_op is exited.
+func (s *BaseSearchGrammarListener) ExitIn_op(ctx *In_opContext) {}
+
+// EnterBetween_op is called when production between_op is entered.
+func (s *BaseSearchGrammarListener) EnterBetween_op(ctx *Between_opContext) {}
+
+// ExitBetween_op is called when production between_op is exited.
+func (s *BaseSearchGrammarListener) ExitBetween_op(ctx *Between_opContext) {}
+
 // EnterNegation_op is called when production negation_op is entered.
 func (s *BaseSearchGrammarListener) EnterNegation_op(ctx *Negation_opContext) {}
 
@@ -44,6 +44,9 @@ type SearchGrammarListener interface {
 	// EnterAnd_search_expr is called when entering the and_search_expr production.
 	EnterAnd_search_expr(c *And_search_exprContext)
 
+	// EnterIn_search_expr is called when entering the in_search_expr production.
+	EnterIn_search_expr(c *In_search_exprContext)
+
 	// EnterOr_search_expr is called when entering the or_search_expr production.
 	EnterOr_search_expr(c *Or_search_exprContext)
 
@@ -53,6 +56,9 @@ type SearchGrammarListener interface {
 	// EnterExists_search_expr is called when entering the exists_search_expr production.
 	EnterExists_search_expr(c *Exists_search_exprContext)
 
+	// EnterBetween_search_expr is called when entering the between_search_expr production.
+	EnterBetween_search_expr(c *Between_search_exprContext)
+
 	// EnterKey_val_search_expr is called when entering the key_val_search_expr production.
 	EnterKey_val_search_expr(c *Key_val_search_exprContext)
 
@@ -74,6 +80,12 @@ type SearchGrammarListener interface {
 	// EnterExists_op is called when entering the exists_op production.
 	EnterExists_op(c *Exists_opContext)
 
+	// EnterIn_op is called when entering the in_op production.
+	EnterIn_op(c *In_opContext)
+
+	// EnterBetween_op is called when entering the between_op production.
+	EnterBetween_op(c *Between_opContext)
+
 	// EnterNegation_op is called when entering the negation_op production.
 	EnterNegation_op(c *Negation_opContext)
 
@@ -119,6 +131,9 @@ type SearchGrammarListener interface {
 	// ExitAnd_search_expr is called when exiting the and_search_expr production.
 	ExitAnd_search_expr(c *And_search_exprContext)
 
+	// ExitIn_search_expr is called when exiting the in_search_expr production.
+	ExitIn_search_expr(c *In_search_exprContext)
+
 	// ExitOr_search_expr is called when exiting the or_search_expr production.
 	ExitOr_search_expr(c *Or_search_exprContext)
 
@@ -128,6 +143,9 @@ type SearchGrammarListener interface {
 	// ExitExists_search_expr is called when exiting the exists_search_expr production.
 	ExitExists_search_expr(c *Exists_search_exprContext)
 
+	// ExitBetween_search_expr is called when exiting the between_search_expr production.
+	ExitBetween_search_expr(c *Between_search_exprContext)
+
 	// ExitKey_val_search_expr is called when exiting the key_val_search_expr production.
 	ExitKey_val_search_expr(c *Key_val_search_exprContext)
 
@@ -149,6 +167,12 @@ type SearchGrammarListener interface {
 	// ExitExists_op is called when exiting the exists_op production.
 	ExitExists_op(c *Exists_opContext)
 
+	// ExitIn_op is called when exiting the in_op production.
+	ExitIn_op(c *In_opContext)
+
+	// ExitBetween_op is called when exiting the between_op production.
+	ExitBetween_op(c *Between_opContext)
+
 	// ExitNegation_op is called when exiting the negation_op production.
 	ExitNegation_op(c *Negation_opContext)
 
@@ -33,74 +33,89 @@ var SearchGrammarParserStaticData struct {
 func searchgrammarParserInit() {
 	staticData := &SearchGrammarParserStaticData
 	staticData.LiteralNames = []string{
-		"", "'AND'", "'OR'", "'NOT'", "'EXISTS'", "'!'", "'='", "'!='", "'<'",
-		"'<='", "'>'", "'>='", "'('", "')'", "':'",
+		"", "'AND'", "'OR'", "'NOT'", "'EXISTS'", "'IN'", "'BETWEEN'", "'!'",
+		"'='", "'!='", "'=~'", "'!~'", "'<'", "'<='", "'>'", "'>='", "'('",
+		"')'", "':'",
 	}
 	staticData.SymbolicNames = []string{
-		"", "AND", "OR", "NOT", "EXISTS", "BANG", "EQ", "NEQ", "LT", "LTE",
-		"GT", "GTE", "LPAREN", "RPAREN", "COLON", "ID", "STRING", "VALUE", "WS",
-		"ERROR_CHARACTERS",
+		"", "AND", "OR", "NOT", "EXISTS", "IN", "BETWEEN", "BANG", "EQ", "NEQ",
+		"REGEX_MATCH", "NOT_REGEX_MATCH", "LT", "LTE", "GT", "GTE", "LPAREN",
+		"RPAREN", "COLON", "ID", "STRING", "VALUE", "WS", "ERROR_CHARACTERS",
 	}
 	staticData.RuleNames = []string{
 		"search_query", "top_col_expr", "col_expr", "search_expr", "search_key",
-		"and_op", "implicit_and_op", "or_op", "exists_op", "negation_op", "bin_op",
-		"search_value",
+		"and_op", "implicit_and_op", "or_op", "exists_op", "in_op", "between_op",
+		"negation_op", "bin_op", "search_value",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 19, 129, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 1, 23, 159, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
 		4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2, 10, 7,
-		10, 2, 11, 7, 11, 1, 0, 1, 0, 1, 0, 1, 0, 3, 0, 29, 8, 0, 1, 1, 1, 1, 1,
-		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 39, 8, 1, 1, 2, 1, 2, 1, 2, 1, 2,
-		1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 3, 2, 50, 8, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1,
-		2, 1, 2, 5, 2, 58, 8, 2, 10, 2, 12, 2, 61, 9, 2, 1, 3, 1, 3, 1, 3, 1, 3,
-		1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 3, 3, 74, 8, 3, 1, 3, 1, 3, 1,
-		3, 1, 3, 3, 3, 80, 8, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3,
-		1, 3, 1, 3, 1, 3, 1, 3, 5, 3, 94, 8, 3, 10, 3, 12, 3, 97, 9, 3, 1, 4, 1,
-		4, 1, 5, 1, 5, 1, 6, 1, 6, 1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 3, 8, 110, 8,
-		8, 1, 9, 1, 9, 1, 10, 5, 10, 115, 8, 10, 10, 10, 12, 10, 118, 9, 10, 1,
-		10, 1, 10, 5, 10, 122, 8, 10, 10, 10, 12, 10, 125, 9, 10, 1, 11, 1, 11,
-		1, 11, 0, 2, 4, 6, 12, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 0, 2,
-		2, 0, 5, 11, 14, 14, 1, 0, 15, 17, 134, 0, 28, 1, 0, 0, 0, 2, 38, 1, 0,
-		0, 0, 4, 49, 1, 0, 0, 0, 6, 79, 1, 0, 0, 0, 8, 98, 1, 0, 0, 0, 10, 100,
-		1, 0, 0, 0, 12, 102, 1, 0, 0, 0, 14, 104, 1, 0, 0, 0, 16, 109, 1, 0, 0,
-		0, 18, 111, 1, 0, 0, 0, 20, 116, 1, 0, 0, 0, 22, 126, 1, 0, 0, 0, 24, 29,
-		5, 0, 0, 1, 25, 26, 3, 6, 3, 0, 26, 27, 5, 0, 0, 1, 27, 29, 1, 0, 0, 0,
-		28, 24, 1, 0, 0, 0, 28, 25, 1, 0, 0, 0, 29, 1, 1, 0, 0, 0, 30, 31, 5, 12,
-		0, 0, 31, 32, 3, 4, 2, 0, 32, 33, 5, 13, 0, 0, 33, 39, 1, 0, 0, 0, 34,
-		35, 3, 18, 9, 0, 35, 36, 3, 2, 1, 0, 36, 39, 1, 0, 0, 0, 37, 39, 3, 22,
-		11, 0, 38, 30, 1, 0, 0, 0, 38, 34, 1, 0, 0, 0, 38, 37, 1, 0, 0, 0, 39,
-		3, 1, 0, 0, 0, 40, 41, 6, 2, -1, 0, 41, 42, 5, 12, 0, 0, 42, 43, 3, 4,
-		2, 0, 43, 44, 5, 13, 0, 0, 44, 50, 1, 0, 0, 0, 45, 46, 3, 18, 9, 0, 46,
-		47, 3, 4, 2, 4, 47, 50, 1, 0, 0, 0, 48, 50, 3, 22, 11, 0, 49, 40, 1, 0,
-		0, 0, 49, 45, 1, 0, 0, 0, 49, 48, 1, 0, 0, 0, 50, 59, 1, 0, 0, 0, 51, 52,
-		10, 3, 0, 0, 52, 53, 5, 1, 0, 0, 53, 58, 3, 4, 2, 4, 54, 55, 10, 2, 0,
-		0, 55, 56, 5, 2, 0, 0, 56, 58, 3, 4, 2, 3, 57, 51, 1, 0, 0, 0, 57, 54,
-		1, 0, 0, 0, 58, 61, 1, 0, 0, 0, 59, 57, 1, 0, 0, 0, 59, 60, 1, 0, 0, 0,
-		60, 5, 1, 0, 0, 0, 61, 59, 1, 0, 0, 0, 62, 63, 6, 3, -1, 0, 63, 64, 5,
-		12, 0, 0, 64, 65, 3, 6, 3, 0, 65, 66, 5, 13, 0, 0, 66, 80, 1, 0, 0, 0,
-		67, 68, 3, 18, 9, 0, 68, 69, 3, 6, 3, 7, 69, 80, 1, 0, 0, 0, 70, 71, 3,
-		8, 4, 0, 71, 73, 3, 20, 10, 0, 72, 74, 3, 2, 1, 0, 73, 72, 1, 0, 0, 0,
-		73, 74, 1, 0, 0, 0, 74, 80, 1, 0, 0, 0, 75, 76, 3, 8, 4, 0, 76, 77, 3,
-		16, 8, 0, 77, 80, 1, 0, 0, 0, 78, 80, 3, 2, 1, 0, 79, 62, 1, 0, 0, 0, 79,
-		67, 1, 0, 0, 0, 79, 70, 1, 0, 0, 0, 79, 75, 1, 0, 0, 0, 79, 78, 1, 0, 0,
-		0, 80, 95, 1, 0, 0, 0, 81, 82, 10, 6, 0, 0, 82, 83, 3, 10, 5, 0, 83, 84,
-		3, 6, 3, 7, 84, 94, 1, 0, 0, 0, 85, 86, 10, 5, 0, 0, 86, 87, 3, 14, 7,
-		0, 87, 88, 3, 6, 3, 6, 88, 94, 1, 0, 0, 0, 89, 90, 10, 4, 0, 0, 90, 91,
-		3, 12, 6, 0, 91, 92, 3, 6, 3, 5, 92, 94, 1, 0, 0, 0, 93, 81, 1, 0, 0, 0,
-		93, 85, 1, 0, 0, 0, 93, 89, 1, 0, 0, 0, 94, 97, 1, 0, 0, 0, 95, 93, 1,
-		0, 0, 0, 95, 96, 1, 0, 0, 0, 96, 7, 1, 0, 0, 0, 97, 95, 1, 0, 0, 0, 98,
-		99, 5, 15, 0, 0, 99, 9, 1, 0, 0, 0, 100, 101, 5, 1, 0, 0, 101, 11, 1, 0,
-		0, 0, 102, 103, 1, 0, 0, 0, 103, 13, 1, 0, 0, 0, 104, 105, 5, 2, 0, 0,
-		105, 15, 1, 0, 0, 0, 106, 110, 5, 4, 0, 0, 107, 108, 5, 3, 0, 0, 108, 110,
-		5, 4, 0, 0, 109, 106, 1, 0, 0, 0, 109, 107, 1, 0, 0, 0, 110, 17, 1, 0,
-		0, 0, 111, 112, 5, 3, 0, 0, 112, 19, 1, 0, 0, 0, 113, 115, 5, 18, 0, 0,
-		114, 113, 1, 0, 0, 0, 115, 118, 1, 0, 0, 0, 116, 114, 1, 0, 0, 0, 116,
-		117, 1, 0, 0, 0, 117, 119, 1, 0, 0, 0, 118, 116, 1, 0, 0, 0, 119, 123,
-		7, 0, 0, 0, 120, 122, 5, 18, 0, 0, 121, 120, 1, 0, 0, 0, 122, 125, 1, 0,
-		0, 0, 123, 121, 1, 0, 0, 0, 123, 124, 1, 0, 0, 0, 124, 21, 1, 0, 0, 0,
-		125, 123, 1, 0, 0, 0, 126, 127, 7, 1, 0, 0, 127, 23, 1, 0, 0, 0, 12, 28,
-		38, 49, 57, 59, 73, 79, 93, 95, 109, 116, 123,
+		10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 1, 0, 1, 0, 1, 0, 1, 0, 3,
+		0, 33, 8, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 43,
+		8, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 3, 2, 54, 8,
+		2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 5, 2, 62, 8, 2, 10, 2, 12, 2, 65,
+		9, 2, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3,
+		1, 3, 4, 3, 79, 8, 3, 11, 3, 12, 3, 80, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1,
+		3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 3, 3, 94, 8, 3, 1, 3, 1, 3, 1, 3, 1, 3,
+		3, 3, 100, 8, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3,
+		1, 3, 1, 3, 1, 3, 5, 3, 114, 8, 3, 10, 3, 12, 3, 117, 9, 3, 1, 4, 1, 4,
+		1, 5, 1, 5, 1, 6, 1, 6, 1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 3, 8, 130, 8, 8,
+		1, 9, 1, 9, 1, 9, 3, 9, 135, 8, 9, 1, 10, 1, 10, 1, 10, 3, 10, 140, 8,
+		10, 1, 11, 1, 11, 1, 12, 5, 12, 145, 8, 12, 10, 12, 12, 12, 148, 9, 12,
+		1, 12, 1, 12, 5, 12, 152, 8, 12, 10, 12, 12, 12, 155, 9, 12, 1, 13, 1,
+		13, 1, 13, 0, 2, 4, 6, 14, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24,
+		26, 0, 2, 2, 0, 7, 15, 18, 18, 2, 0, 5, 6, 19, 21, 167, 0, 32, 1, 0, 0,
+		0, 2, 42, 1, 0, 0, 0, 4, 53, 1, 0, 0, 0, 6, 99, 1, 0, 0, 0, 8, 118, 1,
+		0, 0, 0, 10, 120, 1, 0, 0, 0, 12, 122, 1, 0, 0, 0, 14, 124, 1, 0, 0, 0,
+		16, 129, 1, 0, 0, 0, 18, 134, 1, 0, 0, 0, 20, 139, 1, 0, 0, 0, 22, 141,
+		1, 0, 0, 0, 24, 146, 1, 0, 0, 0, 26, 156, 1, 0, 0, 0, 28, 33, 5, 0, 0,
+		1, 29, 30, 3, 6, 3, 0, 30, 31, 5, 0, 0, 1, 31, 33, 1, 0, 0, 0, 32, 28,
+		1, 0, 0, 0, 32, 29, 1, 0, 0, 0, 33, 1, 1, 0, 0, 0, 34, 35, 5, 16, 0, 0,
+		35, 36, 3, 4, 2, 0, 36, 37, 5, 17, 0, 0, 37, 43, 1, 0, 0, 0, 38, 39, 3,
+		22, 11, 0, 39, 40, 3, 2, 1, 0, 40, 43, 1, 0, 0, 0, 41, 43, 3, 26, 13, 0,
+		42, 34, 1, 0, 0, 0, 42, 38, 1, 0, 0, 0, 42, 41, 1, 0, 0, 0, 43, 3, 1, 0,
+		0, 0, 44, 45, 6, 2, -1, 0, 45, 46, 5, 16, 0, 0, 46, 47, 3, 4, 2, 0, 47,
+		48, 5, 17, 0, 0, 48, 54, 1, 0, 0, 0, 49, 50, 3, 22, 11, 0, 50, 51, 3, 4,
+		2, 4, 51, 54, 1, 0, 0, 0, 52, 54, 3, 26, 13, 0, 53, 44, 1, 0, 0, 0, 53,
+		49, 1, 0, 0, 0, 53, 52, 1, 0, 0, 0, 54, 63, 1, 0, 0, 0, 55, 56, 10, 3,
+		0, 0, 56, 57, 5, 1, 0, 0, 57, 62, 3, 4, 2, 4, 58, 59, 10, 2, 0, 0, 59,
+		60, 5, 2, 0, 0, 60, 62, 3, 4, 2, 3, 61, 55, 1, 0, 0, 0, 61, 58, 1, 0, 0,
+		0, 62, 65, 1, 0, 0, 0, 63, 61, 1, 0, 0, 0, 63, 64, 1, 0, 0, 0, 64, 5, 1,
+		0, 0, 0, 65, 63, 1, 0, 0, 0, 66, 67, 6, 3, -1, 0, 67, 68, 5, 16, 0, 0,
+		68, 69, 3, 6, 3, 0, 69, 70, 5, 17, 0, 0, 70, 100, 1, 0, 0, 0, 71, 72, 3,
+		22, 11, 0, 72, 73, 3, 6, 3, 9, 73, 100, 1, 0, 0, 0, 74, 75, 3, 8, 4, 0,
+		75, 76, 3, 18, 9, 0, 76, 78, 5, 16, 0, 0, 77, 79, 3, 26, 13, 0, 78, 77,
+		1, 0, 0, 0, 79, 80, 1, 0, 0, 0, 80, 78, 1, 0, 0, 0, 80, 81, 1, 0, 0, 0,
+		81, 82, 1, 0, 0, 0, 82, 83, 5, 17, 0, 0, 83, 100, 1, 0, 0, 0, 84, 85, 3,
+		8, 4, 0, 85, 86, 3, 20, 10, 0, 86, 87, 3, 26, 13, 0, 87, 88, 5, 1, 0, 0,
+		88, 89, 3, 26, 13, 0, 89, 100, 1, 0, 0, 0, 90, 91, 3, 8, 4, 0, 91, 93,
+		3, 24, 12, 0, 92, 94, 3, 2, 1, 0, 93, 92, 1, 0, 0, 0, 93, 94, 1, 0, 0,
+		0, 94, 100, 1, 0, 0, 0, 95, 96, 3, 8, 4, 0, 96, 97, 3, 16, 8, 0, 97, 100,
+		1, 0, 0, 0, 98, 100, 3, 2, 1, 0, 99, 66, 1, 0, 0, 0, 99, 71, 1, 0, 0, 0,
+		99, 74, 1, 0, 0, 0, 99, 84, 1, 0, 0, 0, 99, 90, 1, 0, 0, 0, 99, 95, 1,
+		0, 0, 0, 99, 98, 1, 0, 0, 0, 100, 115, 1, 0, 0, 0, 101, 102, 10, 8, 0,
+		0, 102, 103, 3, 10, 5, 0, 103, 104, 3, 6, 3, 9, 104, 114, 1, 0, 0, 0, 105,
+		106, 10, 7, 0, 0, 106, 107, 3, 14, 7, 0, 107, 108, 3, 6, 3, 8, 108, 114,
+		1, 0, 0, 0, 109, 110, 10, 6, 0, 0, 110, 111, 3, 12, 6, 0, 111, 112, 3,
+		6, 3, 7, 112, 114, 1, 0, 0, 0, 113, 101, 1, 0, 0, 0, 113, 105, 1, 0, 0,
+		0, 113, 109, 1, 0, 0, 0, 114, 117, 1, 0, 0, 0, 115, 113, 1, 0, 0, 0, 115,
+		116, 1, 0, 0, 0, 116, 7, 1, 0, 0, 0, 117, 115, 1, 0, 0, 0, 118, 119, 5,
+		19, 0, 0, 119, 9, 1, 0, 0, 0, 120, 121, 5, 1, 0, 0, 121, 11, 1, 0, 0, 0,
+		122, 123, 1, 0, 0, 0, 123, 13, 1, 0, 0, 0, 124, 125, 5, 2, 0, 0, 125, 15,
+		1, 0, 0, 0, 126, 130, 5, 4, 0, 0, 127, 128, 5, 3, 0, 0, 128, 130, 5, 4,
+		0, 0, 129, 126, 1, 0, 0, 0, 129, 127, 1, 0, 0, 0, 130, 17, 1, 0, 0, 0,
+		131, 135, 5, 5, 0, 0, 132, 133, 5, 3, 0, 0, 133, 135, 5, 5, 0, 0, 134,
+		131, 1, 0, 0, 0, 134, 132, 1, 0, 0, 0, 135, 19, 1, 0, 0, 0, 136, 140, 5,
+		6, 0, 0, 137, 138, 5, 3, 0, 0, 138, 140, 5, 6, 0, 0, 139, 136, 1, 0, 0,
+		0, 139, 137, 1, 0, 0, 0, 140, 21, 1, 0, 0, 0, 141, 142, 5, 3, 0, 0, 142,
+		23, 1, 0, 0, 0, 143, 145, 5, 22, 0, 0, 144, 143, 1, 0, 0, 0, 145, 148,
+		1, 0, 0, 0, 146, 144, 1, 0, 0, 0, 146, 147, 1, 0, 0, 0, 147, 149, 1, 0,
+		0, 0, 148, 146, 1, 0, 0, 0, 149, 153, 7, 0, 0, 0, 150, 152, 5, 22, 0, 0,
+		151, 150, 1, 0, 0, 0, 152, 155, 1, 0, 0, 0, 153, 151, 1, 0, 0, 0, 153,
+		154, 1, 0, 0, 0, 154, 25, 1, 0, 0, 0, 155, 153, 1, 0, 0, 0, 156, 157, 7,
+		1, 0, 0, 157, 27, 1, 0, 0, 0, 15, 32, 42, 53, 61, 63, 80, 93, 99, 113,
+		115, 129, 134, 139, 146, 153,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -143,21 +158,25 @@ const (
 	SearchGrammarParserOR               = 2
 	SearchGrammarParserNOT              = 3
 	SearchGrammarParserEXISTS           = 4
-	SearchGrammarParserBANG             = 5
-	SearchGrammarParserEQ               = 6
-	SearchGrammarParserNEQ              = 7
-	SearchGrammarParserLT               = 8
-	SearchGrammarParserLTE              = 9
-	SearchGrammarParserGT               = 10
-	SearchGrammarParserGTE              = 11
-	SearchGrammarParserLPAREN           = 12
-	SearchGrammarParserRPAREN           = 13
-	SearchGrammarParserCOLON            = 14
-	SearchGrammarParserID               = 15
-	SearchGrammarParserSTRING           = 16
-	SearchGrammarParserVALUE            = 17
-	SearchGrammarParserWS               = 18
-	SearchGrammarParserERROR_CHARACTERS = 19
+	SearchGrammarParserIN               = 5
+	SearchGrammarParserBETWEEN          = 6
+	SearchGrammarParserBANG             = 7
+	SearchGrammarParserEQ               = 8
+	SearchGrammarParserNEQ              = 9
+	SearchGrammarParserREGEX_MATCH      = 10
+	SearchGrammarParserNOT_REGEX_MATCH  = 11
+	SearchGrammarParserLT               = 12
+	SearchGrammarParserLTE              = 13
+	SearchGrammarParserGT               = 14
+	SearchGrammarParserGTE              = 15
+	SearchGrammarParserLPAREN           = 16
+	SearchGrammarParserRPAREN           = 17
+	SearchGrammarParserCOLON            = 18
+	SearchGrammarParserID               = 19
+	SearchGrammarParserSTRING           = 20
+	SearchGrammarParserVALUE            = 21
+	SearchGrammarParserWS               = 22
+	SearchGrammarParserERROR_CHARACTERS = 23
 )
 
 // SearchGrammarParser rules.
@@ -171,9 +190,11 @@ const (
 	SearchGrammarParserRULE_implicit_and_op = 6
 	SearchGrammarParserRULE_or_op           = 7
 	SearchGrammarParserRULE_exists_op       = 8
-	SearchGrammarParserRULE_negation_op     = 9
-	SearchGrammarParserRULE_bin_op          = 10
-	SearchGrammarParserRULE_search_value    = 11
+	SearchGrammarParserRULE_in_op           = 9
+	SearchGrammarParserRULE_between_op      = 10
+	SearchGrammarParserRULE_negation_op     = 11
+	SearchGrammarParserRULE_bin_op          = 12
+	SearchGrammarParserRULE_search_value    = 13
 )
 
 // ISearch_queryContext is an interface to support dynamic dispatch.
@@ -266,7 +287,7 @@ func (s *Search_queryContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *SearchGrammarParser) Search_query() (localctx ISearch_queryContext) {
 	localctx = NewSearch_queryContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 0, SearchGrammarParserRULE_search_query)
-	p.SetState(28)
+	p.SetState(32)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -276,7 +297,7 @@ func (p *SearchGrammarParser) Search_query() (localctx ISearch_queryContext) {
 	case SearchGrammarParserEOF:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(24)
+			p.SetState(28)
 			p.Match(SearchGrammarParserEOF)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -284,14 +305,14 @@ func (p *SearchGrammarParser) Search_query() (localctx ISearch_queryContext) {
 			}
 		}
 
-	case SearchGrammarParserNOT, SearchGrammarParserLPAREN, SearchGrammarParserID, SearchGrammarParserSTRING, SearchGrammarParserVALUE:
+	case SearchGrammarParserNOT, SearchGrammarParserIN, SearchGrammarParserBETWEEN, SearchGrammarParserLPAREN, SearchGrammarParserID, SearchGrammarParserSTRING, SearchGrammarParserVALUE:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(25)
+			p.SetState(29)
 			p.search_expr(0)
 		}
 		{
-			p.SetState(26)
+			p.SetState(30)
 			p.Match(SearchGrammarParserEOF)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -536,7 +557,7 @@ func (s *Top_col_search_valueContext) ExitRule(listener antlr.ParseTreeListener)
 func (p *SearchGrammarParser) Top_col_expr() (localctx ITop_col_exprContext) {
 	localctx = NewTop_col_exprContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 2, SearchGrammarParserRULE_top_col_expr)
-	p.SetState(38)
+	p.SetState(42)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -547,7 +568,7 @@ func (p *SearchGrammarParser) Top_col_expr() (localctx ITop_col_exprContext) {
 		localctx = NewTop_paren_col_exprContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(30)
+			p.SetState(34)
 			p.Match(SearchGrammarParserLPAREN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -555,11 +576,11 @@ func (p *SearchGrammarParser) Top_col_expr() (localctx ITop_col_exprContext) {
 			}
 		}
 		{
-			p.SetState(31)
+			p.SetState(35)
 			p.col_expr(0)
 		}
 		{
-			p.SetState(32)
+			p.SetState(36)
 			p.Match(SearchGrammarParserRPAREN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -571,19 +592,19 @@ func (p *SearchGrammarParser) Top_col_expr() (localctx ITop_col_exprContext) {
 		localctx = NewNegated_top_col_exprContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(34)
+			p.SetState(38)
 			p.Negation_op()
 		}
 		{
-			p.SetState(35)
+			p.SetState(39)
 			p.Top_col_expr()
 		}
 
-	case SearchGrammarParserID, SearchGrammarParserSTRING, SearchGrammarParserVALUE:
+	case SearchGrammarParserIN, SearchGrammarParserBETWEEN, SearchGrammarParserID, SearchGrammarParserSTRING, SearchGrammarParserVALUE:
 		localctx = NewTop_col_search_valueContext(p, localctx)
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(37)
+			p.SetState(41)
 			p.Search_value()
 		}
 
@@ -987,7 +1008,7 @@ func (p *SearchGrammarParser) col_expr(_p int) (localctx ICol_exprContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(49)
+	p.SetState(53)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -1000,7 +1021,7 @@ func (p *SearchGrammarParser) col_expr(_p int) (localctx ICol_exprContext) {
 		_prevctx = localctx
 
 		{
-			p.SetState(41)
+			p.SetState(45)
 			p.Match(SearchGrammarParserLPAREN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1008,11 +1029,11 @@ func (p *SearchGrammarParser) col_expr(_p int) (localctx ICol_exprContext) {
 			}
 		}
 		{
-			p.SetState(42)
+			p.SetState(46)
 			p.col_expr(0)
 		}
 		{
-			p.SetState(43)
+			p.SetState(47)
 			p.Match(SearchGrammarParserRPAREN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1025,20 +1046,20 @@ func (p *SearchGrammarParser) col_expr(_p int) (localctx ICol_exprContext) {
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(45)
+			p.SetState(49)
 			p.Negation_op()
 		}
 		{
-			p.SetState(46)
+			p.SetState(50)
 			p.col_expr(4)
 		}
 
-	case SearchGrammarParserID, SearchGrammarParserSTRING, SearchGrammarParserVALUE:
+	case SearchGrammarParserIN, SearchGrammarParserBETWEEN, SearchGrammarParserID, SearchGrammarParserSTRING, SearchGrammarParserVALUE:
 		localctx = NewCol_search_valueContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(48)
+			p.SetState(52)
 			p.Search_value()
 		}
 
@@ -1047,7 +1068,7 @@ func (p *SearchGrammarParser) col_expr(_p int) (localctx ICol_exprContext) {
 		goto errorExit
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(59)
+	p.SetState(63)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -1062,7 +1083,7 @@ func (p *SearchGrammarParser) col_expr(_p int) (localctx ICol_exprContext) {
 				p.TriggerExitRuleEvent()
 			}
 			_prevctx = localctx
-			p.SetState(57)
+			p.SetState(61)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -1072,14 +1093,14 @@ func (p *SearchGrammarParser) col_expr(_p int) (localctx ICol_exprContext) {
 			case 1:
 				localctx = NewAnd_col_exprContext(p, NewCol_exprContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, SearchGrammarParserRULE_col_expr)
-				p.SetState(51)
+				p.SetState(55)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 3)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(52)
+					p.SetState(56)
 					p.Match(SearchGrammarParserAND)
 					if p.HasError() {
 						// Recognition error - abort rule
@@ -1087,21 +1108,21 @@ func (p *SearchGrammarParser) col_expr(_p int) (localctx ICol_exprContext) {
 					}
 				}
 				{
-					p.SetState(53)
+					p.SetState(57)
 					p.col_expr(4)
 				}
 
 			case 2:
 				localctx = NewOr_col_exprContext(p, NewCol_exprContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, SearchGrammarParserRULE_col_expr)
-				p.SetState(54)
+				p.SetState(58)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 2)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(55)
+					p.SetState(59)
 					p.Match(SearchGrammarParserOR)
 					if p.HasError() {
 						// Recognition error - abort rule
@@ -1109,7 +1130,7 @@ func (p *SearchGrammarParser) col_expr(_p int) (localctx ICol_exprContext) {
 					}
 				}
 				{
-					p.SetState(56)
+					p.SetState(60)
 					p.col_expr(3)
 				}
 
@@ -1118,7 +1139,7 @@ func (p *SearchGrammarParser) col_expr(_p int) (localctx ICol_exprContext) {
 			}
 
 		}
-		p.SetState(61)
+		p.SetState(65)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -1391,6 +1412,117 @@ func (s *And_search_exprContext) ExitRule(listener antlr.ParseTreeListener) {
 	}
 }
 
+type In_search_exprContext struct {
+	Search_exprContext
+}
+
+func NewIn_search_exprContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *In_search_exprContext {
+	var p = new(In_search_exprContext)
+
+	InitEmptySearch_exprContext(&p.Search_exprContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*Search_exprContext))
+
+	return p
+}
+
+func (s *In_search_exprContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *In_search_exprContext) Search_key() ISearch_keyContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ISearch_keyContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ISearch_keyContext)
+}
+
+func (s *In_search_exprContext) In_op() IIn_opContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IIn_opContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIn_opContext)
+}
+
+func (s *In_search_exprContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserLPAREN, 0)
+}
+
+func (s *In_search_exprContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserRPAREN, 0)
+}
+
+func (s *In_search_exprContext) AllSearch_value() []ISearch_valueContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(ISearch_valueContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]ISearch_valueContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(ISearch_valueContext); ok {
+			tst[i] = t.(ISearch_valueContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *In_search_exprContext) Search_value(i int) ISearch_valueContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ISearch_valueContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ISearch_valueContext)
+}
+
+func (s *In_search_exprContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.EnterIn_search_expr(s)
+	}
+}
+
+func (s *In_search_exprContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.ExitIn_search_expr(s)
+	}
+}
+
 type Or_search_exprContext struct {
 	Search_exprContext
 }
@@ -1627,6 +1759,113 @@ func (s *Exists_search_exprContext) ExitRule(listener antlr.ParseTreeListener) {
 	}
 }
 
+type Between_search_exprContext struct {
+	Search_exprContext
+}
+
+func NewBetween_search_exprContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *Between_search_exprContext {
+	var p = new(Between_search_exprContext)
+
+	InitEmptySearch_exprContext(&p.Search_exprContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*Search_exprContext))
+
+	return p
+}
+
+func (s *Between_search_exprContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *Between_search_exprContext) Search_key() ISearch_keyContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ISearch_keyContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ISearch_keyContext)
+}
+
+func (s *Between_search_exprContext) Between_op() IBetween_opContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IBetween_opContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IBetween_opContext)
+}
+
+func (s *Between_search_exprContext) AllSearch_value() []ISearch_valueContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(ISearch_valueContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]ISearch_valueContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(ISearch_valueContext); ok {
+			tst[i] = t.(ISearch_valueContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *Between_search_exprContext) Search_value(i int) ISearch_valueContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ISearch_valueContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ISearch_valueContext)
+}
+
+func (s *Between_search_exprContext) AND() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserAND, 0)
+}
+
+func (s *Between_search_exprContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.EnterBetween_search_expr(s)
+	}
+}
+
+func (s *Between_search_exprContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.ExitBetween_search_expr(s)
+	}
+}
+
 type Key_val_search_exprContext struct {
 	Search_exprContext
 }
@@ -1772,23 +2011,25 @@ func (p *SearchGrammarParser) search_expr(_p int) (localctx ISearch_exprContext)
 	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
 	_startState := 6
 	p.EnterRecursionRule(localctx, 6, SearchGrammarParserRULE_search_expr, _p)
+	var _la int
+
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(79)
+	p.SetState(99)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 6, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 7, p.GetParserRuleContext()) {
 	case 1:
 		localctx = NewParen_search_exprContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 
 		{
-			p.SetState(63)
+			p.SetState(67)
 			p.Match(SearchGrammarParserLPAREN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1796,11 +2037,11 @@ func (p *SearchGrammarParser) search_expr(_p int) (localctx ISearch_exprContext)
 			}
 		}
 		{
-			p.SetState(64)
+			p.SetState(68)
 			p.search_expr(0)
 		}
 		{
-			p.SetState(65)
+			p.SetState(69)
 			p.Match(SearchGrammarParserRPAREN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1813,32 +2054,110 @@ func (p *SearchGrammarParser) search_expr(_p int) (localctx ISearch_exprContext)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(67)
+			p.SetState(71)
 			p.Negation_op()
 		}
 		{
-			p.SetState(68)
-			p.search_expr(7)
+			p.SetState(72)
+			p.search_expr(9)
 		}
 
 	case 3:
+		localctx = NewIn_search_exprContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(74)
+			p.Search_key()
+		}
+		{
+			p.SetState(75)
+			p.In_op()
+		}
+		{
+			p.SetState(76)
+			p.Match(SearchGrammarParserLPAREN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(78)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		for ok := true; ok; ok = (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&3670112) != 0 {
+			{
+				p.SetState(77)
+				p.Search_value()
+			}
+
+			p.SetState(80)
+			p.GetErrorHandler().Sync(p)
+			if p.HasError() {
+				goto errorExit
+			}
+			_la = p.GetTokenStream().LA(1)
+		}
+		{
+			p.SetState(82)
+			p.Match(SearchGrammarParserRPAREN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case 4:
+		localctx = NewBetween_search_exprContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(84)
+			p.Search_key()
+		}
+		{
+			p.SetState(85)
+			p.Between_op()
+		}
+		{
+			p.SetState(86)
+			p.Search_value()
+		}
+		{
+			p.SetState(87)
+			p.Match(SearchGrammarParserAND)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(88)
+			p.Search_value()
+		}
+
+	case 5:
 		localctx = NewKey_val_search_exprContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(70)
+			p.SetState(90)
 			p.Search_key()
 		}
 		{
-			p.SetState(71)
+			p.SetState(91)
 			p.Bin_op()
 		}
-		p.SetState(73)
+		p.SetState(93)
 		p.GetErrorHandler().Sync(p)
 
-		if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 5, p.GetParserRuleContext()) == 1 {
+		if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 6, p.GetParserRuleContext()) == 1 {
 			{
-				p.SetState(72)
+				p.SetState(92)
 				p.Top_col_expr()
 			}
 
@@ -1846,25 +2165,25 @@ func (p *SearchGrammarParser) search_expr(_p int) (localctx ISearch_exprContext)
 			goto errorExit
 		}
 
-	case 4:
+	case 6:
 		localctx = NewExists_search_exprContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(75)
+			p.SetState(95)
 			p.Search_key()
 		}
 		{
-			p.SetState(76)
+			p.SetState(96)
 			p.Exists_op()
 		}
 
-	case 5:
+	case 7:
 		localctx = NewBody_search_exprContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(78)
+			p.SetState(98)
 			p.Top_col_expr()
 		}
 
@@ -1872,12 +2191,12 @@ func (p *SearchGrammarParser) search_expr(_p int) (localctx ISearch_exprContext)
 		goto errorExit
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(95)
+	p.SetState(115)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
-	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 8, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 9, p.GetParserRuleContext())
 	if p.HasError() {
 		goto errorExit
 	}
@@ -1887,65 +2206,65 @@ func (p *SearchGrammarParser) search_expr(_p int) (localctx ISearch_exprContext)
 				p.TriggerExitRuleEvent()
 			}
 			_prevctx = localctx
-			p.SetState(93)
+			p.SetState(113)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
 			}
 
-			switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 7, p.GetParserRuleContext()) {
+			switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 8, p.GetParserRuleContext()) {
 			case 1:
 				localctx = NewAnd_search_exprContext(p, NewSearch_exprContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, SearchGrammarParserRULE_search_expr)
-				p.SetState(81)
+				p.SetState(101)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 8)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 8)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(82)
+					p.SetState(102)
 					p.And_op()
 				}
 				{
-					p.SetState(83)
-					p.search_expr(7)
+					p.SetState(103)
+					p.search_expr(9)
 				}
 
 			case 2:
 				localctx = NewOr_search_exprContext(p, NewSearch_exprContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, SearchGrammarParserRULE_search_expr)
-				p.SetState(85)
+				p.SetState(105)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(86)
+					p.SetState(106)
 					p.Or_op()
 				}
 				{
-					p.SetState(87)
-					p.search_expr(6)
+					p.SetState(107)
+					p.search_expr(8)
 				}
 
 			case 3:
 				localctx = NewImplicit_and_search_exprContext(p, NewSearch_exprContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, SearchGrammarParserRULE_search_expr)
-				p.SetState(89)
+				p.SetState(109)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 4)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 4)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(90)
+					p.SetState(110)
 					p.Implicit_and_op()
 				}
 				{
-					p.SetState(91)
-					p.search_expr(5)
+					p.SetState(111)
+					p.search_expr(7)
 				}
 
 			case antlr.ATNInvalidAltNumber:
@@ -1953,12 +2272,12 @@ func (p *SearchGrammarParser) search_expr(_p int) (localctx ISearch_exprContext)
 			}
 
 		}
-		p.SetState(97)
+		p.SetState(117)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
-		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 8, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 9, p.GetParserRuleContext())
 		if p.HasError() {
 			goto errorExit
 		}
@@ -2052,7 +2371,7 @@ func (p *SearchGrammarParser) Search_key() (localctx ISearch_keyContext) {
 	p.EnterRule(localctx, 8, SearchGrammarParserRULE_search_key)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(98)
+		p.SetState(118)
 		p.Match(SearchGrammarParserID)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -2148,7 +2467,7 @@ func (p *SearchGrammarParser) And_op() (localctx IAnd_opContext) {
 	p.EnterRule(localctx, 10, SearchGrammarParserRULE_and_op)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(100)
+		p.SetState(120)
 		p.Match(SearchGrammarParserAND)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -2323,7 +2642,7 @@ func (p *SearchGrammarParser) Or_op() (localctx IOr_opContext) {
 	p.EnterRule(localctx, 14, SearchGrammarParserRULE_or_op)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(104)
+		p.SetState(124)
 		p.Match(SearchGrammarParserOR)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -2422,7 +2741,7 @@ func (s *Exists_opContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *SearchGrammarParser) Exists_op() (localctx IExists_opContext) {
 	localctx = NewExists_opContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 16, SearchGrammarParserRULE_exists_op)
-	p.SetState(109)
+	p.SetState(129)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -2432,7 +2751,7 @@ func (p *SearchGrammarParser) Exists_op() (localctx IExists_opContext) {
 	case SearchGrammarParserEXISTS:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(106)
+			p.SetState(126)
 			p.Match(SearchGrammarParserEXISTS)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2443,7 +2762,7 @@ func (p *SearchGrammarParser) Exists_op() (localctx IExists_opContext) {
 	case SearchGrammarParserNOT:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(107)
+			p.SetState(127)
 			p.Match(SearchGrammarParserNOT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2451,7 +2770,7 @@ func (p *SearchGrammarParser) Exists_op() (localctx IExists_opContext) {
 			}
 		}
 		{
-			p.SetState(108)
+			p.SetState(128)
 			p.Match(SearchGrammarParserEXISTS)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2477,6 +2796,272 @@ errorExit:
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
 
+// IIn_opContext is an interface to support dynamic dispatch.
+type IIn_opContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	IN() antlr.TerminalNode
+	NOT() antlr.TerminalNode
+
+	// IsIn_opContext differentiates from other interfaces.
+	IsIn_opContext()
+}
+
+type In_opContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyIn_opContext() *In_opContext {
+	var p = new(In_opContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SearchGrammarParserRULE_in_op
+	return p
+}
+
+func InitEmptyIn_opContext(p *In_opContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SearchGrammarParserRULE_in_op
+}
+
+func (*In_opContext) IsIn_opContext() {}
+
+func NewIn_opContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *In_opContext {
+	var p = new(In_opContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SearchGrammarParserRULE_in_op
+
+	return p
+}
+
+func (s *In_opContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *In_opContext) IN() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserIN, 0)
+}
+
+func (s *In_opContext) NOT() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserNOT, 0)
+}
+
+func (s *In_opContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *In_opContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *In_opContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.EnterIn_op(s)
+	}
+}
+
+func (s *In_opContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.ExitIn_op(s)
+	}
+}
+
+func (p *SearchGrammarParser) In_op() (localctx IIn_opContext) {
+	localctx = NewIn_opContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 18, SearchGrammarParserRULE_in_op)
+	p.SetState(134)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetTokenStream().LA(1) {
+	case SearchGrammarParserIN:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(131)
+			p.Match(SearchGrammarParserIN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case SearchGrammarParserNOT:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(132)
+			p.Match(SearchGrammarParserNOT)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(133)
+			p.Match(SearchGrammarParserIN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	default:
+		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		goto errorExit
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IBetween_opContext is an interface to support dynamic dispatch.
+type IBetween_opContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	BETWEEN() antlr.TerminalNode
+	NOT() antlr.TerminalNode
+
+	// IsBetween_opContext differentiates from other interfaces.
+	IsBetween_opContext()
+}
+
+type Between_opContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyBetween_opContext() *Between_opContext {
+	var p = new(Between_opContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SearchGrammarParserRULE_between_op
+	return p
+}
+
+func InitEmptyBetween_opContext(p *Between_opContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SearchGrammarParserRULE_between_op
+}
+
+func (*Between_opContext) IsBetween_opContext() {}
+
+func NewBetween_opContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *Between_opContext {
+	var p = new(Between_opContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SearchGrammarParserRULE_between_op
+
+	return p
+}
+
+func (s *Between_opContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *Between_opContext) BETWEEN() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserBETWEEN, 0)
+}
+
+func (s *Between_opContext) NOT() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserNOT, 0)
+}
+
+func (s *Between_opContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *Between_opContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *Between_opContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.EnterBetween_op(s)
+	}
+}
+
+func (s *Between_opContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.ExitBetween_op(s)
+	}
+}
+
+func (p *SearchGrammarParser) Between_op() (localctx IBetween_opContext) {
+	localctx = NewBetween_opContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 20, SearchGrammarParserRULE_between_op)
+	p.SetState(139)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetTokenStream().LA(1) {
+	case SearchGrammarParserBETWEEN:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(136)
+			p.Match(SearchGrammarParserBETWEEN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case SearchGrammarParserNOT:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(137)
+			p.Match(SearchGrammarParserNOT)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(138)
+			p.Match(SearchGrammarParserBETWEEN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	default:
+		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		goto errorExit
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
 // INegation_opContext is an interface to support dynamic dispatch.
 type INegation_opContext interface {
 	antlr.ParserRuleContext
@@ -2549,10 +3134,10 @@ func (s *Negation_opContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SearchGrammarParser) Negation_op() (localctx INegation_opContext) {
 	localctx = NewNegation_opContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 18, SearchGrammarParserRULE_negation_op)
+	p.EnterRule(localctx, 22, SearchGrammarParserRULE_negation_op)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(111)
+		p.SetState(141)
 		p.Match(SearchGrammarParserNOT)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -2584,6 +3169,8 @@ type IBin_opContext interface {
 	BANG() antlr.TerminalNode
 	EQ() antlr.TerminalNode
 	NEQ() antlr.TerminalNode
+	REGEX_MATCH() antlr.TerminalNode
+	NOT_REGEX_MATCH() antlr.TerminalNode
 	GT() antlr.TerminalNode
 	GTE() antlr.TerminalNode
 	LT() antlr.TerminalNode
@@ -2640,6 +3227,14 @@ func (s *Bin_opContext) NEQ() antlr.TerminalNode {
 	return s.GetToken(SearchGrammarParserNEQ, 0)
 }
 
+func (s *Bin_opContext) REGEX_MATCH() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserREGEX_MATCH, 0)
+}
+
+func (s *Bin_opContext) NOT_REGEX_MATCH() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserNOT_REGEX_MATCH, 0)
+}
+
 func (s *Bin_opContext) GT() antlr.TerminalNode {
 	return s.GetToken(SearchGrammarParserGT, 0)
 }
@@ -2690,13 +3285,13 @@ func (s *Bin_opContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SearchGrammarParser) Bin_op() (localctx IBin_opContext) {
 	localctx = NewBin_opContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 20, SearchGrammarParserRULE_bin_op)
+	p.EnterRule(localctx, 24, SearchGrammarParserRULE_bin_op)
 	var _la int
 
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(116)
+	p.SetState(146)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -2705,7 +3300,7 @@ func (p *SearchGrammarParser) Bin_op() (localctx IBin_opContext) {
 
 	for _la == SearchGrammarParserWS {
 		{
-			p.SetState(113)
+			p.SetState(143)
 			p.Match(SearchGrammarParserWS)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2713,7 +3308,7 @@ func (p *SearchGrammarParser) Bin_op() (localctx IBin_opContext) {
 			}
 		}
 
-		p.SetState(118)
+		p.SetState(148)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -2721,29 +3316,29 @@ func (p *SearchGrammarParser) Bin_op() (localctx IBin_opContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(119)
+		p.SetState(149)
 		_la = p.GetTokenStream().LA(1)
 
-		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&20448) != 0) {
+		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&327552) != 0) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
 			p.Consume()
 		}
 	}
-	p.SetState(123)
+	p.SetState(153)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
-	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 11, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 14, p.GetParserRuleContext())
 	if p.HasError() {
 		goto errorExit
 	}
 	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		if _alt == 1 {
 			{
-				p.SetState(120)
+				p.SetState(150)
 				p.Match(SearchGrammarParserWS)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -2752,12 +3347,12 @@ func (p *SearchGrammarParser) Bin_op() (localctx IBin_opContext) {
 			}
 
 		}
-		p.SetState(125)
+		p.SetState(155)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
-		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 11, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 14, p.GetParserRuleContext())
 		if p.HasError() {
 			goto errorExit
 		}
@@ -2787,6 +3382,8 @@ type ISearch_valueContext interface {
 	STRING() antlr.TerminalNode
 	ID() antlr.TerminalNode
 	VALUE() antlr.TerminalNode
+	IN() antlr.TerminalNode
+	BETWEEN() antlr.TerminalNode
 
 	// IsSearch_valueContext differentiates from other interfaces.
 	IsSearch_valueContext()
@@ -2836,6 +3433,14 @@ func (s *Search_valueContext) VALUE() antlr.TerminalNode {
 	return s.GetToken(SearchGrammarParserVALUE, 0)
 }
 
+func (s *Search_valueContext) IN() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserIN, 0)
+}
+
+func (s *Search_valueContext) BETWEEN() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserBETWEEN, 0)
+}
+
 func (s *Search_valueContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -2858,15 +3463,15 @@ func (s *Search_valueContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SearchGrammarParser) Search_value() (localctx ISearch_valueContext) {
 	localctx = NewSearch_valueContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, SearchGrammarParserRULE_search_value)
+	p.EnterRule(localctx, 26, SearchGrammarParserRULE_search_value)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(126)
+		p.SetState(156)
 		_la = p.GetTokenStream().LA(1)
 
-		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&229376) != 0) {
+		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&3670112) != 0) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -2924,13 +3529,13 @@ func (p *SearchGrammarParser) Col_expr_Sempred(localctx antlr.RuleContext, predI
 func (p *SearchGrammarParser) Search_expr_Sempred(localctx antlr.RuleContext, predIndex int) bool {
 	switch predIndex {
 	case 2:
-		return p.Precpred(p.GetParserRuleContext(), 6)
+		return p.Precpred(p.GetParserRuleContext(), 8)
 
 	case 3:
-		return p.Precpred(p.GetParserRuleContext(), 5)
+		return p.Precpred(p.GetParserRuleContext(), 7)
 
 	case 4:
-		return p.Precpred(p.GetParserRuleContext(), 4)
+		return p.Precpred(p.GetParserRuleContext(), 6)
 
 	default:
 		panic("No predicate with index: " + fmt.Sprint(predIndex))
@@ -26,6 +26,8 @@ var OperatorGreaterThan Operator = ">"
 var OperatorGreaterThanOrEqualTo Operator = ">="
 var OperatorLessThan Operator = "<"
 var OperatorLessThanOrEqualTo Operator = "<="
+var OperatorIn Operator = "IN"
+var OperatorBetween Operator = "BETWEEN"
 var OperatorAnd Operator = "AND"
 var OperatorOr Operator = "OR"
 
@@ -44,6 +46,8 @@ type SearchListener struct {
 
 	currentKey        string
 	currentOp         string
+	currentValues     []string
+	listValues        bool
 	rules             []string
 	ops               Filters
 	sb                *sqlbuilder.SelectBuilder
@@ -80,7 +84,7 @@ var attributeFilterTypeArray = "array"
 func getAttributeFilterExpr(key string, filterType attributeFilterType, col string, op Operator, value any) sqlbuilder.Builder {
 	var prefix, postfix string
 	if op == OperatorGreaterThan || op == OperatorGreaterThanOrEqualTo ||
-		op == OperatorLessThan || op == OperatorLessThanOrEqualTo {
+		op == OperatorLessThan || op == OperatorLessThanOrEqualTo || op == OperatorBetween {
 		prefix = "toFloat64OrNull("
 		postfix = ")"
 	}
@@ -222,17 +226,55 @@ func (s *SearchListener) ExitOr_search_expr(ctx *parser.Or_search_exprContext) {
 
 func (s *SearchListener) EnterKey_val_search_expr(ctx *parser.Key_val_search_exprContext) {}
 func (s *SearchListener) ExitKey_val_search_expr(ctx *parser.Key_val_search_exprContext) {
-	if s.currentOp == "!=" {
-		rule := s.rules[len(s.rules)-1]
-		s.rules = s.rules[:len(s.rules)-1]
-		s.rules = append(s.rules, fmt.Sprintf("NOT (%s)", rule))
+	if s.currentOp == "!=" || s.currentOp == "!~" {
+		s.negateLastRule()
+	}
+}
 
-		op := s.ops[len(s.ops)-1]
-		s.ops = s.ops[:len(s.ops)-1]
-		s.ops = append(s.ops, &FilterOperation{
-			Operator: OperatorNot,
-			Filters:  Filters{op},
-		})
+func (s *SearchListener) EnterIn_search_expr(ctx *parser.In_search_exprContext) {
+	s.currentValues = []string{}
+	s.listValues = true
+}
+func (s *SearchListener) ExitIn_search_expr(ctx *parser.In_search_exprContext) {
+	s.listValues = false
+
+	var values []string
+	for _, value := range s.currentValues {
+		if Unquote(value) != value {
+			values = append(values, Unquote(value))
+			continue
+		}
+		// Commas are lexed as part of unquoted values, e.g. (a, b) is lexed
+		// as `a,` and `b`, and (a,b) as a single value.
+		for _, v := range strings.Split(value, ",") {
+			if v != "" {
+				values = append(values, Unquote(v))
+			}
+		}
+	}
+	rules := len(s.rules)
+	s.appendInRules(values)
+
+	if s.currentOp == "NOT IN" && len(s.rules) > rules {
+		s.negateLastRule()
+	}
+}
+
+func (s *SearchListener) EnterBetween_search_expr(ctx *parser.Between_search_exprContext) {
+	s.currentValues = []string{}
+	s.listValues = true
+}
+func (s *SearchListener) ExitBetween_search_expr(ctx *parser.Between_search_exprContext) {
+	s.listValues = false
+	if len(s.currentValues) != 2 {
+		return
+	}
+
+	rules := len(s.rules)
+	s.appendBetweenRules(Unquote(s.currentValues[0]), Unquote(s.currentValues[1]))
+
+	if s.currentOp == "NOT BETWEEN" && len(s.rules) > rules {
+		s.negateLastRule()
 	}
 }
 
@@ -253,6 +295,32 @@ func (s *SearchListener) ExitOr_op(ctx *parser.Or_opContext)  {}
 func (s *SearchListener) EnterImplicit_and_op(ctx *parser.Implicit_and_opContext) {}
 func (s *SearchListener) ExitImplicit_and_op(ctx *parser.Implicit_and_opContext)  {}
 
+func (s *SearchListener) EnterIn_op(ctx *parser.In_opContext) {}
+func (s *SearchListener) ExitIn_op(ctx *parser.In_opContext) {
+	op := strings.ToUpper(ctx.GetText())
+	switch op {
+	case "IN":
+		s.currentOp = "IN"
+	case "NOTIN":
+		s.currentOp = "NOT IN"
+	default:
+		fmt.Printf("Unknown in operator: %s\n", op)
+	}
+}
+
+func (s *SearchListener) EnterBetween_op(ctx *parser.Between_opContext) {}
+func (s *SearchListener) ExitBetween_op(ctx *parser.Between_opContext) {
+	op := strings.ToUpper(ctx.GetText())
+	switch op {
+	case "BETWEEN":
+		s.currentOp = "BETWEEN"
+	case "NOTBETWEEN":
+		s.currentOp = "NOT BETWEEN"
+	default:
+		fmt.Printf("Unknown between operator: %s\n", op)
+	}
+}
+
 func (s *SearchListener) EnterNegation_op(ctx *parser.Negation_opContext) {}
 func (s *SearchListener) ExitNegation_op(ctx *parser.Negation_opContext)  {}
 
@@ -290,6 +358,10 @@ func (s *SearchListener) ExitExists_op(ctx *parser.Exists_opContext) {
 }
 
 func (s *SearchListener) EnterSearch_value(ctx *parser.Search_valueContext) {
+	if s.listValues {
+		s.currentValues = append(s.currentValues, ctx.GetText())
+		return
+	}
 	s.appendRules(ctx.GetText())
 }
 func (s *SearchListener) ExitSearch_value(ctx *parser.Search_valueContext) {}
@@ -332,30 +404,15 @@ func (s *SearchListener) appendRules(value string) {
 		return
 	}
 
-	extendedAttributeKey := false
-	filterKey, ok := s.tableConfig.KeysToColumns[s.currentKey]
-	if !ok {
-		extendedAttributeKey = true
-	}
-
-	// Special case for non-string columns
-	if value == "" && !extendedAttributeKey {
-		filterKey = fmt.Sprintf("toString(%s)", filterKey)
-	}
+	filterKey, attributesColumn, filterType, extendedAttributeKey := s.filterTarget(value == "")
 
-	attributesColumn := model.GetAttributesColumn(s.attributesColumns, s.currentKey)
-	filterType := attributeFilterTypeValue
-	if isArray := s.tableConfig.ArrayColumns[filterKey]; isArray {
-		extendedAttributeKey = true
-		attributesColumn = filterKey
-		filterType = attributeFilterTypeArray
-	} else if s.attributesList {
-		filterType = attributeFilterTypeMap
-	}
-
-	if s.currentOp == ":" || s.currentOp == "=" || s.currentOp == "!=" {
-		if strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/") {
-			value = strings.Trim(value, "/")
+	regexpOp := s.currentOp == "=~" || s.currentOp == "!~"
+	if regexpOp || s.currentOp == ":" || s.currentOp == "=" || s.currentOp == "!=" {
+		isRegexp := strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/")
+		if regexpOp || isRegexp {
+			if isRegexp {
+				value = strings.Trim(value, "/")
+			}
 			if extendedAttributeKey {
 				s.rules = append(s.rules, s.sb.Var(getAttributeFilterExpr(s.currentKey, filterType, attributesColumn, OperatorRegExp, value)))
 				s.ops = append(s.ops, &FilterOperation{
@@ -486,6 +543,104 @@ func (s *SearchListener) appendRules(value string) {
 	}
 }
 
+// filterTarget resolves the current key to either a table column or an
+// attributes column, along with how the attributes column should be filtered.
+func (s *SearchListener) filterTarget(emptyValue bool) (string, string, attributeFilterType, bool) {
+	extendedAttributeKey := false
+	filterKey, ok := s.tableConfig.KeysToColumns[s.currentKey]
+	if !ok {
+		extendedAttributeKey = true
+	}
+
+	// Special case for non-string columns
+	if emptyValue && !extendedAttributeKey {
+		filterKey = fmt.Sprintf("toString(%s)", filterKey)
+	}
+
+	attributesColumn := model.GetAttributesColumn(s.attributesColumns, s.currentKey)
+	filterType := attributeFilterTypeValue
+	if isArray := s.tableConfig.ArrayColumns[filterKey]; isArray {
+		extendedAttributeKey = true
+		attributesColumn = filterKey
+		filterType = attributeFilterTypeArray
+	} else if s.attributesList {
+		filterType = attributeFilterTypeMap
+	}
+
+	return filterKey, attributesColumn, filterType, extendedAttributeKey
+}
+
+func (s *SearchListener) appendInRules(values []string) {
+	if s.tableConfig.IgnoredFilters != nil && s.tableConfig.IgnoredFilters[s.currentKey] {
+		s.IgnoredFilters[s.currentKey] = strings.Join(values, ",")
+		return
+	}
+
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		args[i] = value
+	}
+
+	filterKey, attributesColumn, filterType, extendedAttributeKey := s.filterTarget(false)
+	if extendedAttributeKey {
+		s.rules = append(s.rules, s.sb.Var(getAttributeFilterExpr(s.currentKey, filterType, attributesColumn, OperatorIn, sqlbuilder.Tuple(args...))))
+		s.ops = append(s.ops, &FilterOperation{
+			Key:      s.currentKey,
+			Column:   attributesColumn,
+			Operator: OperatorIn,
+			Values:   values,
+		})
+	} else {
+		s.rules = append(s.rules, s.sb.In(fmt.Sprintf("toString(%s)", filterKey), args...))
+		s.ops = append(s.ops, &FilterOperation{
+			Key:      filterKey,
+			Operator: OperatorIn,
+			Values:   values,
+		})
+	}
+}
+
+func (s *SearchListener) appendBetweenRules(lower string, upper string) {
+	if s.tableConfig.IgnoredFilters != nil && s.tableConfig.IgnoredFilters[s.currentKey] {
+		s.IgnoredFilters[s.currentKey] = lower + "," + upper
+		return
+	}
+
+	filterKey, attributesColumn, filterType, extendedAttributeKey := s.filterTarget(false)
+	lower = NumericValue(lower, filterKey)
+	upper = NumericValue(upper, filterKey)
+	if extendedAttributeKey {
+		s.rules = append(s.rules, s.sb.Var(getAttributeFilterExpr(s.currentKey, filterType, attributesColumn, OperatorBetween, sqlbuilder.Buildf("%s AND %s", lower, upper))))
+		s.ops = append(s.ops, &FilterOperation{
+			Key:      s.currentKey,
+			Column:   attributesColumn,
+			Operator: OperatorBetween,
+			Values:   []string{lower, upper},
+		})
+	} else {
+		s.rules = append(s.rules, s.sb.Between(filterKey, lower, upper))
+		s.ops = append(s.ops, &FilterOperation{
+			Key:      filterKey,
+			Operator: OperatorBetween,
+			Values:   []string{lower, upper},
+		})
+	}
+}
+
+// negateLastRule wraps the most recently added rule and filter in a NOT.
+func (s *SearchListener) negateLastRule() {
+	rule := s.rules[len(s.rules)-1]
+	s.rules = s.rules[:len(s.rules)-1]
+	s.rules = append(s.rules, fmt.Sprintf("NOT (%s)", rule))
+
+	op := s.ops[len(s.ops)-1]
+	s.ops = s.ops[:len(s.ops)-1]
+	s.ops = append(s.ops, &FilterOperation{
+		Operator: OperatorNot,
+		Filters:  Filters{op},
+	})
+}
+
 func wildcardValue(value string) string {
 	value = strings.ReplaceAll(strings.ReplaceAll(value, "_", "\\_"), "*", "%")
 
@@ -58,6 +58,52 @@ func TestSpacesInSearch(t *testing.T) {
 	assert.Equal(t, "SELECT * FROM t WHERE NOT (toString(SpanName) = 'KafkaWorkersOnStrike')", sql)
 }
 
+func TestInSearch(t *testing.T) {
+	sql, _ := buildSqlForQuery("service_name IN (api, worker, \"cron job\")")
+	assert.Equal(t, "SELECT * FROM t WHERE toString(ServiceName) IN ('api', 'worker', 'cron job')", sql)
+
+	sql, _ = buildSqlForQuery("service_name in (api,worker)")
+	assert.Equal(t, "SELECT * FROM t WHERE toString(ServiceName) IN ('api', 'worker')", sql)
+
+	sql, _ = buildSqlForQuery("service_name NOT IN (api, worker) level=info")
+	assert.Equal(t, "SELECT * FROM t WHERE NOT (toString(ServiceName) IN ('api', 'worker')) AND toString(Level) = 'info'", sql)
+
+	sql, _ = buildSqlForQuery("http.method IN (GET, POST) custom NOT IN (a, b)")
+	assert.Equal(t, "SELECT * FROM t WHERE HttpAttributes['http.method'] IN ('GET', 'POST') AND NOT (TraceAttributes['custom'] IN ('a', 'b'))", sql)
+
+	sql, _ = buildSqlForQuery("service_name IN (\"api, v2\",worker ,cron)")
+	assert.Equal(t, "SELECT * FROM t WHERE toString(ServiceName) IN ('api, v2', 'worker', 'cron')", sql)
+}
+
+func TestCommasInSearch(t *testing.T) {
+	sql, _ := buildSqlForQuery("hello, world")
+	assert.Equal(t, "SELECT * FROM t WHERE SpanName ILIKE '%hello,%' AND hasTokenCaseInsensitive(SpanName, 'world')", sql)
+
+	sql, _ = buildSqlForQuery("connection refused, retrying")
+	assert.Equal(t, "SELECT * FROM t WHERE hasTokenCaseInsensitive(SpanName, 'connection') AND SpanName ILIKE '%refused,%' AND hasTokenCaseInsensitive(SpanName, 'retrying')", sql)
+
+	sql, _ = buildSqlForQuery("foo=bar,")
+	assert.Equal(t, "SELECT * FROM t WHERE TraceAttributes['foo'] = 'bar,'", sql)
+}
+
+func TestBetweenSearch(t *testing.T) {
+	sql, _ := buildSqlForQuery("duration BETWEEN 100ms AND 2s")
+	assert.Equal(t, "SELECT * FROM t WHERE Duration BETWEEN '100000000' AND '2000000000'", sql)
+
+	sql, _ = buildSqlForQuery("duration NOT BETWEEN 1 AND 5 OR custom between 1 and 5")
+	assert.Equal(t, "SELECT * FROM t WHERE (NOT (Duration BETWEEN '1' AND '5') OR toFloat64OrNull(TraceAttributes['custom']) BETWEEN '1' AND '5')", sql)
+}
+
+func TestRegexOperatorSearch(t *testing.T) {
+	sql, _ := buildSqlForQuery("service_name=~api-.* custom!~/^test/")
+	assert.Equal(t, "SELECT * FROM t WHERE ServiceName REGEXP 'api-.*' AND NOT (TraceAttributes['custom'] REGEXP '^test')", sql)
+}
+
+func TestInKeywordAsValue(t *testing.T) {
+	sql, _ := buildSqlForQuery("span_name=in")
+	assert.Equal(t, "SELECT * FROM t WHERE toString(SpanName) = 'in'", sql)
+}
+
 func buildSqlForQuery(query string) (string, error) {
 	sqlBuilder := sqlbuilder.NewSelectBuilder()
 	sb := sqlBuilder.Select("*").From("t")
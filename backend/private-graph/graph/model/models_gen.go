@@ -339,6 +339,28 @@ type ErrorGroupTagAggregationBucket struct {
 	Percent  float64 `json:"percent"`
 }
 
+type ErrorGroupingDryRun struct {
+	ErrorObjectCount  int                         `json:"error_object_count"`
+	CurrentGroupCount int                         `json:"current_group_count"`
+	NewGroupCount     int                         `json:"new_group_count"`
+	Groups            []*ErrorGroupingDryRunGroup `json:"groups"`
+}
+
+type ErrorGroupingDryRunGroup struct {
+	Fingerprint         string   `json:"fingerprint"`
+	Event               string   `json:"event"`
+	ErrorObjectCount    int      `json:"error_object_count"`
+	ErrorGroupSecureIds []string `json:"error_group_secure_ids"`
+}
+
+type ErrorGroupingRuleInput struct {
+	Name        string                `json:"name"`
+	Type        ErrorGroupingRuleType `json:"type"`
+	Pattern     *string               `json:"pattern,omitempty"`
+	Replacement *string               `json:"replacement,omitempty"`
+	Attribute   *string               `json:"attribute,omitempty"`
+}
+
 type ErrorMetadata struct {
 	ErrorID         int        `json:"error_id"`
 	SessionID       int        `json:"session_id"`
@@ -378,6 +400,14 @@ type ErrorObjectResults struct {
 	TotalCount   int64              `json:"totalCount"`
 }
 
+type ErrorOwnershipRuleInput struct {
+	Type      ErrorOwnershipRuleType `json:"type"`
+	Pattern   string                 `json:"pattern"`
+	Attribute *string                `json:"attribute,omitempty"`
+	AdminID   *int                   `json:"admin_id,omitempty"`
+	TeamID    *int                   `json:"team_id,omitempty"`
+}
+
 type ErrorTrace struct {
 	FileName                   *string             `json:"fileName,omitempty"`
 	LineNumber                 *int                `json:"lineNumber,omitempty"`
@@ -393,6 +423,24 @@ type ErrorTrace struct {
 	EnhancementVersion         *string             `json:"enhancementVersion,omitempty"`
 }
 
+type ExportJobInput struct {
+	Name        string                `json:"name"`
+	Product     ProductType           `json:"product"`
+	Query       string                `json:"query"`
+	Format      ExportFormat          `json:"format"`
+	Destination ExportDestinationType `json:"destination"`
+	Endpoint    *string               `json:"endpoint,omitempty"`
+	Region      *string               `json:"region,omitempty"`
+	Bucket      *string               `json:"bucket,omitempty"`
+	Prefix      *string               `json:"prefix,omitempty"`
+	AccessKeyID *string               `json:"access_key_id,omitempty"`
+	// Kept when omitted on update
+	SecretAccessKey *string `json:"secret_access_key,omitempty"`
+	Disabled        *bool   `json:"disabled,omitempty"`
+	// The first hour exported by a new job, defaults to the current hour
+	StartDate *time.Time `json:"start_date,omitempty"`
+}
+
 type FunnelStep struct {
 	Title string `json:"title"`
 	Query string `json:"query"`
@@ -592,6 +640,30 @@ type LogLine struct {
 	Labels    string    `json:"labels"`
 }
 
+type LogMetricRuleInput struct {
+	Name           string            `json:"name"`
+	Query          string            `json:"query"`
+	Type           LogMetricRuleType `json:"type"`
+	GroupBy        []string          `json:"group_by,omitempty"`
+	ValueAttribute *string           `json:"value_attribute,omitempty"`
+	ValuePattern   *string           `json:"value_pattern,omitempty"`
+	Buckets        []float64         `json:"buckets,omitempty"`
+}
+
+type LogPattern struct {
+	PatternID   string              `json:"pattern_id"`
+	ServiceName string              `json:"service_name"`
+	Template    string              `json:"template"`
+	Count       uint64              `json:"count"`
+	FirstSeen   time.Time           `json:"first_seen"`
+	Trend       []*LogPatternBucket `json:"trend"`
+}
+
+type LogPatternBucket struct {
+	Timestamp time.Time `json:"timestamp"`
+	Count     uint64    `json:"count"`
+}
+
 type LogsHistogram struct {
 	Buckets      []*LogsHistogramBucket `json:"buckets"`
 	TotalCount   uint64                 `json:"totalCount"`
@@ -625,6 +697,7 @@ type MetricBucket struct {
 	Column      string           `json:"column"`
 	MetricType  MetricAggregator `json:"metric_type"`
 	MetricValue *float64         `json:"metric_value,omitempty"`
+	Yhat        *float64         `json:"yhat,omitempty"`
 	YhatLower   *float64         `json:"yhat_lower,omitempty"`
 	YhatUpper   *float64         `json:"yhat_upper,omitempty"`
 }
@@ -787,12 +860,27 @@ type RageClickEventForProject struct {
 	UserProperties  string `json:"user_properties"`
 }
 
+type RedactionRuleInput struct {
+	Name          string             `json:"name"`
+	Pattern       *string            `json:"pattern,omitempty"`
+	Detector      *RedactionDetector `json:"detector,omitempty"`
+	AttributeKeys []string           `json:"attribute_keys,omitempty"`
+	Action        RedactionAction    `json:"action"`
+}
+
 type ReferrerTablePayload struct {
 	Host    string  `json:"host"`
 	Count   int     `json:"count"`
 	Percent float64 `json:"percent"`
 }
 
+type ReleaseAdoptionBucket struct {
+	Timestamp       time.Time `json:"timestamp"`
+	Sessions        uint64    `json:"sessions"`
+	TotalSessions   uint64    `json:"total_sessions"`
+	AdoptionPercent float64   `json:"adoption_percent"`
+}
+
 type S3File struct {
 	Key *string `json:"key,omitempty"`
 }
@@ -803,39 +891,43 @@ type SSOLogin struct {
 }
 
 type Sampling struct {
-	SessionSamplingRate    float64 `json:"session_sampling_rate"`
-	ErrorSamplingRate      float64 `json:"error_sampling_rate"`
-	LogSamplingRate        float64 `json:"log_sampling_rate"`
-	TraceSamplingRate      float64 `json:"trace_sampling_rate"`
-	MetricSamplingRate     float64 `json:"metric_sampling_rate"`
-	SessionMinuteRateLimit *int64  `json:"session_minute_rate_limit,omitempty"`
-	ErrorMinuteRateLimit   *int64  `json:"error_minute_rate_limit,omitempty"`
-	LogMinuteRateLimit     *int64  `json:"log_minute_rate_limit,omitempty"`
-	TraceMinuteRateLimit   *int64  `json:"trace_minute_rate_limit,omitempty"`
-	MetricMinuteRateLimit  *int64  `json:"metric_minute_rate_limit,omitempty"`
-	SessionExclusionQuery  *string `json:"session_exclusion_query,omitempty"`
-	ErrorExclusionQuery    *string `json:"error_exclusion_query,omitempty"`
-	LogExclusionQuery      *string `json:"log_exclusion_query,omitempty"`
-	TraceExclusionQuery    *string `json:"trace_exclusion_query,omitempty"`
-	MetricExclusionQuery   *string `json:"metric_exclusion_query,omitempty"`
+	SessionSamplingRate            float64 `json:"session_sampling_rate"`
+	ErrorSamplingRate              float64 `json:"error_sampling_rate"`
+	LogSamplingRate                float64 `json:"log_sampling_rate"`
+	TraceSamplingRate              float64 `json:"trace_sampling_rate"`
+	MetricSamplingRate             float64 `json:"metric_sampling_rate"`
+	SessionMinuteRateLimit         *int64  `json:"session_minute_rate_limit,omitempty"`
+	ErrorMinuteRateLimit           *int64  `json:"error_minute_rate_limit,omitempty"`
+	LogMinuteRateLimit             *int64  `json:"log_minute_rate_limit,omitempty"`
+	TraceMinuteRateLimit           *int64  `json:"trace_minute_rate_limit,omitempty"`
+	MetricMinuteRateLimit          *int64  `json:"metric_minute_rate_limit,omitempty"`
+	SessionExclusionQuery          *string `json:"session_exclusion_query,omitempty"`
+	ErrorExclusionQuery            *string `json:"error_exclusion_query,omitempty"`
+	LogExclusionQuery              *string `json:"log_exclusion_query,omitempty"`
+	TraceExclusionQuery            *string `json:"trace_exclusion_query,omitempty"`
+	MetricExclusionQuery           *string `json:"metric_exclusion_query,omitempty"`
+	TraceTailSamplingRate          float64 `json:"trace_tail_sampling_rate"`
+	TraceTailSamplingWindowSeconds int     `json:"trace_tail_sampling_window_seconds"`
 }
 
 type SamplingInput struct {
-	SessionSamplingRate    *float64 `json:"session_sampling_rate,omitempty"`
-	ErrorSamplingRate      *float64 `json:"error_sampling_rate,omitempty"`
-	LogSamplingRate        *float64 `json:"log_sampling_rate,omitempty"`
-	TraceSamplingRate      *float64 `json:"trace_sampling_rate,omitempty"`
-	MetricSamplingRate     *float64 `json:"metric_sampling_rate,omitempty"`
-	SessionMinuteRateLimit *int64   `json:"session_minute_rate_limit,omitempty"`
-	ErrorMinuteRateLimit   *int64   `json:"error_minute_rate_limit,omitempty"`
-	LogMinuteRateLimit     *int64   `json:"log_minute_rate_limit,omitempty"`
-	TraceMinuteRateLimit   *int64   `json:"trace_minute_rate_limit,omitempty"`
-	MetricMinuteRateLimit  *int64   `json:"metric_minute_rate_limit,omitempty"`
-	SessionExclusionQuery  *string  `json:"session_exclusion_query,omitempty"`
-	ErrorExclusionQuery    *string  `json:"error_exclusion_query,omitempty"`
-	LogExclusionQuery      *string  `json:"log_exclusion_query,omitempty"`
-	TraceExclusionQuery    *string  `json:"trace_exclusion_query,omitempty"`
-	MetricExclusionQuery   *string  `json:"metric_exclusion_query,omitempty"`
+	SessionSamplingRate            *float64 `json:"session_sampling_rate,omitempty"`
+	ErrorSamplingRate              *float64 `json:"error_sampling_rate,omitempty"`
+	LogSamplingRate                *float64 `json:"log_sampling_rate,omitempty"`
+	TraceSamplingRate              *float64 `json:"trace_sampling_rate,omitempty"`
+	MetricSamplingRate             *float64 `json:"metric_sampling_rate,omitempty"`
+	SessionMinuteRateLimit         *int64   `json:"session_minute_rate_limit,omitempty"`
+	ErrorMinuteRateLimit           *int64   `json:"error_minute_rate_limit,omitempty"`
+	LogMinuteRateLimit             *int64   `json:"log_minute_rate_limit,omitempty"`
+	TraceMinuteRateLimit           *int64   `json:"trace_minute_rate_limit,omitempty"`
+	MetricMinuteRateLimit          *int64   `json:"metric_minute_rate_limit,omitempty"`
+	SessionExclusionQuery          *string  `json:"session_exclusion_query,omitempty"`
+	ErrorExclusionQuery            *string  `json:"error_exclusion_query,omitempty"`
+	LogExclusionQuery              *string  `json:"log_exclusion_query,omitempty"`
+	TraceExclusionQuery            *string  `json:"trace_exclusion_query,omitempty"`
+	MetricExclusionQuery           *string  `json:"metric_exclusion_query,omitempty"`
+	TraceTailSamplingRate          *float64 `json:"trace_tail_sampling_rate,omitempty"`
+	TraceTailSamplingWindowSeconds *int     `json:"trace_tail_sampling_window_seconds,omitempty"`
 }
 
 type SanitizedAdmin struct {
@@ -869,6 +961,27 @@ type ServiceConnection struct {
 func (ServiceConnection) IsConnection()               {}
 func (this ServiceConnection) GetPageInfo() *PageInfo { return this.PageInfo }
 
+type ServiceDependencyEdge struct {
+	Caller      string  `json:"caller"`
+	Callee      string  `json:"callee"`
+	CalleeType  string  `json:"callee_type"`
+	CallCount   uint64  `json:"call_count"`
+	ErrorCount  uint64  `json:"error_count"`
+	ErrorRate   float64 `json:"error_rate"`
+	P50Duration float64 `json:"p50_duration"`
+	P95Duration float64 `json:"p95_duration"`
+}
+
+type ServiceDependencyGraph struct {
+	Nodes []*ServiceDependencyNode `json:"nodes"`
+	Edges []*ServiceDependencyEdge `json:"edges"`
+}
+
+type ServiceDependencyNode struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
 type ServiceEdge struct {
 	Cursor string       `json:"cursor"`
 	Node   *ServiceNode `json:"node"`
@@ -1077,6 +1190,12 @@ type TracePayload struct {
 	Errors []*TraceError `json:"errors"`
 }
 
+type TraceSamplingPolicyInput struct {
+	Name         string  `json:"name"`
+	Query        string  `json:"query"`
+	SamplingRate float64 `json:"sampling_rate"`
+}
+
 type TrackPropertyInput struct {
 	ID    *int   `json:"id,omitempty"`
 	Name  string `json:"name"`
@@ -1446,6 +1565,94 @@ func (e EnhancementSource) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type ErrorGroupingRuleType string
+
+const (
+	ErrorGroupingRuleTypeNormalizeMessage      ErrorGroupingRuleType = "NormalizeMessage"
+	ErrorGroupingRuleTypeIgnoreFrames          ErrorGroupingRuleType = "IgnoreFrames"
+	ErrorGroupingRuleTypeExceptionTypeTopFrame ErrorGroupingRuleType = "ExceptionTypeTopFrame"
+	ErrorGroupingRuleTypeCustomFingerprint     ErrorGroupingRuleType = "CustomFingerprint"
+)
+
+var AllErrorGroupingRuleType = []ErrorGroupingRuleType{
+	ErrorGroupingRuleTypeNormalizeMessage,
+	ErrorGroupingRuleTypeIgnoreFrames,
+	ErrorGroupingRuleTypeExceptionTypeTopFrame,
+	ErrorGroupingRuleTypeCustomFingerprint,
+}
+
+func (e ErrorGroupingRuleType) IsValid() bool {
+	switch e {
+	case ErrorGroupingRuleTypeNormalizeMessage, ErrorGroupingRuleTypeIgnoreFrames, ErrorGroupingRuleTypeExceptionTypeTopFrame, ErrorGroupingRuleTypeCustomFingerprint:
+		return true
+	}
+	return false
+}
+
+func (e ErrorGroupingRuleType) String() string {
+	return string(e)
+}
+
+func (e *ErrorGroupingRuleType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ErrorGroupingRuleType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ErrorGroupingRuleType", str)
+	}
+	return nil
+}
+
+func (e ErrorGroupingRuleType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ErrorOwnershipRuleType string
+
+const (
+	ErrorOwnershipRuleTypeFilePath    ErrorOwnershipRuleType = "FilePath"
+	ErrorOwnershipRuleTypeServiceName ErrorOwnershipRuleType = "ServiceName"
+	ErrorOwnershipRuleTypeAttribute   ErrorOwnershipRuleType = "Attribute"
+)
+
+var AllErrorOwnershipRuleType = []ErrorOwnershipRuleType{
+	ErrorOwnershipRuleTypeFilePath,
+	ErrorOwnershipRuleTypeServiceName,
+	ErrorOwnershipRuleTypeAttribute,
+}
+
+func (e ErrorOwnershipRuleType) IsValid() bool {
+	switch e {
+	case ErrorOwnershipRuleTypeFilePath, ErrorOwnershipRuleTypeServiceName, ErrorOwnershipRuleTypeAttribute:
+		return true
+	}
+	return false
+}
+
+func (e ErrorOwnershipRuleType) String() string {
+	return string(e)
+}
+
+func (e *ErrorOwnershipRuleType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ErrorOwnershipRuleType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ErrorOwnershipRuleType", str)
+	}
+	return nil
+}
+
+func (e ErrorOwnershipRuleType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type ErrorState string
 
 const (
@@ -1489,6 +1696,129 @@ func (e ErrorState) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type ExportDestinationType string
+
+const (
+	ExportDestinationTypeStorage      ExportDestinationType = "Storage"
+	ExportDestinationTypeS3Compatible ExportDestinationType = "S3Compatible"
+)
+
+var AllExportDestinationType = []ExportDestinationType{
+	ExportDestinationTypeStorage,
+	ExportDestinationTypeS3Compatible,
+}
+
+func (e ExportDestinationType) IsValid() bool {
+	switch e {
+	case ExportDestinationTypeStorage, ExportDestinationTypeS3Compatible:
+		return true
+	}
+	return false
+}
+
+func (e ExportDestinationType) String() string {
+	return string(e)
+}
+
+func (e *ExportDestinationType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ExportDestinationType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ExportDestinationType", str)
+	}
+	return nil
+}
+
+func (e ExportDestinationType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ExportFormat string
+
+const (
+	ExportFormatNdjson  ExportFormat = "NDJSON"
+	ExportFormatParquet ExportFormat = "Parquet"
+)
+
+var AllExportFormat = []ExportFormat{
+	ExportFormatNdjson,
+	ExportFormatParquet,
+}
+
+func (e ExportFormat) IsValid() bool {
+	switch e {
+	case ExportFormatNdjson, ExportFormatParquet:
+		return true
+	}
+	return false
+}
+
+func (e ExportFormat) String() string {
+	return string(e)
+}
+
+func (e *ExportFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ExportFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ExportFormat", str)
+	}
+	return nil
+}
+
+func (e ExportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type IngestKeyScope string
+
+const (
+	IngestKeyScopeFrontend IngestKeyScope = "Frontend"
+	IngestKeyScopeBackend  IngestKeyScope = "Backend"
+)
+
+var AllIngestKeyScope = []IngestKeyScope{
+	IngestKeyScopeFrontend,
+	IngestKeyScopeBackend,
+}
+
+func (e IngestKeyScope) IsValid() bool {
+	switch e {
+	case IngestKeyScopeFrontend, IngestKeyScopeBackend:
+		return true
+	}
+	return false
+}
+
+func (e IngestKeyScope) String() string {
+	return string(e)
+}
+
+func (e *IngestKeyScope) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = IngestKeyScope(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid IngestKeyScope", str)
+	}
+	return nil
+}
+
+func (e IngestKeyScope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type IngestReason string
 
 const (
@@ -1691,6 +2021,49 @@ func (e LogLevel) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type LogMetricRuleType string
+
+const (
+	LogMetricRuleTypeCount     LogMetricRuleType = "Count"
+	LogMetricRuleTypeSum       LogMetricRuleType = "Sum"
+	LogMetricRuleTypeHistogram LogMetricRuleType = "Histogram"
+)
+
+var AllLogMetricRuleType = []LogMetricRuleType{
+	LogMetricRuleTypeCount,
+	LogMetricRuleTypeSum,
+	LogMetricRuleTypeHistogram,
+}
+
+func (e LogMetricRuleType) IsValid() bool {
+	switch e {
+	case LogMetricRuleTypeCount, LogMetricRuleTypeSum, LogMetricRuleTypeHistogram:
+		return true
+	}
+	return false
+}
+
+func (e LogMetricRuleType) String() string {
+	return string(e)
+}
+
+func (e *LogMetricRuleType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LogMetricRuleType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LogMetricRuleType", str)
+	}
+	return nil
+}
+
+func (e LogMetricRuleType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type LogSource string
 
 const (
@@ -2191,6 +2564,137 @@ func (e ProductType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type RedactionAction string
+
+const (
+	RedactionActionMask RedactionAction = "Mask"
+	RedactionActionHash RedactionAction = "Hash"
+	RedactionActionDrop RedactionAction = "Drop"
+)
+
+var AllRedactionAction = []RedactionAction{
+	RedactionActionMask,
+	RedactionActionHash,
+	RedactionActionDrop,
+}
+
+func (e RedactionAction) IsValid() bool {
+	switch e {
+	case RedactionActionMask, RedactionActionHash, RedactionActionDrop:
+		return true
+	}
+	return false
+}
+
+func (e RedactionAction) String() string {
+	return string(e)
+}
+
+func (e *RedactionAction) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RedactionAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RedactionAction", str)
+	}
+	return nil
+}
+
+func (e RedactionAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type RedactionDetector string
+
+const (
+	RedactionDetectorEmail      RedactionDetector = "Email"
+	RedactionDetectorCardNumber RedactionDetector = "CardNumber"
+	RedactionDetectorJwt        RedactionDetector = "JWT"
+	RedactionDetectorAPIKey     RedactionDetector = "APIKey"
+)
+
+var AllRedactionDetector = []RedactionDetector{
+	RedactionDetectorEmail,
+	RedactionDetectorCardNumber,
+	RedactionDetectorJwt,
+	RedactionDetectorAPIKey,
+}
+
+func (e RedactionDetector) IsValid() bool {
+	switch e {
+	case RedactionDetectorEmail, RedactionDetectorCardNumber, RedactionDetectorJwt, RedactionDetectorAPIKey:
+		return true
+	}
+	return false
+}
+
+func (e RedactionDetector) String() string {
+	return string(e)
+}
+
+func (e *RedactionDetector) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RedactionDetector(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RedactionDetector", str)
+	}
+	return nil
+}
+
+func (e RedactionDetector) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ReleaseMetric string
+
+const (
+	ReleaseMetricNewErrorGroups       ReleaseMetric = "NewErrorGroups"
+	ReleaseMetricErrorRateChange      ReleaseMetric = "ErrorRateChange"
+	ReleaseMetricCrashFreeSessionRate ReleaseMetric = "CrashFreeSessionRate"
+)
+
+var AllReleaseMetric = []ReleaseMetric{
+	ReleaseMetricNewErrorGroups,
+	ReleaseMetricErrorRateChange,
+	ReleaseMetricCrashFreeSessionRate,
+}
+
+func (e ReleaseMetric) IsValid() bool {
+	switch e {
+	case ReleaseMetricNewErrorGroups, ReleaseMetricErrorRateChange, ReleaseMetricCrashFreeSessionRate:
+		return true
+	}
+	return false
+}
+
+func (e ReleaseMetric) String() string {
+	return string(e)
+}
+
+func (e *ReleaseMetric) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReleaseMetric(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReleaseMetric", str)
+	}
+	return nil
+}
+
+func (e ReleaseMetric) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type ReservedErrorGroupKey string
 
 const (
@@ -2456,6 +2960,7 @@ const (
 	ReservedLogKeyEnvironment     ReservedLogKey = "environment"
 	ReservedLogKeyLevel           ReservedLogKey = "level"
 	ReservedLogKeyMessage         ReservedLogKey = "message"
+	ReservedLogKeyPatternID       ReservedLogKey = "pattern_id"
 	ReservedLogKeySecureSessionID ReservedLogKey = "secure_session_id"
 	ReservedLogKeySpanID          ReservedLogKey = "span_id"
 	ReservedLogKeyTraceID         ReservedLogKey = "trace_id"
@@ -2469,6 +2974,7 @@ var AllReservedLogKey = []ReservedLogKey{
 	ReservedLogKeyEnvironment,
 	ReservedLogKeyLevel,
 	ReservedLogKeyMessage,
+	ReservedLogKeyPatternID,
 	ReservedLogKeySecureSessionID,
 	ReservedLogKeySpanID,
 	ReservedLogKeyTraceID,
@@ -2480,7 +2986,7 @@ var AllReservedLogKey = []ReservedLogKey{
 
 func (e ReservedLogKey) IsValid() bool {
 	switch e {
-	case ReservedLogKeyEnvironment, ReservedLogKeyLevel, ReservedLogKeyMessage, ReservedLogKeySecureSessionID, ReservedLogKeySpanID, ReservedLogKeyTraceID, ReservedLogKeySource, ReservedLogKeyServiceName, ReservedLogKeyServiceVersion, ReservedLogKeyTimestamp:
+	case ReservedLogKeyEnvironment, ReservedLogKeyLevel, ReservedLogKeyMessage, ReservedLogKeyPatternID, ReservedLogKeySecureSessionID, ReservedLogKeySpanID, ReservedLogKeyTraceID, ReservedLogKeySource, ReservedLogKeyServiceName, ReservedLogKeyServiceVersion, ReservedLogKeyTimestamp:
 		return true
 	}
 	return false
@@ -3362,18 +3868,20 @@ func (e ThresholdCondition) MarshalGQL(w io.Writer) {
 type ThresholdType string
 
 const (
-	ThresholdTypeConstant ThresholdType = "Constant"
-	ThresholdTypeAnomaly  ThresholdType = "Anomaly"
+	ThresholdTypeConstant   ThresholdType = "Constant"
+	ThresholdTypeAnomaly    ThresholdType = "Anomaly"
+	ThresholdTypeRegression ThresholdType = "Regression"
 )
 
 var AllThresholdType = []ThresholdType{
 	ThresholdTypeConstant,
 	ThresholdTypeAnomaly,
+	ThresholdTypeRegression,
 }
 
 func (e ThresholdType) IsValid() bool {
 	switch e {
-	case ThresholdTypeConstant, ThresholdTypeAnomaly:
+	case ThresholdTypeConstant, ThresholdTypeAnomaly, ThresholdTypeRegression:
 		return true
 	}
 	return false
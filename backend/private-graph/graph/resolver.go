@@ -76,6 +76,7 @@ import (
 	"github.com/highlight-run/highlight/backend/model"
 	"github.com/highlight-run/highlight/backend/pricing"
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/promql"
 	"github.com/highlight-run/highlight/backend/storage"
 	"github.com/highlight-run/highlight/backend/util"
 	"github.com/highlight/highlight/sdk/highlight-go"
@@ -1346,6 +1347,21 @@ func (r *Resolver) validateAdminRole(ctx context.Context, workspaceID int) error
 	return nil
 }
 
+// validateReleaseAlert returns an error if the release metric cannot be alerted on for the product type.
+func validateReleaseAlert(productType modelInputs.ProductType, releaseMetric modelInputs.ReleaseMetric) error {
+	switch releaseMetric {
+	case modelInputs.ReleaseMetricNewErrorGroups, modelInputs.ReleaseMetricErrorRateChange:
+		if productType != modelInputs.ProductTypeErrors {
+			return e.Errorf("%s release alerts are only supported for errors", releaseMetric)
+		}
+	case modelInputs.ReleaseMetricCrashFreeSessionRate:
+		if productType != modelInputs.ProductTypeSessions {
+			return e.Errorf("%s release alerts are only supported for sessions", releaseMetric)
+		}
+	}
+	return nil
+}
+
 // GenerateRandomBytes returns securely generated random bytes.
 // It will return an error if the system's secure random
 // number generator fails to function correctly, in which
@@ -1843,6 +1859,14 @@ func (r *Resolver) AssetHandler(w http.ResponseWriter, req *http.Request) {
 	http.Redirect(w, req, url, http.StatusFound)
 }
 
+// PrometheusRoutes serves the prometheus HTTP API over the metrics of the `project_id` url parameter.
+func (r *Resolver) PrometheusRoutes(router chi.Router) {
+	promql.NewAPI(r.ClickhouseClient, func(ctx context.Context, projectID int) error {
+		_, err := r.isUserInProjectOrDemoProject(ctx, projectID)
+		return err
+	}).Routes(router)
+}
+
 func (r *Resolver) StripeWebhook(ctx context.Context, endpointSecret string) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, req *http.Request) {
 		const MaxBodyBytes = int64(65536)
@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/rand"
 	"net/url"
@@ -20,6 +21,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/DmitriyVTitov/size"
 	"github.com/PaesslerAG/jsonpath"
 	mpeTypes "github.com/aws/aws-sdk-go-v2/service/marketplaceentitlementservice/types"
@@ -39,6 +41,7 @@ import (
 	"github.com/highlight-run/highlight/backend/clickup"
 	Email "github.com/highlight-run/highlight/backend/email"
 	"github.com/highlight-run/highlight/backend/env"
+	"github.com/highlight-run/highlight/backend/errorgroups"
 	"github.com/highlight-run/highlight/backend/integrations/cloudflare"
 	"github.com/highlight-run/highlight/backend/integrations/height"
 	kafka_queue "github.com/highlight-run/highlight/backend/kafka-queue"
@@ -53,6 +56,7 @@ import (
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
 	"github.com/highlight-run/highlight/backend/prompts"
 	"github.com/highlight-run/highlight/backend/redis"
+	"github.com/highlight-run/highlight/backend/stacktraces"
 	"github.com/highlight-run/highlight/backend/storage"
 	"github.com/highlight-run/highlight/backend/store"
 	"github.com/highlight-run/highlight/backend/util"
@@ -312,6 +316,13 @@ func (r *graphResolver) Expressions(ctx context.Context, obj *model.Graph) (expr
 	return
 }
 
+// Products is the resolver for the products field.
+func (r *ingestKeyResolver) Products(ctx context.Context, obj *model.IngestKey) ([]modelInputs.ProductType, error) {
+	return lo.Map(obj.Products, func(p string, _ int) modelInputs.ProductType {
+		return modelInputs.ProductType(p)
+	}), nil
+}
+
 // ChannelsToNotify is the resolver for the ChannelsToNotify field.
 func (r *logAlertResolver) ChannelsToNotify(ctx context.Context, obj *model.LogAlert) ([]*modelInputs.SanitizedSlackChannel, error) {
 	return obj.GetChannelsToNotify()
@@ -348,6 +359,11 @@ func (r *logAlertResolver) DailyFrequency(ctx context.Context, obj *model.LogAle
 	return obj.GetDailyLogEventFrequency(r.DB, obj.ID)
 }
 
+// Buckets is the resolver for the buckets field.
+func (r *logMetricRuleResolver) Buckets(ctx context.Context, obj *model.LogMetricRule) ([]float64, error) {
+	return obj.Buckets, nil
+}
+
 // Event is the resolver for the event field.
 func (r *matchedErrorObjectResolver) Event(ctx context.Context, obj *model.MatchedErrorObject) ([]*string, error) {
 	return util.JsonStringToStringArray(obj.Event), nil
@@ -668,18 +684,20 @@ func (r *mutationResolver) EditProjectSettings(ctx context.Context, projectID in
 	allProjectSettings.FilterSessionsWithoutError = projectFilterSettings.FilterSessionsWithoutError
 	allProjectSettings.AutoResolveStaleErrorsDayInterval = projectFilterSettings.AutoResolveStaleErrorsDayInterval
 	allProjectSettings.Sampling = &modelInputs.Sampling{
-		SessionSamplingRate:    projectFilterSettings.SessionSamplingRate,
-		ErrorSamplingRate:      projectFilterSettings.SessionSamplingRate,
-		LogSamplingRate:        projectFilterSettings.SessionSamplingRate,
-		TraceSamplingRate:      projectFilterSettings.SessionSamplingRate,
-		SessionMinuteRateLimit: projectFilterSettings.SessionMinuteRateLimit,
-		ErrorMinuteRateLimit:   projectFilterSettings.ErrorMinuteRateLimit,
-		LogMinuteRateLimit:     projectFilterSettings.LogMinuteRateLimit,
-		TraceMinuteRateLimit:   projectFilterSettings.TraceMinuteRateLimit,
-		SessionExclusionQuery:  projectFilterSettings.SessionExclusionQuery,
-		ErrorExclusionQuery:    projectFilterSettings.ErrorExclusionQuery,
-		LogExclusionQuery:      projectFilterSettings.LogExclusionQuery,
-		TraceExclusionQuery:    projectFilterSettings.TraceExclusionQuery,
+		SessionSamplingRate:            projectFilterSettings.SessionSamplingRate,
+		ErrorSamplingRate:              projectFilterSettings.SessionSamplingRate,
+		LogSamplingRate:                projectFilterSettings.SessionSamplingRate,
+		TraceSamplingRate:              projectFilterSettings.SessionSamplingRate,
+		SessionMinuteRateLimit:         projectFilterSettings.SessionMinuteRateLimit,
+		ErrorMinuteRateLimit:           projectFilterSettings.ErrorMinuteRateLimit,
+		LogMinuteRateLimit:             projectFilterSettings.LogMinuteRateLimit,
+		TraceMinuteRateLimit:           projectFilterSettings.TraceMinuteRateLimit,
+		SessionExclusionQuery:          projectFilterSettings.SessionExclusionQuery,
+		ErrorExclusionQuery:            projectFilterSettings.ErrorExclusionQuery,
+		LogExclusionQuery:              projectFilterSettings.LogExclusionQuery,
+		TraceExclusionQuery:            projectFilterSettings.TraceExclusionQuery,
+		TraceTailSamplingRate:          projectFilterSettings.TraceTailSamplingRate,
+		TraceTailSamplingWindowSeconds: projectFilterSettings.TraceTailSamplingWindowSeconds,
 	}
 
 	return &allProjectSettings, nil
@@ -922,7 +940,7 @@ func (r *mutationResolver) MarkSessionAsViewed(ctx context.Context, secureID str
 }
 
 // UpdateErrorGroupState is the resolver for the updateErrorGroupState field.
-func (r *mutationResolver) UpdateErrorGroupState(ctx context.Context, secureID string, state modelInputs.ErrorState, snoozedUntil *time.Time) (*model.ErrorGroup, error) {
+func (r *mutationResolver) UpdateErrorGroupState(ctx context.Context, secureID string, state modelInputs.ErrorState, snoozedUntil *time.Time, resolvedInVersion *string, resolveInNextRelease *bool) (*model.ErrorGroup, error) {
 	errorGroup, err := r.canAdminModifyErrorGroup(ctx, secureID)
 	if err != nil {
 		return nil, err
@@ -930,12 +948,68 @@ func (r *mutationResolver) UpdateErrorGroupState(ctx context.Context, secureID s
 	admin, err := r.getCurrentAdmin(ctx)
 
 	return r.Store.UpdateErrorGroupStateByAdmin(ctx, *admin, store.UpdateErrorGroupParams{
-		ID:           errorGroup.ID,
-		State:        state,
-		SnoozedUntil: snoozedUntil,
+		ID:                   errorGroup.ID,
+		State:                state,
+		SnoozedUntil:         snoozedUntil,
+		ResolvedInVersion:    resolvedInVersion,
+		ResolveInNextRelease: pointy.BoolValue(resolveInNextRelease, false),
+	})
+}
+
+// AssignErrorGroup is the resolver for the assignErrorGroup field.
+func (r *mutationResolver) AssignErrorGroup(ctx context.Context, secureID string, adminID *int, teamID *int) (*model.ErrorGroup, error) {
+	errorGroup, err := r.canAdminModifyErrorGroup(ctx, secureID)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := r.getCurrentAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	project, err := r.Store.GetProject(ctx, errorGroup.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Store.AssignErrorGroupByAdmin(ctx, *admin, project.WorkspaceID, store.AssignErrorGroupParams{
+		ID:      errorGroup.ID,
+		AdminID: adminID,
+		TeamID:  teamID,
 	})
 }
 
+// MergeErrorGroups is the resolver for the mergeErrorGroups field.
+func (r *mutationResolver) MergeErrorGroups(ctx context.Context, secureID string, intoSecureID string) (*model.ErrorGroup, error) {
+	errorGroup, err := r.canAdminModifyErrorGroup(ctx, secureID)
+	if err != nil {
+		return nil, err
+	}
+	intoErrorGroup, err := r.canAdminModifyErrorGroup(ctx, intoSecureID)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := r.getCurrentAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Store.MergeErrorGroups(ctx, *admin, errorGroup, intoErrorGroup)
+}
+
+// UnmergeErrorGroup is the resolver for the unmergeErrorGroup field.
+func (r *mutationResolver) UnmergeErrorGroup(ctx context.Context, secureID string) (*model.ErrorGroup, error) {
+	errorGroup, err := r.canAdminModifyErrorGroup(ctx, secureID)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := r.getCurrentAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Store.UnmergeErrorGroup(ctx, *admin, errorGroup)
+}
+
 // DeleteProject is the resolver for the deleteProject field.
 func (r *mutationResolver) DeleteProject(ctx context.Context, id int) (*bool, error) {
 	project, err := r.isUserInProject(ctx, id)
@@ -3407,7 +3481,7 @@ func (r *mutationResolver) UpdateMetricMonitor(ctx context.Context, metricMonito
 }
 
 // CreateAlert is the resolver for the createAlert field.
-func (r *mutationResolver) CreateAlert(ctx context.Context, projectID int, name string, productType modelInputs.ProductType, functionType modelInputs.MetricAggregator, functionColumn *string, query *string, groupByKey *string, defaultArg *bool, thresholdValue *float64, thresholdWindow *int, thresholdCooldown *int, thresholdType *modelInputs.ThresholdType, thresholdCondition *modelInputs.ThresholdCondition, destinations []*modelInputs.AlertDestinationInput, sql *string) (*model.Alert, error) {
+func (r *mutationResolver) CreateAlert(ctx context.Context, projectID int, name string, productType modelInputs.ProductType, functionType modelInputs.MetricAggregator, functionColumn *string, query *string, groupByKey *string, defaultArg *bool, thresholdValue *float64, thresholdWindow *int, thresholdCooldown *int, thresholdType *modelInputs.ThresholdType, thresholdCondition *modelInputs.ThresholdCondition, destinations []*modelInputs.AlertDestinationInput, sql *string, releaseMetric *modelInputs.ReleaseMetric) (*model.Alert, error) {
 	project, err := r.isUserInProject(ctx, projectID)
 	admin, _ := r.getCurrentAdmin(ctx)
 	if err != nil {
@@ -3423,6 +3497,14 @@ func (r *mutationResolver) CreateAlert(ctx context.Context, projectID int, name
 	if thresholdType != nil {
 		thresholdTypeDeref = *thresholdType
 	}
+	if thresholdTypeDeref == modelInputs.ThresholdTypeRegression && productType != modelInputs.ProductTypeErrors {
+		return nil, e.New("regression alerts are only supported for errors")
+	}
+	if releaseMetric != nil {
+		if err := validateReleaseAlert(productType, *releaseMetric); err != nil {
+			return nil, err
+		}
+	}
 
 	thresholdConditionDeref := modelInputs.ThresholdConditionAbove
 	if thresholdCondition != nil {
@@ -3446,6 +3528,7 @@ func (r *mutationResolver) CreateAlert(ctx context.Context, projectID int, name
 		ThresholdCondition: thresholdConditionDeref,
 		LastAdminToEditID:  admin.ID,
 		Sql:                sql,
+		ReleaseMetric:      releaseMetric,
 	}
 
 	createdAlert := &model.Alert{}
@@ -3484,13 +3567,22 @@ func (r *mutationResolver) CreateAlert(ctx context.Context, projectID int, name
 }
 
 // UpdateAlert is the resolver for the updateAlert field.
-func (r *mutationResolver) UpdateAlert(ctx context.Context, projectID int, alertID int, name *string, productType *modelInputs.ProductType, functionType *modelInputs.MetricAggregator, functionColumn *string, query *string, groupByKey *string, thresholdValue *float64, thresholdWindow *int, thresholdCooldown *int, thresholdType *modelInputs.ThresholdType, thresholdCondition *modelInputs.ThresholdCondition, destinations []*modelInputs.AlertDestinationInput, sql *string) (*model.Alert, error) {
+func (r *mutationResolver) UpdateAlert(ctx context.Context, projectID int, alertID int, name *string, productType *modelInputs.ProductType, functionType *modelInputs.MetricAggregator, functionColumn *string, query *string, groupByKey *string, thresholdValue *float64, thresholdWindow *int, thresholdCooldown *int, thresholdType *modelInputs.ThresholdType, thresholdCondition *modelInputs.ThresholdCondition, destinations []*modelInputs.AlertDestinationInput, sql *string, releaseMetric *modelInputs.ReleaseMetric) (*model.Alert, error) {
 	project, err := r.isUserInProject(ctx, projectID)
 	admin, _ := r.getCurrentAdmin(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	if thresholdType != nil && *thresholdType == modelInputs.ThresholdTypeRegression && productType != nil && *productType != modelInputs.ProductTypeErrors {
+		return nil, e.New("regression alerts are only supported for errors")
+	}
+	if releaseMetric != nil && productType != nil {
+		if err := validateReleaseAlert(*productType, *releaseMetric); err != nil {
+			return nil, err
+		}
+	}
+
 	alertUpdates := map[string]interface{}{
 		"MetricId":           uuid.New().String(),
 		"LastAdminToEditID":  admin.ID,
@@ -3506,6 +3598,7 @@ func (r *mutationResolver) UpdateAlert(ctx context.Context, projectID int, alert
 		"ThresholdType":      thresholdType,
 		"ThresholdCondition": thresholdCondition,
 		"Sql":                sql,
+		"ReleaseMetric":      releaseMetric,
 	}
 
 	alert := &model.Alert{}
@@ -4655,6 +4748,232 @@ func (r *mutationResolver) EditServiceGithubSettings(ctx context.Context, id int
 	return service, nil
 }
 
+// CreateIngestKey is the resolver for the createIngestKey field.
+func (r *mutationResolver) CreateIngestKey(ctx context.Context, projectID int, name string, scope modelInputs.IngestKeyScope, products []modelInputs.ProductType) (*model.IngestKeyWithSecret, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validateAdminRole(ctx, project.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	return r.Store.CreateIngestKey(ctx, project.ID, name, scope, products)
+}
+
+// RotateIngestKey is the resolver for the rotateIngestKey field.
+func (r *mutationResolver) RotateIngestKey(ctx context.Context, projectID int, id int) (*model.IngestKeyWithSecret, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validateAdminRole(ctx, project.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	return r.Store.RotateIngestKey(ctx, project.ID, id)
+}
+
+// RevokeIngestKey is the resolver for the revokeIngestKey field.
+func (r *mutationResolver) RevokeIngestKey(ctx context.Context, projectID int, id int) (*model.IngestKey, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validateAdminRole(ctx, project.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	return r.Store.RevokeIngestKey(ctx, project.ID, id)
+}
+
+// UpdateTraceSamplingPolicies is the resolver for the updateTraceSamplingPolicies field.
+func (r *mutationResolver) UpdateTraceSamplingPolicies(ctx context.Context, projectID int, policies []*modelInputs.TraceSamplingPolicyInput) ([]*model.TraceSamplingPolicy, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validateAdminRole(ctx, project.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	workspaceSettings, err := r.Store.GetAllWorkspaceSettingsByProject(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !workspaceSettings.EnableIngestSampling {
+		return nil, e.New("ingest sampling is not enabled for this workspace")
+	}
+
+	return r.Store.UpdateTraceSamplingPolicies(ctx, project.ID, policies)
+}
+
+// UpdateLogMetricRules is the resolver for the updateLogMetricRules field.
+func (r *mutationResolver) UpdateLogMetricRules(ctx context.Context, projectID int, rules []*modelInputs.LogMetricRuleInput) ([]*model.LogMetricRule, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validateAdminRole(ctx, project.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	return r.Store.UpdateLogMetricRules(ctx, project.ID, rules)
+}
+
+// CreateExportJob is the resolver for the createExportJob field.
+func (r *mutationResolver) CreateExportJob(ctx context.Context, projectID int, job modelInputs.ExportJobInput) (*model.ExportJob, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validateAdminRole(ctx, project.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	return r.Store.CreateExportJob(ctx, project.ID, job)
+}
+
+// UpdateExportJob is the resolver for the updateExportJob field.
+func (r *mutationResolver) UpdateExportJob(ctx context.Context, projectID int, id int, job modelInputs.ExportJobInput) (*model.ExportJob, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validateAdminRole(ctx, project.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	return r.Store.UpdateExportJob(ctx, project.ID, id, job)
+}
+
+// DeleteExportJob is the resolver for the deleteExportJob field.
+func (r *mutationResolver) DeleteExportJob(ctx context.Context, projectID int, id int) (bool, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return false, err
+	}
+	if err := r.validateAdminRole(ctx, project.WorkspaceID); err != nil {
+		return false, err
+	}
+
+	if err := r.Store.DeleteExportJob(ctx, project.ID, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UpdateErrorGroupingRules is the resolver for the updateErrorGroupingRules field.
+func (r *mutationResolver) UpdateErrorGroupingRules(ctx context.Context, projectID int, rules []*modelInputs.ErrorGroupingRuleInput) ([]*model.ErrorGroupingRule, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validateAdminRole(ctx, project.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	return r.Store.UpdateErrorGroupingRules(ctx, project.ID, rules)
+}
+
+// UpdateRedactionRules is the resolver for the updateRedactionRules field.
+func (r *mutationResolver) UpdateRedactionRules(ctx context.Context, projectID int, rules []*modelInputs.RedactionRuleInput) ([]*model.RedactionRule, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validateAdminRole(ctx, project.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	return r.Store.UpdateRedactionRules(ctx, project.ID, rules)
+}
+
+// CreateRelease is the resolver for the createRelease field.
+func (r *mutationResolver) CreateRelease(ctx context.Context, apiKey string, version string, commitSha *string, releasedAt *time.Time) (*model.Release, error) {
+	projectId, err := r.Query().APIKeyToOrgID(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if projectId == nil || *projectId == 0 {
+		return nil, e.New("invalid API key")
+	}
+
+	// releases are created once their source maps are uploaded, so the debug ids can be indexed
+	stacktraces.QueueSourceMapDebugIDIndex(ctx, *projectId, &version, r.StorageClient)
+
+	return r.Store.CreateRelease(ctx, *projectId, version, commitSha, releasedAt)
+}
+
+// UploadProguardMapping is the resolver for the uploadProguardMapping field.
+func (r *mutationResolver) UploadProguardMapping(ctx context.Context, apiKey string, version *string, mapping graphql.Upload) (bool, error) {
+	projectId, err := r.Query().APIKeyToOrgID(ctx, apiKey)
+	if err != nil {
+		return false, err
+	}
+	if projectId == nil || *projectId == 0 {
+		return false, e.New("invalid API key")
+	}
+	if version != nil && (*version == "" || strings.Contains(*version, "/")) {
+		return false, e.New("invalid version")
+	}
+
+	mappingBytes, err := io.ReadAll(mapping.File)
+	if err != nil {
+		return false, e.Wrap(err, "error reading proguard mapping")
+	}
+	// validate the mapping so that errors are not retraced with an unusable file
+	if _, err := stacktraces.ParseProguardMapping(mappingBytes); err != nil {
+		return false, err
+	}
+	if _, err := r.StorageClient.PushProguardMapping(ctx, *projectId, version, mappingBytes); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UpdateErrorOwnershipRules is the resolver for the updateErrorOwnershipRules field.
+func (r *mutationResolver) UpdateErrorOwnershipRules(ctx context.Context, projectID int, rules []*modelInputs.ErrorOwnershipRuleInput) ([]*model.ErrorOwnershipRule, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validateAdminRole(ctx, project.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	return r.Store.UpdateErrorOwnershipRules(ctx, project, rules)
+}
+
+// UpsertTeam is the resolver for the upsertTeam field.
+func (r *mutationResolver) UpsertTeam(ctx context.Context, workspaceID int, id *int, name string, adminIds []int, slackMentionID *string) (*model.Team, error) {
+	workspace, err := r.isUserInWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validateAdminRole(ctx, workspace.ID); err != nil {
+		return nil, err
+	}
+
+	return r.Store.UpsertTeam(ctx, workspace.ID, id, name, adminIds, slackMentionID)
+}
+
+// DeleteTeam is the resolver for the deleteTeam field.
+func (r *mutationResolver) DeleteTeam(ctx context.Context, workspaceID int, id int) (bool, error) {
+	workspace, err := r.isUserInWorkspace(ctx, workspaceID)
+	if err != nil {
+		return false, err
+	}
+	if err := r.validateAdminRole(ctx, workspace.ID); err != nil {
+		return false, err
+	}
+
+	if err := r.Store.DeleteTeam(ctx, workspace.ID, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // CreateErrorTag is the resolver for the createErrorTag field.
 func (r *mutationResolver) CreateErrorTag(ctx context.Context, title string, description string) (*model.ErrorTag, error) {
 	return r.Resolver.CreateErrorTag(ctx, title, description)
@@ -5877,6 +6196,11 @@ func (r *queryResolver) ErrorIssue(ctx context.Context, errorGroupSecureID strin
 		return nil, err
 	}
 
+	errorGroupIDs, err := r.Store.GetMergedErrorGroupIDs(ctx, errorGroup.ID)
+	if err != nil {
+		return nil, e.Wrap(err, "error querying merged error groups")
+	}
+
 	errorIssues := []*model.ExternalAttachment{}
 
 	if err := r.DB.WithContext(ctx).Raw(`
@@ -5890,12 +6214,12 @@ func (r *queryResolver) ErrorIssue(ctx context.Context, errorGroupSecureID strin
 	  		FROM
 				error_comments
 	  		WHERE
-				error_id = ? AND removed <> true
+				error_id IN ? AND removed <> true
 			)
   		ORDER BY
 			created_at DESC
 		`,
-		errorGroup.ID,
+		errorGroupIDs,
 	).Scan(&errorIssues).Error; err != nil {
 		return nil, e.Wrap(err, "error querying error issues for error_group")
 	}
@@ -5910,8 +6234,13 @@ func (r *queryResolver) ErrorComments(ctx context.Context, errorGroupSecureID st
 		return nil, err
 	}
 
+	errorGroupIDs, err := r.Store.GetMergedErrorGroupIDs(ctx, errorGroup.ID)
+	if err != nil {
+		return nil, e.Wrap(err, "error querying merged error groups")
+	}
+
 	errorComments := []*model.ErrorComment{}
-	if err := r.DB.WithContext(ctx).Preload("Attachments").Preload("Replies").Where(model.ErrorComment{ErrorId: errorGroup.ID}).Order("created_at asc").Find(&errorComments).Error; err != nil {
+	if err := r.DB.WithContext(ctx).Preload("Attachments").Preload("Replies").Where("error_id IN ?", errorGroupIDs).Order("created_at asc").Find(&errorComments).Error; err != nil {
 		return nil, e.Wrap(err, "error querying error comments for error_group")
 	}
 	return errorComments, nil
@@ -8068,18 +8397,20 @@ func (r *queryResolver) ProjectSettings(ctx context.Context, projectID int) (*mo
 		FilterSessionsWithoutError:        projectFilterSettings.FilterSessionsWithoutError,
 		AutoResolveStaleErrorsDayInterval: projectFilterSettings.AutoResolveStaleErrorsDayInterval,
 		Sampling: &modelInputs.Sampling{
-			SessionSamplingRate:    projectFilterSettings.SessionSamplingRate,
-			ErrorSamplingRate:      projectFilterSettings.ErrorSamplingRate,
-			LogSamplingRate:        projectFilterSettings.LogSamplingRate,
-			TraceSamplingRate:      projectFilterSettings.TraceSamplingRate,
-			SessionMinuteRateLimit: projectFilterSettings.SessionMinuteRateLimit,
-			ErrorMinuteRateLimit:   projectFilterSettings.ErrorMinuteRateLimit,
-			LogMinuteRateLimit:     projectFilterSettings.LogMinuteRateLimit,
-			TraceMinuteRateLimit:   projectFilterSettings.TraceMinuteRateLimit,
-			SessionExclusionQuery:  projectFilterSettings.SessionExclusionQuery,
-			ErrorExclusionQuery:    projectFilterSettings.ErrorExclusionQuery,
-			LogExclusionQuery:      projectFilterSettings.LogExclusionQuery,
-			TraceExclusionQuery:    projectFilterSettings.TraceExclusionQuery,
+			SessionSamplingRate:            projectFilterSettings.SessionSamplingRate,
+			ErrorSamplingRate:              projectFilterSettings.ErrorSamplingRate,
+			LogSamplingRate:                projectFilterSettings.LogSamplingRate,
+			TraceSamplingRate:              projectFilterSettings.TraceSamplingRate,
+			SessionMinuteRateLimit:         projectFilterSettings.SessionMinuteRateLimit,
+			ErrorMinuteRateLimit:           projectFilterSettings.ErrorMinuteRateLimit,
+			LogMinuteRateLimit:             projectFilterSettings.LogMinuteRateLimit,
+			TraceMinuteRateLimit:           projectFilterSettings.TraceMinuteRateLimit,
+			SessionExclusionQuery:          projectFilterSettings.SessionExclusionQuery,
+			ErrorExclusionQuery:            projectFilterSettings.ErrorExclusionQuery,
+			LogExclusionQuery:              projectFilterSettings.LogExclusionQuery,
+			TraceExclusionQuery:            projectFilterSettings.TraceExclusionQuery,
+			TraceTailSamplingRate:          projectFilterSettings.TraceTailSamplingRate,
+			TraceTailSamplingWindowSeconds: projectFilterSettings.TraceTailSamplingWindowSeconds,
 		},
 	}
 
@@ -9126,6 +9457,16 @@ func (r *queryResolver) LogsHistogram(ctx context.Context, projectID int, params
 	return r.ClickhouseClient.ReadLogsHistogram(ctx, project.ID, params, 48)
 }
 
+// LogPatterns is the resolver for the log_patterns field.
+func (r *queryResolver) LogPatterns(ctx context.Context, projectID int, params modelInputs.QueryInput, limit *int) ([]*modelInputs.LogPattern, error) {
+	project, err := r.isUserInProjectOrDemoProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.ClickhouseClient.ReadLogPatterns(ctx, project.ID, params, pointy.IntValue(limit, clickhouse.LogPatternsLimit))
+}
+
 // LogsMetrics is the resolver for the logs_metrics field.
 func (r *queryResolver) LogsMetrics(ctx context.Context, projectID int, params modelInputs.QueryInput, sql *string, column *string, metricTypes []modelInputs.MetricAggregator, groupBy []string, bucketBy string, bucketCount *int, bucketWindow *int, limit *int, limitAggregator *modelInputs.MetricAggregator, limitColumn *string, expressions []*modelInputs.MetricExpressionInput) (*modelInputs.MetricsBuckets, error) {
 	project, err := r.isUserInProjectOrDemoProject(ctx, projectID)
@@ -9330,6 +9671,183 @@ func (r *queryResolver) ServiceByName(ctx context.Context, projectID int, name s
 	return r.Store.FindService(ctx, projectID, name)
 }
 
+// IngestKeys is the resolver for the ingest_keys field.
+func (r *queryResolver) IngestKeys(ctx context.Context, projectID int) ([]*model.IngestKey, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Store.GetIngestKeys(ctx, project.ID)
+}
+
+// TraceSamplingPolicies is the resolver for the trace_sampling_policies field.
+func (r *queryResolver) TraceSamplingPolicies(ctx context.Context, projectID int) ([]*model.TraceSamplingPolicy, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Store.GetTraceSamplingPolicies(ctx, project.ID)
+}
+
+// LogMetricRules is the resolver for the log_metric_rules field.
+func (r *queryResolver) LogMetricRules(ctx context.Context, projectID int) ([]*model.LogMetricRule, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Store.GetLogMetricRules(ctx, project.ID)
+}
+
+// ExportJobs is the resolver for the export_jobs field.
+func (r *queryResolver) ExportJobs(ctx context.Context, projectID int) ([]*model.ExportJob, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Store.GetExportJobs(ctx, project.ID)
+}
+
+// ErrorGroupingRules is the resolver for the error_grouping_rules field.
+func (r *queryResolver) ErrorGroupingRules(ctx context.Context, projectID int) ([]*model.ErrorGroupingRule, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Store.GetErrorGroupingRules(ctx, project.ID)
+}
+
+// ErrorGroupingRulesDryRun is the resolver for the error_grouping_rules_dry_run field.
+func (r *queryResolver) ErrorGroupingRulesDryRun(ctx context.Context, projectID int, rules []*modelInputs.ErrorGroupingRuleInput, count *int) (*modelInputs.ErrorGroupingDryRun, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupingRules, err := store.ErrorGroupingRulesFromInput(project.ID, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := 1000
+	if count != nil {
+		limit = max(1, min(*count, 10000))
+	}
+	var errorObjects []*model.ErrorObject
+	if err := r.DB.WithContext(ctx).Model(&model.ErrorObject{}).
+		Select("id", "project_id", "error_group_id", "event", "type", "stack_trace", "mapped_stack_trace", "payload").
+		Where(&model.ErrorObject{ProjectID: project.ID}).
+		Order("id DESC").
+		Limit(limit).
+		Find(&errorObjects).Error; err != nil {
+		return nil, e.Wrap(err, "error querying recent error objects")
+	}
+
+	var dryRunObjects []*errorgroups.DryRunErrorObject
+	for _, errorObject := range errorObjects {
+		var frames []*modelInputs.ErrorTrace
+		stackTrace := errorObject.MappedStackTrace
+		if stackTrace == nil {
+			stackTrace = errorObject.StackTrace
+		}
+		if stackTrace != nil {
+			frames, _ = r.Store.StructuredStackTrace(ctx, *stackTrace)
+		}
+		dryRunObjects = append(dryRunObjects, &errorgroups.DryRunErrorObject{ErrorObject: errorObject, Frames: frames})
+	}
+	currentGroupCount, groups := errorgroups.DryRunGroupingRules(groupingRules, dryRunObjects)
+
+	const maxGroups = 100
+	result := &modelInputs.ErrorGroupingDryRun{
+		ErrorObjectCount:  len(errorObjects),
+		CurrentGroupCount: currentGroupCount,
+		NewGroupCount:     len(groups),
+		Groups:            []*modelInputs.ErrorGroupingDryRunGroup{},
+	}
+	groups = lo.Slice(groups, 0, maxGroups)
+
+	var errorGroups []*model.ErrorGroup
+	if err := r.DB.WithContext(ctx).Model(&model.ErrorGroup{}).
+		Select("id", "secure_id").
+		Where("id IN ?", lo.Uniq(lo.FlatMap(groups, func(g *errorgroups.DryRunGroup, _ int) []int { return g.ErrorGroupIDs }))).
+		Find(&errorGroups).Error; err != nil {
+		return nil, e.Wrap(err, "error querying error groups")
+	}
+	secureIDs := lo.SliceToMap(errorGroups, func(eg *model.ErrorGroup) (int, string) { return eg.ID, eg.SecureID })
+
+	for _, group := range groups {
+		result.Groups = append(result.Groups, &modelInputs.ErrorGroupingDryRunGroup{
+			Fingerprint:      group.Fingerprint,
+			Event:            group.Event,
+			ErrorObjectCount: group.ErrorObjectCount,
+			ErrorGroupSecureIds: lo.FilterMap(group.ErrorGroupIDs, func(id int, _ int) (string, bool) {
+				secureID, ok := secureIDs[id]
+				return secureID, ok
+			}),
+		})
+	}
+	return result, nil
+}
+
+// ErrorOwnershipRules is the resolver for the error_ownership_rules field.
+func (r *queryResolver) ErrorOwnershipRules(ctx context.Context, projectID int) ([]*model.ErrorOwnershipRule, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Store.GetErrorOwnershipRules(ctx, project.ID)
+}
+
+// RedactionRules is the resolver for the redaction_rules field.
+func (r *queryResolver) RedactionRules(ctx context.Context, projectID int) ([]*model.RedactionRule, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Store.GetRedactionRulesWithCounts(ctx, project.ID)
+}
+
+// Teams is the resolver for the teams field.
+func (r *queryResolver) Teams(ctx context.Context, workspaceID int) ([]*model.Team, error) {
+	workspace, err := r.isUserInWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Store.GetTeams(ctx, workspace.ID)
+}
+
+// Releases is the resolver for the releases field.
+func (r *queryResolver) Releases(ctx context.Context, projectID int) ([]*model.Release, error) {
+	project, err := r.isUserInProjectOrDemoProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Store.GetReleases(ctx, project.ID)
+}
+
+// ReleaseHealth is the resolver for the release_health field.
+func (r *queryResolver) ReleaseHealth(ctx context.Context, projectID int, version string, dateRange modelInputs.DateRangeRequiredInput) (*model.ReleaseHealth, error) {
+	project, err := r.isUserInProjectOrDemoProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := r.Store.GetRelease(ctx, project.ID, version)
+	if err != nil {
+		return nil, e.Wrap(err, "error querying release")
+	}
+
+	return r.Store.GetReleaseHealth(ctx, release, dateRange.StartDate, dateRange.EndDate)
+}
+
 // ErrorTags is the resolver for the error_tags field.
 func (r *queryResolver) ErrorTags(ctx context.Context) ([]*model.ErrorTag, error) {
 	return r.GetErrorTags()
@@ -9440,6 +9958,16 @@ func (r *queryResolver) TracesKeyValues(ctx context.Context, projectID int, keyN
 	return r.ClickhouseClient.TracesKeyValues(ctx, project.ID, keyName, dateRange.StartDate, dateRange.EndDate, query, count)
 }
 
+// ServiceDependencies is the resolver for the service_dependencies field.
+func (r *queryResolver) ServiceDependencies(ctx context.Context, projectID int, environment *string, dateRange modelInputs.DateRangeRequiredInput) (*modelInputs.ServiceDependencyGraph, error) {
+	project, err := r.isUserInProjectOrDemoProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.ClickhouseClient.ReadServiceDependencies(ctx, project.ID, environment, dateRange)
+}
+
 // ErrorsKeys is the resolver for the errors_keys field.
 func (r *queryResolver) ErrorsKeys(ctx context.Context, projectID int, dateRange modelInputs.DateRangeRequiredInput, query *string, typeArg *modelInputs.KeyType) ([]*modelInputs.QueryKey, error) {
 	_, err := r.isUserInProjectOrDemoProject(ctx, projectID)
@@ -10233,9 +10761,15 @@ func (r *Resolver) ErrorObject() generated.ErrorObjectResolver { return &errorOb
 // Graph returns generated.GraphResolver implementation.
 func (r *Resolver) Graph() generated.GraphResolver { return &graphResolver{r} }
 
+// IngestKey returns generated.IngestKeyResolver implementation.
+func (r *Resolver) IngestKey() generated.IngestKeyResolver { return &ingestKeyResolver{r} }
+
 // LogAlert returns generated.LogAlertResolver implementation.
 func (r *Resolver) LogAlert() generated.LogAlertResolver { return &logAlertResolver{r} }
 
+// LogMetricRule returns generated.LogMetricRuleResolver implementation.
+func (r *Resolver) LogMetricRule() generated.LogMetricRuleResolver { return &logMetricRuleResolver{r} }
+
 // MatchedErrorObject returns generated.MatchedErrorObjectResolver implementation.
 func (r *Resolver) MatchedErrorObject() generated.MatchedErrorObjectResolver {
 	return &matchedErrorObjectResolver{r}
@@ -10285,7 +10819,9 @@ type errorCommentResolver struct{ *Resolver }
 type errorGroupResolver struct{ *Resolver }
 type errorObjectResolver struct{ *Resolver }
 type graphResolver struct{ *Resolver }
+type ingestKeyResolver struct{ *Resolver }
 type logAlertResolver struct{ *Resolver }
+type logMetricRuleResolver struct{ *Resolver }
 type matchedErrorObjectResolver struct{ *Resolver }
 type metricMonitorResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
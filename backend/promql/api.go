@@ -0,0 +1,331 @@
+package promql
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	e "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// the range of the labels and series endpoints when no start is given
+const defaultSeriesLookback = 24 * time.Hour
+
+type errorType string
+
+const (
+	errorBadData   errorType = "bad_data"
+	errorExecution errorType = "execution"
+	errorForbidden errorType = "forbidden"
+)
+
+type response struct {
+	Status    string    `json:"status"`
+	Data      any       `json:"data,omitempty"`
+	ErrorType errorType `json:"errorType,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+type queryData struct {
+	ResultType ValueType `json:"resultType"`
+	Result     any       `json:"result"`
+}
+
+// point is serialized as `[<unix seconds>, "<value>"]`
+type point struct {
+	T int64
+	V float64
+}
+
+func (p point) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{json.Number(strconv.FormatFloat(float64(p.T)/1000, 'f', -1, 64)), formatValue(p.V)})
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+type vectorResult struct {
+	Metric Labels `json:"metric"`
+	Value  point  `json:"value"`
+}
+
+type matrixResult struct {
+	Metric Labels  `json:"metric"`
+	Values []point `json:"values"`
+}
+
+func formatResult(value Value) queryData {
+	switch v := value.(type) {
+	case Scalar:
+		return queryData{ResultType: ValueTypeScalar, Result: point(v)}
+	case Vector:
+		result := make([]vectorResult, 0, len(v))
+		for _, sample := range v {
+			result = append(result, vectorResult{Metric: sample.Labels, Value: point{T: sample.T, V: sample.V}})
+		}
+		return queryData{ResultType: ValueTypeVector, Result: result}
+	case Matrix:
+		result := make([]matrixResult, 0, len(v))
+		for _, series := range v {
+			values := make([]point, 0, len(series.Samples))
+			for _, sample := range series.Samples {
+				values = append(values, point(sample))
+			}
+			result = append(result, matrixResult{Metric: series.Labels, Values: values})
+		}
+		return queryData{ResultType: ValueTypeMatrix, Result: result}
+	}
+	return queryData{}
+}
+
+// API serves the subset of the prometheus HTTP API needed by prometheus compatible data sources.
+type API struct {
+	storage   Storage
+	authorize func(ctx context.Context, projectID int) error
+}
+
+func NewAPI(storage Storage, authorize func(ctx context.Context, projectID int) error) *API {
+	return &API{storage: storage, authorize: authorize}
+}
+
+// Routes registers the endpoints on a router with a `project_id` url parameter.
+func (a *API) Routes(r chi.Router) {
+	r.HandleFunc("/query", a.handle(a.query))
+	r.HandleFunc("/query_range", a.handle(a.queryRange))
+	r.HandleFunc("/labels", a.handle(a.labels))
+	r.HandleFunc("/label/{name}/values", a.handle(a.labelValues))
+	r.HandleFunc("/series", a.handle(a.series))
+}
+
+type apiError struct {
+	typ    errorType
+	status int
+	err    error
+}
+
+func badData(err error) *apiError {
+	return &apiError{typ: errorBadData, status: http.StatusBadRequest, err: err}
+}
+
+func (a *API) handle(fn func(r *http.Request, projectID int) (any, *apiError)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		data, apiErr := func() (any, *apiError) {
+			projectID, err := strconv.Atoi(chi.URLParam(r, "project_id"))
+			if err != nil {
+				return nil, badData(e.New("invalid project_id"))
+			}
+			if err := a.authorize(ctx, projectID); err != nil {
+				return nil, &apiError{typ: errorForbidden, status: http.StatusForbidden, err: e.New("not authorized to query the project")}
+			}
+			if err := r.ParseForm(); err != nil {
+				return nil, badData(err)
+			}
+			return fn(r, projectID)
+		}()
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := response{Status: "success", Data: data}
+		if apiErr != nil {
+			log.WithContext(ctx).WithError(apiErr.err).WithField("path", r.URL.Path).Warn("prometheus api request failed")
+			resp = response{Status: "error", ErrorType: apiErr.typ, Error: apiErr.err.Error()}
+			w.WriteHeader(apiErr.status)
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.WithContext(ctx).WithError(err).Error("failed to write prometheus api response")
+		}
+	}
+}
+
+// parseTime parses a unix timestamp in seconds or an RFC 3339 time.
+func parseTime(value string, defaultValue time.Time) (time.Time, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		whole, fraction := math.Modf(seconds)
+		return time.Unix(int64(whole), int64(fraction*1e9)).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, e.Errorf("cannot parse %q to a valid timestamp", value)
+	}
+	return t, nil
+}
+
+func parseRange(r *http.Request) (time.Time, time.Time, error) {
+	end, err := parseTime(r.Form.Get("end"), time.Now())
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	start, err := parseTime(r.Form.Get("start"), end.Add(-defaultSeriesLookback))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, e.New("end timestamp must not be before start time")
+	}
+	return start, end, nil
+}
+
+func (a *API) query(r *http.Request, projectID int) (any, *apiError) {
+	ts, err := parseTime(r.Form.Get("time"), time.Now())
+	if err != nil {
+		return nil, badData(err)
+	}
+	query := r.Form.Get("query")
+	if _, err := Parse(query); err != nil {
+		return nil, badData(err)
+	}
+	value, err := NewEngine(a.storage, projectID).Query(r.Context(), query, ts)
+	if err != nil {
+		return nil, &apiError{typ: errorExecution, status: http.StatusUnprocessableEntity, err: err}
+	}
+	return formatResult(value), nil
+}
+
+func (a *API) queryRange(r *http.Request, projectID int) (any, *apiError) {
+	start, err := parseTime(r.Form.Get("start"), time.Time{})
+	if err != nil || start.IsZero() {
+		return nil, badData(e.New("invalid parameter \"start\""))
+	}
+	end, err := parseTime(r.Form.Get("end"), time.Time{})
+	if err != nil || end.IsZero() {
+		return nil, badData(e.New("invalid parameter \"end\""))
+	}
+	step, err := ParseDuration(r.Form.Get("step"))
+	if err != nil {
+		return nil, badData(e.New("invalid parameter \"step\""))
+	}
+	query := r.Form.Get("query")
+	if _, err := Parse(query); err != nil {
+		return nil, badData(err)
+	}
+	matrix, err := NewEngine(a.storage, projectID).QueryRange(r.Context(), query, start, end, step)
+	if err != nil {
+		return nil, &apiError{typ: errorExecution, status: http.StatusUnprocessableEntity, err: err}
+	}
+	return formatResult(matrix), nil
+}
+
+// readLabels returns the label sets of the series matching any of the `match[]` selectors.
+func (a *API) readLabels(r *http.Request, projectID int) ([]Labels, *apiError) {
+	start, end, err := parseRange(r)
+	if err != nil {
+		return nil, badData(err)
+	}
+
+	var matcherSets [][]*Matcher
+	for _, match := range r.Form["match[]"] {
+		expr, err := Parse(match)
+		if err != nil {
+			return nil, badData(err)
+		}
+		selector, ok := expr.(*VectorSelector)
+		if !ok {
+			return nil, badData(e.Errorf("invalid series selector %q", match))
+		}
+		matcherSets = append(matcherSets, selector.Matchers)
+	}
+	if len(matcherSets) == 0 {
+		matcherSets = [][]*Matcher{nil}
+	}
+
+	seen := map[string]bool{}
+	var result []Labels
+	for _, matchers := range matcherSets {
+		labels, err := a.storage.ReadPrometheusSeriesLabels(r.Context(), projectID, matchers, start, end)
+		if err != nil {
+			return nil, &apiError{typ: errorExecution, status: http.StatusUnprocessableEntity, err: err}
+		}
+		for _, l := range labels {
+			if key := l.Key(); !seen[key] && MatchesLabels(matchers, l) {
+				seen[key] = true
+				result = append(result, l)
+			}
+		}
+	}
+	return result, nil
+}
+
+func limitResult[T any](r *http.Request, result []T) ([]T, *apiError) {
+	if value := r.Form.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			return nil, badData(e.New("invalid parameter \"limit\""))
+		}
+		if limit > 0 && len(result) > limit {
+			result = result[:limit]
+		}
+	}
+	return result, nil
+}
+
+func (a *API) labels(r *http.Request, projectID int) (any, *apiError) {
+	labels, apiErr := a.readLabels(r, projectID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	names := map[string]bool{}
+	for _, l := range labels {
+		for name := range l {
+			names[name] = true
+		}
+	}
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return limitResult(r, result)
+}
+
+func (a *API) labelValues(r *http.Request, projectID int) (any, *apiError) {
+	name := chi.URLParam(r, "name")
+	labels, apiErr := a.readLabels(r, projectID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	values := map[string]bool{}
+	for _, l := range labels {
+		if value, ok := l[name]; ok && value != "" {
+			values[value] = true
+		}
+	}
+	result := make([]string, 0, len(values))
+	for value := range values {
+		result = append(result, value)
+	}
+	sort.Strings(result)
+	return limitResult(r, result)
+}
+
+func (a *API) series(r *http.Request, projectID int) (any, *apiError) {
+	if len(r.Form["match[]"]) == 0 {
+		return nil, badData(e.New("no match[] parameter provided"))
+	}
+	labels, apiErr := a.readLabels(r, projectID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Key() < labels[j].Key() })
+	if labels == nil {
+		labels = []Labels{}
+	}
+	return limitResult(r, labels)
+}
@@ -0,0 +1,397 @@
+package promql
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/util"
+	e "github.com/pkg/errors"
+)
+
+const (
+	// how far back an instant vector selector looks for the latest sample
+	LookbackDelta = 5 * time.Minute
+	// the maximum number of points per series of a range query, as in prometheus
+	MaxPoints = 11_000
+	// the maximum number of samples loaded by a query
+	MaxSamples = 5_000_000
+)
+
+type Value interface {
+	Type() ValueType
+}
+
+type Scalar struct {
+	T int64
+	V float64
+}
+
+type VectorSample struct {
+	Labels Labels
+	T      int64
+	V      float64
+}
+
+type Vector []VectorSample
+
+type Matrix []*Series
+
+func (Scalar) Type() ValueType { return ValueTypeScalar }
+func (Vector) Type() ValueType { return ValueTypeVector }
+func (Matrix) Type() ValueType { return ValueTypeMatrix }
+
+// Engine evaluates queries against the series of a project.
+type Engine struct {
+	storage   Storage
+	projectID int
+}
+
+func NewEngine(storage Storage, projectID int) *Engine {
+	return &Engine{storage: storage, projectID: projectID}
+}
+
+type evaluator struct {
+	series map[*VectorSelector][]*Series
+}
+
+// load reads the series of all selectors of the expression needed to evaluate it between start and end.
+func (en *Engine) load(ctx context.Context, expr Expr, start time.Time, end time.Time) (*evaluator, error) {
+	ev := &evaluator{series: map[*VectorSelector][]*Series{}}
+	var samples int
+	for selector, r := range selectors(expr) {
+		if r == 0 {
+			r = LookbackDelta
+		}
+		series, err := en.storage.ReadPrometheusSeries(ctx, en.projectID, selector.Matchers, start.Add(-selector.Offset-r), end.Add(-selector.Offset))
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range series {
+			if !MatchesLabels(selector.Matchers, s.Labels) {
+				continue
+			}
+			samples += len(s.Samples)
+			if samples > MaxSamples {
+				return nil, e.New("query processing would load too many samples into memory")
+			}
+			ev.series[selector] = append(ev.series[selector], s)
+		}
+	}
+	return ev, nil
+}
+
+// Query evaluates an instant query.
+func (en *Engine) Query(ctx context.Context, query string, ts time.Time) (Value, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "promql.Query", util.Tag("projectID", en.projectID))
+	defer span.Finish()
+
+	expr, err := Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	ev, err := en.load(ctx, expr, ts, ts)
+	if err != nil {
+		return nil, err
+	}
+
+	t := ts.UnixMilli()
+	switch expr.Type() {
+	case ValueTypeScalar:
+		return Scalar{T: t, V: ev.evalScalar(expr, t)}, nil
+	case ValueTypeMatrix:
+		selector := expr.(*MatrixSelector)
+		var matrix Matrix
+		for _, s := range ev.series[selector.VectorSelector] {
+			if samples := window(s.Samples, t-selector.Offset.Milliseconds(), selector.Range); len(samples) > 0 {
+				matrix = append(matrix, &Series{Labels: s.Labels, Samples: samples})
+			}
+		}
+		return matrix, nil
+	default:
+		vector, err := ev.evalVector(expr, t)
+		if err != nil {
+			return nil, err
+		}
+		return vector, nil
+	}
+}
+
+// QueryRange evaluates a query at each step between start and end.
+func (en *Engine) QueryRange(ctx context.Context, query string, start time.Time, end time.Time, step time.Duration) (Matrix, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "promql.QueryRange", util.Tag("projectID", en.projectID))
+	defer span.Finish()
+
+	if step <= 0 {
+		return nil, e.New("zero or negative query resolution step widths are not accepted")
+	}
+	if end.Before(start) {
+		return nil, e.New("end timestamp must not be before start time")
+	}
+	if end.Sub(start)/step > MaxPoints {
+		return nil, e.New("exceeded maximum resolution of 11,000 points per timeseries")
+	}
+
+	expr, err := Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	if expr.Type() != ValueTypeScalar && expr.Type() != ValueTypeVector {
+		return nil, e.Errorf("invalid expression type %q for range query, must be scalar or instant vector", expr.Type())
+	}
+	ev, err := en.load(ctx, expr, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	series := map[string]*Series{}
+	for ts := start; !ts.After(end); ts = ts.Add(step) {
+		t := ts.UnixMilli()
+		if expr.Type() == ValueTypeScalar {
+			key := Labels{}.Key()
+			if _, ok := series[key]; !ok {
+				series[key] = &Series{Labels: Labels{}}
+			}
+			series[key].Samples = append(series[key].Samples, Sample{T: t, V: ev.evalScalar(expr, t)})
+			continue
+		}
+		vector, err := ev.evalVector(expr, t)
+		if err != nil {
+			return nil, err
+		}
+		for _, sample := range vector {
+			key := sample.Labels.Key()
+			if _, ok := series[key]; !ok {
+				series[key] = &Series{Labels: sample.Labels}
+			}
+			series[key].Samples = append(series[key].Samples, Sample{T: t, V: sample.V})
+		}
+	}
+
+	keys := make([]string, 0, len(series))
+	for key := range series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	matrix := make(Matrix, 0, len(keys))
+	for _, key := range keys {
+		matrix = append(matrix, series[key])
+	}
+	return matrix, nil
+}
+
+// window returns the samples in (t - r, t].
+func window(samples []Sample, t int64, r time.Duration) []Sample {
+	from := sort.Search(len(samples), func(i int) bool { return samples[i].T > t-r.Milliseconds() })
+	to := sort.Search(len(samples), func(i int) bool { return samples[i].T > t })
+	return samples[from:to]
+}
+
+func (ev *evaluator) evalScalar(expr Expr, t int64) float64 {
+	switch n := expr.(type) {
+	case *NumberLiteral:
+		return n.Val
+	case *UnaryExpr:
+		return -ev.evalScalar(n.Expr, t)
+	case *BinaryExpr:
+		return applyOperator(n.Op, ev.evalScalar(n.LHS, t), ev.evalScalar(n.RHS, t))
+	case *Call:
+		switch n.Func.Name {
+		case "time":
+			return float64(t) / 1000
+		case "scalar":
+			vector, err := ev.evalVector(n.Args[0], t)
+			if err != nil || len(vector) != 1 {
+				return math.NaN()
+			}
+			return vector[0].V
+		}
+	}
+	return math.NaN()
+}
+
+func (ev *evaluator) evalVector(expr Expr, t int64) (Vector, error) {
+	switch n := expr.(type) {
+	case *VectorSelector:
+		var vector Vector
+		for _, s := range ev.series[n] {
+			samples := window(s.Samples, t-n.Offset.Milliseconds(), LookbackDelta)
+			if len(samples) == 0 {
+				continue
+			}
+			vector = append(vector, VectorSample{Labels: s.Labels, T: t, V: samples[len(samples)-1].V})
+		}
+		sortVector(vector)
+		return vector, nil
+	case *UnaryExpr:
+		vector, err := ev.evalVector(n.Expr, t)
+		if err != nil {
+			return nil, err
+		}
+		result := make(Vector, 0, len(vector))
+		for _, sample := range vector {
+			result = append(result, VectorSample{Labels: sample.Labels.Without(MetricNameLabel), T: t, V: -sample.V})
+		}
+		return result, nil
+	case *BinaryExpr:
+		return ev.evalBinary(n, t)
+	case *AggregateExpr:
+		return ev.evalAggregate(n, t)
+	case *Call:
+		return ev.evalCall(n, t)
+	}
+	return nil, e.Errorf("unexpected expression %T", expr)
+}
+
+func applyOperator(op string, lhs float64, rhs float64) float64 {
+	switch op {
+	case "+":
+		return lhs + rhs
+	case "-":
+		return lhs - rhs
+	case "*":
+		return lhs * rhs
+	case "/":
+		return lhs / rhs
+	case "%":
+		return math.Mod(lhs, rhs)
+	case "^":
+		return math.Pow(lhs, rhs)
+	}
+	return math.NaN()
+}
+
+func (ev *evaluator) evalBinary(n *BinaryExpr, t int64) (Vector, error) {
+	if n.LHS.Type() == ValueTypeScalar || n.RHS.Type() == ValueTypeScalar {
+		vectorExpr, scalarExpr, scalarLeft := n.LHS, n.RHS, false
+		if n.LHS.Type() == ValueTypeScalar {
+			vectorExpr, scalarExpr, scalarLeft = n.RHS, n.LHS, true
+		}
+		vector, err := ev.evalVector(vectorExpr, t)
+		if err != nil {
+			return nil, err
+		}
+		scalar := ev.evalScalar(scalarExpr, t)
+		result := make(Vector, 0, len(vector))
+		for _, sample := range vector {
+			value := applyOperator(n.Op, sample.V, scalar)
+			if scalarLeft {
+				value = applyOperator(n.Op, scalar, sample.V)
+			}
+			result = append(result, VectorSample{Labels: sample.Labels.Without(MetricNameLabel), T: t, V: value})
+		}
+		return result, nil
+	}
+
+	lhs, err := ev.evalVector(n.LHS, t)
+	if err != nil {
+		return nil, err
+	}
+	rhs, err := ev.evalVector(n.RHS, t)
+	if err != nil {
+		return nil, err
+	}
+	// samples are matched one-to-one by their labels, ignoring the metric name
+	rhsByLabels := map[string]VectorSample{}
+	for _, sample := range rhs {
+		key := sample.Labels.Without(MetricNameLabel).Key()
+		if _, ok := rhsByLabels[key]; ok {
+			return nil, e.New("found duplicate series for the match group on the right hand-side of the operation, many-to-many matching not allowed")
+		}
+		rhsByLabels[key] = sample
+	}
+	matched := map[string]bool{}
+	var result Vector
+	for _, sample := range lhs {
+		labels := sample.Labels.Without(MetricNameLabel)
+		key := labels.Key()
+		other, ok := rhsByLabels[key]
+		if !ok {
+			continue
+		}
+		if matched[key] {
+			return nil, e.New("found duplicate series for the match group on the left hand-side of the operation, many-to-many matching not allowed")
+		}
+		matched[key] = true
+		result = append(result, VectorSample{Labels: labels, T: t, V: applyOperator(n.Op, sample.V, other.V)})
+	}
+	return result, nil
+}
+
+func (ev *evaluator) evalAggregate(n *AggregateExpr, t int64) (Vector, error) {
+	vector, err := ev.evalVector(n.Expr, t)
+	if err != nil {
+		return nil, err
+	}
+
+	groupLabels := func(labels Labels) Labels {
+		if n.Without {
+			return labels.Without(append([]string{MetricNameLabel}, n.Grouping...)...)
+		}
+		return labels.Only(n.Grouping...)
+	}
+	groups := map[string][]VectorSample{}
+	var keys []string
+	for _, sample := range vector {
+		key := groupLabels(sample.Labels).Key()
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], sample)
+	}
+	sort.Strings(keys)
+
+	var result Vector
+	for _, key := range keys {
+		samples := groups[key]
+		if n.Op == "topk" || n.Op == "bottomk" {
+			k := int(ev.evalScalar(n.Param, t))
+			sort.SliceStable(samples, func(i, j int) bool {
+				// NaN values are ranked last
+				if math.IsNaN(samples[j].V) {
+					return !math.IsNaN(samples[i].V)
+				}
+				if n.Op == "topk" {
+					return samples[i].V > samples[j].V
+				}
+				return samples[i].V < samples[j].V
+			})
+			if k < len(samples) {
+				samples = samples[:max(k, 0)]
+			}
+			result = append(result, samples...)
+			continue
+		}
+
+		value := samples[0].V
+		for _, sample := range samples[1:] {
+			switch n.Op {
+			case "sum", "avg":
+				value += sample.V
+			case "min":
+				if sample.V < value || math.IsNaN(value) {
+					value = sample.V
+				}
+			case "max":
+				if sample.V > value || math.IsNaN(value) {
+					value = sample.V
+				}
+			}
+		}
+		switch n.Op {
+		case "avg":
+			value /= float64(len(samples))
+		case "count":
+			value = float64(len(samples))
+		}
+		result = append(result, VectorSample{Labels: groupLabels(samples[0].Labels), T: t, V: value})
+	}
+	return result, nil
+}
+
+func sortVector(vector Vector) {
+	sort.Slice(vector, func(i, j int) bool {
+		return vector[i].Labels.Key() < vector[j].Labels.Key()
+	})
+}
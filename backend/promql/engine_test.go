@@ -0,0 +1,209 @@
+package promql
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	e "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStorage []*Series
+
+func (s memoryStorage) ReadPrometheusSeries(_ context.Context, _ int, matchers []*Matcher, start time.Time, end time.Time) ([]*Series, error) {
+	var result []*Series
+	for _, series := range s {
+		if !MatchesLabels(matchers, series.Labels) {
+			continue
+		}
+		filtered := &Series{Labels: series.Labels}
+		for _, sample := range series.Samples {
+			if sample.T >= start.UnixMilli() && sample.T <= end.UnixMilli() {
+				filtered.Samples = append(filtered.Samples, sample)
+			}
+		}
+		result = append(result, filtered)
+	}
+	return result, nil
+}
+
+func (s memoryStorage) ReadPrometheusSeriesLabels(_ context.Context, _ int, matchers []*Matcher, _ time.Time, _ time.Time) ([]Labels, error) {
+	var result []Labels
+	for _, series := range s {
+		if MatchesLabels(matchers, series.Labels) {
+			result = append(result, series.Labels)
+		}
+	}
+	return result, nil
+}
+
+var testStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// counter returns a series incremented by `increment` every 15 seconds for 10 minutes.
+func counter(labels Labels, increment float64) *Series {
+	series := &Series{Labels: labels}
+	for i := 0; i <= 40; i++ {
+		series.Samples = append(series.Samples, Sample{T: testStart.Add(time.Duration(i) * 15 * time.Second).UnixMilli(), V: float64(i) * increment})
+	}
+	return series
+}
+
+func testStorage() memoryStorage {
+	return memoryStorage{
+		counter(Labels{MetricNameLabel: "http_requests_total", "job": "api", "method": "GET"}, 3),
+		counter(Labels{MetricNameLabel: "http_requests_total", "job": "api", "method": "POST"}, 1.5),
+		counter(Labels{MetricNameLabel: "http_requests_total", "job": "web", "method": "GET"}, 0.75),
+		counter(Labels{MetricNameLabel: "request_duration_seconds_bucket", "job": "api", "le": "0.1"}, 6),
+		counter(Labels{MetricNameLabel: "request_duration_seconds_bucket", "job": "api", "le": "0.5"}, 9),
+		counter(Labels{MetricNameLabel: "request_duration_seconds_bucket", "job": "api", "le": "+Inf"}, 12),
+		{Labels: Labels{MetricNameLabel: "go_goroutines", "job": "api"}, Samples: []Sample{{T: testStart.UnixMilli(), V: 42}}},
+	}
+}
+
+func vectorValues(t *testing.T, value Value) map[string]float64 {
+	vector, ok := value.(Vector)
+	require.True(t, ok, "expected a vector, got %T", value)
+	result := map[string]float64{}
+	for _, sample := range vector {
+		result[sample.Labels.Key()] = sample.V
+	}
+	return result
+}
+
+func TestEngineQuery(t *testing.T) {
+	engine := NewEngine(testStorage(), 1)
+	ctx := context.Background()
+	ts := testStart.Add(10 * time.Minute)
+
+	value, err := engine.Query(ctx, `rate(http_requests_total{job="api"}[5m])`, ts)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{
+		Labels{"job": "api", "method": "GET"}.Key():  0.2,
+		Labels{"job": "api", "method": "POST"}.Key(): 0.1,
+	}, vectorValues(t, value))
+
+	value, err = engine.Query(ctx, `sum by (job) (increase(http_requests_total[5m]))`, ts)
+	require.NoError(t, err)
+	values := vectorValues(t, value)
+	assert.InDelta(t, 90, values[Labels{"job": "api"}.Key()], 1e-9)
+	assert.InDelta(t, 15, values[Labels{"job": "web"}.Key()], 1e-9)
+
+	value, err = engine.Query(ctx, `topk(1, rate(http_requests_total[5m]))`, ts)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{Labels{"job": "api", "method": "GET"}.Key(): 0.2}, vectorValues(t, value))
+
+	// 50% of the observations are below 0.1, 75% below 0.5
+	value, err = engine.Query(ctx, `histogram_quantile(0.9, sum by (le) (rate(request_duration_seconds_bucket[5m])))`, ts)
+	require.NoError(t, err)
+	values = vectorValues(t, value)
+	require.Len(t, values, 1)
+	assert.InDelta(t, 0.5, values[Labels{}.Key()], 1e-9)
+
+	value, err = engine.Query(ctx, `histogram_quantile(0.5, sum by (le) (rate(request_duration_seconds_bucket[5m])))`, ts)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.1, vectorValues(t, value)[Labels{}.Key()], 1e-9)
+
+	value, err = engine.Query(ctx, `sum(rate(http_requests_total{method="POST"}[5m])) / sum(rate(http_requests_total[5m])) * 100`, ts)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.1/0.35*100, vectorValues(t, value)[Labels{}.Key()], 1e-9)
+
+	value, err = engine.Query(ctx, `1 + 1`, ts)
+	require.NoError(t, err)
+	assert.Equal(t, Scalar{T: ts.UnixMilli(), V: 2}, value)
+
+	// the latest sample is older than the lookback
+	value, err = engine.Query(ctx, `go_goroutines`, ts)
+	require.NoError(t, err)
+	assert.Empty(t, value)
+	value, err = engine.Query(ctx, `go_goroutines`, testStart.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{Labels{MetricNameLabel: "go_goroutines", "job": "api"}.Key(): 42}, vectorValues(t, value))
+}
+
+func TestEngineQueryRange(t *testing.T) {
+	engine := NewEngine(testStorage(), 1)
+	matrix, err := engine.QueryRange(context.Background(), `sum by (method) (rate(http_requests_total[1m]))`, testStart.Add(5*time.Minute), testStart.Add(10*time.Minute), time.Minute)
+	require.NoError(t, err)
+	require.Len(t, matrix, 2)
+	assert.Equal(t, Labels{"method": "GET"}, matrix[0].Labels)
+	require.Len(t, matrix[0].Samples, 6)
+	for _, sample := range matrix[0].Samples {
+		assert.InDelta(t, 0.25, sample.V, 1e-9)
+	}
+
+	_, err = engine.QueryRange(context.Background(), `up`, testStart, testStart.Add(24*time.Hour), time.Second)
+	assert.Error(t, err)
+	_, err = engine.QueryRange(context.Background(), `http_requests_total[5m]`, testStart, testStart.Add(time.Hour), time.Minute)
+	assert.Error(t, err)
+}
+
+func TestExtrapolatedRateCounterReset(t *testing.T) {
+	samples := []Sample{{T: 0, V: 10}, {T: 15_000, V: 20}, {T: 30_000, V: 5}, {T: 45_000, V: 15}}
+	// 10 before the reset and 15 after it over 45s, extrapolated to the 60s range
+	value, ok := extrapolatedRate(samples, 60_000, time.Minute, true, false)
+	require.True(t, ok)
+	assert.InDelta(t, 25*(45+7.5+7.5)/45, value, 1e-9)
+
+	assert.True(t, math.IsNaN(bucketQuantile(0.5, []bucket{{upperBound: 1, count: 1}})))
+}
+
+func TestAPI(t *testing.T) {
+	api := NewAPI(testStorage(), func(ctx context.Context, projectID int) error {
+		if projectID != 1 {
+			return e.New("forbidden")
+		}
+		return nil
+	})
+	r := chi.NewRouter()
+	r.Route("/prometheus/{project_id}/api/v1", api.Routes)
+
+	get := func(path string, params url.Values) (int, map[string]any) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path+"?"+params.Encode(), nil))
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return w.Code, body
+	}
+
+	code, body := get("/prometheus/1/api/v1/query", url.Values{"query": {`sum(rate(http_requests_total{job="api", method="GET"}[5m]))`}, "time": {"1704067800"}})
+	require.Equal(t, http.StatusOK, code, body)
+	assert.Equal(t, "success", body["status"])
+	data := body["data"].(map[string]any)
+	assert.Equal(t, "vector", data["resultType"])
+	result := data["result"].([]any)
+	require.Len(t, result, 1)
+	assert.Equal(t, []any{1704067800., "0.2"}, result[0].(map[string]any)["value"])
+
+	code, body = get("/prometheus/1/api/v1/query_range", url.Values{"query": {`go_goroutines`}, "start": {"2024-01-01T00:00:00Z"}, "end": {"2024-01-01T00:02:00Z"}, "step": {"1m"}})
+	require.Equal(t, http.StatusOK, code, body)
+	data = body["data"].(map[string]any)
+	assert.Equal(t, "matrix", data["resultType"])
+	assert.Len(t, data["result"].([]any)[0].(map[string]any)["values"], 3)
+
+	code, body = get("/prometheus/1/api/v1/labels", url.Values{})
+	require.Equal(t, http.StatusOK, code, body)
+	assert.Equal(t, []any{"__name__", "job", "le", "method"}, body["data"])
+
+	code, body = get("/prometheus/1/api/v1/label/method/values", url.Values{})
+	require.Equal(t, http.StatusOK, code, body)
+	assert.Equal(t, []any{"GET", "POST"}, body["data"])
+
+	code, body = get("/prometheus/1/api/v1/series", url.Values{"match[]": {`http_requests_total{method="GET"}`}})
+	require.Equal(t, http.StatusOK, code, body)
+	assert.Len(t, body["data"], 2)
+
+	code, body = get("/prometheus/1/api/v1/query", url.Values{"query": {`rate(`}})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "bad_data", body["errorType"])
+
+	code, _ = get("/prometheus/2/api/v1/labels", url.Values{})
+	assert.Equal(t, http.StatusForbidden, code)
+}
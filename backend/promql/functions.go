@@ -0,0 +1,247 @@
+package promql
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type Function struct {
+	Name       string
+	ArgTypes   []ValueType
+	ReturnType ValueType
+}
+
+// Functions are the supported PromQL functions.
+var Functions = map[string]*Function{}
+
+func init() {
+	for _, fn := range []*Function{
+		{Name: "rate", ArgTypes: []ValueType{ValueTypeMatrix}, ReturnType: ValueTypeVector},
+		{Name: "increase", ArgTypes: []ValueType{ValueTypeMatrix}, ReturnType: ValueTypeVector},
+		{Name: "irate", ArgTypes: []ValueType{ValueTypeMatrix}, ReturnType: ValueTypeVector},
+		{Name: "delta", ArgTypes: []ValueType{ValueTypeMatrix}, ReturnType: ValueTypeVector},
+		{Name: "avg_over_time", ArgTypes: []ValueType{ValueTypeMatrix}, ReturnType: ValueTypeVector},
+		{Name: "sum_over_time", ArgTypes: []ValueType{ValueTypeMatrix}, ReturnType: ValueTypeVector},
+		{Name: "min_over_time", ArgTypes: []ValueType{ValueTypeMatrix}, ReturnType: ValueTypeVector},
+		{Name: "max_over_time", ArgTypes: []ValueType{ValueTypeMatrix}, ReturnType: ValueTypeVector},
+		{Name: "count_over_time", ArgTypes: []ValueType{ValueTypeMatrix}, ReturnType: ValueTypeVector},
+		{Name: "histogram_quantile", ArgTypes: []ValueType{ValueTypeScalar, ValueTypeVector}, ReturnType: ValueTypeVector},
+		{Name: "abs", ArgTypes: []ValueType{ValueTypeVector}, ReturnType: ValueTypeVector},
+		{Name: "vector", ArgTypes: []ValueType{ValueTypeScalar}, ReturnType: ValueTypeVector},
+		{Name: "scalar", ArgTypes: []ValueType{ValueTypeVector}, ReturnType: ValueTypeScalar},
+		{Name: "time", ArgTypes: []ValueType{}, ReturnType: ValueTypeScalar},
+	} {
+		Functions[fn.Name] = fn
+	}
+}
+
+func (ev *evaluator) evalCall(n *Call, t int64) (Vector, error) {
+	switch n.Func.Name {
+	case "vector":
+		return Vector{{Labels: Labels{}, T: t, V: ev.evalScalar(n.Args[0], t)}}, nil
+	case "abs":
+		vector, err := ev.evalVector(n.Args[0], t)
+		if err != nil {
+			return nil, err
+		}
+		result := make(Vector, 0, len(vector))
+		for _, sample := range vector {
+			result = append(result, VectorSample{Labels: sample.Labels.Without(MetricNameLabel), T: t, V: math.Abs(sample.V)})
+		}
+		return result, nil
+	case "histogram_quantile":
+		vector, err := ev.evalVector(n.Args[1], t)
+		if err != nil {
+			return nil, err
+		}
+		return histogramQuantile(ev.evalScalar(n.Args[0], t), vector, t), nil
+	}
+
+	selector := n.Args[0].(*MatrixSelector)
+	var result Vector
+	for _, s := range ev.series[selector.VectorSelector] {
+		samples := window(s.Samples, t-selector.Offset.Milliseconds(), selector.Range)
+		if len(samples) == 0 {
+			continue
+		}
+		value, ok := evalRangeFunction(n.Func.Name, samples, t-selector.Offset.Milliseconds(), selector.Range)
+		if !ok {
+			continue
+		}
+		result = append(result, VectorSample{Labels: s.Labels.Without(MetricNameLabel), T: t, V: value})
+	}
+	sortVector(result)
+	return result, nil
+}
+
+func evalRangeFunction(name string, samples []Sample, t int64, r time.Duration) (float64, bool) {
+	switch name {
+	case "rate":
+		return extrapolatedRate(samples, t, r, true, true)
+	case "increase":
+		return extrapolatedRate(samples, t, r, true, false)
+	case "delta":
+		return extrapolatedRate(samples, t, r, false, false)
+	case "irate":
+		if len(samples) < 2 {
+			return 0, false
+		}
+		last, previous := samples[len(samples)-1], samples[len(samples)-2]
+		value := last.V - previous.V
+		if last.V < previous.V {
+			// counter reset
+			value = last.V
+		}
+		return value / (float64(last.T-previous.T) / 1000), true
+	case "count_over_time":
+		return float64(len(samples)), true
+	}
+
+	value := samples[0].V
+	for _, sample := range samples[1:] {
+		switch name {
+		case "sum_over_time", "avg_over_time":
+			value += sample.V
+		case "min_over_time":
+			value = math.Min(value, sample.V)
+		case "max_over_time":
+			value = math.Max(value, sample.V)
+		}
+	}
+	if name == "avg_over_time" {
+		value /= float64(len(samples))
+	}
+	return value, true
+}
+
+// extrapolatedRate implements rate, increase and delta as prometheus does, extrapolating the
+// difference between the first and last sample to the boundaries of the range.
+func extrapolatedRate(samples []Sample, t int64, r time.Duration, isCounter bool, isRate bool) (float64, bool) {
+	if len(samples) < 2 {
+		return 0, false
+	}
+	rangeStart := t - r.Milliseconds()
+	rangeEnd := t
+	first, last := samples[0], samples[len(samples)-1]
+
+	result := last.V - first.V
+	if isCounter {
+		var previous float64
+		for _, sample := range samples {
+			if sample.V < previous {
+				result += previous
+			}
+			previous = sample.V
+		}
+	}
+
+	durationToStart := float64(first.T-rangeStart) / 1000
+	durationToEnd := float64(rangeEnd-last.T) / 1000
+	sampledInterval := float64(last.T-first.T) / 1000
+	averageDurationBetweenSamples := sampledInterval / float64(len(samples)-1)
+
+	// a counter can't be extrapolated below zero
+	if isCounter && result > 0 && first.V >= 0 {
+		if durationToZero := sampledInterval * (first.V / result); durationToZero < durationToStart {
+			durationToStart = durationToZero
+		}
+	}
+
+	extrapolationThreshold := averageDurationBetweenSamples * 1.1
+	extrapolateToInterval := sampledInterval
+	if durationToStart < extrapolationThreshold {
+		extrapolateToInterval += durationToStart
+	} else {
+		extrapolateToInterval += averageDurationBetweenSamples / 2
+	}
+	if durationToEnd < extrapolationThreshold {
+		extrapolateToInterval += durationToEnd
+	} else {
+		extrapolateToInterval += averageDurationBetweenSamples / 2
+	}
+	result *= extrapolateToInterval / sampledInterval
+	if isRate {
+		result /= r.Seconds()
+	}
+	return result, true
+}
+
+type bucket struct {
+	upperBound float64
+	count      float64
+}
+
+// histogramQuantile computes the quantile of each histogram from its cumulative `le` buckets.
+func histogramQuantile(q float64, vector Vector, t int64) Vector {
+	histograms := map[string][]bucket{}
+	labels := map[string]Labels{}
+	var keys []string
+	for _, sample := range vector {
+		upperBound, err := strconv.ParseFloat(sample.Labels[BucketLabel], 64)
+		if err != nil {
+			continue
+		}
+		l := sample.Labels.Without(BucketLabel, MetricNameLabel)
+		key := l.Key()
+		if _, ok := histograms[key]; !ok {
+			keys = append(keys, key)
+			labels[key] = l
+		}
+		histograms[key] = append(histograms[key], bucket{upperBound: upperBound, count: sample.V})
+	}
+	sort.Strings(keys)
+
+	result := make(Vector, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, VectorSample{Labels: labels[key], T: t, V: bucketQuantile(q, histograms[key])})
+	}
+	return result
+}
+
+func bucketQuantile(q float64, buckets []bucket) float64 {
+	if math.IsNaN(q) {
+		return math.NaN()
+	}
+	if q < 0 {
+		return math.Inf(-1)
+	}
+	if q > 1 {
+		return math.Inf(1)
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].upperBound < buckets[j].upperBound })
+	if len(buckets) < 2 || !math.IsInf(buckets[len(buckets)-1].upperBound, 1) {
+		return math.NaN()
+	}
+
+	// buckets are cumulative, so counts must not decrease
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i].count < buckets[i-1].count {
+			buckets[i].count = buckets[i-1].count
+		}
+	}
+
+	observations := buckets[len(buckets)-1].count
+	if observations == 0 {
+		return math.NaN()
+	}
+	rank := q * observations
+	b := sort.Search(len(buckets)-1, func(i int) bool { return buckets[i].count >= rank })
+	if b == len(buckets)-1 {
+		return buckets[len(buckets)-2].upperBound
+	}
+	if b == 0 && buckets[0].upperBound <= 0 {
+		return buckets[0].upperBound
+	}
+
+	bucketStart := 0.
+	bucketEnd := buckets[b].upperBound
+	count := buckets[b].count
+	if b > 0 {
+		bucketStart = buckets[b-1].upperBound
+		count -= buckets[b-1].count
+		rank -= buckets[b-1].count
+	}
+	return bucketStart + (bucketEnd-bucketStart)*(rank/count)
+}
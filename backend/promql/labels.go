@@ -0,0 +1,161 @@
+package promql
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	e "github.com/pkg/errors"
+)
+
+const (
+	MetricNameLabel = "__name__"
+	BucketLabel     = "le"
+	QuantileLabel   = "quantile"
+)
+
+// Labels identify a series.
+type Labels map[string]string
+
+// Key returns a string uniquely identifying the label set.
+func (l Labels) Key() string {
+	names := make([]string, 0, len(l))
+	for name := range l {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(0xff)
+		b.WriteString(l[name])
+		b.WriteByte(0xff)
+	}
+	return b.String()
+}
+
+// Without returns a copy of the labels without the given names.
+func (l Labels) Without(names ...string) Labels {
+	result := make(Labels, len(l))
+	for name, value := range l {
+		result[name] = value
+	}
+	for _, name := range names {
+		delete(result, name)
+	}
+	return result
+}
+
+// Only returns a copy of the labels with only the given names.
+func (l Labels) Only(names ...string) Labels {
+	result := make(Labels, len(names))
+	for _, name := range names {
+		if value, ok := l[name]; ok {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+type MatchType int
+
+const (
+	MatchEqual MatchType = iota
+	MatchNotEqual
+	MatchRegexp
+	MatchNotRegexp
+)
+
+func (m MatchType) String() string {
+	switch m {
+	case MatchNotEqual:
+		return "!="
+	case MatchRegexp:
+		return "=~"
+	case MatchNotRegexp:
+		return "!~"
+	default:
+		return "="
+	}
+}
+
+// Matcher filters series by the value of a label. A missing label has an empty value.
+type Matcher struct {
+	Type  MatchType
+	Name  string
+	Value string
+
+	re *regexp.Regexp
+}
+
+func NewMatcher(t MatchType, name, value string) (*Matcher, error) {
+	m := &Matcher{Type: t, Name: name, Value: value}
+	if t == MatchRegexp || t == MatchNotRegexp {
+		re, err := regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return nil, e.Wrapf(err, "invalid regular expression %q", value)
+		}
+		m.re = re
+	}
+	return m, nil
+}
+
+func (m *Matcher) Matches(value string) bool {
+	switch m.Type {
+	case MatchNotEqual:
+		return value != m.Value
+	case MatchRegexp:
+		return m.re.MatchString(value)
+	case MatchNotRegexp:
+		return !m.re.MatchString(value)
+	default:
+		return value == m.Value
+	}
+}
+
+func (m *Matcher) String() string {
+	return m.Name + m.Type.String() + `"` + m.Value + `"`
+}
+
+// MatchesLabels reports whether all matchers match the labels.
+func MatchesLabels(matchers []*Matcher, labels Labels) bool {
+	for _, m := range matchers {
+		if !m.Matches(labels[m.Name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Sample is a value at a timestamp in milliseconds.
+type Sample struct {
+	T int64
+	V float64
+}
+
+// Series is a label set with samples ordered by timestamp.
+type Series struct {
+	Labels  Labels
+	Samples []Sample
+}
+
+// Storage reads the series of a project. Returned series may include series not matching
+// the matchers, which are filtered by the engine.
+type Storage interface {
+	ReadPrometheusSeries(ctx context.Context, projectID int, matchers []*Matcher, start time.Time, end time.Time) ([]*Series, error)
+	ReadPrometheusSeriesLabels(ctx context.Context, projectID int, matchers []*Matcher, start time.Time, end time.Time) ([]Labels, error)
+}
+
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// SanitizeLabelName converts an attribute key, such as `http.method`, to a valid label name.
+func SanitizeLabelName(name string) string {
+	name = invalidLabelChars.ReplaceAllString(name, "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
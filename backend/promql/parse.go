@@ -0,0 +1,666 @@
+package promql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	e "github.com/pkg/errors"
+)
+
+type ValueType string
+
+const (
+	ValueTypeScalar ValueType = "scalar"
+	ValueTypeVector ValueType = "vector"
+	ValueTypeMatrix ValueType = "matrix"
+)
+
+// Expr is a node of a parsed query.
+type Expr interface {
+	Type() ValueType
+}
+
+type NumberLiteral struct {
+	Val float64
+}
+
+type VectorSelector struct {
+	Matchers []*Matcher
+	Offset   time.Duration
+}
+
+type MatrixSelector struct {
+	*VectorSelector
+	Range time.Duration
+}
+
+type Call struct {
+	Func *Function
+	Args []Expr
+}
+
+type AggregateExpr struct {
+	Op       string
+	Param    Expr
+	Expr     Expr
+	Grouping []string
+	Without  bool
+}
+
+type BinaryExpr struct {
+	Op  string
+	LHS Expr
+	RHS Expr
+}
+
+type UnaryExpr struct {
+	Expr Expr
+}
+
+func (*NumberLiteral) Type() ValueType  { return ValueTypeScalar }
+func (*VectorSelector) Type() ValueType { return ValueTypeVector }
+func (*MatrixSelector) Type() ValueType { return ValueTypeMatrix }
+func (c *Call) Type() ValueType         { return c.Func.ReturnType }
+func (*AggregateExpr) Type() ValueType  { return ValueTypeVector }
+func (u *UnaryExpr) Type() ValueType    { return u.Expr.Type() }
+func (b *BinaryExpr) Type() ValueType {
+	if b.LHS.Type() == ValueTypeScalar && b.RHS.Type() == ValueTypeScalar {
+		return ValueTypeScalar
+	}
+	return ValueTypeVector
+}
+
+var aggregators = map[string]bool{
+	"sum":     true,
+	"avg":     true,
+	"min":     true,
+	"max":     true,
+	"count":   true,
+	"topk":    true,
+	"bottomk": true,
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdentifier
+	tokenNumber
+	tokenDuration
+	tokenString
+	tokenOperator
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func lex(input string) ([]token, error) {
+	var tokens []token
+	for pos := 0; pos < len(input); {
+		c := rune(input[pos])
+		switch {
+		case unicode.IsSpace(c):
+			pos++
+		case c == '#':
+			// comments run until the end of the line
+			for pos < len(input) && input[pos] != '\n' {
+				pos++
+			}
+		case c == '"' || c == '\'' || c == '`':
+			end := pos + 1
+			for end < len(input) && rune(input[end]) != c {
+				if input[end] == '\\' && c != '`' {
+					end++
+				}
+				end++
+			}
+			if end >= len(input) {
+				return nil, e.Errorf("unterminated string at position %d", pos)
+			}
+			value, err := unquote(input[pos : end+1])
+			if err != nil {
+				return nil, e.Wrapf(err, "invalid string at position %d", pos)
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value, pos: pos})
+			pos = end + 1
+		case c >= '0' && c <= '9' || c == '.' && pos+1 < len(input) && input[pos+1] >= '0' && input[pos+1] <= '9':
+			end := pos
+			for end < len(input) && (input[end] >= '0' && input[end] <= '9' || input[end] == '.') {
+				end++
+			}
+			if end < len(input) && strings.ContainsRune("smhdwy", rune(input[end])) {
+				for end < len(input) && (input[end] >= '0' && input[end] <= '9' || strings.ContainsRune("smhdwy", rune(input[end]))) {
+					end++
+				}
+				tokens = append(tokens, token{kind: tokenDuration, value: input[pos:end], pos: pos})
+				pos = end
+				continue
+			}
+			if end < len(input) && (input[end] == 'e' || input[end] == 'E') {
+				end++
+				if end < len(input) && (input[end] == '+' || input[end] == '-') {
+					end++
+				}
+				for end < len(input) && input[end] >= '0' && input[end] <= '9' {
+					end++
+				}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: input[pos:end], pos: pos})
+			pos = end
+		case c == '_' || c == ':' || unicode.IsLetter(c):
+			end := pos
+			for end < len(input) && (input[end] == '_' || input[end] == ':' || unicode.IsLetter(rune(input[end])) || unicode.IsDigit(rune(input[end]))) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, value: input[pos:end], pos: pos})
+			pos = end
+		default:
+			op := string(c)
+			if pos+1 < len(input) {
+				if two := input[pos : pos+2]; two == "!=" || two == "=~" || two == "!~" || two == "==" || two == ">=" || two == "<=" {
+					op = two
+				}
+			}
+			if !strings.Contains("(){}[],=+-*/%^<>", op) && len(op) == 1 || op == "!" {
+				return nil, e.Errorf("unexpected character %q at position %d", c, pos)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, value: op, pos: pos})
+			pos += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(input)}), nil
+}
+
+func unquote(s string) (string, error) {
+	switch s[0] {
+	case '`':
+		return s[1 : len(s)-1], nil
+	case '\'':
+		inner := strings.ReplaceAll(s[1:len(s)-1], `\'`, `'`)
+		return strconv.Unquote(`"` + strings.ReplaceAll(inner, `"`, `\"`) + `"`)
+	default:
+		return strconv.Unquote(s)
+	}
+}
+
+var durationUnits = map[string]time.Duration{
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+	"y":  365 * 24 * time.Hour,
+}
+
+// ParseDuration parses a duration such as `5m` or `1h30m`, or a number of seconds.
+func ParseDuration(s string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+		if math.IsNaN(seconds) || math.IsInf(seconds, 0) || seconds < 0 {
+			return 0, e.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	var result time.Duration
+	rest := s
+	for rest != "" {
+		digits := 0
+		for digits < len(rest) && rest[digits] >= '0' && rest[digits] <= '9' {
+			digits++
+		}
+		if digits == 0 {
+			return 0, e.Errorf("invalid duration %q", s)
+		}
+		value, _ := strconv.Atoi(rest[:digits])
+		rest = rest[digits:]
+
+		unit := "ms"
+		if !strings.HasPrefix(rest, unit) {
+			if rest == "" {
+				return 0, e.Errorf("missing unit in duration %q", s)
+			}
+			unit = rest[:1]
+		}
+		d, ok := durationUnits[unit]
+		if !ok {
+			return 0, e.Errorf("unknown unit %q in duration %q", unit, s)
+		}
+		rest = rest[len(unit):]
+		result += time.Duration(value) * d
+	}
+	if result == 0 {
+		return 0, e.Errorf("duration %q must be positive", s)
+	}
+	return result, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// Parse parses the supported subset of PromQL: selectors, arithmetic, the aggregations
+// sum, avg, min, max, count, topk and bottomk, and the functions listed in Functions.
+func Parse(input string) (Expr, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	expr, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.unexpected(t)
+	}
+	return expr, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) unexpected(t token) error {
+	if t.kind == tokenEOF {
+		return e.New("unexpected end of query")
+	}
+	return e.Errorf("unexpected %q at position %d", t.value, t.pos)
+}
+
+func (p *parser) expect(value string) error {
+	if t := p.next(); t.kind != tokenOperator || t.value != value {
+		return p.unexpected(t)
+	}
+	return nil
+}
+
+func (p *parser) acceptOperator(value string) bool {
+	if t := p.peek(); t.kind == tokenOperator && t.value == value {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+var binaryPrecedence = map[string]int{
+	"+": 1,
+	"-": 1,
+	"*": 2,
+	"/": 2,
+	"%": 2,
+	"^": 3,
+}
+
+func (p *parser) parseExpr(minPrecedence int) (Expr, error) {
+	lhs, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tokenOperator {
+			return lhs, nil
+		}
+		switch t.value {
+		case "==", "!=", ">", "<", ">=", "<=":
+			return nil, e.Errorf("comparison operator %q is not supported", t.value)
+		}
+		precedence, ok := binaryPrecedence[t.value]
+		if !ok || precedence < minPrecedence {
+			return lhs, nil
+		}
+		p.next()
+		if t := p.peek(); t.kind == tokenIdentifier {
+			switch t.value {
+			case "bool", "on", "ignoring", "group_left", "group_right":
+				return nil, e.Errorf("vector matching modifier %q is not supported", t.value)
+			}
+		}
+
+		// `^` is right associative
+		nextPrecedence := precedence + 1
+		if t.value == "^" {
+			nextPrecedence = precedence
+		}
+		rhs, err := p.parseExpr(nextPrecedence)
+		if err != nil {
+			return nil, err
+		}
+		for _, operand := range []Expr{lhs, rhs} {
+			if operand.Type() != ValueTypeScalar && operand.Type() != ValueTypeVector {
+				return nil, e.Errorf("binary expression must contain only scalar and instant vector types")
+			}
+		}
+		lhs = &BinaryExpr{Op: t.value, LHS: lhs, RHS: rhs}
+	}
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if p.acceptOperator("-") {
+		expr, err := p.parseExpr(binaryPrecedence["^"])
+		if err != nil {
+			return nil, err
+		}
+		if number, ok := expr.(*NumberLiteral); ok {
+			return &NumberLiteral{Val: -number.Val}, nil
+		}
+		return &UnaryExpr{Expr: expr}, nil
+	}
+	if p.acceptOperator("+") {
+		return p.parseExpr(binaryPrecedence["^"])
+	}
+	expr, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	return p.parsePostfix(expr)
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, e.Errorf("invalid number %q", t.value)
+		}
+		return &NumberLiteral{Val: value}, nil
+	case tokenOperator:
+		switch t.value {
+		case "(":
+			expr, err := p.parseExpr(0)
+			if err != nil {
+				return nil, err
+			}
+			return expr, p.expect(")")
+		case "{":
+			return p.parseSelector("")
+		}
+	case tokenIdentifier:
+		switch strings.ToLower(t.value) {
+		case "inf":
+			return &NumberLiteral{Val: math.Inf(1)}, nil
+		case "nan":
+			return &NumberLiteral{Val: math.NaN()}, nil
+		}
+		next := p.peek()
+		isParen := next.kind == tokenOperator && next.value == "("
+		isGrouping := next.kind == tokenIdentifier && (next.value == "by" || next.value == "without")
+		if aggregators[t.value] && (isParen || isGrouping) {
+			return p.parseAggregate(t.value)
+		}
+		if isParen {
+			return p.parseCall(t)
+		}
+		if next.kind == tokenOperator && next.value == "{" {
+			p.next()
+		} else {
+			return p.newSelector(t.value, nil)
+		}
+		return p.parseSelector(t.value)
+	}
+	return nil, p.unexpected(t)
+}
+
+func (p *parser) newSelector(name string, matchers []*Matcher) (Expr, error) {
+	if name != "" {
+		m, _ := NewMatcher(MatchEqual, MetricNameLabel, name)
+		matchers = append([]*Matcher{m}, matchers...)
+	}
+	// as in prometheus, a selector must not match every series
+	matchesEverything := true
+	for _, m := range matchers {
+		if !m.Matches("") {
+			matchesEverything = false
+		}
+	}
+	if matchesEverything {
+		return nil, e.New("vector selector must contain at least one non-empty matcher")
+	}
+	return &VectorSelector{Matchers: matchers}, nil
+}
+
+// parseSelector parses the label matchers following the opening brace.
+func (p *parser) parseSelector(name string) (Expr, error) {
+	var matchers []*Matcher
+	for !p.acceptOperator("}") {
+		label := p.next()
+		if label.kind != tokenIdentifier {
+			return nil, p.unexpected(label)
+		}
+		op := p.next()
+		var matchType MatchType
+		switch op.value {
+		case "=":
+			matchType = MatchEqual
+		case "!=":
+			matchType = MatchNotEqual
+		case "=~":
+			matchType = MatchRegexp
+		case "!~":
+			matchType = MatchNotRegexp
+		default:
+			return nil, p.unexpected(op)
+		}
+		value := p.next()
+		if value.kind != tokenString {
+			return nil, p.unexpected(value)
+		}
+		m, err := NewMatcher(matchType, label.value, value.value)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+		if !p.acceptOperator(",") {
+			if err := p.expect("}"); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	return p.newSelector(name, matchers)
+}
+
+func (p *parser) parseDuration() (time.Duration, error) {
+	t := p.next()
+	if t.kind != tokenDuration {
+		return 0, p.unexpected(t)
+	}
+	return ParseDuration(t.value)
+}
+
+// parsePostfix parses a range and an offset following a selector.
+func (p *parser) parsePostfix(expr Expr) (Expr, error) {
+	selector, ok := expr.(*VectorSelector)
+	if !ok {
+		return expr, nil
+	}
+	if p.acceptOperator("[") {
+		d, err := p.parseDuration()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.peek(); t.kind == tokenOperator && t.value != "]" {
+			return nil, e.New("subqueries are not supported")
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		expr = &MatrixSelector{VectorSelector: selector, Range: d}
+	}
+	if t := p.peek(); t.kind == tokenIdentifier && t.value == "offset" {
+		p.next()
+		negative := p.acceptOperator("-")
+		d, err := p.parseDuration()
+		if err != nil {
+			return nil, err
+		}
+		if negative {
+			d = -d
+		}
+		selector.Offset = d
+	}
+	return expr, nil
+}
+
+func (p *parser) parseGrouping() ([]string, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var labels []string
+	for !p.acceptOperator(")") {
+		t := p.next()
+		if t.kind != tokenIdentifier {
+			return nil, p.unexpected(t)
+		}
+		labels = append(labels, t.value)
+		if !p.acceptOperator(",") {
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	return labels, nil
+}
+
+func (p *parser) parseAggregate(op string) (Expr, error) {
+	agg := &AggregateExpr{Op: op}
+	parseModifier := func() error {
+		if t := p.peek(); t.kind == tokenIdentifier && (t.value == "by" || t.value == "without") {
+			p.next()
+			grouping, err := p.parseGrouping()
+			if err != nil {
+				return err
+			}
+			agg.Grouping = grouping
+			agg.Without = t.value == "without"
+		}
+		return nil
+	}
+
+	if err := parseModifier(); err != nil {
+		return nil, err
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	if op == "topk" || op == "bottomk" {
+		param, err := p.parseExpr(0)
+		if err != nil {
+			return nil, err
+		}
+		if param.Type() != ValueTypeScalar {
+			return nil, e.Errorf("expected scalar parameter of %s", op)
+		}
+		agg.Param = param
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+	expr, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+	if expr.Type() != ValueTypeVector {
+		return nil, e.Errorf("expected instant vector in aggregation %s, got %s", op, expr.Type())
+	}
+	agg.Expr = expr
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	if agg.Grouping == nil {
+		if err := parseModifier(); err != nil {
+			return nil, err
+		}
+	}
+	return agg, nil
+}
+
+func (p *parser) parseCall(name token) (Expr, error) {
+	fn, ok := Functions[name.value]
+	if !ok {
+		return nil, e.Errorf("unknown function %q", name.value)
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	call := &Call{Func: fn}
+	for !p.acceptOperator(")") {
+		arg, err := p.parseExpr(0)
+		if err != nil {
+			return nil, err
+		}
+		call.Args = append(call.Args, arg)
+		if !p.acceptOperator(",") {
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	if len(call.Args) != len(fn.ArgTypes) {
+		return nil, e.Errorf("expected %d arguments for %s, got %d", len(fn.ArgTypes), fn.Name, len(call.Args))
+	}
+	for idx, arg := range call.Args {
+		if arg.Type() != fn.ArgTypes[idx] {
+			return nil, e.Errorf("expected type %s in call to %s, got %s", fn.ArgTypes[idx], fn.Name, arg.Type())
+		}
+	}
+	return call, nil
+}
+
+// selectors returns the vector selectors of an expression with the range of samples they read.
+func selectors(expr Expr) map[*VectorSelector]time.Duration {
+	result := map[*VectorSelector]time.Duration{}
+	var walk func(Expr)
+	walk = func(expr Expr) {
+		switch n := expr.(type) {
+		case *VectorSelector:
+			result[n] = 0
+		case *MatrixSelector:
+			result[n.VectorSelector] = n.Range
+		case *Call:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *AggregateExpr:
+			if n.Param != nil {
+				walk(n.Param)
+			}
+			walk(n.Expr)
+		case *BinaryExpr:
+			walk(n.LHS)
+			walk(n.RHS)
+		case *UnaryExpr:
+			walk(n.Expr)
+		}
+	}
+	walk(expr)
+	return result
+}
+
+func (s *VectorSelector) String() string {
+	matchers := make([]string, 0, len(s.Matchers))
+	for _, m := range s.Matchers {
+		matchers = append(matchers, m.String())
+	}
+	return fmt.Sprintf("{%s}", strings.Join(matchers, ","))
+}
@@ -0,0 +1,104 @@
+package promql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for query, expected := range map[string]ValueType{
+		`1 + 1`:                                   ValueTypeScalar,
+		`http_requests_total`:                     ValueTypeVector,
+		`http_requests_total{method="GET"}[5m]`:   ValueTypeMatrix,
+		`rate(http_requests_total[5m] offset 1h)`: ValueTypeVector,
+		`sum by (method) (rate(http_requests_total{job=~"api|web"}[5m]))`:                   ValueTypeVector,
+		`sum(rate(http_requests_total[5m])) without (instance)`:                             ValueTypeVector,
+		`topk(5, sum by (route) (increase(http_requests_total[1h])))`:                       ValueTypeVector,
+		`histogram_quantile(0.95, sum by (le) (rate(request_duration_seconds_bucket[5m])))`: ValueTypeVector,
+		`sum(rate(errors_total[5m])) / sum(rate(http_requests_total[5m])) * 100`:            ValueTypeVector,
+		`-go_goroutines{job!="test", instance!~'10\\..*'}`:                                  ValueTypeVector,
+		`{__name__="go_goroutines"}`:                                                        ValueTypeVector,
+		`scalar(sum(up))`:                                                                   ValueTypeScalar,
+	} {
+		t.Run(query, func(t *testing.T) {
+			expr, err := Parse(query)
+			require.NoError(t, err)
+			assert.Equal(t, expected, expr.Type())
+		})
+	}
+}
+
+func TestParseStructure(t *testing.T) {
+	expr, err := Parse(`sum by (method, route) (rate(http_requests_total{status=~"5.."}[5m]))`)
+	require.NoError(t, err)
+
+	agg, ok := expr.(*AggregateExpr)
+	require.True(t, ok)
+	assert.Equal(t, "sum", agg.Op)
+	assert.Equal(t, []string{"method", "route"}, agg.Grouping)
+	assert.False(t, agg.Without)
+
+	call, ok := agg.Expr.(*Call)
+	require.True(t, ok)
+	assert.Equal(t, "rate", call.Func.Name)
+
+	selector, ok := call.Args[0].(*MatrixSelector)
+	require.True(t, ok)
+	assert.Equal(t, 5*time.Minute, selector.Range)
+	assert.Equal(t, `{__name__="http_requests_total",status=~"5.."}`, selector.VectorSelector.String())
+	assert.True(t, MatchesLabels(selector.Matchers, Labels{MetricNameLabel: "http_requests_total", "status": "503"}))
+	assert.False(t, MatchesLabels(selector.Matchers, Labels{MetricNameLabel: "http_requests_total", "status": "200"}))
+
+	expr, err = Parse(`1 + 2 * 3 ^ 2 ^ 0.5`)
+	require.NoError(t, err)
+	binary := expr.(*BinaryExpr)
+	assert.Equal(t, "+", binary.Op)
+	assert.Equal(t, "*", binary.RHS.(*BinaryExpr).Op)
+	// `^` is right associative
+	assert.Equal(t, "^", binary.RHS.(*BinaryExpr).RHS.(*BinaryExpr).RHS.(*BinaryExpr).Op)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, query := range []string{
+		``,
+		`rate(http_requests_total)`,
+		`unknown_function(up)`,
+		`{job=~".*"}`,
+		`up{job="api"`,
+		`sum(up) by job`,
+		`up == 1`,
+		`up / on(job) down`,
+		`rate(up[5m:1m])`,
+		`histogram_quantile(up, up)`,
+		`up[5m] + 1`,
+		`up{job=~"("}`,
+	} {
+		t.Run(query, func(t *testing.T) {
+			_, err := Parse(query)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"5m":    5 * time.Minute,
+		"1h30m": 90 * time.Minute,
+		"1d":    24 * time.Hour,
+		"250ms": 250 * time.Millisecond,
+		"15":    15 * time.Second,
+		"0.5":   500 * time.Millisecond,
+	} {
+		d, err := ParseDuration(value)
+		require.NoError(t, err)
+		assert.Equal(t, expected, d, value)
+	}
+
+	for _, value := range []string{"", "m", "5x", "-1"} {
+		_, err := ParseDuration(value)
+		assert.Error(t, err, value)
+	}
+}
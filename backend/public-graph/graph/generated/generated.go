@@ -412,6 +412,7 @@ input StackFrameInput {
 	isEval: Boolean
 	isNative: Boolean
 	source: String
+	debugId: String
 }
 
 input ErrorObjectInput {
@@ -4238,7 +4239,7 @@ func (ec *executionContext) unmarshalInputStackFrameInput(ctx context.Context, o
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"functionName", "args", "fileName", "lineNumber", "columnNumber", "isEval", "isNative", "source"}
+	fieldsInOrder := [...]string{"functionName", "args", "fileName", "lineNumber", "columnNumber", "isEval", "isNative", "source", "debugId"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -4301,6 +4302,13 @@ func (ec *executionContext) unmarshalInputStackFrameInput(ctx context.Context, o
 				return it, err
 			}
 			it.Source = data
+		case "debugId":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("debugId"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.DebugID = data
 		}
 	}
 
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	highlightHttp "github.com/highlight-run/highlight/backend/http"
 	"github.com/highlight-run/highlight/backend/model"
 )
 
@@ -31,10 +32,13 @@ func PublicMiddleware(next http.Handler) http.Handler {
 		UserAgent := r.Header.Get("user-agent")
 		// get the accept-language string
 		AcceptLanguage := r.Header.Get("Accept-Language")
+		// the ingest key authorizing backend payloads
+		IngestKey := r.Header.Get(highlightHttp.IngestKeyHeader)
 		// Pass the user's id, ip address, user agent, and accept-language through context.
 		ctx := context.WithValue(r.Context(), model.ContextKeys.IP, IPAddress)
 		ctx = context.WithValue(ctx, model.ContextKeys.UserAgent, UserAgent)
 		ctx = context.WithValue(ctx, model.ContextKeys.AcceptLanguage, AcceptLanguage)
+		ctx = context.WithValue(ctx, model.ContextKeys.IngestKey, IngestKey)
 		r = r.WithContext(ctx)
 		next.ServeHTTP(w, r)
 	})
@@ -89,6 +89,7 @@ type StackFrameInput struct {
 	IsEval       *bool         `json:"isEval,omitempty"`
 	IsNative     *bool         `json:"isNative,omitempty"`
 	Source       *string       `json:"source,omitempty"`
+	DebugID      *string       `json:"debugId,omitempty"`
 }
 
 type PublicGraphError string
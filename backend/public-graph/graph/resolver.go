@@ -24,6 +24,7 @@ import (
 	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/highlight-run/go-resthooks"
 	"github.com/highlight-run/highlight/backend/alerts"
+	alertsV2 "github.com/highlight-run/highlight/backend/alerts/v2"
 	"github.com/highlight-run/highlight/backend/clickhouse"
 	"github.com/highlight-run/highlight/backend/email"
 	"github.com/highlight-run/highlight/backend/embeddings"
@@ -42,6 +43,7 @@ import (
 	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
 	modelInputs "github.com/highlight-run/highlight/backend/public-graph/graph/model"
 	publicModel "github.com/highlight-run/highlight/backend/public-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/redaction"
 	"github.com/highlight-run/highlight/backend/redis"
 	"github.com/highlight-run/highlight/backend/stacktraces"
 	"github.com/highlight-run/highlight/backend/storage"
@@ -398,6 +400,28 @@ func (r *Resolver) getMappedStackTraceString(ctx context.Context, stackTrace []*
 	return newMappedStackTraceString, mappedStackTrace, nil
 }
 
+// getRetracedStackTraceString deobfuscates a JVM stack trace with the ProGuard/R8 mapping uploaded for the error's version.
+// Returns nil if the stack trace was not retraced.
+func (r *Resolver) getRetracedStackTraceString(ctx context.Context, stackTrace string, projectID int, errorObj *model.ErrorObject) (*string, []*privateModel.ErrorTrace, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "getRetracedStackTraceString")
+	defer span.Finish()
+
+	structuredStackTrace, err := r.Store.StructuredStackTrace(ctx, stackTrace)
+	if err != nil {
+		return nil, nil, e.Wrap(err, "error parsing stacktrace to retrace")
+	}
+	retracedStackTrace, err := stacktraces.RetraceStackTrace(ctx, structuredStackTrace, projectID, r.GetErrorAppVersion(ctx, errorObj), r.StorageClient)
+	if err != nil || retracedStackTrace == nil {
+		return nil, nil, err
+	}
+
+	retracedStackTraceBytes, err := json.Marshal(retracedStackTrace)
+	if err != nil {
+		return nil, nil, e.Wrap(err, "error marshalling retraced stack trace")
+	}
+	return pointy.String(string(retracedStackTraceBytes)), retracedStackTrace, nil
+}
+
 func (r *Resolver) tagErrorGroup(ctx context.Context, errorObj *model.ErrorObject) *int {
 	eMatchCtx, cancel := context.WithTimeout(ctx, embeddings.InferenceTimeout)
 	defer cancel()
@@ -450,6 +474,7 @@ func (r *Resolver) GetOrCreateErrorGroup(ctx context.Context, errorObj *model.Er
 			Type:             errorObj.Type,
 			State:            privateModel.ErrorStateOpen,
 			ServiceName:      errorObj.ServiceName,
+			FirstSeenVersion: lo.EmptyableToPtr(errorObj.ServiceVersion),
 		}
 
 		if tagGroup {
@@ -466,6 +491,7 @@ func (r *Resolver) GetOrCreateErrorGroup(ctx context.Context, errorObj *model.Er
 			}
 		}
 
+		newErrorGroup.Created = true
 		errorGroup = newErrorGroup
 	} else {
 		if err := r.DB.WithContext(ctx).Where(&model.ErrorGroup{
@@ -474,24 +500,44 @@ func (r *Resolver) GetOrCreateErrorGroup(ctx context.Context, errorObj *model.Er
 			return nil, e.Wrap(err, "error retrieving top matched error group")
 		}
 
-		updatedState := errorGroup.State
+		// errors matching a merged error group are grouped into the group it was merged into
+		if errorGroup.MergedIntoID != nil {
+			if err := r.DB.WithContext(ctx).Where(&model.ErrorGroup{
+				Model: model.Model{ID: *errorGroup.MergedIntoID},
+			}).Take(&errorGroup).Error; err != nil {
+				return nil, e.Wrap(err, "error retrieving merged error group")
+			}
+		}
 
-		// Reopen resolved errors
+		// Reopen resolved errors, unless they were resolved in a version that is newer than the error's
 		// Note that ignored errors do change state
-		var shouldUpdate bool
-		if updatedState == privateModel.ErrorStateResolved {
-			updatedState = privateModel.ErrorStateOpen
-			shouldUpdate = true
+		if errorgroups.IsRegression(errorGroup, errorObj.ServiceVersion) {
+			s, sCtx := util.StartSpanFromContext(ctx, "GetOrCreateErrorGroup.Regress")
+			// only the request that reopens the group records the regression
+			result := r.DB.WithContext(sCtx).Model(errorGroup).
+				Where("state = ?", privateModel.ErrorStateResolved).
+				Updates(map[string]interface{}{
+					"State":                privateModel.ErrorStateOpen,
+					"ResolvedInVersion":    nil,
+					"ResolvedAfterVersion": nil,
+				})
+			if result.Error != nil {
+				s.Finish(result.Error)
+				return nil, e.Wrap(result.Error, "Error reopening regressed error group")
+			}
+			s.Finish()
+			if result.RowsAffected > 0 {
+				errorGroup.State = privateModel.ErrorStateOpen
+				errorGroup.ResolvedInVersion = nil
+				errorGroup.ResolvedAfterVersion = nil
+				errorGroup.Regressed = true
+			}
 		}
 
 		if errorGroup.ErrorTagID == nil && tagGroup {
 			errorGroup.ErrorTagID = r.tagErrorGroup(ctx, errorObj)
-			shouldUpdate = true
-		}
-		if shouldUpdate {
 			s, sCtx := util.StartSpanFromContext(ctx, "GetOrCreateErrorGroup.Update")
 			if err := r.DB.WithContext(sCtx).Model(errorGroup).Updates(&model.ErrorGroup{
-				State:      updatedState,
 				ErrorTagID: errorGroup.ErrorTagID,
 			}).Error; err != nil {
 				s.Finish(err)
@@ -619,7 +665,7 @@ func (r *Resolver) GetTopErrorGroupMatch(ctx context.Context, event string, proj
 			FROM json_array_elements_text(@jsonString) with ordinality
 		)
 	    SELECT id, sum(score) FROM (
-			SELECT id, 100 AS score, 0
+			SELECT COALESCE(merged_into_id, id) AS id, 100 AS score, 0
 			FROM error_groups
 			WHERE event = @event
 			AND id IS NOT NULL
@@ -703,6 +749,26 @@ func (r *Resolver) isWithinErrorQuota(ctx context.Context, workspace *model.Work
 	return withinBillingQuota
 }
 
+// GetErrorGroupMatchByRuleFingerprint returns the error group with the fingerprint of a grouping rule.
+func (r *Resolver) GetErrorGroupMatchByRuleFingerprint(ctx context.Context, projectID int, fingerprint string) (*int, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "resolver.GetErrorGroupMatchByRuleFingerprint", util.Tag("projectID", projectID))
+	defer span.Finish()
+
+	var errorGroupIDs []int
+	if err := r.DB.WithContext(ctx).Model(&model.ErrorFingerprint{}).
+		Where(&model.ErrorFingerprint{ProjectID: projectID, Type: model.Fingerprint.GroupingRule, Value: fingerprint}).
+		Where("error_group_id IS NOT NULL").
+		Order("error_group_id DESC").
+		Limit(1).
+		Pluck("error_group_id", &errorGroupIDs).Error; err != nil {
+		return nil, e.Wrap(err, "error querying error group by grouping rule fingerprint")
+	}
+	if len(errorGroupIDs) == 0 {
+		return nil, nil
+	}
+	return &errorGroupIDs[0], nil
+}
+
 // HandleErrorAndGroup caches the result of handleErrorAndGroup under the exact match of the error body + stacktrace.
 // Improves performance of handleErrorAndGroup by first checking if the exact error object has been grouped before.
 func (r *Resolver) HandleErrorAndGroup(ctx context.Context, errorObj *model.ErrorObject, timestamps []time.Time, structuredStackTrace []*privateModel.ErrorTrace, projectID int, workspace *model.Workspace) (*model.ErrorGroup, []*model.ErrorObject, error) {
@@ -744,11 +810,28 @@ func (r *Resolver) HandleErrorAndGroup(ctx context.Context, errorObj *model.Erro
 		}
 	}
 
-	key := errorgroups.GetKey(projectID, errorObj, structuredStackTrace)
+	// redact personal data before the error is grouped or written
+	redactor, err := r.Store.GetRedactor(ctx, projectID)
+	if err != nil {
+		return nil, nil, e.Wrap(err, "error querying redaction rules")
+	}
+	redactor.RedactErrorObject(errorObj)
+	redactor.RedactErrorTrace(structuredStackTrace)
+	if err := r.Store.RecordRedactions(ctx, redactor, redaction.PathErrors); err != nil {
+		log.WithContext(ctx).WithError(err).Error("failed to record redactions")
+	}
+
+	groupingRules, err := r.Store.GetErrorGroupingRules(ctx, projectID)
+	if err != nil {
+		return nil, nil, e.Wrap(err, "error querying error grouping rules")
+	}
+	grouping := errorgroups.ApplyGroupingRules(groupingRules, errorObj, structuredStackTrace)
+
+	key := errorgroups.GetKey(projectID, errorObj, grouping)
 	var cacheMiss bool
 	eg, err := redis.CachedEval(ctx, r.Redis, key, 10*time.Second, time.Hour, func() (*model.ErrorGroup, error) {
 		cacheMiss = true
-		return r.handleErrorAndGroup(ctx, project, errorObj, structuredStackTrace, projectID, workspace)
+		return r.handleErrorAndGroup(ctx, project, errorObj, grouping, projectID, workspace)
 	})
 	if eg == nil || err != nil {
 		log.WithContext(ctx).WithError(err).WithField("project_id", projectID).Error("failed to group error")
@@ -789,16 +872,116 @@ func (r *Resolver) HandleErrorAndGroup(ctx context.Context, errorObj *model.Erro
 		return nil, nil, err
 	}
 
+	if errorObj.ServiceVersion != "" {
+		if _, err := r.Store.UpsertRelease(ctx, projectID, errorObj.ServiceVersion); err != nil {
+			log.WithContext(ctx).WithError(err).Error("failed to create release")
+		}
+	}
+
+	if eg.Created && len(newObjects) > 0 {
+		if err := r.assignErrorGroupOwner(ctx, project, eg, newObjects[0], structuredStackTrace); err != nil {
+			log.WithContext(ctx).WithError(err).WithField("error_group_id", eg.ID).Error("failed to assign error group owner")
+		}
+	}
+
+	if eg.Regressed && len(newObjects) > 0 {
+		if err := r.Store.CreateErrorGroupRegressedActivityLog(ctx, newObjects[0]); err != nil {
+			log.WithContext(ctx).WithError(err).WithField("error_group_id", eg.ID).Error("failed to record error group regression")
+		}
+		go func() {
+			defer util.Recover()
+			r.sendErrorGroupRegressionAlerts(context.WithoutCancel(ctx), eg, newObjects[0])
+		}()
+	}
+
 	return eg, newObjects, err
 }
 
+// assignErrorGroupOwner assigns a new error group to the owner of the first matching ownership rule of the project.
+func (r *Resolver) assignErrorGroupOwner(ctx context.Context, project *model.Project, group *model.ErrorGroup, errorObject *model.ErrorObject, structuredStackTrace []*privateModel.ErrorTrace) error {
+	rules, err := r.Store.GetErrorOwnershipRules(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+	rule := errorgroups.MatchOwnershipRule(rules, errorObject, structuredStackTrace)
+	if rule == nil {
+		return nil
+	}
+
+	assigned, err := r.Store.AssignErrorGroupBySystem(ctx, project.WorkspaceID, store.AssignErrorGroupParams{
+		ID:      group.ID,
+		AdminID: rule.AdminID,
+		TeamID:  rule.TeamID,
+	})
+	if err != nil {
+		return err
+	}
+	group.AssigneeAdminID = assigned.AssigneeAdminID
+	group.AssigneeTeamID = assigned.AssigneeTeamID
+	return nil
+}
+
+// sendErrorGroupRegressionAlerts sends the project's regression alerts matching the error that reopened the group.
+func (r *Resolver) sendErrorGroupRegressionAlerts(ctx context.Context, group *model.ErrorGroup, errorObject *model.ErrorObject) {
+	span, ctx := util.StartSpanFromContext(ctx, "resolver.sendErrorGroupRegressionAlerts", util.Tag("error_group_id", group.ID))
+	defer span.Finish()
+
+	var regressionAlerts []*model.Alert
+	if err := r.DB.WithContext(ctx).Model(&model.Alert{}).
+		Where(&model.Alert{ProjectID: group.ProjectID, ProductType: privateModel.ProductTypeErrors, ThresholdType: privateModel.ThresholdTypeRegression}).
+		Where("disabled = ?", false).
+		Find(&regressionAlerts).Error; err != nil {
+		log.WithContext(ctx).WithError(err).WithField("project_id", group.ProjectID).Error("failed to query regression alerts")
+		return
+	}
+
+	for _, alert := range regressionAlerts {
+		if alert.Query != nil && *alert.Query != "" {
+			testErrorObject := &publicModel.BackendErrorObjectInput{
+				Environment: errorObject.Environment,
+				Event:       errorObject.Event,
+				Payload:     errorObject.Payload,
+				Service: &modelInputs.ServiceInput{
+					Name:    errorObject.ServiceName,
+					Version: errorObject.ServiceVersion,
+				},
+				Source:     errorObject.Source,
+				StackTrace: pointy.StringValue(errorObject.StackTrace, ""),
+				Timestamp:  errorObject.Timestamp,
+				Type:       errorObject.Type,
+				URL:        errorObject.URL,
+			}
+			filters := parser.Parse(*alert.Query, clickhouse.BackendErrorObjectInputConfig)
+			if !clickhouse.ErrorMatchesQuery(testErrorObject, filters) {
+				continue
+			}
+		}
+
+		if err := alertsV2.SendAlerts(ctx, r.DB, r.MailClient, r.LambdaClient, alert, "secure_id", group.SecureID, 1); err != nil {
+			log.WithContext(ctx).WithError(err).WithField("alert_id", alert.ID).Error("failed to send regression alert")
+		}
+	}
+}
+
 // Matches the ErrorObject with an existing ErrorGroup, or creates a new one if the group does not exist
-func (r *Resolver) handleErrorAndGroup(ctx context.Context, project *model.Project, errorObj *model.ErrorObject, structuredStackTrace []*privateModel.ErrorTrace, projectID int, workspace *model.Workspace) (*model.ErrorGroup, error) {
+func (r *Resolver) handleErrorAndGroup(ctx context.Context, project *model.Project, errorObj *model.ErrorObject, grouping *errorgroups.GroupingResult, projectID int, workspace *model.Workspace) (*model.ErrorGroup, error) {
 	span, ctx := util.StartSpanFromContext(ctx, "handleErrorAndGroup", util.Tag("projectID", projectID))
 	defer span.Finish()
 
 	var fingerprints []*model.ErrorFingerprint
-	fingerprints = append(fingerprints, errorgroups.GetFingerprints(projectID, structuredStackTrace)...)
+	fingerprints = append(fingerprints, errorgroups.GetFingerprints(projectID, grouping.Frames)...)
+
+	// a grouping rule decided the group of the error, so it is grouped by exact fingerprint match
+	if ruleFingerprint := errorgroups.GetRuleFingerprint(projectID, grouping); ruleFingerprint != nil {
+		errorObj.ErrorGroupingMethod = model.ErrorGroupingMethodClassic
+		errorGroup, err := r.GetOrCreateErrorGroup(ctx, errorObj, func() (*int, error) {
+			return r.GetErrorGroupMatchByRuleFingerprint(ctx, projectID, ruleFingerprint.Value)
+		}, nil, false)
+		if err != nil {
+			return nil, e.Wrap(err, "Error getting or creating error group")
+		}
+		return r.replaceErrorGroupFingerprints(ctx, errorGroup, append(fingerprints, ruleFingerprint))
+	}
 
 	// Try unmarshalling the Event to JSON.
 	// If this works, create an error fingerprint for each of the project's JSON paths.
@@ -882,6 +1065,11 @@ func (r *Resolver) handleErrorAndGroup(ctx context.Context, project *model.Proje
 		}
 	}
 
+	return r.replaceErrorGroupFingerprints(ctx, errorGroup, fingerprints)
+}
+
+// replaceErrorGroupFingerprints sets the fingerprints of the latest error object as the fingerprints of its error group.
+func (r *Resolver) replaceErrorGroupFingerprints(ctx context.Context, errorGroup *model.ErrorGroup, fingerprints []*model.ErrorFingerprint) (*model.ErrorGroup, error) {
 	if err := r.DB.Transaction(func(tx *gorm.DB) error {
 		for _, f := range fingerprints {
 			f.ErrorGroupId = errorGroup.ID
@@ -897,6 +1085,7 @@ func (r *Resolver) handleErrorAndGroup(ctx context.Context, project *model.Proje
 			newIds = append(newIds, fingerprint.ID)
 		}
 
+		// fingerprints of error groups merged into this one are kept so that matching errors are redirected
 		if err := r.DB.Exec(`
 			UPDATE error_fingerprints
 			SET error_group_id = NULL
@@ -905,6 +1094,7 @@ func (r *Resolver) handleErrorAndGroup(ctx context.Context, project *model.Proje
 				FROM error_fingerprints
 				WHERE id NOT IN (?)
 				AND error_group_id = ?
+				AND merged_from_error_group_id IS NULL
 				ORDER BY id
 				FOR UPDATE
 			)
@@ -1290,6 +1480,12 @@ func (r *Resolver) InitializeSessionImpl(ctx context.Context, input *kafka_queue
 		}
 	}
 
+	if session.AppVersion != nil && *session.AppVersion != "" {
+		if _, err := r.Store.UpsertRelease(ctx, project.ID, *session.AppVersion); err != nil {
+			log.WithContext(ctx).Error(e.Wrap(err, "failed to create release"))
+		}
+	}
+
 	return session, nil
 }
 
@@ -2229,6 +2425,16 @@ func (r *Resolver) ProcessBackendPayloadImpl(ctx context.Context, sessionSecureI
 			stack = &firstInput.StackTrace
 		}
 
+		// deobfuscate android stack traces so that they are enhanced and grouped by their original frames
+		retraced, structured, err := r.getRetracedStackTraceString(ctx, *stack, projectID, firstObject)
+		if err != nil {
+			log.WithContext(ctx).WithError(err).Errorf("Failed to retrace stacktrace %v", *stack)
+		} else if retraced != nil {
+			firstObject.MappedStackTrace = retraced
+			structuredStackTrace = structured
+			stack = retraced
+		}
+
 		mapped, structured, err := r.Store.EnhancedStackTrace(ctx, *stack, workspace, &project, firstObject, nil)
 		if err != nil {
 			log.WithContext(ctx).WithError(err).Errorf("Failed to generate structured stacktrace %v", *stack)
@@ -2714,6 +2920,11 @@ func (r *Resolver) ProcessPayload(ctx context.Context, sessionSecureID string, e
 			hasFullSnapshot := false
 			hostUrl := parse.GetHostUrlFromEvents(parsedEvents.Events)
 
+			redactor, err := r.Store.GetRedactor(ctx, projectID)
+			if err != nil {
+				return e.Wrap(err, "error querying redaction rules")
+			}
+
 			eventLoopSpan, sCtx := util.StartSpanFromContext(ctx, "public-graph.pushPayload.eventLoop", util.Tag("project_id", projectID))
 			for _, event := range parsedEvents.Events {
 				if event.Type == parse.FullSnapshot || event.Type == parse.IncrementalSnapshot {
@@ -2729,6 +2940,9 @@ func (r *Resolver) ProcessPayload(ctx context.Context, sessionSecureID string, e
 						log.WithContext(sCtx).Error(e.Wrap(err, "Error escaping snapshot javascript"))
 					}
 
+					// redact personal data from text nodes and input values
+					snapshot.Redact(redactor)
+
 					// Replace any static resources with our own, hosted in S3
 					if settings != nil && settings.ReplaceAssets {
 						project, err := r.Store.GetProject(sCtx, projectID)
@@ -2775,6 +2989,10 @@ func (r *Resolver) ProcessPayload(ctx context.Context, sessionSecureID string, e
 			}
 			eventLoopSpan.Finish()
 
+			if err := r.Store.RecordRedactions(ctx, redactor, redaction.PathReplays); err != nil {
+				log.WithContext(ctx).WithError(err).Error("failed to record redactions")
+			}
+
 			remarshalSpan, _ := util.StartSpanFromContext(ctx, "public-graph.pushPayload.parseEvents.remarshalEvents",
 				util.Tag("project_id", projectID))
 			// Re-format as a string to write to the db.
@@ -102,6 +102,7 @@ func TestMain(m *testing.M) {
 		StorageClient:        &storage.FilesystemClient{},
 		Store:                store.NewStore(db, redisClient, integrations.NewIntegrationsClient(db), &storage.FilesystemClient{}, &kafka_queue.MockMessageQueue{}, nil),
 		EmbeddingsClient:     &mockEmbeddingsClient{},
+		ProducerQueue:        &kafka_queue.MockMessageQueue{},
 		DataSyncQueue:        &kafka_queue.MockMessageQueue{},
 		TracesQueue:          &kafka_queue.MockMessageQueue{},
 		MetricSumQueue:       &kafka_queue.MockMessageQueue{},
@@ -676,6 +677,40 @@ func TestInitializeSessionImpl(t *testing.T) {
 	})
 }
 
+func TestPushBackendPayloadIngestKeys(t *testing.T) {
+	util.RunTestWithDBWipe(t, resolver.DB, func(t *testing.T) {
+		ctx := context.TODO()
+		workspace := model.Workspace{}
+		resolver.DB.Create(&workspace)
+
+		project := model.Project{WorkspaceID: workspace.ID}
+		resolver.DB.Create(&project)
+
+		session := model.Session{ProjectID: project.ID, SecureID: "ingest-key-session"}
+		resolver.DB.Create(&session)
+
+		created, err := resolver.Store.CreateIngestKey(ctx, project.ID, "backend errors", privateModel.IngestKeyScopeBackend, []privateModel.ProductType{privateModel.ProductTypeErrors})
+		assert.NoError(t, err)
+
+		mutation := &mutationResolver{resolver}
+		errors := []*publicModel.BackendErrorObjectInput{{
+			SessionSecureID: ptr.String(session.SecureID),
+			Event:           "dummy event",
+			StackTrace:      "[]",
+		}}
+
+		// errors pushed with only a session secure id are checked against the keys of the session project
+		_, err = mutation.PushBackendPayload(ctx, nil, errors)
+		assert.Equal(t, store.ErrIngestKeyMissing, err)
+
+		_, err = mutation.PushBackendPayload(context.WithValue(ctx, model.ContextKeys.IngestKey, created.Key), nil, errors)
+		assert.NoError(t, err)
+
+		_, err = mutation.PushBackendPayload(ctx, ptr.String(project.VerboseID()), errors)
+		assert.Equal(t, store.ErrIngestKeyMissing, err)
+	})
+}
+
 func TestErrorIngestFilters(t *testing.T) {
 	ctx := context.TODO()
 
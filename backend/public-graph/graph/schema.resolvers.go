@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	kafkaqueue "github.com/highlight-run/highlight/backend/kafka-queue"
 	"github.com/highlight-run/highlight/backend/model"
+	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
 	generated1 "github.com/highlight-run/highlight/backend/public-graph/graph/generated"
 	customModels "github.com/highlight-run/highlight/backend/public-graph/graph/model"
 	"github.com/highlight-run/highlight/backend/util"
@@ -229,8 +230,43 @@ func (r *mutationResolver) PushPayloadCompressed(ctx context.Context, sessionSec
 
 // PushBackendPayload is the resolver for the pushBackendPayload field.
 func (r *mutationResolver) PushBackendPayload(ctx context.Context, projectID *string, errors []*customModels.BackendErrorObjectInput) (interface{}, error) {
+	ingestKey, _ := ctx.Value(model.ContextKeys.IngestKey).(string)
+	if projectID != nil {
+		projectIDInt, err := model.FromVerboseID(*projectID)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.Store.ValidateIngestKey(ctx, projectIDInt, ingestKey, privateModel.ProductTypeErrors, privateModel.LogSourceBackend); err != nil {
+			return nil, err
+		}
+	}
+
 	errorsBySecureID := map[*string][]*customModels.BackendErrorObjectInput{}
+	// whether the session of a secure id exists and its project accepts the ingest key
+	validSecureIDs := map[string]bool{}
 	for _, backendError := range errors {
+		// errors without a project are attributed to the project of their session,
+		// so the ingest keys of that project apply
+		if projectID == nil && backendError.SessionSecureID != nil {
+			secureID := *backendError.SessionSecureID
+			valid, ok := validSecureIDs[secureID]
+			if !ok {
+				session, err := r.Store.GetSessionFromSecureID(ctx, secureID)
+				if err == nil {
+					if err := r.Store.ValidateIngestKey(ctx, session.ProjectID, ingestKey, privateModel.ProductTypeErrors, privateModel.LogSourceBackend); err != nil {
+						return nil, err
+					}
+				} else {
+					log.WithContext(ctx).WithError(err).WithField("secure_id", secureID).
+						Warn("dropping backend errors of an unknown session")
+				}
+				valid = err == nil
+				validSecureIDs[secureID] = valid
+			}
+			if !valid {
+				continue
+			}
+		}
 		errorsBySecureID[backendError.SessionSecureID] = append(errorsBySecureID[backendError.SessionSecureID], backendError)
 	}
 	var messages []kafkaqueue.RetryableMessage
@@ -0,0 +1,383 @@
+package redaction
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	hmetric "github.com/highlight/highlight/sdk/highlight-go/metric"
+	e "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/util"
+)
+
+const (
+	// Mask replaces the values redacted by Mask rules.
+	Mask = "[REDACTED]"
+	// the prefix of the values redacted by Hash rules, followed by the start of their sha256
+	hashPrefix = "redacted:"
+	hashLength = 16
+	MaxRules   = 50
+)
+
+// Path is the ingest path of the redacted values, reported with the redaction counters.
+type Path string
+
+const (
+	PathLogs    Path = "logs"
+	PathTraces  Path = "traces"
+	PathErrors  Path = "errors"
+	PathReplays Path = "replays"
+)
+
+var detectors = map[modelInputs.RedactionDetector]*regexp.Regexp{
+	modelInputs.RedactionDetectorEmail:      regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+	modelInputs.RedactionDetectorCardNumber: regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+	modelInputs.RedactionDetectorJwt:        regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	// the keys of Stripe, AWS, GitHub, Slack and Google
+	modelInputs.RedactionDetectorAPIKey: regexp.MustCompile(`\b(?:(?:sk|pk|rk)_(?:live|test)_[A-Za-z0-9]{16,}|AKIA[0-9A-Z]{16}|gh[pousr]_[A-Za-z0-9]{36,}|xox[abprs]-[A-Za-z0-9-]{10,}|AIza[0-9A-Za-z_-]{35})\b`),
+}
+
+// matches of a detector are only redacted if they pass its check
+var detectorChecks = map[modelInputs.RedactionDetector]func(string) bool{
+	modelInputs.RedactionDetectorCardNumber: IsLuhnValid,
+}
+
+// IsLuhnValid returns whether the digits of a card number pass the Luhn checksum, ignoring spaces and dashes.
+func IsLuhnValid(number string) bool {
+	var sum, digits int
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if digits%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+	}
+	return digits >= 13 && digits <= 19 && sum%10 == 0
+}
+
+// ValidateRule returns an error if a rule cannot be applied.
+func ValidateRule(rule *model.RedactionRule) error {
+	if rule.Name == "" {
+		return e.New("redaction rule requires a name")
+	}
+	matchers := 0
+	if rule.Pattern != nil && *rule.Pattern != "" {
+		if _, err := util.CompileRegexp(*rule.Pattern); err != nil {
+			return e.Wrapf(err, "invalid pattern for redaction rule %s", rule.Name)
+		}
+		matchers++
+	}
+	if rule.Detector != nil {
+		if _, ok := detectors[*rule.Detector]; !ok {
+			return e.Errorf("invalid detector %s for redaction rule %s", *rule.Detector, rule.Name)
+		}
+		matchers++
+	}
+	if len(rule.AttributeKeys) > 0 {
+		for _, key := range rule.AttributeKeys {
+			if strings.TrimSpace(key) == "" {
+				return e.Errorf("redaction rule %s has an empty attribute key", rule.Name)
+			}
+		}
+		matchers++
+	}
+	if matchers != 1 {
+		return e.Errorf("redaction rule %s requires one of a pattern, a detector or attribute keys", rule.Name)
+	}
+	if !rule.Action.IsValid() {
+		return e.Errorf("invalid redaction action %s", rule.Action)
+	}
+	return nil
+}
+
+type rule struct {
+	name    string
+	pattern *regexp.Regexp
+	check   func(string) bool
+	// the lowercase attribute keys of a deny-list rule
+	keys   map[string]bool
+	action modelInputs.RedactionAction
+}
+
+// Redactor applies the redaction rules of a project and counts the values redacted by each rule.
+// The methods of a nil Redactor leave their input unchanged.
+type Redactor struct {
+	projectID int
+	rules     []*rule
+	keyRules  []*rule
+
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+// New compiles the redaction rules of a project, skipping rules that cannot be applied.
+func New(projectID int, rules []*model.RedactionRule) *Redactor {
+	r := &Redactor{projectID: projectID, counts: map[string]int64{}}
+	for _, redactionRule := range rules {
+		if err := ValidateRule(redactionRule); err != nil {
+			log.WithError(err).WithField("project_id", projectID).Warn("skipping invalid redaction rule")
+			continue
+		}
+		compiled := &rule{name: redactionRule.Name, action: redactionRule.Action}
+		switch {
+		case redactionRule.Pattern != nil && *redactionRule.Pattern != "":
+			compiled.pattern, _ = util.CompileRegexp(*redactionRule.Pattern)
+		case redactionRule.Detector != nil:
+			compiled.pattern = detectors[*redactionRule.Detector]
+			compiled.check = detectorChecks[*redactionRule.Detector]
+		default:
+			compiled.keys = map[string]bool{}
+			for _, key := range redactionRule.AttributeKeys {
+				compiled.keys[strings.ToLower(strings.TrimSpace(key))] = true
+			}
+			r.keyRules = append(r.keyRules, compiled)
+			continue
+		}
+		r.rules = append(r.rules, compiled)
+	}
+	return r
+}
+
+func (r *Redactor) ProjectID() int {
+	if r == nil {
+		return 0
+	}
+	return r.projectID
+}
+
+// Empty returns whether the redactor has no rules to apply.
+func (r *Redactor) Empty() bool {
+	return r == nil || len(r.rules)+len(r.keyRules) == 0
+}
+
+func (r *Redactor) count(rule *rule) {
+	r.mu.Lock()
+	r.counts[rule.name]++
+	r.mu.Unlock()
+}
+
+// replacement returns the value replacing a match of the rule. Dropped matches are removed.
+func (r *Redactor) replacement(rule *rule, match string) string {
+	switch rule.action {
+	case modelInputs.RedactionActionHash:
+		// hashes are salted by project so equal values can be correlated within a project only
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%s", r.projectID, match)))
+		return hashPrefix + hex.EncodeToString(sum[:])[:hashLength]
+	case modelInputs.RedactionActionDrop:
+		return ""
+	default:
+		return Mask
+	}
+}
+
+// Redact redacts the matches of the pattern and detector rules in a text.
+func (r *Redactor) Redact(value string) string {
+	return r.redact(value, true)
+}
+
+func (r *Redactor) redact(value string, count bool) string {
+	if r == nil || value == "" {
+		return value
+	}
+	for _, rule := range r.rules {
+		value = rule.pattern.ReplaceAllStringFunc(value, func(match string) string {
+			if rule.check != nil && !rule.check(match) {
+				return match
+			}
+			if count {
+				r.count(rule)
+			}
+			return r.replacement(rule, match)
+		})
+	}
+	return value
+}
+
+// keyRule returns the deny-list rule of an attribute key. Keys are matched case-insensitively,
+// and nested keys such as http.request.header.authorization also match by their last segment.
+func (r *Redactor) keyRule(key string) *rule {
+	key = strings.ToLower(key)
+	last := key
+	if idx := strings.LastIndex(key, "."); idx >= 0 {
+		last = key[idx+1:]
+	}
+	for _, rule := range r.keyRules {
+		if rule.keys[key] || rule.keys[last] {
+			return rule
+		}
+	}
+	return nil
+}
+
+// RedactAttributes redacts the attributes in place. The values of deny-listed keys are replaced,
+// or the attributes removed by Drop rules, and the other values are redacted like texts.
+func (r *Redactor) RedactAttributes(attributes map[string]string) {
+	if r.Empty() {
+		return
+	}
+	for key, value := range attributes {
+		if rule := r.keyRule(key); rule != nil {
+			r.count(rule)
+			if rule.action == modelInputs.RedactionActionDrop {
+				delete(attributes, key)
+			} else {
+				attributes[key] = r.replacement(rule, value)
+			}
+			continue
+		}
+		attributes[key] = r.Redact(value)
+	}
+}
+
+// RedactJSON redacts the keys and strings of a json document, or the whole value as a text if it is not json.
+// The value is returned unchanged if nothing was redacted.
+func (r *Redactor) RedactJSON(value string) string {
+	if r.Empty() || value == "" {
+		return value
+	}
+	var doc interface{}
+	if err := json.Unmarshal([]byte(value), &doc); err != nil {
+		return r.Redact(value)
+	}
+	redacted, changed := r.redactJSONValue(doc)
+	if !changed {
+		return value
+	}
+	b, err := json.Marshal(redacted)
+	if err != nil {
+		return r.Redact(value)
+	}
+	return string(b)
+}
+
+func (r *Redactor) redactJSONValue(value interface{}) (interface{}, bool) {
+	changed := false
+	switch typed := value.(type) {
+	case string:
+		redacted := r.Redact(typed)
+		return redacted, redacted != typed
+	case []interface{}:
+		for i, item := range typed {
+			redacted, itemChanged := r.redactJSONValue(item)
+			typed[i] = redacted
+			changed = changed || itemChanged
+		}
+	case map[string]interface{}:
+		for key, item := range typed {
+			if rule := r.keyRule(key); rule != nil {
+				r.count(rule)
+				changed = true
+				if rule.action == modelInputs.RedactionActionDrop {
+					delete(typed, key)
+				} else {
+					typed[key] = r.replacement(rule, fmt.Sprint(item))
+				}
+				continue
+			}
+			redacted, itemChanged := r.redactJSONValue(item)
+			typed[key] = redacted
+			changed = changed || itemChanged
+		}
+	}
+	return value, changed
+}
+
+// RedactLogRow redacts the body and attributes of a log.
+func (r *Redactor) RedactLogRow(row *clickhouse.LogRow) {
+	if r.Empty() {
+		return
+	}
+	row.Body = r.Redact(row.Body)
+	r.RedactAttributes(row.LogAttributes)
+}
+
+// RedactTraceRow redacts the attributes, status message and events of a span.
+func (r *Redactor) RedactTraceRow(row *clickhouse.TraceRow) {
+	if r.Empty() {
+		return
+	}
+	r.RedactAttributes(row.TraceAttributes)
+	row.StatusMessage = r.Redact(row.StatusMessage)
+	for _, event := range row.Events {
+		event.Name = r.Redact(event.Name)
+		r.RedactAttributes(event.Attributes)
+	}
+	for _, link := range row.Links {
+		r.RedactAttributes(link.Attributes)
+	}
+}
+
+// RedactErrorObject redacts the event, url, stack traces and payload of an error.
+func (r *Redactor) RedactErrorObject(errorObj *model.ErrorObject) {
+	if r.Empty() {
+		return
+	}
+	errorObj.Event = r.Redact(errorObj.Event)
+	errorObj.URL = r.Redact(errorObj.URL)
+	for _, value := range []*string{errorObj.StackTrace, errorObj.MappedStackTrace, errorObj.Payload} {
+		if value != nil {
+			*value = r.RedactJSON(*value)
+		}
+	}
+}
+
+// RedactErrorTrace redacts the error and source lines of the frames of a structured stack trace.
+// Matches are not counted, as the frames are also redacted in the stack trace of the error object.
+func (r *Redactor) RedactErrorTrace(frames []*modelInputs.ErrorTrace) {
+	if r.Empty() {
+		return
+	}
+	for _, frame := range frames {
+		if frame == nil {
+			continue
+		}
+		for _, value := range []*string{frame.Error, frame.LineContent, frame.LinesBefore, frame.LinesAfter} {
+			if value != nil {
+				*value = r.redact(*value, false)
+			}
+		}
+	}
+}
+
+// Flush returns the number of values redacted by each rule since the last flush and reports them
+// to the redaction counters of the path.
+func (r *Redactor) Flush(ctx context.Context, path Path) map[string]int64 {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	counts := r.counts
+	r.counts = map[string]int64{}
+	r.mu.Unlock()
+
+	for name, count := range counts {
+		hmetric.Histogram(ctx, "redaction.redacted", float64(count), []attribute.KeyValue{
+			attribute.Int("project_id", r.projectID),
+			attribute.String("rule", name),
+			attribute.String("path", string(path)),
+		}, 1)
+	}
+	return counts
+}
@@ -25,6 +25,7 @@ import (
 
 const CacheKeyHubspotCompanies = "hubspot-companies"
 const CacheKeySessionsToProcess = "sessions-to-process"
+const ServiceDependencyWatermarkKey = "service-dependency-watermark"
 
 type Client struct {
 	Client  redis.Cmdable
@@ -66,10 +67,18 @@ func GithubRateLimitKey(gitHubRepo string) string {
 	return fmt.Sprintf("github-rate-limit-exceeded-%s", gitHubRepo)
 }
 
+func RedactionCountsKey(projectId int) string {
+	return fmt.Sprintf("redaction-counts-%d", projectId)
+}
+
 func GitHubFileErrorKey(gitHubRepo string, version string, fileName string) string {
 	return fmt.Sprintf("github-file-error-%s-%s-%s", gitHubRepo, version, fileName)
 }
 
+func ServiceDependencyWindowLockKey(window time.Time) string {
+	return fmt.Sprintf("service-dependency-window-%d", window.Unix())
+}
+
 func SessionFieldsKey(sessionSecureId string) string {
 	return fmt.Sprintf("session-fields-%s", sessionSecureId)
 }
@@ -677,6 +686,48 @@ func (r *Client) ResetServiceErrorCount(ctx context.Context, projectId int) (int
 	return r.Client.Del(ctx, serviceKey).Result()
 }
 
+// IncrementRedactionCounts adds to the number of values redacted by each redaction rule of a project.
+func (r *Client) IncrementRedactionCounts(ctx context.Context, projectId int, counts map[string]int64) error {
+	key := RedactionCountsKey(projectId)
+	_, err := r.Client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for name, count := range counts {
+			pipe.HIncrBy(ctx, key, name, count)
+		}
+		return nil
+	})
+	return err
+}
+
+// GetRedactionCounts returns the number of values redacted by each redaction rule of a project.
+func (r *Client) GetRedactionCounts(ctx context.Context, projectId int) (map[string]int64, error) {
+	values, err := r.Client.HGetAll(ctx, RedactionCountsKey(projectId)).Result()
+	if err != nil {
+		return nil, err
+	}
+	counts := map[string]int64{}
+	for name, value := range values {
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		counts[name] = count
+	}
+	return counts, nil
+}
+
+// GetServiceDependencyWatermark returns the start of the last window of computed service dependencies.
+func (r *Client) GetServiceDependencyWatermark(ctx context.Context) (time.Time, error) {
+	result, err := r.getString(ctx, ServiceDependencyWatermarkKey)
+	if err != nil || result == "" {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339Nano, result)
+}
+
+func (r *Client) SetServiceDependencyWatermark(ctx context.Context, window time.Time) error {
+	return set(ctx, r, ServiceDependencyWatermarkKey, window.Format(time.RFC3339Nano), 0)
+}
+
 func (r *Client) SetGitHubFileError(ctx context.Context, gitHubRepo string, version string, fileName string) error {
 	return r.setFlag(ctx, GitHubFileErrorKey(gitHubRepo, version, fileName), true, 1*time.Hour)
 }
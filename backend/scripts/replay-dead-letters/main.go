@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	kafkaqueue "github.com/highlight-run/highlight/backend/kafka-queue"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	topicType   = flag.String("topic", string(kafkaqueue.TopicTypeDefault), "the topic type whose dead-letter topic should be read")
+	payloadType = flag.Int("type", -1, "only include messages of this payload type")
+	reason      = flag.String("reason", "", "only include messages dead-lettered for this reason")
+	errorFilter = flag.String("error", "", "only include messages whose last error contains this text")
+	since       = flag.Duration("since", 0, "only include messages that failed within this duration")
+	limit       = flag.Int("limit", 0, "stop after this many matching messages")
+	confirm     = flag.Bool("confirm", false, "replay matching messages to the original topic or run in dry run mode")
+)
+
+func init() {
+	flag.Parse()
+}
+
+type summary struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Type      *kafkaqueue.PayloadType
+	Reason    kafkaqueue.DeadLetterReason
+	Error     string
+	Failures  int
+	FailedAt  time.Time
+	SizeBytes int
+	Truncated bool
+}
+
+func matches(msg *kafkaqueue.DeadLetterMessage) bool {
+	if *payloadType >= 0 && (msg.Type == nil || *msg.Type != *payloadType) {
+		return false
+	}
+	if *reason != "" && string(msg.Reason) != *reason {
+		return false
+	}
+	if *errorFilter != "" && !strings.Contains(msg.Error, *errorFilter) {
+		return false
+	}
+	if *since > 0 && msg.FailedAt.Before(time.Now().Add(-*since)) {
+		return false
+	}
+	return true
+}
+
+func main() {
+	ctx := context.TODO()
+	dryRun := !*confirm
+
+	if dryRun {
+		log.WithContext(ctx).Info("Running in dry run mode")
+	} else {
+		log.WithContext(ctx).Info("Running in replay mode")
+	}
+
+	// use the same message size limits as the topic's producers and workers
+	topicConfig, err := kafkaqueue.GetTopicConfig(kafkaqueue.TopicType(*topicType))
+	if err != nil {
+		log.WithContext(ctx).WithError(err).Fatal("failed to get kafka topic config")
+	}
+
+	topic := kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicType(*topicType)})
+	deadLetterTopic := kafkaqueue.GetDeadLetterTopic(topic)
+	consumer := kafkaqueue.New(ctx, deadLetterTopic, kafkaqueue.Consumer, topicConfig)
+	defer consumer.Stop(ctx)
+
+	var producer, requeue *kafkaqueue.Queue
+	if !dryRun {
+		producer = kafkaqueue.New(ctx, topic, kafkaqueue.Producer, topicConfig)
+		defer producer.Stop(ctx)
+		// non-matching messages are written back to the dead-letter topic since
+		// committing past them would otherwise drop them
+		requeue = kafkaqueue.New(ctx, deadLetterTopic, kafkaqueue.Producer, topicConfig)
+		defer requeue.Stop(ctx)
+	}
+
+	// stop at messages written after the run started, ie. the ones requeued by this run
+	start := time.Now()
+	var matched, skipped int
+	for *limit <= 0 || matched < *limit {
+		msg, m, err := consumer.ReceiveDeadLetter(ctx)
+		if err != nil {
+			if m == nil {
+				log.WithContext(ctx).WithError(err).Fatal("failed to read dead-letter message")
+			}
+			log.WithContext(ctx).WithError(err).WithField("offset", m.Offset).Error("skipping invalid dead-letter message")
+			if !dryRun {
+				consumer.Commit(ctx, m)
+			}
+			continue
+		}
+		if msg == nil || m.Time.After(start) {
+			log.WithContext(ctx).Info("no more dead-letter messages")
+			break
+		}
+
+		if !matches(msg) {
+			skipped++
+			if !dryRun {
+				if err := requeue.Requeue(ctx, msg); err != nil {
+					log.WithContext(ctx).WithError(err).Fatal("failed to requeue dead-letter message")
+				}
+				consumer.Commit(ctx, m)
+			}
+			continue
+		}
+		matched++
+
+		out, _ := json.Marshal(summary{
+			Topic:     msg.Topic,
+			Partition: msg.Partition,
+			Offset:    msg.Offset,
+			Type:      msg.Type,
+			Reason:    msg.Reason,
+			Error:     msg.Error,
+			Failures:  msg.Failures,
+			FailedAt:  msg.FailedAt,
+			SizeBytes: len(msg.Value),
+			Truncated: msg.Truncated,
+		})
+		fmt.Println(string(out))
+
+		if !dryRun {
+			if msg.Truncated {
+				// only the metadata was stored, so the message has to be recovered from the original topic
+				log.WithContext(ctx).WithField("partition", msg.Partition).WithField("offset", msg.Offset).Warn("keeping truncated dead-letter message")
+				if err := requeue.Requeue(ctx, msg); err != nil {
+					log.WithContext(ctx).WithError(err).Fatal("failed to requeue dead-letter message")
+				}
+				consumer.Commit(ctx, m)
+				continue
+			}
+			if err := producer.Replay(ctx, msg); err != nil {
+				log.WithContext(ctx).WithError(err).Fatal("failed to replay dead-letter message")
+			}
+			consumer.Commit(ctx, m)
+		}
+	}
+
+	log.WithContext(ctx).
+		WithField("topic", deadLetterTopic).
+		WithField("matched", matched).
+		WithField("skipped", skipped).
+		Info("done processing dead-letter messages")
+}
@@ -0,0 +1,189 @@
+package stacktraces
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/storage"
+	"github.com/highlight-run/highlight/backend/util"
+	e "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// how often the uploaded sourcemaps of a version are scanned for debug ids that are not indexed yet
+const DEBUG_ID_INDEX_INTERVAL = 5 * time.Minute
+
+// the number of versions that may wait to be indexed in the background
+const DEBUG_ID_INDEX_QUEUE_SIZE = 1000
+
+// only the end of a minified file is searched for its `//# debugId=` comment
+const DEBUG_ID_COMMENT_MAX_OFFSET = 4096
+
+var debugIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{12}$`)
+
+var debugIDCommentPattern = regexp.MustCompile(`//# debugId=([0-9a-fA-F-]{32,36})`)
+
+// versions of a project recently queued to be indexed
+var debugIDIndexedVersions sync.Map
+
+// ParseDebugID returns the normalized form of a debug id, the lowercase uuid with dashes.
+func ParseDebugID(debugID string) (string, bool) {
+	debugID = strings.TrimSpace(debugID)
+	if !debugIDPattern.MatchString(debugID) {
+		return "", false
+	}
+	debugID = strings.ToLower(strings.ReplaceAll(debugID, "-", ""))
+	return fmt.Sprintf("%s-%s-%s-%s-%s", debugID[0:8], debugID[8:12], debugID[12:16], debugID[16:20], debugID[20:]), true
+}
+
+// minifiedFileDebugID returns the debug id embedded in a minified file by a `//# debugId=` comment.
+func minifiedFileDebugID(minifiedFileBytes []byte) string {
+	tail := minifiedFileBytes
+	if len(tail) > DEBUG_ID_COMMENT_MAX_OFFSET {
+		tail = tail[len(tail)-DEBUG_ID_COMMENT_MAX_OFFSET:]
+	}
+	matches := debugIDCommentPattern.FindAllSubmatch(tail, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+	debugID, _ := ParseDebugID(string(matches[len(matches)-1][1]))
+	return debugID
+}
+
+// sourceMapDebugID returns the debug id of a source map, set either as `debugId` or `debug_id`.
+func sourceMapDebugID(sourceMapFileBytes []byte) string {
+	if !bytes.Contains(sourceMapFileBytes, []byte(`"debugId"`)) && !bytes.Contains(sourceMapFileBytes, []byte(`"debug_id"`)) {
+		return ""
+	}
+	var sourceMap struct {
+		DebugID      string `json:"debugId"`
+		DebugIDSnake string `json:"debug_id"`
+	}
+	if err := json.Unmarshal(sourceMapFileBytes, &sourceMap); err != nil {
+		return ""
+	}
+	if debugID, ok := ParseDebugID(sourceMap.DebugID); ok {
+		return debugID
+	}
+	debugID, _ := ParseDebugID(sourceMap.DebugIDSnake)
+	return debugID
+}
+
+// IndexSourceMapDebugIDs indexes the uploaded source maps of a version by their debug id.
+// Source maps are uploaded directly to storage, so they are indexed in the background after
+// their release is created or when a debug id is first looked up.
+func IndexSourceMapDebugIDs(ctx context.Context, projectId int, version *string, storageClient storage.Client) error {
+	span, ctx := util.StartSpanFromContext(ctx, "stacktraces.IndexSourceMapDebugIDs", util.Tag("project_id", projectId))
+	defer span.Finish()
+
+	fileNames, err := storageClient.ListSourceMapFileNames(ctx, projectId, version)
+	if err != nil {
+		return e.Wrap(err, "error listing sourcemap files")
+	}
+	span.SetAttribute("num_files", len(fileNames))
+	for _, fileName := range fileNames {
+		if !strings.HasSuffix(fileName, ".map") {
+			continue
+		}
+		// the source maps are only read once, so they are not cached
+		sourceMapFileBytes, err := storageClient.ReadSourceMapFile(ctx, projectId, version, fileName)
+		if err != nil || sourceMapFileBytes == nil {
+			continue
+		}
+		debugID := sourceMapDebugID(sourceMapFileBytes)
+		if debugID == "" {
+			continue
+		}
+		if err := storageClient.PushSourceMapDebugID(ctx, projectId, debugID, &storage.SourceMapDebugID{Version: version, FileName: fileName}); err != nil {
+			log.WithContext(ctx).WithError(err).WithField("debug_id", debugID).Error("failed to index sourcemap debug id")
+		}
+	}
+	return nil
+}
+
+type debugIDIndexTask struct {
+	projectId     int
+	version       *string
+	storageClient storage.Client
+}
+
+// versions waiting to be indexed by the background indexer
+var debugIDIndexTasks = make(chan debugIDIndexTask, DEBUG_ID_INDEX_QUEUE_SIZE)
+
+var startDebugIDIndexer sync.Once
+
+// QueueSourceMapDebugIDIndex indexes the source maps of a version in the background, unless the
+// version was recently indexed. Versions are dropped when the indexer is backed up.
+func QueueSourceMapDebugIDIndex(ctx context.Context, projectId int, version *string, storageClient storage.Client) {
+	versionName := "unversioned"
+	if version != nil && *version != "" {
+		versionName = *version
+	}
+	key := fmt.Sprintf("%d/%s", projectId, versionName)
+	if indexedAt, ok := debugIDIndexedVersions.Load(key); ok && time.Since(indexedAt.(time.Time)) < DEBUG_ID_INDEX_INTERVAL {
+		return
+	}
+	debugIDIndexedVersions.Store(key, time.Now())
+
+	startDebugIDIndexer.Do(func() {
+		go func() {
+			defer util.Recover()
+			for task := range debugIDIndexTasks {
+				if err := IndexSourceMapDebugIDs(context.Background(), task.projectId, task.version, task.storageClient); err != nil {
+					log.WithError(err).WithField("project_id", task.projectId).Warn("failed to index sourcemap debug ids")
+				}
+			}
+		}()
+	})
+
+	select {
+	case debugIDIndexTasks <- debugIDIndexTask{projectId: projectId, version: version, storageClient: storageClient}:
+	default:
+		debugIDIndexedVersions.Delete(key)
+		log.WithContext(ctx).WithField("project_id", projectId).Warn("dropping sourcemap debug id index of a backed up indexer")
+	}
+}
+
+func readSourceMapDebugID(ctx context.Context, projectId int, version *string, debugID string, storageClient storage.Client) (*storage.SourceMapDebugID, error) {
+	sourceMap, err := storageClient.ReadSourceMapDebugID(ctx, projectId, debugID)
+	if sourceMap != nil && err == nil {
+		return sourceMap, nil
+	}
+	// the source map may have been uploaded without being indexed yet. the lookup falls
+	// back to the path of the minified file while the version is indexed.
+	QueueSourceMapDebugIDIndex(ctx, projectId, version, storageClient)
+	if version != nil {
+		QueueSourceMapDebugIDIndex(ctx, projectId, nil, storageClient)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, e.Errorf("no sourcemap indexed with debug id %s", debugID)
+}
+
+// getDebugIDSourcemap finds an uploaded source map by the debug id of its minified file.
+func getDebugIDSourcemap(ctx context.Context, projectId int, version *string, debugID string, storageClient storage.Client, stackTraceError *privateModel.SourceMappingError) (string, []byte, error) {
+	sourceMap, err := readSourceMapDebugID(ctx, projectId, version, debugID, storageClient)
+	if err != nil {
+		return "", nil, err
+	}
+	sourceMapFileBytes, err := storageClient.ReadSourceMapFileCached(ctx, projectId, sourceMap.Version, sourceMap.FileName)
+	if err != nil {
+		return "", nil, e.Wrapf(err, "error reading sourcemap with debug id %s", debugID)
+	}
+	if sourceMapFileBytes == nil {
+		return "", nil, e.Errorf("sourcemap with debug id %s is missing from storage", debugID)
+	}
+	sourcemapFetchStrategy := "Debug ID"
+	stackTraceError.SourcemapFetchStrategy = &sourcemapFetchStrategy
+	stackTraceError.ActualSourcemapFetchedPath = &sourceMap.FileName
+	stackTraceError.SourceMapURL = &sourceMap.FileName
+	return sourceMap.FileName, sourceMapFileBytes, nil
+}
@@ -247,6 +247,13 @@ func getURLSourcemap(ctx context.Context, projectId int, version *string, stackT
 		return "", nil, err
 	}
 
+	// a minified file with a debug id is matched to the uploaded source map with the same debug id
+	if debugID := minifiedFileDebugID(minifiedFileBytes); debugID != "" {
+		if sourceMapURL, sourceMapFileBytes, err := getDebugIDSourcemap(ctx, projectId, version, debugID, storageClient, stackTraceError); err == nil {
+			return sourceMapURL, sourceMapFileBytes, nil
+		}
+	}
+
 	sourceMapFileName := string(regexp.MustCompile(`(?m)^//# sourceMappingURL=(.*)$`).Find(minifiedFileBytes))
 	if len(sourceMapFileName) < 1 {
 		sourceMapFileName = mapFileForJS(path.Base(stackTraceFileURL))
@@ -423,19 +430,27 @@ func processStackFrame(ctx context.Context, projectId int, version *string, stac
 
 	var sourceMapURL string
 	var sourceMapFileBytes []byte
-	var versions = []*string{version}
-	if versions[0] != nil {
-		versions = append(versions, nil)
+	// the debug id reported for the file is tried before looking up the source map by path
+	if stackTrace.DebugID != nil {
+		if debugID, ok := ParseDebugID(*stackTrace.DebugID); ok {
+			sourceMapURL, sourceMapFileBytes, err = getDebugIDSourcemap(ctx, projectId, version, debugID, storageClient, &stackTraceError)
+		}
 	}
-	for _, v := range versions {
-		if u.Scheme == "file" {
-			// if this is an electron file reference, treat it as a path so we can match a subdirectory
-			sourceMapURL, sourceMapFileBytes, err = getFileSourcemap(ctx, projectId, v, u.Path, storageClient, &stackTraceError)
-		} else {
-			sourceMapURL, sourceMapFileBytes, err = getURLSourcemap(ctx, projectId, v, stackTraceFileURL, stackTraceFilePath, stackFileNameIndex, storageClient, &stackTraceError)
+	if sourceMapFileBytes == nil {
+		var versions = []*string{version}
+		if versions[0] != nil {
+			versions = append(versions, nil)
 		}
-		if err == nil {
-			break
+		for _, v := range versions {
+			if u.Scheme == "file" {
+				// if this is an electron file reference, treat it as a path so we can match a subdirectory
+				sourceMapURL, sourceMapFileBytes, err = getFileSourcemap(ctx, projectId, v, u.Path, storageClient, &stackTraceError)
+			} else {
+				sourceMapURL, sourceMapFileBytes, err = getURLSourcemap(ctx, projectId, v, stackTraceFileURL, stackTraceFilePath, stackFileNameIndex, storageClient, &stackTraceError)
+			}
+			if err == nil {
+				break
+			}
 		}
 	}
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"github.com/highlight-run/highlight/backend/redis"
 	"github.com/openlyinc/pointy"
 	"github.com/stretchr/testify/assert"
@@ -423,3 +424,101 @@ func TestEnhanceStackTraceProd(t *testing.T) {
 	assert.Equal(t, "", *mappedStackTrace[0].FunctionName)
 	assert.Equal(t, "      console.error(`Supplementary data not found for identifier ${identifier}`);\n", *mappedStackTrace[0].LineContent)
 }
+
+func TestParseDebugID(t *testing.T) {
+	debugID, ok := ParseDebugID(" 85314830-023F-4CF1-A267-535F4E37BB17 ")
+	assert.True(t, ok)
+	assert.Equal(t, "85314830-023f-4cf1-a267-535f4e37bb17", debugID)
+
+	debugID, ok = ParseDebugID("85314830023f4cf1a267535f4e37bb17")
+	assert.True(t, ok)
+	assert.Equal(t, "85314830-023f-4cf1-a267-535f4e37bb17", debugID)
+
+	for _, invalid := range []string{"", "85314830-023f-4cf1-a267", "../../1/unversioned/app.js.map", "85314830-023f-4cf1-a267-535f4e37bb1z"} {
+		_, ok := ParseDebugID(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
+
+func TestExtractDebugID(t *testing.T) {
+	minifiedFileBytes, err := os.ReadFile("./test-files/debug-id.min.js")
+	assert.NoError(t, err)
+	assert.Equal(t, "85314830-023f-4cf1-a267-535f4e37bb17", minifiedFileDebugID(minifiedFileBytes))
+
+	sourceMapFileBytes, err := os.ReadFile("./test-files/debug-id.json")
+	assert.NoError(t, err)
+	assert.Equal(t, "85314830-023f-4cf1-a267-535f4e37bb17", sourceMapDebugID(sourceMapFileBytes))
+	assert.Equal(t, "85314830-023f-4cf1-a267-535f4e37bb17", sourceMapDebugID([]byte(`{"version":3,"debug_id":"85314830-023f-4cf1-a267-535f4e37bb17"}`)))
+
+	lodashMapBytes, err := os.ReadFile("./test-files/lodash.min.js.map")
+	assert.NoError(t, err)
+	assert.Empty(t, sourceMapDebugID(lodashMapBytes))
+}
+
+func TestEnhanceStackTraceDebugID(t *testing.T) {
+	ctx := context.Background()
+	fsRoot := t.TempDir()
+	client, err := storage.NewFSClient(ctx, "http://localhost:8082/public", fsRoot)
+	if err != nil {
+		t.Fatalf("error creating storage client: %v", err)
+	}
+	fetch = DiskFetcher{}
+
+	sourceMapFileBytes, err := os.ReadFile("./test-files/debug-id.json")
+	assert.NoError(t, err)
+	// source maps uploaded in a nested directory are indexed by their path relative to the version
+	_, err = client.PushSourceMapFile(ctx, 1, pointy.String("release-1"), "assets/app.js.map", sourceMapFileBytes)
+	assert.NoError(t, err)
+
+	// unindexed debug ids are not resolved while the version is indexed in the background
+	_, err = readSourceMapDebugID(ctx, 1, pointy.String("release-2"), "85314830-023f-4cf1-a267-535f4e37bb17", client)
+	assert.Error(t, err)
+	assert.NoError(t, IndexSourceMapDebugIDs(ctx, 1, pointy.String("release-1"), client))
+
+	expected := modelInput.ErrorTrace{
+		FileName:     ptr.String("src/boom.ts"),
+		LineNumber:   ptr.Int(2),
+		ColumnNumber: ptr.Int(2),
+		FunctionName: ptr.String(""),
+		LineContent:  ptr.String("  throw new Error('boom')\n"),
+		LinesBefore:  ptr.String("export function boom() {\n"),
+		LinesAfter:   ptr.String("}\nboom()\n"),
+	}
+
+	// the debug id reported by the client resolves a hashed cdn path
+	mappedStackTrace, err := EnhanceStackTrace(ctx, []*publicModelInput.StackFrameInput{{
+		FileName:     ptr.String("https://cdn.example.com/assets/index-9f8e7d.js"),
+		LineNumber:   ptr.Int(1),
+		ColumnNumber: ptr.Int(16),
+		DebugID:      ptr.String("85314830-023f-4cf1-a267-535f4e37bb17"),
+	}}, 1, pointy.String("release-1"), client)
+	assert.NoError(t, err)
+	assert.Len(t, mappedStackTrace, 1)
+	assert.Empty(t, deep.Equal(expected, *mappedStackTrace[0]))
+
+	sourceMap, err := client.ReadSourceMapDebugID(ctx, 1, "85314830-023f-4cf1-a267-535f4e37bb17")
+	assert.NoError(t, err)
+	assert.Equal(t, "assets/app.js.map", sourceMap.FileName)
+
+	// the debug id embedded in the minified file is used when the client does not report one
+	mappedStackTrace, err = EnhanceStackTrace(ctx, []*publicModelInput.StackFrameInput{{
+		FileName:     ptr.String("./test-files/debug-id.min.js"),
+		LineNumber:   ptr.Int(1),
+		ColumnNumber: ptr.Int(16),
+	}}, 1, nil, client)
+	assert.NoError(t, err)
+	assert.Len(t, mappedStackTrace, 1)
+	assert.Empty(t, deep.Equal(expected, *mappedStackTrace[0]))
+
+	// unknown debug ids fall back to the path-based lookup
+	mappedStackTrace, err = EnhanceStackTrace(ctx, []*publicModelInput.StackFrameInput{{
+		FileName:     ptr.String("./test-files/lodash.min.js"),
+		LineNumber:   ptr.Int(1),
+		ColumnNumber: ptr.Int(813),
+		DebugID:      ptr.String("00000000-0000-0000-0000-000000000000"),
+	}}, 1, nil, client)
+	assert.NoError(t, err)
+	assert.Len(t, mappedStackTrace, 1)
+	assert.Nil(t, mappedStackTrace[0].Error)
+	assert.Equal(t, "lodash.js", *mappedStackTrace[0].FileName)
+}
@@ -0,0 +1,396 @@
+package stacktraces
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/openlyinc/pointy"
+
+	publicModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+)
+
+const JVM Language = "jvm"
+const PHP Language = "php"
+const Rust Language = "rust"
+const Elixir Language = "elixir"
+const Swift Language = "swift"
+
+var (
+	jvmFramePattern        = regexp.MustCompile(`^\s+at (?:\S+/)?([^\s/(]+)\((?:(?:([\w$-]+\.(?:java|kt|kts|scala|groovy|clj)|SourceFile)|Unknown Source)(?::(\d+))?|Native Method)\)`)
+	jvmCausePattern        = regexp.MustCompile(`^Caused by: (.+)$`)
+	jvmSuppressedPattern   = regexp.MustCompile(`^\s+Suppressed: `)
+	jvmThreadPattern       = regexp.MustCompile(`^Exception in thread ".*" `)
+	phpFramePattern        = regexp.MustCompile(`^#\d+ (?:(.+)\((\d+)\): (.+)|\[internal function\]: (.+)|\{main\})$`)
+	phpExceptionPattern    = regexp.MustCompile(`^(?:(?:Next|PHP Fatal error: +Uncaught|Fatal error: +Uncaught) )?(.+?) in (\S+):(\d+)$`)
+	phpThrownPattern       = regexp.MustCompile(`^\s+thrown in (.+) on line (\d+)$`)
+	rustPanicPattern       = regexp.MustCompile(`^thread '.*' panicked at (?:'(.*)', )?(.+?):(\d+):(\d+):?$`)
+	rustBacktracePattern   = regexp.MustCompile(`(?i)^stack backtrace:$`)
+	rustFramePattern       = regexp.MustCompile(`^\s*\d+:\s+(?:0x[0-9a-f]+ - )?(.+)$`)
+	rustLocationPattern    = regexp.MustCompile(`^\s+at (.+?):(\d+)(?::(\d+))?$`)
+	rustHashPattern        = regexp.MustCompile(`::h[0-9a-f]{16}$`)
+	elixirFramePattern     = regexp.MustCompile(`^\s+(?:\([\w.]+(?: [^)]+)?\) )?([^\s:]+\.(?:ex|exs|erl|hrl)):(\d+): (.+)$`)
+	elixirCallPattern      = regexp.MustCompile(`^\s{4}(:?[\w.]+\.[^\s(]+)\(.*\)$`)
+	erlangFramePattern     = regexp.MustCompile(`^\s+in (?:function|call from)\s+(\S+)(?: \((.+), line (\d+)\))?$`)
+	swiftFramePattern      = regexp.MustCompile(`^\d+(?: \[\w+\])* 0x[0-9a-fA-F]+ (.+?)(?: \+ \d+)? in \S+(?: at (.+?):(\d+)(?::(\d+))?)?$`)
+	swiftSymbolPattern     = regexp.MustCompile(`^\d+\s+\S+\s+0x[0-9a-fA-F]+ (.+?)(?: \+ \d+)?$`)
+	swiftSymbolFilePattern = regexp.MustCompile(`^(.+?) \(in \S+\) \((.+?):(\d+)\)$`)
+	swiftFatalErrorPattern = regexp.MustCompile(`^(?:(.+\.swift):(\d+): )?(Fatal error: .+?)(?:: file (.+), line (\d+))?$`)
+	swiftThreadPattern     = regexp.MustCompile(`^Thread \d+.*:$`)
+)
+
+type languageParser struct {
+	language  Language
+	detect    func(line string) bool
+	structure func(lines []string) []*publicModel.ErrorTrace
+}
+
+// languageParsers structure the stack traces of languages whose frames can't be
+// parsed line by line. Each returns the deepest frame first.
+var languageParsers = []languageParser{
+	{
+		language: Rust,
+		detect: func(line string) bool {
+			return rustPanicPattern.MatchString(line) || rustBacktracePattern.MatchString(line)
+		},
+		structure: structureRustStackTrace,
+	},
+	{language: JVM, detect: jvmFramePattern.MatchString, structure: structureJVMStackTrace},
+	{language: PHP, detect: phpFramePattern.MatchString, structure: structurePHPStackTrace},
+	{
+		language: Elixir,
+		detect: func(line string) bool {
+			return elixirFramePattern.MatchString(line) || erlangFramePattern.MatchString(line)
+		},
+		structure: structureElixirStackTrace,
+	},
+	{
+		language: Swift,
+		detect: func(line string) bool {
+			return swiftFramePattern.MatchString(line) || swiftSymbolPattern.MatchString(line)
+		},
+		structure: structureSwiftStackTrace,
+	},
+}
+
+func detectLanguageParser(lines []string) *languageParser {
+	for idx := range languageParsers {
+		for _, line := range lines {
+			if languageParsers[idx].detect(line) {
+				return &languageParsers[idx]
+			}
+		}
+	}
+	return nil
+}
+
+func parseInt(value string) *int {
+	if value == "" {
+		return nil
+	}
+	i, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil
+	}
+	return pointy.Int(int(i))
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return pointy.String(value)
+}
+
+// structureJVMStackTrace parses java, kotlin and scala stack traces. Frames of each exception
+// in a `Caused by:` chain are returned from the root cause to the outermost exception, each
+// with the message of its exception. Frames elided by `... N more` are already part of the
+// enclosing exception and suppressed exceptions are skipped.
+func structureJVMStackTrace(lines []string) []*publicModel.ErrorTrace {
+	type exception struct {
+		message string
+		frames  []*publicModel.ErrorTrace
+	}
+	var exceptions []*exception
+	var current *exception
+	var suppressed bool
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if matches := jvmCausePattern.FindStringSubmatch(line); matches != nil {
+			current = &exception{message: matches[1]}
+			exceptions = append(exceptions, current)
+			suppressed = false
+			continue
+		}
+		if jvmSuppressedPattern.MatchString(line) {
+			suppressed = true
+			continue
+		}
+		if suppressed {
+			continue
+		}
+		if matches := jvmFramePattern.FindStringSubmatch(line); matches != nil {
+			if current == nil {
+				current = &exception{}
+				exceptions = append(exceptions, current)
+			}
+			current.frames = append(current.frames, &publicModel.ErrorTrace{
+				FunctionName: pointy.String(matches[1]),
+				FileName:     optionalString(matches[2]),
+				LineNumber:   parseInt(matches[3]),
+			})
+			continue
+		}
+		if current == nil {
+			current = &exception{message: jvmThreadPattern.ReplaceAllString(line, "")}
+			exceptions = append(exceptions, current)
+		} else if len(current.frames) == 0 {
+			// multi-line exception messages
+			current.message = current.message + "\n" + line
+		}
+	}
+
+	frames := []*publicModel.ErrorTrace{}
+	for i := len(exceptions) - 1; i >= 0; i-- {
+		for _, frame := range exceptions[i].frames {
+			frame.Error = pointy.String(exceptions[i].message)
+			frames = append(frames, frame)
+		}
+	}
+	return frames
+}
+
+func phpFunctionName(call string) string {
+	if idx := strings.Index(call, "("); idx > 0 {
+		return call[:idx]
+	}
+	return call
+}
+
+// structurePHPStackTrace parses the output of Exception::getTraceAsString with the uncaught
+// exception header. Each PHP frame lists the location of a call with the function being called,
+// so functions are shifted to the frame of the location they were executing at.
+func structurePHPStackTrace(lines []string) []*publicModel.ErrorTrace {
+	type call struct {
+		fileName   string
+		lineNumber string
+		function   string
+	}
+	var frames []*publicModel.ErrorTrace
+	var errMsg, thrownFile, thrownLine string
+	var calls []call
+	flush := func() {
+		locations := append([]call{{fileName: thrownFile, lineNumber: thrownLine}}, calls...)
+		for idx, location := range locations {
+			if idx == 0 && thrownFile == "" {
+				continue
+			}
+			function := "{main}"
+			if idx < len(calls) {
+				function = calls[idx].function
+			}
+			frames = append(frames, &publicModel.ErrorTrace{
+				Error:        pointy.String(errMsg),
+				FunctionName: pointy.String(function),
+				FileName:     optionalString(location.fileName),
+				LineNumber:   parseInt(location.lineNumber),
+			})
+		}
+		calls = nil
+		thrownFile, thrownLine = "", ""
+	}
+	for _, line := range lines {
+		line = strings.TrimRight(line, " ")
+		if line == "" || line == "Stack trace:" || line == "[stacktrace]" {
+			continue
+		}
+		if matches := phpFramePattern.FindStringSubmatch(line); matches != nil {
+			switch {
+			case matches[1] != "":
+				calls = append(calls, call{fileName: matches[1], lineNumber: matches[2], function: phpFunctionName(matches[3])})
+			case matches[4] != "":
+				calls = append(calls, call{function: phpFunctionName(matches[4])})
+			}
+			continue
+		}
+		if matches := phpThrownPattern.FindStringSubmatch(line); matches != nil {
+			if thrownFile == "" {
+				thrownFile, thrownLine = matches[1], matches[2]
+			}
+			continue
+		}
+		// chained exceptions are printed from the innermost one
+		flush()
+		if matches := phpExceptionPattern.FindStringSubmatch(line); matches != nil {
+			errMsg = matches[1]
+			thrownFile, thrownLine = matches[2], matches[3]
+		} else {
+			errMsg = line
+		}
+	}
+	flush()
+	if frames == nil {
+		frames = []*publicModel.ErrorTrace{}
+	}
+	return frames
+}
+
+// structureRustStackTrace parses a panic with its `RUST_BACKTRACE` backtrace. Without a
+// backtrace, the panic location is returned as the only frame.
+func structureRustStackTrace(lines []string) []*publicModel.ErrorTrace {
+	var errMsg string
+	var panicFrame *publicModel.ErrorTrace
+	var frame *publicModel.ErrorTrace
+	frames := []*publicModel.ErrorTrace{}
+	var backtrace bool
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if matches := rustPanicPattern.FindStringSubmatch(line); matches != nil && !backtrace {
+			errMsg = matches[1]
+			panicFrame = &publicModel.ErrorTrace{
+				FileName:     pointy.String(matches[2]),
+				LineNumber:   parseInt(matches[3]),
+				ColumnNumber: parseInt(matches[4]),
+			}
+			continue
+		}
+		if rustBacktracePattern.MatchString(line) {
+			backtrace = true
+			continue
+		}
+		if !backtrace {
+			if errMsg == "" {
+				errMsg = line
+			} else if panicFrame == nil {
+				errMsg = errMsg + "\n" + line
+			}
+			continue
+		}
+		if matches := rustLocationPattern.FindStringSubmatch(line); matches != nil && frame != nil {
+			frame.FileName = pointy.String(matches[1])
+			frame.LineNumber = parseInt(matches[2])
+			frame.ColumnNumber = parseInt(matches[3])
+			continue
+		}
+		if matches := rustFramePattern.FindStringSubmatch(line); matches != nil {
+			frame = &publicModel.ErrorTrace{
+				FunctionName: pointy.String(rustHashPattern.ReplaceAllString(matches[1], "")),
+			}
+			frames = append(frames, frame)
+		}
+	}
+	if len(frames) == 0 && panicFrame != nil {
+		frames = append(frames, panicFrame)
+	}
+	for _, frame := range frames {
+		frame.Error = pointy.String(errMsg)
+	}
+	return frames
+}
+
+// structureElixirStackTrace parses elixir stacktraces as formatted by Exception.format and
+// erlang shell exceptions.
+func structureElixirStackTrace(lines []string) []*publicModel.ErrorTrace {
+	var errMsg string
+	frames := []*publicModel.ErrorTrace{}
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if matches := elixirFramePattern.FindStringSubmatch(line); matches != nil {
+			frames = append(frames, &publicModel.ErrorTrace{
+				FunctionName: pointy.String(matches[3]),
+				FileName:     pointy.String(matches[1]),
+				LineNumber:   parseInt(matches[2]),
+			})
+		} else if matches := erlangFramePattern.FindStringSubmatch(line); matches != nil {
+			frames = append(frames, &publicModel.ErrorTrace{
+				FunctionName: pointy.String(matches[1]),
+				FileName:     optionalString(matches[2]),
+				LineNumber:   parseInt(matches[3]),
+			})
+		} else if matches := elixirCallPattern.FindStringSubmatch(line); matches != nil && errMsg != "" {
+			// calls of functions without debug info, such as erlang BIFs
+			frames = append(frames, &publicModel.ErrorTrace{
+				FunctionName: pointy.String(matches[1]),
+			})
+		} else if len(frames) == 0 {
+			line = strings.TrimPrefix(strings.TrimSpace(line), "** ")
+			if errMsg == "" {
+				errMsg = line
+			} else {
+				errMsg = errMsg + "\n" + line
+			}
+		}
+	}
+	for _, frame := range frames {
+		frame.Error = pointy.String(errMsg)
+	}
+	return frames
+}
+
+// structureSwiftStackTrace parses the backtraces of the swift runtime and symbolicated
+// Thread.callStackSymbols. Only the crashed thread is returned.
+func structureSwiftStackTrace(lines []string) []*publicModel.ErrorTrace {
+	var errMsg string
+	var fatalFrame *publicModel.ErrorTrace
+	frames := []*publicModel.ErrorTrace{}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if matches := parseSwiftFrame(line); matches != nil {
+			frames = append(frames, matches)
+			continue
+		}
+		if swiftThreadPattern.MatchString(line) && len(frames) > 0 {
+			break
+		}
+		if matches := swiftFatalErrorPattern.FindStringSubmatch(line); matches != nil {
+			errMsg = matches[3]
+			if matches[1] != "" {
+				fatalFrame = &publicModel.ErrorTrace{FileName: pointy.String(matches[1]), LineNumber: parseInt(matches[2])}
+			} else if matches[4] != "" {
+				fatalFrame = &publicModel.ErrorTrace{FileName: pointy.String(matches[4]), LineNumber: parseInt(matches[5])}
+			}
+			continue
+		}
+		if errMsg == "" {
+			errMsg = line
+		}
+	}
+	if len(frames) == 0 && fatalFrame != nil {
+		frames = append(frames, fatalFrame)
+	}
+	for _, frame := range frames {
+		frame.Error = pointy.String(errMsg)
+	}
+	return frames
+}
+
+func parseSwiftFrame(line string) *publicModel.ErrorTrace {
+	if matches := swiftFramePattern.FindStringSubmatch(line); matches != nil {
+		return &publicModel.ErrorTrace{
+			FunctionName: pointy.String(matches[1]),
+			FileName:     optionalString(matches[2]),
+			LineNumber:   parseInt(matches[3]),
+			ColumnNumber: parseInt(matches[4]),
+		}
+	}
+	if matches := swiftSymbolPattern.FindStringSubmatch(line); matches != nil {
+		if m := swiftSymbolFilePattern.FindStringSubmatch(matches[1]); m != nil {
+			return &publicModel.ErrorTrace{
+				FunctionName: pointy.String(m[1]),
+				FileName:     pointy.String(m[2]),
+				LineNumber:   parseInt(m[3]),
+			}
+		}
+		return &publicModel.ErrorTrace{FunctionName: pointy.String(matches[1])}
+	}
+	return nil
+}
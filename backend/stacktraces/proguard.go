@@ -0,0 +1,303 @@
+package stacktraces
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/openlyinc/pointy"
+	e "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/storage"
+	"github.com/highlight-run/highlight/backend/util"
+)
+
+// the number of parsed mappings kept in memory, and how long until a re-uploaded mapping is used
+const PROGUARD_MAPPING_CACHE_SIZE = 32
+const PROGUARD_MAPPING_CACHE_TTL = 5 * time.Minute
+
+var (
+	proguardClassPattern   = regexp.MustCompile(`^(\S+) -> (\S+):$`)
+	proguardMemberPattern  = regexp.MustCompile(`^\s+(?:(\d+):(\d+):)?\S+ ([^\s(]+)\([^)]*\)(?::(\d+)(?::(\d+))?)? -> (\S+)$`)
+	proguardMessagePattern = regexp.MustCompile(`^([A-Za-z_$][\w$]*(?:\.[A-Za-z_$][\w$]*)+)(:|$)`)
+	jvmSourceFilePattern   = regexp.MustCompile(`\.(?:java|kt|kts|scala|groovy|clj)$`)
+)
+
+// parsed mappings by project and version, nil when no mapping was uploaded
+var proguardMappings = expirable.NewLRU[string, *ProguardMapping](PROGUARD_MAPPING_CACHE_SIZE, nil, PROGUARD_MAPPING_CACHE_TTL)
+
+type proguardMember struct {
+	// the range of obfuscated lines of the member, zero when the mapping has no line numbers
+	startLine int
+	endLine   int
+	// the class of a method inlined from another class
+	originalClass string
+	originalName  string
+	// the original lines of the member, zero when they are the obfuscated lines
+	originalStartLine int
+	originalEndLine   int
+}
+
+func (m *proguardMember) originalLine(line int) int {
+	switch {
+	case m.originalStartLine == 0:
+		return line
+	case m.originalEndLine == 0 || m.originalEndLine-m.originalStartLine != m.endLine-m.startLine:
+		// inlined callers and ranges collapsed by R8 map to the first original line
+		return m.originalStartLine
+	default:
+		return m.originalStartLine + line - m.startLine
+	}
+}
+
+type proguardClass struct {
+	originalName string
+	// members by obfuscated name, in the order of the mapping
+	members map[string][]*proguardMember
+}
+
+// ProguardMapping is a ProGuard or R8 `mapping.txt`, used to retrace obfuscated JVM stack traces.
+type ProguardMapping struct {
+	// classes by obfuscated name
+	classes map[string]*proguardClass
+	// the source files of classes by original name, from R8 metadata
+	sourceFiles map[string]string
+}
+
+// ParseProguardMapping parses a ProGuard or R8 mapping. Fields and unknown lines are ignored.
+func ParseProguardMapping(data []byte) (*ProguardMapping, error) {
+	mapping := &ProguardMapping{
+		classes:     map[string]*proguardClass{},
+		sourceFiles: map[string]string{},
+	}
+	var class *proguardClass
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "#") {
+			if class != nil {
+				var metadata struct {
+					ID       string `json:"id"`
+					FileName string `json:"fileName"`
+				}
+				if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))), &metadata); err == nil && metadata.ID == "sourceFile" && metadata.FileName != "" {
+					mapping.sourceFiles[class.originalName] = metadata.FileName
+				}
+			}
+			continue
+		}
+		if matches := proguardClassPattern.FindStringSubmatch(line); matches != nil {
+			class = &proguardClass{originalName: matches[1], members: map[string][]*proguardMember{}}
+			mapping.classes[matches[2]] = class
+			continue
+		}
+		if class == nil {
+			continue
+		}
+		if matches := proguardMemberPattern.FindStringSubmatch(line); matches != nil {
+			member := &proguardMember{
+				startLine:         atoi(matches[1]),
+				endLine:           atoi(matches[2]),
+				originalName:      matches[3],
+				originalStartLine: atoi(matches[4]),
+				originalEndLine:   atoi(matches[5]),
+			}
+			if idx := strings.LastIndex(member.originalName, "."); idx > 0 {
+				member.originalClass = member.originalName[:idx]
+				member.originalName = member.originalName[idx+1:]
+			}
+			class.members[matches[6]] = append(class.members[matches[6]], member)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, e.Wrap(err, "failed to read proguard mapping")
+	}
+	if len(mapping.classes) == 0 {
+		return nil, e.New("invalid proguard mapping: no class mappings found")
+	}
+	return mapping, nil
+}
+
+func atoi(value string) int {
+	i, _ := strconv.Atoi(value)
+	return i
+}
+
+// sourceFile returns the source file of a class, inferred from its outermost class when R8 did not record it.
+func (m *ProguardMapping) sourceFile(className string) string {
+	if sourceFile, ok := m.sourceFiles[className]; ok {
+		return sourceFile
+	}
+	simpleName := className[strings.LastIndex(className, ".")+1:]
+	if idx := strings.Index(simpleName, "$"); idx > 0 {
+		simpleName = simpleName[:idx]
+	}
+	return simpleName + ".java"
+}
+
+// RetraceMessage deobfuscates the exception class at the start of an exception message.
+func (m *ProguardMapping) RetraceMessage(message string) string {
+	matches := proguardMessagePattern.FindStringSubmatchIndex(message)
+	if matches == nil {
+		return message
+	}
+	class, ok := m.classes[message[matches[2]:matches[3]]]
+	if !ok {
+		return message
+	}
+	return class.originalName + message[matches[3]:]
+}
+
+// retraceFrame returns the original frames of an obfuscated frame. A frame of a method that
+// inlined other methods is expanded into a frame per inlined method, the innermost first.
+func (m *ProguardMapping) retraceFrame(frame *privateModel.ErrorTrace) []*privateModel.ErrorTrace {
+	functionName := pointy.StringValue(frame.FunctionName, "")
+	idx := strings.LastIndex(functionName, ".")
+	if idx <= 0 {
+		return []*privateModel.ErrorTrace{frame}
+	}
+	class, ok := m.classes[functionName[:idx]]
+	if !ok {
+		return []*privateModel.ErrorTrace{frame}
+	}
+	methodName := functionName[idx+1:]
+	line := pointy.IntValue(frame.LineNumber, 0)
+
+	retraced := func(className, methodName string, lineNumber *int) *privateModel.ErrorTrace {
+		result := *frame
+		result.FunctionName = pointy.String(className + "." + methodName)
+		if className != class.originalName || frame.FileName == nil || !jvmSourceFilePattern.MatchString(*frame.FileName) {
+			result.FileName = pointy.String(m.sourceFile(className))
+		}
+		result.LineNumber = lineNumber
+		return &result
+	}
+
+	var members []*proguardMember
+	if line > 0 {
+		for _, member := range class.members[methodName] {
+			if member.startLine > 0 && member.startLine <= line && line <= member.endLine {
+				members = append(members, member)
+			}
+		}
+	}
+	if len(members) > 0 {
+		var frames []*privateModel.ErrorTrace
+		for _, member := range members {
+			className := class.originalName
+			if member.originalClass != "" {
+				className = member.originalClass
+			}
+			frames = append(frames, retraced(className, member.originalName, pointy.Int(member.originalLine(line))))
+		}
+		return frames
+	}
+
+	// without a line range, the method is only known when its obfuscated name is not ambiguous
+	names := map[string]*proguardMember{}
+	for _, member := range class.members[methodName] {
+		names[member.originalClass+"."+member.originalName] = member
+	}
+	if len(names) == 1 {
+		for _, member := range names {
+			className := class.originalName
+			if member.originalClass != "" {
+				className = member.originalClass
+			}
+			return []*privateModel.ErrorTrace{retraced(className, member.originalName, frame.LineNumber)}
+		}
+	}
+	return []*privateModel.ErrorTrace{retraced(class.originalName, methodName, frame.LineNumber)}
+}
+
+// Retrace deobfuscates the class and method names, files and line numbers of JVM frames.
+func (m *ProguardMapping) Retrace(frames []*privateModel.ErrorTrace) []*privateModel.ErrorTrace {
+	var result []*privateModel.ErrorTrace
+	for _, frame := range frames {
+		if frame == nil {
+			continue
+		}
+		for _, retraced := range m.retraceFrame(frame) {
+			if retraced.Error != nil {
+				retraced.Error = pointy.String(m.RetraceMessage(*retraced.Error))
+			}
+			result = append(result, retraced)
+		}
+	}
+	return result
+}
+
+// isJVMFrame returns true for frames that may be obfuscated JVM frames, which have a fully
+// qualified method name and a JVM source file or no source file.
+func isJVMFrame(frame *privateModel.ErrorTrace) bool {
+	if frame == nil || frame.FunctionName == nil || !strings.Contains(*frame.FunctionName, ".") {
+		return false
+	}
+	return frame.FileName == nil || *frame.FileName == "SourceFile" || jvmSourceFilePattern.MatchString(*frame.FileName)
+}
+
+func getProguardMapping(ctx context.Context, projectId int, version *string, storageClient storage.Client) (*ProguardMapping, error) {
+	key := fmt.Sprintf("%d/%s", projectId, pointy.StringValue(version, ""))
+	if mapping, ok := proguardMappings.Get(key); ok {
+		return mapping, nil
+	}
+
+	data, err := storageClient.ReadProguardMapping(ctx, projectId, version)
+	if err != nil || len(data) == 0 {
+		// an error reading the mapping most likely means that none was uploaded for the version
+		log.WithContext(ctx).WithError(err).WithField("project_id", projectId).WithField("version", version).Debug("no proguard mapping found")
+		proguardMappings.Add(key, nil)
+		return nil, nil
+	}
+	mapping, err := ParseProguardMapping(data)
+	if err != nil {
+		proguardMappings.Add(key, nil)
+		return nil, err
+	}
+	proguardMappings.Add(key, mapping)
+	return mapping, nil
+}
+
+// RetraceStackTrace deobfuscates a JVM stack trace with the ProGuard/R8 mapping uploaded for the
+// version, falling back to the unversioned mapping. Returns nil if the stack trace has no JVM
+// frames or no mapping was uploaded.
+func RetraceStackTrace(ctx context.Context, frames []*privateModel.ErrorTrace, projectId int, version *string, storageClient storage.Client) ([]*privateModel.ErrorTrace, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "stacktraces.RetraceStackTrace", util.Tag("project_id", projectId))
+	defer span.Finish()
+
+	hasJVMFrames := false
+	for _, frame := range frames {
+		if isJVMFrame(frame) {
+			hasJVMFrames = true
+			break
+		}
+	}
+	if !hasJVMFrames {
+		return nil, nil
+	}
+
+	versions := []*string{version}
+	if version != nil {
+		versions = append(versions, nil)
+	}
+	for _, v := range versions {
+		mapping, err := getProguardMapping(ctx, projectId, v, storageClient)
+		if err != nil {
+			return nil, err
+		}
+		if mapping != nil {
+			return mapping.Retrace(frames), nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,117 @@
+package stacktraces
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/storage"
+)
+
+func TestParseProguardMapping(t *testing.T) {
+	mappingBytes, err := os.ReadFile("./test-files/proguard-mapping.txt")
+	require.NoError(t, err)
+
+	mapping, err := ParseProguardMapping(mappingBytes)
+	require.NoError(t, err)
+	assert.Len(t, mapping.classes, 4)
+	assert.Equal(t, "com.example.app.checkout.CartViewModel", mapping.classes["a.b.c"].originalName)
+	assert.Equal(t, "CartViewModel.kt", mapping.sourceFiles["com.example.app.checkout.CartViewModel"])
+	// fields are not mapped
+	assert.Empty(t, mapping.classes["a.b.c"].members["a"])
+	assert.Len(t, mapping.classes["com.example.app.MainActivity"].members["onCreate"], 4)
+
+	_, err = ParseProguardMapping([]byte("not a mapping"))
+	assert.Error(t, err)
+}
+
+func TestProguardRetraceMessage(t *testing.T) {
+	mappingBytes, err := os.ReadFile("./test-files/proguard-mapping.txt")
+	require.NoError(t, err)
+	mapping, err := ParseProguardMapping(mappingBytes)
+	require.NoError(t, err)
+
+	assert.Equal(t, "com.example.app.checkout.CheckoutException: cart is empty", mapping.RetraceMessage("a.b.e: cart is empty"))
+	assert.Equal(t, "com.example.app.checkout.CheckoutException", mapping.RetraceMessage("a.b.e"))
+	assert.Equal(t, "java.lang.IllegalStateException: a.b.e", mapping.RetraceMessage("java.lang.IllegalStateException: a.b.e"))
+	assert.Equal(t, "cart is empty", mapping.RetraceMessage("cart is empty"))
+}
+
+func TestRetraceStackTrace(t *testing.T) {
+	ctx := context.Background()
+	client, err := storage.NewFSClient(ctx, "http://localhost:8082/public", t.TempDir())
+	require.NoError(t, err)
+
+	stackTrace, err := os.ReadFile("./test-files/android.txt")
+	require.NoError(t, err)
+	frames, err := StructureOTELStackTrace(string(stackTrace))
+	require.NoError(t, err)
+	require.Len(t, frames, 7)
+	assert.Equal(t, 7, *frames[0].LineNumber)
+	assert.Nil(t, frames[0].FileName)
+
+	// without an uploaded mapping the stack trace is not retraced
+	retraced, err := RetraceStackTrace(ctx, frames, 1, pointy.String("1.0.0"), client)
+	assert.NoError(t, err)
+	assert.Nil(t, retraced)
+
+	mappingBytes, err := os.ReadFile("./test-files/proguard-mapping.txt")
+	require.NoError(t, err)
+	_, err = client.PushProguardMapping(ctx, 1, pointy.String("2.0.0"), mappingBytes)
+	require.NoError(t, err)
+
+	retraced, err = RetraceStackTrace(ctx, frames, 1, pointy.String("2.0.0"), client)
+	assert.NoError(t, err)
+
+	type frame struct {
+		functionName string
+		fileName     string
+		lineNumber   int
+	}
+	expected := []frame{
+		{functionName: "com.example.app.checkout.CartViewModel.total", fileName: "CartViewModel.kt", lineNumber: 53},
+		{functionName: "com.example.app.checkout.CartViewModel.addItem", fileName: "CartViewModel.kt", lineNumber: 41},
+		{functionName: "com.example.app.checkout.Item$Companion.of", fileName: "Item.java", lineNumber: 70},
+		// methods without line numbers are retraced when their name is not ambiguous
+		{functionName: "com.example.app.checkout.CartViewModel.clear", fileName: "CartViewModel.kt"},
+		{functionName: "com.example.app.checkout.CartViewModel.g", fileName: "CartViewModel.kt"},
+		// inlined methods are expanded into their own frames, the innermost first
+		{functionName: "com.example.app.checkout.CartViewModel.total", fileName: "CartViewModel.kt", lineNumber: 51},
+		{functionName: "com.example.app.MainActivity.loadCart", fileName: "MainActivity.kt", lineNumber: 34},
+		{functionName: "com.example.app.MainActivity.onCreate", fileName: "MainActivity.kt", lineNumber: 24},
+		{functionName: "android.app.Activity.performCreate", fileName: "Activity.java", lineNumber: 8305},
+	}
+	require.Len(t, retraced, len(expected))
+	for idx, f := range retraced {
+		assert.Equal(t, expected[idx].functionName, pointy.StringValue(f.FunctionName, ""))
+		assert.Equal(t, expected[idx].fileName, pointy.StringValue(f.FileName, ""))
+		assert.Equal(t, expected[idx].lineNumber, pointy.IntValue(f.LineNumber, 0))
+		assert.Equal(t, "com.example.app.checkout.CheckoutException: cart is empty", pointy.StringValue(f.Error, ""))
+	}
+
+	// the unversioned mapping is used when none was uploaded for the version
+	_, err = client.PushProguardMapping(ctx, 2, nil, mappingBytes)
+	require.NoError(t, err)
+	retraced, err = RetraceStackTrace(ctx, frames, 2, pointy.String("3.0.0"), client)
+	assert.NoError(t, err)
+	assert.Len(t, retraced, len(expected))
+}
+
+func TestRetraceStackTraceSkipsOtherLanguages(t *testing.T) {
+	ctx := context.Background()
+	client, err := storage.NewFSClient(ctx, "http://localhost:8082/public", t.TempDir())
+	require.NoError(t, err)
+
+	frames := []*privateModel.ErrorTrace{
+		{FunctionName: pointy.String("main.main"), FileName: pointy.String("/app/main.go"), LineNumber: pointy.Int(12)},
+		{FunctionName: pointy.String("handler"), FileName: pointy.String("app.js"), LineNumber: pointy.Int(3)},
+	}
+	retraced, err := RetraceStackTrace(ctx, frames, 1, nil, client)
+	assert.NoError(t, err)
+	assert.Nil(t, retraced)
+}
@@ -52,6 +52,11 @@ func StructureOTELStackTrace(stackTrace string, opts ...StructureStackTraceOptio
 	if err := json.Unmarshal([]byte(stackTrace), &jsonStr); err == nil {
 		stackTrace = jsonStr
 	}
+	lines := strings.Split(strings.ReplaceAll(stackTrace, "\r\n", "\n"), "\n")
+	if parser := detectLanguageParser(lines); parser != nil {
+		return parser.structure(lines), nil
+	}
+
 	var language Language
 	if m := dotnetCSPattern.Find([]byte(stackTrace)); m != nil {
 		language = DotNET
@@ -60,7 +65,6 @@ func StructureOTELStackTrace(stackTrace string, opts ...StructureStackTraceOptio
 	var errMsg string
 	var frame *publicModel.ErrorTrace
 	frames := []*publicModel.ErrorTrace{}
-	lines := strings.Split(stackTrace, "\n")
 	for idx := 0; idx < len(lines); idx++ {
 		line := lines[idx]
 		// frames explicitly set to nil means that this is part of a frame that is resetting the stacktrace
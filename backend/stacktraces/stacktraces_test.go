@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/openlyinc/pointy"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -76,3 +78,145 @@ func TestFormatStructureStackTrace(t *testing.T) {
 		})
 	}
 }
+
+func TestStructureStackTraceFixtures(t *testing.T) {
+	type frame struct {
+		functionName string
+		fileName     string
+		lineNumber   int
+		columnNumber int
+	}
+	var inputs = []struct {
+		fixture       string
+		language      Language
+		frameCount    int
+		errors        []string
+		deepestFrames []frame
+	}{
+		{
+			fixture:    "java.txt",
+			language:   JVM,
+			frameCount: 12,
+			errors:     []string{"java.net.SocketException: Connection reset", "org.postgresql.util.PSQLException: ERROR: duplicate key value violates unique constraint \"orders_pkey\"", "java.lang.IllegalStateException: Failed to process order 1234"},
+			deepestFrames: []frame{
+				{functionName: "sun.nio.ch.NioSocketImpl.implRead", fileName: "NioSocketImpl.java", lineNumber: 323},
+				{functionName: "org.postgresql.core.VisibleBufferedInputStream.read"},
+				{functionName: "org.postgresql.core.v3.QueryExecutorImpl.receiveErrorResponse", fileName: "QueryExecutorImpl.java", lineNumber: 2676},
+			},
+		},
+		{
+			fixture:    "kotlin.txt",
+			language:   JVM,
+			frameCount: 5,
+			errors:     []string{"kotlin.KotlinNullPointerException: user must not be null"},
+			deepestFrames: []frame{
+				{functionName: "com.example.app.UserRepository.find", fileName: "UserRepository.kt", lineNumber: 19},
+				{functionName: "com.example.app.UserService$load$1.invokeSuspend", fileName: "UserService.kt", lineNumber: 33},
+			},
+		},
+		{
+			fixture:    "php.txt",
+			language:   PHP,
+			frameCount: 6,
+			errors:     []string{"InvalidArgumentException: Unknown currency \"XYZ\""},
+			deepestFrames: []frame{
+				{functionName: "App\\Money\\Currency->__construct", fileName: "/var/www/app/src/Money/Currency.php", lineNumber: 42},
+				{functionName: "App\\Money\\Money::of", fileName: "/var/www/app/src/Money/Money.php", lineNumber: 18},
+				{functionName: "App\\Http\\Controller\\CheckoutController->submit", fileName: "/var/www/app/src/Http/Controller/CheckoutController.php", lineNumber: 57},
+				{functionName: "call_user_func_array"},
+			},
+		},
+		{
+			fixture:    "rust.txt",
+			language:   Rust,
+			frameCount: 8,
+			errors:     []string{"called `Option::unwrap()` on a `None` value"},
+			deepestFrames: []frame{
+				{functionName: "rust_begin_unwind", fileName: "/rustc/07dca489ac2d933c78d3c5158e3f43beefeb02ce/library/std/src/panicking.rs", lineNumber: 645, columnNumber: 5},
+			},
+		},
+		{
+			fixture:    "rust-full.txt",
+			language:   Rust,
+			frameCount: 5,
+			errors:     []string{"index out of bounds: the len is 3 but the index is 7"},
+			deepestFrames: []frame{
+				{functionName: "std::backtrace_rs::backtrace::libunwind::trace", fileName: "/rustc/90c541806f23a127002de5b4038be731ba1458ca/library/std/src/../../backtrace/src/backtrace/libunwind.rs", lineNumber: 93, columnNumber: 5},
+				{functionName: "core::fmt::write", fileName: "/rustc/90c541806f23a127002de5b4038be731ba1458ca/library/core/src/fmt/mod.rs", lineNumber: 1254, columnNumber: 17},
+				{functionName: "demo::lookup", fileName: "/home/dev/demo/src/main.rs", lineNumber: 12, columnNumber: 5},
+			},
+		},
+		{
+			fixture:    "elixir.txt",
+			language:   Elixir,
+			frameCount: 7,
+			errors:     []string{"(ArithmeticError) bad argument in arithmetic expression"},
+			deepestFrames: []frame{
+				{functionName: ":erlang./"},
+				{functionName: "Shop.Pricing.unit_price/2", fileName: "lib/shop/pricing.ex", lineNumber: 23},
+				{functionName: "ShopWeb.CartController.show/2", fileName: "lib/shop_web/controllers/cart_controller.ex", lineNumber: 41},
+			},
+		},
+		{
+			fixture:    "erlang.txt",
+			language:   Elixir,
+			frameCount: 3,
+			errors:     []string{"exception error: no match of right hand side value {error,timeout}"},
+			deepestFrames: []frame{
+				{functionName: "shop_cart:checkout/2", fileName: "src/shop_cart.erl", lineNumber: 58},
+			},
+		},
+		{
+			fixture:    "swift.txt",
+			language:   Swift,
+			frameCount: 4,
+			errors:     []string{"Fatal error: Unexpectedly found nil while unwrapping an Optional value"},
+			deepestFrames: []frame{
+				{functionName: "Shop.CartService.total(for:) -> Swift.Double", fileName: "/app/Sources/Shop/CartService.swift", lineNumber: 27, columnNumber: 35},
+				{functionName: "Shop.CartController.show(request:)", fileName: "/app/Sources/Shop/CartController.swift", lineNumber: 14, columnNumber: 22},
+				{functionName: "closure #1 in Shop.routes(app:)", fileName: "/app/Sources/Shop/Routes.swift", lineNumber: 8, columnNumber: 9},
+				{functionName: "__libc_start_main"},
+			},
+		},
+		{
+			fixture:    "swift-symbols.txt",
+			language:   Swift,
+			frameCount: 4,
+			errors:     []string{"Fatal error: Index out of range"},
+			deepestFrames: []frame{
+				{functionName: "$s4Shop11CartServiceC5total3forSdSS_tF"},
+				{functionName: "Shop.CartController.show(request: Shop.Request) -> ()", fileName: "CartController.swift", lineNumber: 14},
+			},
+		},
+	}
+	for _, input := range inputs {
+		t.Run(input.fixture, func(t *testing.T) {
+			stackTrace, err := os.ReadFile("./test-files/" + input.fixture)
+			assert.NoError(t, err)
+
+			lines := strings.Split(string(stackTrace), "\n")
+			assert.Equal(t, input.language, detectLanguageParser(lines).language)
+
+			frames, err := StructureOTELStackTrace(string(stackTrace))
+			assert.NoError(t, err)
+			assert.Equal(t, input.frameCount, len(frames))
+
+			var errors []string
+			for _, f := range frames {
+				if len(errors) == 0 || errors[len(errors)-1] != *f.Error {
+					errors = append(errors, *f.Error)
+				}
+			}
+			assert.Equal(t, input.errors, errors)
+
+			for idx, expected := range input.deepestFrames {
+				assert.Equal(t, expected, frame{
+					functionName: pointy.StringValue(frames[idx].FunctionName, ""),
+					fileName:     pointy.StringValue(frames[idx].FileName, ""),
+					lineNumber:   pointy.IntValue(frames[idx].LineNumber, 0),
+					columnNumber: pointy.IntValue(frames[idx].ColumnNumber, 0),
+				}, idx)
+			}
+		})
+	}
+}
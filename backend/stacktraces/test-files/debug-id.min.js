@@ -0,0 +1,2 @@
+function boom(){throw new Error("boom")}boom();
+//# debugId=85314830-023F-4CF1-A267-535F4E37BB17
@@ -9,9 +9,11 @@ import (
 	"encoding/pem"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"os"
 	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -44,6 +46,7 @@ import (
 
 var (
 	S3SessionsPayloadBucketNameNew = env.Config.AwsS3BucketName
+	S3ExportBucketName             = env.Config.AwsS3ExportBucketName
 	S3SessionsStagingBucketName    = env.Config.AwsS3StagingBucketName
 	S3SourceMapBucketNameNew       = env.Config.AwsS3SourceMapBucketName
 	S3ResourcesBucketName          = env.Config.AwsS3ResourcesBucketName
@@ -88,14 +91,20 @@ type Client interface {
 	GetSourceMapUploadUrl(ctx context.Context, key string) (string, error)
 	GetSourcemapFiles(ctx context.Context, projectId int, version *string) ([]s3Types.Object, error)
 	GetSourcemapVersions(ctx context.Context, projectId int) ([]string, error)
+	ListSourceMapFileNames(ctx context.Context, projectId int, version *string) ([]string, error)
+	PushExportFile(ctx context.Context, projectId int, key string, data []byte) error
 	PushCompressedFile(ctx context.Context, sessionId, projectId int, file *os.File, payloadType PayloadType, retentionPeriod privateModel.RetentionPeriod) (*int64, error)
 	PushFiles(ctx context.Context, sessionId, projectId int, payloadManager *payload.PayloadManager, retentionPeriod privateModel.RetentionPeriod) (int64, error)
 	PushRawEvents(ctx context.Context, sessionId, projectId int, payloadType model.RawPayloadType, events []redis.Z) error
 	PushSourceMapFile(ctx context.Context, projectId int, version *string, fileName string, fileBytes []byte) (*int64, error)
+	PushSourceMapDebugID(ctx context.Context, projectId int, debugId string, sourceMap *SourceMapDebugID) error
+	PushProguardMapping(ctx context.Context, projectId int, version *string, mapping []byte) (*int64, error)
 	ReadResources(ctx context.Context, sessionId int, projectId int) ([]interface{}, error)
 	ReadWebSocketEvents(ctx context.Context, sessionId int, projectId int) ([]interface{}, error)
-	readSourceMapFile(ctx context.Context, projectId int, version *string, fileName string) ([]byte, error)
+	ReadSourceMapFile(ctx context.Context, projectId int, version *string, fileName string) ([]byte, error)
 	ReadSourceMapFileCached(ctx context.Context, projectId int, version *string, fileName string) ([]byte, error)
+	ReadSourceMapDebugID(ctx context.Context, projectId int, debugId string) (*SourceMapDebugID, error)
+	ReadProguardMapping(ctx context.Context, projectId int, version *string) ([]byte, error)
 	ReadTimelineIndicatorEvents(ctx context.Context, sessionId int, projectId int) ([]*model.TimelineIndicatorEvent, error)
 	UploadAsset(ctx context.Context, uuid string, contentType string, reader io.Reader, retentionPeriod privateModel.RetentionPeriod) error
 	ReadGitHubFile(ctx context.Context, repoPath string, fileName string, version string) ([]byte, error)
@@ -104,6 +113,16 @@ type Client interface {
 	CleanupRawEvents(ctx context.Context, projectId int) error
 }
 
+// ProguardMappingFileName is the name of the ProGuard/R8 mapping of a version, stored alongside its source maps
+const ProguardMappingFileName = "proguard/mapping.txt"
+
+// SourceMapDebugID is the location of an uploaded source map, indexed by the debug ID
+// embedded in the source map and in its minified file.
+type SourceMapDebugID struct {
+	Version  *string `json:"version"`
+	FileName string  `json:"file_name"`
+}
+
 type FilesystemClient struct {
 	origin string
 	fsRoot string
@@ -305,7 +324,7 @@ func (f *FilesystemClient) getSourceMapKey(projectId int, version *string, fileN
 	return fmt.Sprintf("%s/%d/%s/%s", f.fsRoot, projectId, *version, fileName)
 }
 
-func (f *FilesystemClient) readSourceMapFile(ctx context.Context, projectId int, version *string, fileName string) ([]byte, error) {
+func (f *FilesystemClient) ReadSourceMapFile(ctx context.Context, projectId int, version *string, fileName string) ([]byte, error) {
 	span, ctx := util.StartSpanFromContext(ctx, "fs.ReadSourceMapFile")
 	defer span.Finish()
 	key := f.getSourceMapKey(projectId, version, fileName)
@@ -317,13 +336,36 @@ func (f *FilesystemClient) readSourceMapFile(ctx context.Context, projectId int,
 	}
 }
 
+// ListSourceMapFileNames returns the names of all files uploaded for a version, relative to the version.
+func (f *FilesystemClient) ListSourceMapFileNames(ctx context.Context, projectId int, version *string) ([]string, error) {
+	span, _ := util.StartSpanFromContext(ctx, "fs.ListSourceMapFileNames")
+	defer span.Finish()
+	root := f.getSourceMapKey(projectId, version, "")
+	var fileNames []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		fileName, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		fileNames = append(fileNames, filepath.ToSlash(fileName))
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return fileNames, err
+}
+
 func (f *FilesystemClient) ReadSourceMapFileCached(ctx context.Context, projectId int, version *string, fileName string) ([]byte, error) {
 	span, ctx := util.StartSpanFromContext(ctx, "fs.ReadSourceMapFileCached")
 	defer span.Finish()
 	key := f.getSourceMapKey(projectId, version, fileName)
 	span.SetAttribute("key", key)
 	b, err := hredis.CachedEval(ctx, f.redis, key, time.Second, time.Minute, func() (*[]byte, error) {
-		bt, err := f.readSourceMapFile(ctx, projectId, version, fileName)
+		bt, err := f.ReadSourceMapFile(ctx, projectId, version, fileName)
 		return &bt, err
 	}, hredis.WithStoreNil(true), hredis.WithIgnoreError(true))
 
@@ -333,6 +375,63 @@ func (f *FilesystemClient) ReadSourceMapFileCached(ctx context.Context, projectI
 	return *b, nil
 }
 
+func (f *FilesystemClient) getSourceMapDebugIDKey(projectId int, debugId string) string {
+	return fmt.Sprintf("%s/debug-ids/%d/%s", f.fsRoot, projectId, debugId)
+}
+
+func (f *FilesystemClient) PushSourceMapDebugID(ctx context.Context, projectId int, debugId string, sourceMap *SourceMapDebugID) error {
+	b, err := json.Marshal(sourceMap)
+	if err != nil {
+		return errors.Wrap(err, "error marshalling sourcemap debug id")
+	}
+	key := f.getSourceMapDebugIDKey(projectId, debugId)
+	if _, err := f.writeFSBytes(ctx, key, bytes.NewReader(b)); err != nil {
+		return err
+	}
+	return f.redis.Cache.Delete(ctx, key)
+}
+
+func (f *FilesystemClient) PushProguardMapping(ctx context.Context, projectId int, version *string, mapping []byte) (*int64, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "fs.PushProguardMapping")
+	defer span.Finish()
+	n, err := f.writeFSBytes(ctx, f.getSourceMapKey(projectId, version, ProguardMappingFileName), bytes.NewReader(mapping))
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func (f *FilesystemClient) ReadProguardMapping(ctx context.Context, projectId int, version *string) ([]byte, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "fs.ReadProguardMapping")
+	defer span.Finish()
+	return f.ReadSourceMapFile(ctx, projectId, version, ProguardMappingFileName)
+}
+
+func (f *FilesystemClient) PushExportFile(ctx context.Context, projectId int, key string, data []byte) error {
+	span, ctx := util.StartSpanFromContext(ctx, "fs.PushExportFile")
+	defer span.Finish()
+	_, err := f.writeFSBytes(ctx, fmt.Sprintf("%s/exports/%d/%s", f.fsRoot, projectId, key), bytes.NewReader(data))
+	return err
+}
+
+func (f *FilesystemClient) ReadSourceMapDebugID(ctx context.Context, projectId int, debugId string) (*SourceMapDebugID, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "fs.ReadSourceMapDebugID")
+	defer span.Finish()
+	key := f.getSourceMapDebugIDKey(projectId, debugId)
+	span.SetAttribute("key", key)
+	return hredis.CachedEval(ctx, f.redis, key, time.Second, time.Minute, func() (*SourceMapDebugID, error) {
+		b, err := f.readFSBytes(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		var sourceMap SourceMapDebugID
+		if err := json.Unmarshal(b.Bytes(), &sourceMap); err != nil {
+			return nil, errors.Wrap(err, "error unmarshalling sourcemap debug id")
+		}
+		return &sourceMap, nil
+	}, hredis.WithStoreNil(true), hredis.WithIgnoreError(true))
+}
+
 func (f *FilesystemClient) GetAssetURL(_ context.Context, projectId string, hashVal string) (string, error) {
 	return fmt.Sprintf("%s/direct/assets/%s/%s", f.origin, projectId, hashVal), nil
 }
@@ -951,7 +1050,7 @@ func (s *S3Client) PushSourceMapFile(ctx context.Context, projectId int, version
 	return s.PushSourceMapFileReaderToS3(ctx, projectId, version, fileName, body)
 }
 
-func (s *S3Client) readSourceMapFile(ctx context.Context, projectId int, version *string, fileName string) ([]byte, error) {
+func (s *S3Client) ReadSourceMapFile(ctx context.Context, projectId int, version *string, fileName string) ([]byte, error) {
 	span, ctx := util.StartSpanFromContext(ctx, "s3.ReadSourceMapFile")
 	defer span.Finish()
 	output, err := s.S3ClientEast2.GetObject(ctx, &s3.GetObjectInput{Bucket: pointy.String(S3SourceMapBucketNameNew),
@@ -967,13 +1066,37 @@ func (s *S3Client) readSourceMapFile(ctx context.Context, projectId int, version
 	return buf.Bytes(), nil
 }
 
+// ListSourceMapFileNames returns the names of all files uploaded for a version, relative to the version.
+func (s *S3Client) ListSourceMapFileNames(ctx context.Context, projectId int, version *string) ([]string, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "s3.ListSourceMapFileNames")
+	defer span.Finish()
+	prefix := s.sourceMapBucketKey(projectId, version, "")
+	paginator := s3.NewListObjectsV2Paginator(s.S3ClientEast2, &s3.ListObjectsV2Input{
+		Bucket: pointy.String(S3SourceMapBucketNameNew),
+		Prefix: prefix,
+	})
+	var fileNames []string
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing sourcemaps from s3")
+		}
+		for _, object := range page.Contents {
+			if object.Key != nil {
+				fileNames = append(fileNames, strings.TrimPrefix(*object.Key, *prefix))
+			}
+		}
+	}
+	return fileNames, nil
+}
+
 func (s *S3Client) ReadSourceMapFileCached(ctx context.Context, projectId int, version *string, fileName string) ([]byte, error) {
 	span, ctx := util.StartSpanFromContext(ctx, "s3.ReadSourceMapFileCached")
 	defer span.Finish()
 	key := s.sourceMapBucketKey(projectId, version, fileName)
 	span.SetAttribute("key", key)
 	b, err := hredis.CachedEval(ctx, s.Redis, *key, time.Second, time.Minute, func() (*[]byte, error) {
-		bt, err := s.readSourceMapFile(ctx, projectId, version, fileName)
+		bt, err := s.ReadSourceMapFile(ctx, projectId, version, fileName)
 		return &bt, err
 	}, hredis.WithStoreNil(true), hredis.WithIgnoreError(true))
 
@@ -983,6 +1106,82 @@ func (s *S3Client) ReadSourceMapFileCached(ctx context.Context, projectId int, v
 	return *b, nil
 }
 
+func (s *S3Client) PushProguardMapping(ctx context.Context, projectId int, version *string, mapping []byte) (*int64, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "s3.PushProguardMapping")
+	defer span.Finish()
+	return s.PushSourceMapFileReaderToS3(ctx, projectId, version, ProguardMappingFileName, bytes.NewReader(mapping))
+}
+
+func (s *S3Client) ReadProguardMapping(ctx context.Context, projectId int, version *string) ([]byte, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "s3.ReadProguardMapping")
+	defer span.Finish()
+	return s.ReadSourceMapFile(ctx, projectId, version, ProguardMappingFileName)
+}
+
+func (s *S3Client) sourceMapDebugIDBucketKey(projectId int, debugId string) *string {
+	var key string
+	if env.IsDevEnv() {
+		key = "dev/"
+	}
+	key += fmt.Sprintf("debug-ids/%d/%s", projectId, debugId)
+	return pointy.String(key)
+}
+
+func (s *S3Client) PushSourceMapDebugID(ctx context.Context, projectId int, debugId string, sourceMap *SourceMapDebugID) error {
+	span, ctx := util.StartSpanFromContext(ctx, "s3.PushSourceMapDebugID")
+	defer span.Finish()
+	b, err := json.Marshal(sourceMap)
+	if err != nil {
+		return errors.Wrap(err, "error marshalling sourcemap debug id")
+	}
+	key := s.sourceMapDebugIDBucketKey(projectId, debugId)
+	if _, err := s.S3ClientEast2.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: pointy.String(S3SourceMapBucketNameNew), Key: key, Body: bytes.NewReader(b),
+	}); err != nil {
+		return errors.Wrap(err, "error 'put'ing sourcemap debug id in s3 bucket")
+	}
+	return s.Redis.Cache.Delete(ctx, *key)
+}
+
+func (s *S3Client) PushExportFile(ctx context.Context, projectId int, key string, data []byte) error {
+	span, ctx := util.StartSpanFromContext(ctx, "s3.PushExportFile")
+	defer span.Finish()
+	if S3ExportBucketName == "" {
+		return errors.New("no export bucket is configured")
+	}
+	var prefix string
+	if env.IsDevEnv() {
+		prefix = "dev/"
+	}
+	if _, err := s.S3ClientEast2.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: pointy.String(S3ExportBucketName),
+		Key:    pointy.String(fmt.Sprintf("%sexports/%d/%s", prefix, projectId, key)),
+		Body:   bytes.NewReader(data),
+	}); err != nil {
+		return errors.Wrap(err, "error 'put'ing export file in s3 bucket")
+	}
+	return nil
+}
+
+func (s *S3Client) ReadSourceMapDebugID(ctx context.Context, projectId int, debugId string) (*SourceMapDebugID, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "s3.ReadSourceMapDebugID")
+	defer span.Finish()
+	key := s.sourceMapDebugIDBucketKey(projectId, debugId)
+	span.SetAttribute("key", key)
+	return hredis.CachedEval(ctx, s.Redis, *key, time.Second, time.Minute, func() (*SourceMapDebugID, error) {
+		output, err := s.S3ClientEast2.GetObject(ctx, &s3.GetObjectInput{Bucket: pointy.String(S3SourceMapBucketNameNew), Key: key})
+		if err != nil {
+			return nil, errors.Wrap(err, "error getting object from s3")
+		}
+		defer output.Body.Close()
+		var sourceMap SourceMapDebugID
+		if err := json.NewDecoder(output.Body).Decode(&sourceMap); err != nil {
+			return nil, errors.Wrap(err, "error unmarshalling sourcemap debug id")
+		}
+		return &sourceMap, nil
+	}, hredis.WithStoreNil(true), hredis.WithIgnoreError(true))
+}
+
 func (s *S3Client) GetDirectDownloadURL(_ context.Context, projectId int, sessionId int, payloadType PayloadType, chunkId *int) (*string, error) {
 	if s.URLSigner == nil {
 		return nil, nil
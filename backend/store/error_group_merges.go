@@ -0,0 +1,178 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
+	kafka_queue "github.com/highlight-run/highlight/backend/kafka-queue"
+	"github.com/highlight-run/highlight/backend/model"
+)
+
+// the number of moved error objects submitted to the data sync queue at once
+const mergeErrorObjectsBatchSize = 1000
+
+// MergeErrorGroups merges the source error group into the target. The error objects and fingerprints
+// of the source are moved to the target, remembering their original group so the merge can be undone,
+// and new errors matching the source are grouped into the target. Groups previously merged into the
+// source are merged into the target.
+func (store *Store) MergeErrorGroups(ctx context.Context, admin model.Admin, source *model.ErrorGroup, target *model.ErrorGroup) (*model.ErrorGroup, error) {
+	if source.ID == target.ID {
+		return nil, errors.New("cannot merge an error group into itself")
+	}
+	if source.ProjectID != target.ProjectID {
+		return nil, errors.New("cannot merge error groups of different projects")
+	}
+	if source.MergedIntoID != nil {
+		return nil, errors.New("the error group was already merged")
+	}
+	if target.MergedIntoID != nil {
+		return nil, errors.New("cannot merge into an error group that was merged")
+	}
+
+	// the source and the groups merged into it, whose moved objects are written to clickhouse
+	var mergedIDs []int
+	if err := store.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.ErrorGroup{}).Where("merged_into_id = ?", source.ID).Pluck("id", &mergedIDs).Error; err != nil {
+			return err
+		}
+		mergedIDs = append(mergedIDs, source.ID)
+
+		if err := tx.Exec(`
+			UPDATE error_objects
+			SET merged_from_error_group_id = COALESCE(merged_from_error_group_id, error_group_id), error_group_id = @target
+			WHERE error_group_id = @source`,
+			map[string]interface{}{"source": source.ID, "target": target.ID}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`
+			UPDATE error_fingerprints
+			SET merged_from_error_group_id = COALESCE(merged_from_error_group_id, error_group_id), error_group_id = @target
+			WHERE error_group_id = @source`,
+			map[string]interface{}{"source": source.ID, "target": target.ID}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.ErrorGroup{}).Where("id IN ?", mergedIDs).Update("merged_into_id", target.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&[]model.ErrorGroupActivityLog{{
+			AdminID:      admin.ID,
+			EventType:    model.ErrorGroupMergedEvent,
+			ErrorGroupID: source.ID,
+			EventData:    map[string]interface{}{"TargetErrorGroupID": target.ID, "TargetSecureID": target.SecureID},
+		}, {
+			AdminID:      admin.ID,
+			EventType:    model.ErrorGroupMergedEvent,
+			ErrorGroupID: target.ID,
+			EventData:    map[string]interface{}{"SourceErrorGroupID": source.ID, "SourceSecureID": source.SecureID},
+		}}).Error; err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := store.syncMergedErrorGroups(ctx, append(mergedIDs, target.ID), store.DB.WithContext(ctx).
+		Where("error_group_id = ?", target.ID).
+		Where("merged_from_error_group_id IN ?", mergedIDs)); err != nil {
+		return nil, err
+	}
+
+	var merged model.ErrorGroup
+	if err := store.DB.WithContext(ctx).Where(&model.ErrorGroup{Model: model.Model{ID: target.ID}}).Take(&merged).Error; err != nil {
+		return nil, err
+	}
+	return &merged, nil
+}
+
+// UnmergeErrorGroup moves the error objects and fingerprints that originally belonged to a merged error
+// group back to it, so new errors matching it are grouped into it again. Groups that were merged
+// into it before it was merged stay merged into its target.
+func (store *Store) UnmergeErrorGroup(ctx context.Context, admin model.Admin, errorGroup *model.ErrorGroup) (*model.ErrorGroup, error) {
+	if errorGroup.MergedIntoID == nil {
+		return nil, errors.New("the error group is not merged")
+	}
+	targetID := *errorGroup.MergedIntoID
+
+	if err := store.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			UPDATE error_objects
+			SET error_group_id = @source, merged_from_error_group_id = NULL
+			WHERE error_group_id = @target AND merged_from_error_group_id = @source`,
+			map[string]interface{}{"source": errorGroup.ID, "target": targetID}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`
+			UPDATE error_fingerprints
+			SET error_group_id = @source, merged_from_error_group_id = NULL
+			WHERE error_group_id = @target AND merged_from_error_group_id = @source`,
+			map[string]interface{}{"source": errorGroup.ID, "target": targetID}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.ErrorGroup{}).Where("id = ?", errorGroup.ID).Update("merged_into_id", nil).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&[]model.ErrorGroupActivityLog{{
+			AdminID:      admin.ID,
+			EventType:    model.ErrorGroupUnmergedEvent,
+			ErrorGroupID: errorGroup.ID,
+			EventData:    map[string]interface{}{"TargetErrorGroupID": targetID},
+		}, {
+			AdminID:      admin.ID,
+			EventType:    model.ErrorGroupUnmergedEvent,
+			ErrorGroupID: targetID,
+			EventData:    map[string]interface{}{"SourceErrorGroupID": errorGroup.ID, "SourceSecureID": errorGroup.SecureID},
+		}}).Error; err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := store.syncMergedErrorGroups(ctx, []int{errorGroup.ID, targetID}, store.DB.WithContext(ctx).
+		Where("error_group_id = ?", errorGroup.ID)); err != nil {
+		return nil, err
+	}
+
+	var unmerged model.ErrorGroup
+	if err := store.DB.WithContext(ctx).Where(&model.ErrorGroup{Model: model.Model{ID: errorGroup.ID}}).Take(&unmerged).Error; err != nil {
+		return nil, err
+	}
+	return &unmerged, nil
+}
+
+// GetMergedErrorGroupIDs returns the id of the error group and of the groups merged into it.
+func (store *Store) GetMergedErrorGroupIDs(ctx context.Context, errorGroupID int) ([]int, error) {
+	var ids []int
+	if err := store.DB.WithContext(ctx).Model(&model.ErrorGroup{}).
+		Where("merged_into_id = ?", errorGroupID).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return append([]int{errorGroupID}, ids...), nil
+}
+
+// syncMergedErrorGroups submits the error groups and the error objects matching the query to the data sync queue,
+// so the moved error objects are written to clickhouse with their new error group.
+func (store *Store) syncMergedErrorGroups(ctx context.Context, errorGroupIDs []int, errorObjects *gorm.DB) error {
+	for _, id := range errorGroupIDs {
+		if err := store.DataSyncQueue.Submit(ctx, strconv.Itoa(id), &kafka_queue.Message{Type: kafka_queue.ErrorGroupDataSync, ErrorGroupDataSync: &kafka_queue.ErrorGroupDataSyncArgs{ErrorGroupID: id}}); err != nil {
+			return err
+		}
+	}
+
+	var batch []*model.ErrorObject
+	return errorObjects.Model(&model.ErrorObject{}).Select("id").FindInBatches(&batch, mergeErrorObjectsBatchSize, func(tx *gorm.DB, _ int) error {
+		messages := lo.Map(batch, func(obj *model.ErrorObject, _ int) kafka_queue.RetryableMessage {
+			return &kafka_queue.Message{Type: kafka_queue.ErrorObjectDataSync, ErrorObjectDataSync: &kafka_queue.ErrorObjectDataSyncArgs{ErrorObjectID: obj.ID}}
+		})
+		return store.DataSyncQueue.Submit(ctx, "", messages...)
+	}).Error
+}
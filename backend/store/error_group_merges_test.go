@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeErrorGroups(t *testing.T) {
+	defer teardown(t)
+
+	admin := model.Admin{}
+	store.DB.Create(&admin)
+	project := model.Project{}
+	store.DB.Create(&project)
+
+	source := model.ErrorGroup{ProjectID: project.ID}
+	store.DB.Create(&source)
+	target := model.ErrorGroup{ProjectID: project.ID}
+	store.DB.Create(&target)
+	other := model.ErrorGroup{ProjectID: project.ID}
+	store.DB.Create(&other)
+
+	errorObject := model.ErrorObject{ProjectID: project.ID, ErrorGroupID: source.ID}
+	store.DB.Create(&errorObject)
+	fingerprint := model.ErrorFingerprint{ProjectID: project.ID, ErrorGroupId: source.ID, Type: model.Fingerprint.StackFrameCode, Value: "throw"}
+	store.DB.Create(&fingerprint)
+
+	_, err := store.MergeErrorGroups(context.TODO(), admin, &source, &source)
+	assert.Error(t, err)
+
+	// merging a group that other groups were merged into moves them to the target
+	_, err = store.MergeErrorGroups(context.TODO(), admin, &other, &source)
+	assert.NoError(t, err)
+	store.DB.Take(&other, other.ID)
+
+	merged, err := store.MergeErrorGroups(context.TODO(), admin, &source, &target)
+	assert.NoError(t, err)
+	assert.Equal(t, target.ID, merged.ID)
+
+	store.DB.Take(&source, source.ID)
+	store.DB.Take(&other, other.ID)
+	assert.Equal(t, target.ID, *source.MergedIntoID)
+	assert.Equal(t, target.ID, *other.MergedIntoID)
+
+	store.DB.Take(&errorObject, errorObject.ID)
+	assert.Equal(t, target.ID, errorObject.ErrorGroupID)
+	assert.Equal(t, source.ID, *errorObject.MergedFromErrorGroupID)
+
+	ids, err := store.GetMergedErrorGroupIDs(context.TODO(), target.ID)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int{target.ID, source.ID, other.ID}, ids)
+
+	unmerged, err := store.UnmergeErrorGroup(context.TODO(), admin, &source)
+	assert.NoError(t, err)
+	assert.Nil(t, unmerged.MergedIntoID)
+
+	store.DB.Take(&errorObject, errorObject.ID)
+	assert.Equal(t, source.ID, errorObject.ErrorGroupID)
+	assert.Nil(t, errorObject.MergedFromErrorGroupID)
+	store.DB.Take(&fingerprint, fingerprint.ID)
+	assert.Equal(t, source.ID, fingerprint.ErrorGroupId)
+
+	_, err = store.UnmergeErrorGroup(context.TODO(), admin, unmerged)
+	assert.Error(t, err)
+
+	activityLogs, err := store.GetErrorGroupActivityLogs(context.TODO(), source.ID)
+	assert.NoError(t, err)
+	assert.Len(t, activityLogs, 3)
+	assert.Equal(t, model.ErrorGroupUnmergedEvent, activityLogs[2].EventType)
+}
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openlyinc/pointy"
+	"gorm.io/gorm"
+
+	"github.com/highlight-run/highlight/backend/errorgroups"
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/redis"
+)
+
+func errorGroupingRulesCacheKey(projectID int) string {
+	return fmt.Sprintf("error-grouping-rules-%d", projectID)
+}
+
+// GetErrorGroupingRules returns the error grouping rules of a project in evaluation order.
+func (store *Store) GetErrorGroupingRules(ctx context.Context, projectID int, opts ...redis.Option) ([]*model.ErrorGroupingRule, error) {
+	rules, err := redis.CachedEval(ctx, store.Redis, errorGroupingRulesCacheKey(projectID), 250*time.Millisecond, time.Minute, func() (*[]*model.ErrorGroupingRule, error) {
+		var rules []*model.ErrorGroupingRule
+		if err := store.DB.WithContext(ctx).
+			Where(&model.ErrorGroupingRule{ProjectID: projectID}).
+			Order("id").
+			Find(&rules).Error; err != nil {
+			return nil, err
+		}
+		return &rules, nil
+	}, opts...)
+	if err != nil || rules == nil {
+		return nil, err
+	}
+	return *rules, nil
+}
+
+// ErrorGroupingRulesFromInput validates error grouping rules without saving them.
+func ErrorGroupingRulesFromInput(projectID int, inputs []*modelInputs.ErrorGroupingRuleInput) ([]*model.ErrorGroupingRule, error) {
+	var rules []*model.ErrorGroupingRule
+	for _, input := range inputs {
+		rule := &model.ErrorGroupingRule{
+			ProjectID:   projectID,
+			Name:        input.Name,
+			Type:        input.Type,
+			Pattern:     pointy.StringValue(input.Pattern, ""),
+			Replacement: pointy.StringValue(input.Replacement, ""),
+			Attribute:   pointy.StringValue(input.Attribute, ""),
+		}
+		if err := errorgroups.ValidateGroupingRule(rule); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// UpdateErrorGroupingRules replaces the error grouping rules of a project, keeping the provided order.
+func (store *Store) UpdateErrorGroupingRules(ctx context.Context, projectID int, inputs []*modelInputs.ErrorGroupingRuleInput) ([]*model.ErrorGroupingRule, error) {
+	rules, err := ErrorGroupingRulesFromInput(projectID, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(&model.ErrorGroupingRule{ProjectID: projectID}).Delete(&model.ErrorGroupingRule{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	return rules, store.Redis.Del(ctx, errorGroupingRulesCacheKey(projectID))
+}
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateErrorGroupingRules(t *testing.T) {
+	ctx := context.TODO()
+	defer teardown(t)
+
+	project := model.Project{}
+	store.DB.Create(&project)
+
+	rules, err := store.GetErrorGroupingRules(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+
+	_, err = store.UpdateErrorGroupingRules(ctx, project.ID, []*modelInputs.ErrorGroupingRuleInput{
+		{Name: "emails", Type: modelInputs.ErrorGroupingRuleTypeNormalizeMessage, Pattern: pointy.String(`\S+@\S+`), Replacement: pointy.String("<email>")},
+		{Name: "vendor", Type: modelInputs.ErrorGroupingRuleTypeIgnoreFrames, Pattern: pointy.String(`vendor/`)},
+	})
+	assert.NoError(t, err)
+
+	rules, err = store.GetErrorGroupingRules(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "emails", rules[0].Name)
+	assert.Equal(t, "<email>", rules[0].Replacement)
+	assert.Equal(t, "vendor", rules[1].Name)
+
+	_, err = store.UpdateErrorGroupingRules(ctx, project.ID, []*modelInputs.ErrorGroupingRuleInput{
+		{Name: "invalid", Type: modelInputs.ErrorGroupingRuleTypeNormalizeMessage, Pattern: pointy.String(`(`)},
+	})
+	assert.Error(t, err)
+
+	_, err = store.UpdateErrorGroupingRules(ctx, project.ID, nil)
+	assert.NoError(t, err)
+
+	rules, err = store.GetErrorGroupingRules(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+}
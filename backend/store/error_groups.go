@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"errors"
+	"reflect"
 	"strconv"
 	"time"
 
@@ -110,6 +111,10 @@ type UpdateErrorGroupParams struct {
 	ID           int
 	State        privateModel.ErrorState
 	SnoozedUntil *time.Time
+	// when resolving, only reopen the group for errors seen in this version or later
+	ResolvedInVersion *string
+	// when resolving, only reopen the group for errors seen in a version after the latest one
+	ResolveInNextRelease bool
 }
 
 func (store *Store) UpdateErrorGroupStateByAdmin(ctx context.Context,
@@ -142,13 +147,32 @@ func (store *Store) UpdateErrorGroupStateBySystem(ctx context.Context,
 func (store *Store) updateErrorGroupState(ctx context.Context,
 	admin *model.Admin, params UpdateErrorGroupParams) error {
 
+	// versions only apply to resolved groups, and are cleared by any other state change
+	var resolvedInVersion, resolvedAfterVersion *string
+	if params.State == privateModel.ErrorStateResolved {
+		if params.ResolveInNextRelease {
+			version, err := store.getLatestErrorGroupVersion(ctx, params.ID)
+			if err != nil {
+				return err
+			}
+			if version == "" {
+				return errors.New("cannot resolve in the next release: no errors in this group have a service version")
+			}
+			resolvedAfterVersion = &version
+		} else if params.ResolvedInVersion != nil && *params.ResolvedInVersion != "" {
+			resolvedInVersion = params.ResolvedInVersion
+		}
+	}
+
 	if err := AssertRecordFound(store.DB.WithContext(ctx).Where(&model.ErrorGroup{
 		Model: model.Model{
 			ID: params.ID,
 		},
 	}).Model(&model.ErrorGroup{}).Clauses(clause.Returning{}).Updates(map[string]interface{}{
-		"State":        params.State,
-		"SnoozedUntil": params.SnoozedUntil,
+		"State":                params.State,
+		"SnoozedUntil":         params.SnoozedUntil,
+		"ResolvedInVersion":    resolvedInVersion,
+		"ResolvedAfterVersion": resolvedAfterVersion,
 	})); err != nil {
 		return err
 	}
@@ -163,6 +187,12 @@ func (store *Store) updateErrorGroupState(ctx context.Context,
 	if params.SnoozedUntil != nil {
 		eventData["SnoozedUntil"] = params.SnoozedUntil
 	}
+	if resolvedInVersion != nil {
+		eventData["ResolvedInVersion"] = *resolvedInVersion
+	}
+	if resolvedAfterVersion != nil {
+		eventData["ResolvedAfterVersion"] = *resolvedAfterVersion
+	}
 
 	err = store.CreateErrorGroupActivityLog(ctx, model.ErrorGroupActivityLog{
 		Admin:        admin,
@@ -183,6 +213,114 @@ func (store *Store) updateErrorGroupState(ctx context.Context,
 
 }
 
+type AssignErrorGroupParams struct {
+	ID int
+	// the admin or team to assign the group to, both nil to unassign it
+	AdminID *int
+	TeamID  *int
+}
+
+func (store *Store) AssignErrorGroupByAdmin(ctx context.Context,
+	admin model.Admin, workspaceID int, params AssignErrorGroupParams) (*model.ErrorGroup, error) {
+	return store.assignErrorGroup(ctx, &admin, workspaceID, params)
+}
+
+func (store *Store) AssignErrorGroupBySystem(ctx context.Context,
+	workspaceID int, params AssignErrorGroupParams) (*model.ErrorGroup, error) {
+	return store.assignErrorGroup(ctx, nil, workspaceID, params)
+}
+
+func (store *Store) assignErrorGroup(ctx context.Context,
+	admin *model.Admin, workspaceID int, params AssignErrorGroupParams) (*model.ErrorGroup, error) {
+	if params.AdminID != nil && params.TeamID != nil {
+		return nil, errors.New("an error group is assigned to either an admin or a team")
+	}
+	if err := store.validateErrorGroupAssignee(ctx, workspaceID, params.AdminID, params.TeamID); err != nil {
+		return nil, err
+	}
+
+	var errorGroup model.ErrorGroup
+	if err := store.DB.WithContext(ctx).Where(&model.ErrorGroup{Model: model.Model{ID: params.ID}}).Take(&errorGroup).Error; err != nil {
+		return nil, err
+	}
+	if reflect.DeepEqual(errorGroup.AssigneeAdminID, params.AdminID) && reflect.DeepEqual(errorGroup.AssigneeTeamID, params.TeamID) {
+		return &errorGroup, nil
+	}
+
+	eventData := map[string]interface{}{}
+	if errorGroup.AssigneeAdminID != nil {
+		eventData["PreviousAssigneeAdminID"] = *errorGroup.AssigneeAdminID
+	}
+	if errorGroup.AssigneeTeamID != nil {
+		eventData["PreviousAssigneeTeamID"] = *errorGroup.AssigneeTeamID
+	}
+
+	if err := store.DB.WithContext(ctx).Model(&errorGroup).Updates(map[string]interface{}{
+		"AssigneeAdminID": params.AdminID,
+		"AssigneeTeamID":  params.TeamID,
+	}).Error; err != nil {
+		return nil, err
+	}
+	errorGroup.AssigneeAdminID = params.AdminID
+	errorGroup.AssigneeTeamID = params.TeamID
+
+	eventType := model.ErrorGroupUnassignedEvent
+	if params.AdminID != nil {
+		eventType = model.ErrorGroupAssignedEvent
+		eventData["AssigneeAdminID"] = *params.AdminID
+	} else if params.TeamID != nil {
+		eventType = model.ErrorGroupAssignedEvent
+		eventData["AssigneeTeamID"] = *params.TeamID
+	}
+
+	if err := store.CreateErrorGroupActivityLog(ctx, model.ErrorGroupActivityLog{
+		Admin:        admin,
+		EventType:    eventType,
+		ErrorGroupID: errorGroup.ID,
+		EventData:    eventData,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := store.DataSyncQueue.Submit(ctx, strconv.Itoa(errorGroup.ID), &kafka_queue.Message{Type: kafka_queue.ErrorGroupDataSync, ErrorGroupDataSync: &kafka_queue.ErrorGroupDataSyncArgs{ErrorGroupID: errorGroup.ID}}); err != nil {
+		return nil, err
+	}
+
+	return &errorGroup, nil
+}
+
+// getLatestErrorGroupVersion returns the service version of the latest error object of the group.
+func (store *Store) getLatestErrorGroupVersion(ctx context.Context, errorGroupID int) (string, error) {
+	var versions []string
+	if err := store.DB.WithContext(ctx).Model(&model.ErrorObject{}).
+		Where(&model.ErrorObject{ErrorGroupID: errorGroupID}).
+		Where("service_version <> ''").
+		Order("id desc").
+		Limit(1).
+		Pluck("service_version", &versions).Error; err != nil {
+		return "", err
+	}
+	if len(versions) == 0 {
+		return "", nil
+	}
+	return versions[0], nil
+}
+
+// CreateErrorGroupRegressedActivityLog records that an error object reopened a resolved error group.
+func (store *Store) CreateErrorGroupRegressedActivityLog(ctx context.Context, errorObject *model.ErrorObject) error {
+	eventData := map[string]interface{}{
+		"ErrorObjectID": errorObject.ID,
+	}
+	if errorObject.ServiceVersion != "" {
+		eventData["ServiceVersion"] = errorObject.ServiceVersion
+	}
+	return store.CreateErrorGroupActivityLog(ctx, model.ErrorGroupActivityLog{
+		EventType:    model.ErrorGroupRegressedEvent,
+		ErrorGroupID: errorObject.ErrorGroupID,
+		EventData:    eventData,
+	})
+}
+
 func getEventType(state privateModel.ErrorState) (model.ErrorGroupEventType, error) {
 	var event model.ErrorGroupEventType
 
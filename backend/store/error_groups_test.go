@@ -184,3 +184,97 @@ func TestUpdateErrorGroupStateBySystem(t *testing.T) {
 	assert.Equal(t, model.ErrorGroupIgnoredEvent, activityLogs[0].EventType)
 	assert.NotNil(t, activityLogs[0].EventData)
 }
+
+func TestUpdateErrorGroupStateResolveInVersion(t *testing.T) {
+	defer teardown(t)
+	errorGroup := model.ErrorGroup{
+		State: privateModel.ErrorStateOpen,
+	}
+	store.DB.Create(&errorGroup)
+
+	params := UpdateErrorGroupParams{
+		ID:                   errorGroup.ID,
+		State:                privateModel.ErrorStateResolved,
+		ResolveInNextRelease: true,
+	}
+
+	// the next release is unknown without versioned errors
+	err := store.UpdateErrorGroupStateBySystem(context.TODO(), params)
+	assert.Error(t, err)
+
+	store.DB.Create(&model.ErrorObject{ErrorGroupID: errorGroup.ID, ServiceVersion: "1.2.0"})
+	store.DB.Create(&model.ErrorObject{ErrorGroupID: errorGroup.ID, ServiceVersion: "1.3.0"})
+	store.DB.Create(&model.ErrorObject{ErrorGroupID: errorGroup.ID})
+
+	err = store.UpdateErrorGroupStateBySystem(context.TODO(), params)
+	assert.NoError(t, err)
+
+	var updatedErrorGroup *model.ErrorGroup
+	store.DB.Model(model.ErrorGroup{}).Where("id = ?", params.ID).First(&updatedErrorGroup)
+	assert.Equal(t, privateModel.ErrorStateResolved, updatedErrorGroup.State)
+	assert.Equal(t, "1.3.0", ptr.ToString(updatedErrorGroup.ResolvedAfterVersion))
+	assert.Nil(t, updatedErrorGroup.ResolvedInVersion)
+
+	params.ResolveInNextRelease = false
+	params.ResolvedInVersion = ptr.String("2.0.0")
+	err = store.UpdateErrorGroupStateBySystem(context.TODO(), params)
+	assert.NoError(t, err)
+
+	store.DB.Model(model.ErrorGroup{}).Where("id = ?", params.ID).First(&updatedErrorGroup)
+	assert.Equal(t, "2.0.0", ptr.ToString(updatedErrorGroup.ResolvedInVersion))
+	assert.Nil(t, updatedErrorGroup.ResolvedAfterVersion)
+
+	activityLogs, err := store.GetErrorGroupActivityLogs(context.TODO(), errorGroup.ID)
+	assert.NoError(t, err)
+	assert.Len(t, activityLogs, 2)
+	assert.Equal(t, "1.3.0", activityLogs[0].EventData["ResolvedAfterVersion"])
+	assert.Equal(t, "2.0.0", activityLogs[1].EventData["ResolvedInVersion"])
+
+	// reopening clears the version
+	params.State = privateModel.ErrorStateOpen
+	err = store.UpdateErrorGroupStateBySystem(context.TODO(), params)
+	assert.NoError(t, err)
+
+	store.DB.Model(model.ErrorGroup{}).Where("id = ?", params.ID).First(&updatedErrorGroup)
+	assert.Nil(t, updatedErrorGroup.ResolvedInVersion)
+}
+
+func TestAssignErrorGroup(t *testing.T) {
+	defer teardown(t)
+
+	admin := model.Admin{}
+	store.DB.Create(&admin)
+	workspace := model.Workspace{Admins: []model.Admin{admin}}
+	store.DB.Create(&workspace)
+	team := model.Team{WorkspaceID: workspace.ID, Name: "payments"}
+	store.DB.Create(&team)
+	errorGroup := model.ErrorGroup{State: privateModel.ErrorStateOpen}
+	store.DB.Create(&errorGroup)
+
+	_, err := store.AssignErrorGroupByAdmin(context.TODO(), admin, workspace.ID, AssignErrorGroupParams{ID: errorGroup.ID, AdminID: &admin.ID, TeamID: &team.ID})
+	assert.Error(t, err)
+
+	assigned, err := store.AssignErrorGroupByAdmin(context.TODO(), admin, workspace.ID, AssignErrorGroupParams{ID: errorGroup.ID, AdminID: &admin.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, admin.ID, *assigned.AssigneeAdminID)
+
+	// reassigning to the same owner is a no-op
+	_, err = store.AssignErrorGroupByAdmin(context.TODO(), admin, workspace.ID, AssignErrorGroupParams{ID: errorGroup.ID, AdminID: &admin.ID})
+	assert.NoError(t, err)
+
+	assigned, err = store.AssignErrorGroupBySystem(context.TODO(), workspace.ID, AssignErrorGroupParams{ID: errorGroup.ID, TeamID: &team.ID})
+	assert.NoError(t, err)
+	assert.Nil(t, assigned.AssigneeAdminID)
+	assert.Equal(t, team.ID, *assigned.AssigneeTeamID)
+
+	_, err = store.AssignErrorGroupByAdmin(context.TODO(), admin, workspace.ID, AssignErrorGroupParams{ID: errorGroup.ID})
+	assert.NoError(t, err)
+
+	activityLogs, err := store.GetErrorGroupActivityLogs(context.TODO(), errorGroup.ID)
+	assert.NoError(t, err)
+	assert.Len(t, activityLogs, 3)
+	assert.Equal(t, model.ErrorGroupAssignedEvent, activityLogs[0].EventType)
+	assert.Equal(t, admin.ID, activityLogs[0].AdminID)
+	assert.Equal(t, 0, activityLogs[1].AdminID)
+	assert.Equal(t, model.ErrorGroupUnassignedEvent, activityLogs[2].EventType)
+}
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openlyinc/pointy"
+	e "github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/highlight-run/highlight/backend/errorgroups"
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/redis"
+)
+
+func errorOwnershipRulesCacheKey(projectID int) string {
+	return fmt.Sprintf("error-ownership-rules-%d", projectID)
+}
+
+// GetErrorOwnershipRules returns the error ownership rules of a project in evaluation order.
+func (store *Store) GetErrorOwnershipRules(ctx context.Context, projectID int, opts ...redis.Option) ([]*model.ErrorOwnershipRule, error) {
+	rules, err := redis.CachedEval(ctx, store.Redis, errorOwnershipRulesCacheKey(projectID), 250*time.Millisecond, time.Minute, func() (*[]*model.ErrorOwnershipRule, error) {
+		var rules []*model.ErrorOwnershipRule
+		if err := store.DB.WithContext(ctx).
+			Where(&model.ErrorOwnershipRule{ProjectID: projectID}).
+			Order("id").
+			Find(&rules).Error; err != nil {
+			return nil, err
+		}
+		return &rules, nil
+	}, opts...)
+	if err != nil || rules == nil {
+		return nil, err
+	}
+	return *rules, nil
+}
+
+// UpdateErrorOwnershipRules replaces the error ownership rules of a project, keeping the provided order.
+// Rules must assign error groups to admins or teams of the project's workspace.
+func (store *Store) UpdateErrorOwnershipRules(ctx context.Context, project *model.Project, inputs []*modelInputs.ErrorOwnershipRuleInput) ([]*model.ErrorOwnershipRule, error) {
+	var rules []*model.ErrorOwnershipRule
+	for _, input := range inputs {
+		rule := &model.ErrorOwnershipRule{
+			ProjectID: project.ID,
+			Type:      input.Type,
+			Pattern:   input.Pattern,
+			Attribute: pointy.StringValue(input.Attribute, ""),
+			AdminID:   input.AdminID,
+			TeamID:    input.TeamID,
+		}
+		if err := errorgroups.ValidateOwnershipRule(rule); err != nil {
+			return nil, err
+		}
+		if err := store.validateErrorGroupAssignee(ctx, project.WorkspaceID, rule.AdminID, rule.TeamID); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := store.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(&model.ErrorOwnershipRule{ProjectID: project.ID}).Delete(&model.ErrorOwnershipRule{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	return rules, store.Redis.Del(ctx, errorOwnershipRulesCacheKey(project.ID))
+}
+
+// validateErrorGroupAssignee returns an error if the admin is not a member of the workspace or the
+// team does not belong to it.
+func (store *Store) validateErrorGroupAssignee(ctx context.Context, workspaceID int, adminID *int, teamID *int) error {
+	if adminID != nil {
+		var count int64
+		if err := store.DB.WithContext(ctx).Model(&model.WorkspaceAdmin{}).
+			Where(&model.WorkspaceAdmin{AdminID: *adminID, WorkspaceID: workspaceID}).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return e.Errorf("admin %d is not a member of the workspace", *adminID)
+		}
+	}
+	if teamID != nil {
+		if _, err := store.GetTeam(ctx, workspaceID, *teamID); err != nil {
+			return e.Wrapf(err, "team %d does not belong to the workspace", *teamID)
+		}
+	}
+	return nil
+}
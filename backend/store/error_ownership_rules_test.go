@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateErrorOwnershipRules(t *testing.T) {
+	ctx := context.TODO()
+	defer teardown(t)
+
+	admin := model.Admin{}
+	store.DB.Create(&admin)
+	workspace := model.Workspace{Admins: []model.Admin{admin}}
+	store.DB.Create(&workspace)
+	project := model.Project{WorkspaceID: workspace.ID}
+	store.DB.Create(&project)
+	team := model.Team{WorkspaceID: workspace.ID, Name: "payments"}
+	store.DB.Create(&team)
+
+	rules, err := store.GetErrorOwnershipRules(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+
+	_, err = store.UpdateErrorOwnershipRules(ctx, &project, []*modelInputs.ErrorOwnershipRuleInput{
+		{Type: modelInputs.ErrorOwnershipRuleTypeFilePath, Pattern: "/src/billing/", TeamID: &team.ID},
+		{Type: modelInputs.ErrorOwnershipRuleTypeAttribute, Pattern: "^enterprise$", Attribute: pointy.String("tenant"), AdminID: &admin.ID},
+	})
+	assert.NoError(t, err)
+
+	rules, err = store.GetErrorOwnershipRules(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, team.ID, *rules[0].TeamID)
+	assert.Equal(t, "tenant", rules[1].Attribute)
+
+	// owners must belong to the project's workspace
+	_, err = store.UpdateErrorOwnershipRules(ctx, &project, []*modelInputs.ErrorOwnershipRuleInput{
+		{Type: modelInputs.ErrorOwnershipRuleTypeServiceName, Pattern: "checkout", AdminID: pointy.Int(admin.ID + 1)},
+	})
+	assert.Error(t, err)
+
+	_, err = store.UpdateErrorOwnershipRules(ctx, &project, nil)
+	assert.NoError(t, err)
+
+	rules, err = store.GetErrorOwnershipRules(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+}
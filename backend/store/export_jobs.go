@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/highlight-run/highlight/backend/exports"
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+)
+
+// GetExportJobs returns the export jobs of a project.
+func (store *Store) GetExportJobs(ctx context.Context, projectID int) ([]*model.ExportJob, error) {
+	var jobs []*model.ExportJob
+	if err := store.DB.WithContext(ctx).
+		Where(&model.ExportJob{ProjectID: projectID}).
+		Order("id").
+		Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// GetActiveExportJobs returns the export jobs of all projects that are not disabled.
+func (store *Store) GetActiveExportJobs(ctx context.Context) ([]*model.ExportJob, error) {
+	var jobs []*model.ExportJob
+	if err := store.DB.WithContext(ctx).
+		Where("disabled = ?", false).
+		Order("id").
+		Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func applyExportJobInput(job *model.ExportJob, input modelInputs.ExportJobInput) error {
+	job.Name = input.Name
+	job.Product = input.Product
+	job.Query = input.Query
+	job.Format = input.Format
+	job.Destination = input.Destination
+	job.Endpoint = input.Endpoint
+	job.Region = input.Region
+	job.Bucket = input.Bucket
+	job.Prefix = input.Prefix
+	job.AccessKeyID = input.AccessKeyID
+	if input.SecretAccessKey != nil {
+		job.SecretAccessKey = input.SecretAccessKey
+	}
+	if input.Disabled != nil {
+		job.Disabled = *input.Disabled
+	}
+	return exports.ValidateJob(job)
+}
+
+// CreateExportJob creates a job exporting the hours from the start date, or from the current hour.
+func (store *Store) CreateExportJob(ctx context.Context, projectID int, input modelInputs.ExportJobInput) (*model.ExportJob, error) {
+	start := time.Now()
+	if input.StartDate != nil {
+		start = *input.StartDate
+	}
+	job := &model.ExportJob{
+		ProjectID:     projectID,
+		ExportedUntil: start.UTC().Truncate(time.Hour),
+	}
+	if err := applyExportJobInput(job, input); err != nil {
+		return nil, err
+	}
+	if err := store.DB.WithContext(ctx).Create(job).Error; err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// UpdateExportJob updates the configuration of a job. The job resumes from its checkpoint.
+func (store *Store) UpdateExportJob(ctx context.Context, projectID int, id int, input modelInputs.ExportJobInput) (*model.ExportJob, error) {
+	var job model.ExportJob
+	if err := store.DB.WithContext(ctx).
+		Where(&model.ExportJob{Model: model.Model{ID: id}, ProjectID: projectID}).
+		Take(&job).Error; err != nil {
+		return nil, err
+	}
+	if err := applyExportJobInput(&job, input); err != nil {
+		return nil, err
+	}
+
+	if err := AssertRecordFound(store.DB.WithContext(ctx).
+		Model(&job).
+		Clauses(clause.Returning{}).
+		Select("Name", "Product", "Query", "Format", "Destination", "Endpoint", "Region", "Bucket", "Prefix", "AccessKeyID", "SecretAccessKey", "Disabled").
+		Updates(&job)); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (store *Store) DeleteExportJob(ctx context.Context, projectID int, id int) error {
+	return AssertRecordFound(store.DB.WithContext(ctx).
+		Where(&model.ExportJob{Model: model.Model{ID: id}, ProjectID: projectID}).
+		Delete(&model.ExportJob{}))
+}
+
+// UpdateExportJobCheckpoint saves the progress of a job.
+func (store *Store) UpdateExportJobCheckpoint(ctx context.Context, job *model.ExportJob) error {
+	return store.DB.WithContext(ctx).
+		Model(&model.ExportJob{Model: model.Model{ID: job.ID}}).
+		Select("ExportedUntil", "CheckpointCursor", "CheckpointPart", "LastRunAt", "LastError").
+		Updates(job).Error
+}
@@ -0,0 +1,187 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/redis"
+	hmetric "github.com/highlight/highlight/sdk/highlight-go/metric"
+	e "github.com/pkg/errors"
+	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm/clause"
+)
+
+const IngestKeyPrefix = "hik_"
+
+var (
+	ErrIngestKeyMissing = e.New("an ingest key is required for this project")
+	ErrIngestKeyInvalid = e.New("invalid ingest key")
+	ErrIngestKeyRevoked = e.New("ingest key has been revoked")
+	ErrIngestKeyScope   = e.New("ingest key is not allowed to push this data")
+
+	ingestKeyRejections = []error{ErrIngestKeyMissing, ErrIngestKeyInvalid, ErrIngestKeyRevoked, ErrIngestKeyScope}
+)
+
+func ingestKeysCacheKey(projectID int) string {
+	return fmt.Sprintf("ingest-keys-%d", projectID)
+}
+
+func HashIngestKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func generateIngestKey() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return IngestKeyPrefix + hex.EncodeToString(b), nil
+}
+
+// GetIngestKeys returns all keys of a project, including revoked ones.
+func (store *Store) GetIngestKeys(ctx context.Context, projectID int, opts ...redis.Option) ([]*model.IngestKey, error) {
+	keys, err := redis.CachedEval(ctx, store.Redis, ingestKeysCacheKey(projectID), 250*time.Millisecond, time.Minute, func() (*[]*model.IngestKey, error) {
+		var keys []*model.IngestKey
+		if err := store.DB.WithContext(ctx).
+			Where(&model.IngestKey{ProjectID: projectID}).
+			Order("id").
+			Find(&keys).Error; err != nil {
+			return nil, err
+		}
+		return &keys, nil
+	}, opts...)
+	if err != nil || keys == nil {
+		return nil, err
+	}
+	return *keys, nil
+}
+
+func (store *Store) CreateIngestKey(ctx context.Context, projectID int, name string, scope modelInputs.IngestKeyScope, products []modelInputs.ProductType) (*model.IngestKeyWithSecret, error) {
+	key, err := generateIngestKey()
+	if err != nil {
+		return nil, err
+	}
+
+	ingestKey := model.IngestKey{
+		ProjectID: projectID,
+		Name:      name,
+		Scope:     scope,
+		Products: lo.Map(products, func(p modelInputs.ProductType, _ int) string {
+			return p.String()
+		}),
+		KeyHash:   HashIngestKey(key),
+		KeyPrefix: key[:len(IngestKeyPrefix)+6],
+	}
+	if err := store.DB.WithContext(ctx).Create(&ingestKey).Error; err != nil {
+		return nil, err
+	}
+
+	_ = store.Redis.Del(ctx, ingestKeysCacheKey(projectID))
+	return &model.IngestKeyWithSecret{IngestKey: &ingestKey, Key: key}, nil
+}
+
+// RotateIngestKey replaces the key of an active ingest key. The previous key stops working immediately.
+func (store *Store) RotateIngestKey(ctx context.Context, projectID int, id int) (*model.IngestKeyWithSecret, error) {
+	key, err := generateIngestKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var ingestKey model.IngestKey
+	if err := AssertRecordFound(store.DB.WithContext(ctx).
+		Model(&ingestKey).
+		Clauses(clause.Returning{}).
+		Where(&model.IngestKey{Model: model.Model{ID: id}, ProjectID: projectID}).
+		Where("revoked_at IS NULL").
+		Updates(map[string]interface{}{
+			"KeyHash":   HashIngestKey(key),
+			"KeyPrefix": key[:len(IngestKeyPrefix)+6],
+			"RotatedAt": time.Now(),
+		})); err != nil {
+		return nil, err
+	}
+
+	_ = store.Redis.Del(ctx, ingestKeysCacheKey(projectID))
+	return &model.IngestKeyWithSecret{IngestKey: &ingestKey, Key: key}, nil
+}
+
+// RevokeIngestKey disables a key permanently. The project keeps requiring ingest keys.
+func (store *Store) RevokeIngestKey(ctx context.Context, projectID int, id int) (*model.IngestKey, error) {
+	var ingestKey model.IngestKey
+	if err := AssertRecordFound(store.DB.WithContext(ctx).
+		Model(&ingestKey).
+		Clauses(clause.Returning{}).
+		Where(&model.IngestKey{Model: model.Model{ID: id}, ProjectID: projectID}).
+		Where("revoked_at IS NULL").
+		Update("RevokedAt", time.Now())); err != nil {
+		return nil, err
+	}
+
+	_ = store.Redis.Del(ctx, ingestKeysCacheKey(projectID))
+	return &ingestKey, nil
+}
+
+// ValidateIngestKey checks that a key may push the product from the source for the project.
+// Projects without any ingest keys accept all data. Rejections are counted per key.
+func (store *Store) ValidateIngestKey(ctx context.Context, projectID int, key string, product modelInputs.ProductType, source modelInputs.LogSource) error {
+	keys, err := store.GetIngestKeys(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	ingestKey, err := MatchIngestKey(keys, key, product, source)
+	if IsIngestKeyRejection(err) {
+		keyID := 0
+		if ingestKey != nil {
+			keyID = ingestKey.ID
+		}
+		hmetric.Incr(ctx, "ingest-key.rejected", []attribute.KeyValue{
+			attribute.Int("project_id", projectID),
+			attribute.Int("ingest_key_id", keyID),
+			attribute.String("product", product.String()),
+			attribute.String("reason", err.Error()),
+		}, 1)
+	}
+	return err
+}
+
+func IsIngestKeyRejection(err error) bool {
+	return lo.Contains(ingestKeyRejections, err)
+}
+
+// MatchIngestKey returns the key matching the provided secret and whether it may be used.
+// The key is returned alongside scope or revocation errors so that rejections can be attributed.
+func MatchIngestKey(keys []*model.IngestKey, key string, product modelInputs.ProductType, source modelInputs.LogSource) (*model.IngestKey, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	if key == "" {
+		return nil, ErrIngestKeyMissing
+	}
+
+	hash := HashIngestKey(key)
+	ingestKey, found := lo.Find(keys, func(k *model.IngestKey) bool {
+		return k.KeyHash == hash
+	})
+	if !found {
+		return nil, ErrIngestKeyInvalid
+	}
+	if ingestKey.RevokedAt != nil {
+		return ingestKey, ErrIngestKeyRevoked
+	}
+	if ingestKey.Scope == modelInputs.IngestKeyScopeFrontend && source != modelInputs.LogSourceFrontend {
+		return ingestKey, ErrIngestKeyScope
+	}
+	if len(ingestKey.Products) > 0 && !lo.Contains(ingestKey.Products, product.String()) {
+		return ingestKey, ErrIngestKeyScope
+	}
+	return ingestKey, nil
+}
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngestKeys(t *testing.T) {
+	ctx := context.Background()
+
+	defer teardown(t)
+
+	project := model.Project{}
+	store.DB.Create(&project)
+
+	// projects without keys accept all data
+	assert.NoError(t, store.ValidateIngestKey(ctx, project.ID, "", modelInputs.ProductTypeLogs, modelInputs.LogSourceBackend))
+
+	created, err := store.CreateIngestKey(ctx, project.ID, "backend logs", modelInputs.IngestKeyScopeBackend, []modelInputs.ProductType{modelInputs.ProductTypeLogs})
+	assert.NoError(t, err)
+	assert.Equal(t, created.Key[:len(created.IngestKey.KeyPrefix)], created.IngestKey.KeyPrefix)
+	assert.NotEqual(t, created.Key, created.IngestKey.KeyHash)
+
+	assert.Equal(t, ErrIngestKeyMissing, store.ValidateIngestKey(ctx, project.ID, "", modelInputs.ProductTypeLogs, modelInputs.LogSourceBackend))
+	assert.Equal(t, ErrIngestKeyInvalid, store.ValidateIngestKey(ctx, project.ID, "hik_unknown", modelInputs.ProductTypeLogs, modelInputs.LogSourceBackend))
+	assert.Equal(t, ErrIngestKeyScope, store.ValidateIngestKey(ctx, project.ID, created.Key, modelInputs.ProductTypeTraces, modelInputs.LogSourceBackend))
+	assert.NoError(t, store.ValidateIngestKey(ctx, project.ID, created.Key, modelInputs.ProductTypeLogs, modelInputs.LogSourceBackend))
+
+	rotated, err := store.RotateIngestKey(ctx, project.ID, created.IngestKey.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, rotated.IngestKey.RotatedAt)
+	assert.Equal(t, ErrIngestKeyInvalid, store.ValidateIngestKey(ctx, project.ID, created.Key, modelInputs.ProductTypeLogs, modelInputs.LogSourceBackend))
+	assert.NoError(t, store.ValidateIngestKey(ctx, project.ID, rotated.Key, modelInputs.ProductTypeLogs, modelInputs.LogSourceBackend))
+
+	revoked, err := store.RevokeIngestKey(ctx, project.ID, created.IngestKey.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, revoked.RevokedAt)
+	assert.Equal(t, ErrIngestKeyRevoked, store.ValidateIngestKey(ctx, project.ID, rotated.Key, modelInputs.ProductTypeLogs, modelInputs.LogSourceBackend))
+
+	// revoked keys cannot be rotated back into use
+	_, err = store.RotateIngestKey(ctx, project.ID, created.IngestKey.ID)
+	assert.Error(t, err)
+
+	keys, err := store.GetIngestKeys(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
+
+func TestMatchIngestKey(t *testing.T) {
+	frontendKey := &model.IngestKey{Model: model.Model{ID: 1}, Scope: modelInputs.IngestKeyScopeFrontend, KeyHash: HashIngestKey("frontend")}
+	backendKey := &model.IngestKey{Model: model.Model{ID: 2}, Scope: modelInputs.IngestKeyScopeBackend, KeyHash: HashIngestKey("backend")}
+	revokedKey := &model.IngestKey{Model: model.Model{ID: 3}, Scope: modelInputs.IngestKeyScopeBackend, KeyHash: HashIngestKey("revoked"), RevokedAt: &time.Time{}}
+	keys := []*model.IngestKey{frontendKey, backendKey, revokedKey}
+
+	key, err := MatchIngestKey(nil, "", modelInputs.ProductTypeTraces, modelInputs.LogSourceBackend)
+	assert.NoError(t, err)
+	assert.Nil(t, key)
+
+	key, err = MatchIngestKey(keys, "frontend", modelInputs.ProductTypeLogs, modelInputs.LogSourceFrontend)
+	assert.NoError(t, err)
+	assert.Equal(t, frontendKey, key)
+
+	key, err = MatchIngestKey(keys, "frontend", modelInputs.ProductTypeLogs, modelInputs.LogSourceBackend)
+	assert.Equal(t, ErrIngestKeyScope, err)
+	assert.Equal(t, frontendKey, key)
+
+	key, err = MatchIngestKey(keys, "backend", modelInputs.ProductTypeErrors, modelInputs.LogSourceFrontend)
+	assert.NoError(t, err)
+	assert.Equal(t, backendKey, key)
+
+	key, err = MatchIngestKey(keys, "revoked", modelInputs.ProductTypeErrors, modelInputs.LogSourceBackend)
+	assert.Equal(t, ErrIngestKeyRevoked, err)
+	assert.Equal(t, revokedKey, key)
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"github.com/highlight-run/highlight/backend/logmetrics"
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/redis"
+)
+
+func logMetricRulesCacheKey(projectID int) string {
+	return fmt.Sprintf("log-metric-rules-%d", projectID)
+}
+
+// GetLogMetricRules returns the log-to-metric rules of a project.
+func (store *Store) GetLogMetricRules(ctx context.Context, projectID int, opts ...redis.Option) ([]*model.LogMetricRule, error) {
+	rules, err := redis.CachedEval(ctx, store.Redis, logMetricRulesCacheKey(projectID), 250*time.Millisecond, time.Minute, func() (*[]*model.LogMetricRule, error) {
+		var rules []*model.LogMetricRule
+		if err := store.DB.WithContext(ctx).
+			Where(&model.LogMetricRule{ProjectID: projectID}).
+			Order("id").
+			Find(&rules).Error; err != nil {
+			return nil, err
+		}
+		return &rules, nil
+	}, opts...)
+	if err != nil || rules == nil {
+		return nil, err
+	}
+	return *rules, nil
+}
+
+// UpdateLogMetricRules replaces the log-to-metric rules of a project.
+func (store *Store) UpdateLogMetricRules(ctx context.Context, projectID int, inputs []*modelInputs.LogMetricRuleInput) ([]*model.LogMetricRule, error) {
+	var rules []*model.LogMetricRule
+	names := map[string]bool{}
+	for _, input := range inputs {
+		rule := &model.LogMetricRule{
+			ProjectID:      projectID,
+			Name:           input.Name,
+			Query:          input.Query,
+			Type:           input.Type,
+			GroupBy:        pq.StringArray(input.GroupBy),
+			ValueAttribute: input.ValueAttribute,
+			ValuePattern:   input.ValuePattern,
+			Buckets:        pq.Float64Array(input.Buckets),
+		}
+		if err := logmetrics.ValidateRule(rule); err != nil {
+			return nil, err
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("duplicate log metric rule %s", rule.Name)
+		}
+		names[rule.Name] = true
+		rules = append(rules, rule)
+	}
+
+	if err := store.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(&model.LogMetricRule{ProjectID: projectID}).Delete(&model.LogMetricRule{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	return rules, store.Redis.Del(ctx, logMetricRulesCacheKey(projectID))
+}
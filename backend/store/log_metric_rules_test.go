@@ -0,0 +1,47 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateLogMetricRules(t *testing.T) {
+	ctx := context.TODO()
+	defer teardown(t)
+
+	project := model.Project{}
+	store.DB.Create(&project)
+
+	rules, err := store.GetLogMetricRules(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+
+	_, err = store.UpdateLogMetricRules(ctx, project.ID, []*modelInputs.LogMetricRuleInput{
+		{Name: "payments.declined", Query: `"payment declined"`, Type: modelInputs.LogMetricRuleTypeCount, GroupBy: []string{"merchant"}},
+		{Name: "payments.amount", Query: `"payment declined"`, Type: modelInputs.LogMetricRuleTypeHistogram, ValueAttribute: pointy.String("amount"), Buckets: []float64{10, 100, 1000}},
+	})
+	assert.NoError(t, err)
+
+	rules, err = store.GetLogMetricRules(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, []string{"merchant"}, []string(rules[0].GroupBy))
+	assert.Equal(t, []float64{10, 100, 1000}, []float64(rules[1].Buckets))
+
+	_, err = store.UpdateLogMetricRules(ctx, project.ID, []*modelInputs.LogMetricRuleInput{
+		{Name: "payments.amount", Query: `"payment declined"`, Type: modelInputs.LogMetricRuleTypeSum},
+	})
+	assert.Error(t, err)
+
+	_, err = store.UpdateLogMetricRules(ctx, project.ID, nil)
+	assert.NoError(t, err)
+
+	rules, err = store.GetLogMetricRules(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+}
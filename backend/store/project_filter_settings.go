@@ -76,6 +76,12 @@ func (store *Store) UpdateProjectFilterSettings(ctx context.Context, projectID i
 			if updates.Sampling.TraceMinuteRateLimit != nil {
 				projectFilterSettings.TraceMinuteRateLimit = updates.Sampling.TraceMinuteRateLimit
 			}
+			if updates.Sampling.TraceTailSamplingRate != nil {
+				projectFilterSettings.TraceTailSamplingRate = *updates.Sampling.TraceTailSamplingRate
+			}
+			if updates.Sampling.TraceTailSamplingWindowSeconds != nil {
+				projectFilterSettings.TraceTailSamplingWindowSeconds = *updates.Sampling.TraceTailSamplingWindowSeconds
+			}
 		}
 		if updates.Sampling.SessionExclusionQuery != nil {
 			projectFilterSettings.SessionExclusionQuery = updates.Sampling.SessionExclusionQuery
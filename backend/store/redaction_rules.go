@@ -0,0 +1,116 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/redaction"
+	"github.com/highlight-run/highlight/backend/redis"
+)
+
+func redactionRulesCacheKey(projectID int) string {
+	return fmt.Sprintf("redaction-rules-%d", projectID)
+}
+
+// GetRedactionRules returns the redaction rules of a project.
+func (store *Store) GetRedactionRules(ctx context.Context, projectID int, opts ...redis.Option) ([]*model.RedactionRule, error) {
+	rules, err := redis.CachedEval(ctx, store.Redis, redactionRulesCacheKey(projectID), 250*time.Millisecond, time.Minute, func() (*[]*model.RedactionRule, error) {
+		var rules []*model.RedactionRule
+		if err := store.DB.WithContext(ctx).
+			Where(&model.RedactionRule{ProjectID: projectID}).
+			Order("id").
+			Find(&rules).Error; err != nil {
+			return nil, err
+		}
+		return &rules, nil
+	}, opts...)
+	if err != nil || rules == nil {
+		return nil, err
+	}
+	return *rules, nil
+}
+
+// GetRedactionRulesWithCounts returns the redaction rules of a project with the number of values they redacted.
+func (store *Store) GetRedactionRulesWithCounts(ctx context.Context, projectID int) ([]*model.RedactionRule, error) {
+	rules, err := store.GetRedactionRules(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := store.Redis.GetRedactionCounts(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		rule.RedactedCount = counts[rule.Name]
+	}
+	return rules, nil
+}
+
+// UpdateRedactionRules replaces the redaction rules of a project. Rules keep their counts by name.
+func (store *Store) UpdateRedactionRules(ctx context.Context, projectID int, inputs []*modelInputs.RedactionRuleInput) ([]*model.RedactionRule, error) {
+	if len(inputs) > redaction.MaxRules {
+		return nil, fmt.Errorf("a project can have at most %d redaction rules", redaction.MaxRules)
+	}
+
+	var rules []*model.RedactionRule
+	names := map[string]bool{}
+	for _, input := range inputs {
+		rule := &model.RedactionRule{
+			ProjectID:     projectID,
+			Name:          input.Name,
+			Pattern:       input.Pattern,
+			Detector:      input.Detector,
+			AttributeKeys: pq.StringArray(input.AttributeKeys),
+			Action:        input.Action,
+		}
+		if err := redaction.ValidateRule(rule); err != nil {
+			return nil, err
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("duplicate redaction rule %s", rule.Name)
+		}
+		names[rule.Name] = true
+		rules = append(rules, rule)
+	}
+
+	if err := store.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(&model.RedactionRule{ProjectID: projectID}).Delete(&model.RedactionRule{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := store.Redis.Del(ctx, redactionRulesCacheKey(projectID)); err != nil {
+		return nil, err
+	}
+	return store.GetRedactionRulesWithCounts(ctx, projectID)
+}
+
+// GetRedactor returns the redactor applying the redaction rules of a project.
+func (store *Store) GetRedactor(ctx context.Context, projectID int) (*redaction.Redactor, error) {
+	rules, err := store.GetRedactionRules(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return redaction.New(projectID, rules), nil
+}
+
+// RecordRedactions flushes the counts of the redactor to the redaction counters of the project.
+func (store *Store) RecordRedactions(ctx context.Context, redactor *redaction.Redactor, path redaction.Path) error {
+	counts := redactor.Flush(ctx, path)
+	if len(counts) == 0 {
+		return nil
+	}
+	return store.Redis.IncrementRedactionCounts(ctx, redactor.ProjectID(), counts)
+}
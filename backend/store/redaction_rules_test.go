@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/redaction"
+)
+
+func TestUpdateRedactionRules(t *testing.T) {
+	ctx := context.TODO()
+	defer teardown(t)
+
+	project := model.Project{}
+	store.DB.Create(&project)
+
+	rules, err := store.GetRedactionRules(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+
+	email := modelInputs.RedactionDetectorEmail
+	_, err = store.UpdateRedactionRules(ctx, project.ID, []*modelInputs.RedactionRuleInput{
+		{Name: "emails", Detector: &email, Action: modelInputs.RedactionActionMask},
+		{Name: "auth", AttributeKeys: []string{"authorization", "cookie"}, Action: modelInputs.RedactionActionDrop},
+	})
+	assert.NoError(t, err)
+
+	rules, err = store.GetRedactionRules(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, []string{"authorization", "cookie"}, []string(rules[1].AttributeKeys))
+
+	_, err = store.UpdateRedactionRules(ctx, project.ID, []*modelInputs.RedactionRuleInput{
+		{Name: "accounts", Pattern: pointy.String(`acct-(`), Action: modelInputs.RedactionActionHash},
+	})
+	assert.Error(t, err)
+
+	_, err = store.UpdateRedactionRules(ctx, project.ID, nil)
+	assert.NoError(t, err)
+
+	rules, err = store.GetRedactionRules(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+}
+
+func TestRecordRedactions(t *testing.T) {
+	ctx := context.TODO()
+	defer teardown(t)
+
+	project := model.Project{}
+	store.DB.Create(&project)
+
+	email := modelInputs.RedactionDetectorEmail
+	_, err := store.UpdateRedactionRules(ctx, project.ID, []*modelInputs.RedactionRuleInput{
+		{Name: "emails", Detector: &email, Action: modelInputs.RedactionActionMask},
+	})
+	assert.NoError(t, err)
+
+	redactor, err := store.GetRedactor(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "sent to [REDACTED]", redactor.Redact("sent to jane.doe@example.com"))
+	assert.NoError(t, store.RecordRedactions(ctx, redactor, redaction.PathLogs))
+
+	rules, err := store.GetRedactionRulesWithCounts(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Len(t, rules, 1)
+	assert.Equal(t, int64(1), rules[0].RedactedCount)
+}
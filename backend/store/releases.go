@@ -0,0 +1,213 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/highlight-run/highlight/backend/redis"
+	"github.com/highlight-run/highlight/backend/util"
+)
+
+// the maximum number of new error groups returned with the health of a release
+const releaseNewErrorGroupsLimit = 50
+
+func releaseCacheKey(projectID int, version string) string {
+	return fmt.Sprintf("release-%d-%s", projectID, version)
+}
+
+// UpsertRelease creates the release of a version the first time the version is seen.
+func (store *Store) UpsertRelease(ctx context.Context, projectID int, version string) (*model.Release, error) {
+	return redis.CachedEval(ctx, store.Redis, releaseCacheKey(projectID, version), time.Second, time.Hour, func() (*model.Release, error) {
+		release := model.Release{
+			ProjectID:   projectID,
+			Version:     version,
+			FirstSeenAt: time.Now(),
+		}
+
+		if err := store.DB.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "project_id"}, {Name: "version"}},
+			DoNothing: true,
+		}).Create(&release).Error; err != nil {
+			return nil, err
+		}
+
+		err := store.DB.WithContext(ctx).Where(&model.Release{ProjectID: projectID, Version: version}).Take(&release).Error
+		return &release, err
+	})
+}
+
+// CreateRelease records the deploy of a version, creating its release if the version was not seen yet.
+func (store *Store) CreateRelease(ctx context.Context, projectID int, version string, commitSha *string, releasedAt *time.Time) (*model.Release, error) {
+	if version == "" || strings.Contains(version, "/") {
+		return nil, errors.New("invalid version")
+	}
+	if releasedAt == nil {
+		now := time.Now()
+		releasedAt = &now
+	}
+
+	release := model.Release{
+		ProjectID:   projectID,
+		Version:     version,
+		FirstSeenAt: *releasedAt,
+		ReleasedAt:  releasedAt,
+		CommitSha:   commitSha,
+	}
+	if err := store.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "project_id"}, {Name: "version"}},
+		DoUpdates: clause.AssignmentColumns([]string{"released_at", "commit_sha", "updated_at"}),
+	}).Create(&release).Error; err != nil {
+		return nil, err
+	}
+
+	if err := store.Redis.Del(ctx, releaseCacheKey(projectID, version)); err != nil {
+		return nil, err
+	}
+	return store.GetRelease(ctx, projectID, version)
+}
+
+func (store *Store) GetRelease(ctx context.Context, projectID int, version string) (*model.Release, error) {
+	var release model.Release
+	if err := store.DB.WithContext(ctx).
+		Where(&model.Release{ProjectID: projectID, Version: version}).
+		Take(&release).Error; err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// GetReleases returns the releases of a project, latest first.
+func (store *Store) GetReleases(ctx context.Context, projectID int) ([]*model.Release, error) {
+	var releases []*model.Release
+	if err := store.DB.WithContext(ctx).
+		Where(&model.Release{ProjectID: projectID}).
+		Order("first_seen_at DESC, id DESC").
+		Find(&releases).Error; err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+// GetLatestRelease returns the release of a project seen last, or nil if the project has no releases.
+func (store *Store) GetLatestRelease(ctx context.Context, projectID int) (*model.Release, error) {
+	var releases []*model.Release
+	if err := store.DB.WithContext(ctx).
+		Where(&model.Release{ProjectID: projectID}).
+		Order("first_seen_at DESC, id DESC").
+		Limit(1).
+		Find(&releases).Error; err != nil {
+		return nil, err
+	}
+	if len(releases) == 0 {
+		return nil, nil
+	}
+	return releases[0], nil
+}
+
+// GetPreviousRelease returns the release seen before the release, or nil if it is the first release.
+func (store *Store) GetPreviousRelease(ctx context.Context, release *model.Release) (*model.Release, error) {
+	var releases []*model.Release
+	if err := store.DB.WithContext(ctx).
+		Where(&model.Release{ProjectID: release.ProjectID}).
+		Where("first_seen_at < ? OR (first_seen_at = ? AND id < ?)", release.FirstSeenAt, release.FirstSeenAt, release.ID).
+		Order("first_seen_at DESC, id DESC").
+		Limit(1).
+		Find(&releases).Error; err != nil {
+		return nil, err
+	}
+	if len(releases) == 0 {
+		return nil, nil
+	}
+	return releases[0], nil
+}
+
+// GetReleaseHealth reports the error groups first seen in a release and its errors and sessions over
+// the date range, compared to the previous release.
+func (store *Store) GetReleaseHealth(ctx context.Context, release *model.Release, startDate time.Time, endDate time.Time) (*model.ReleaseHealth, error) {
+	span, ctx := util.StartSpanFromContext(ctx, "store.GetReleaseHealth", util.Tag("projectID", release.ProjectID), util.Tag("version", release.Version))
+	defer span.Finish()
+
+	previous, err := store.GetPreviousRelease(ctx, release)
+	if err != nil {
+		return nil, err
+	}
+
+	health := &model.ReleaseHealth{
+		Release:         release,
+		PreviousRelease: previous,
+	}
+
+	newErrorGroups := store.DB.WithContext(ctx).Model(&model.ErrorGroup{}).
+		Where(&model.ErrorGroup{ProjectID: release.ProjectID, FirstSeenVersion: &release.Version}).
+		Where("merged_into_id IS NULL")
+	var newErrorGroupCount int64
+	if err := newErrorGroups.Session(&gorm.Session{}).Count(&newErrorGroupCount).Error; err != nil {
+		return nil, err
+	}
+	health.NewErrorGroupCount = int(newErrorGroupCount)
+	if err := newErrorGroups.Session(&gorm.Session{}).Order("id DESC").Limit(releaseNewErrorGroupsLimit).Find(&health.NewErrorGroups).Error; err != nil {
+		return nil, err
+	}
+
+	versions := []string{release.Version}
+	if previous != nil {
+		versions = append(versions, previous.Version)
+	}
+	stats, err := store.ClickhouseClient.QueryReleaseStats(ctx, release.ProjectID, versions, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	current := stats[release.Version]
+	health.ErrorCount = current.Errors
+	health.SessionCount = current.Sessions
+	health.CrashedSessionCount = current.CrashedSessions
+	health.ErrorRate = releaseErrorRate(current, release, startDate, endDate)
+	if current.Sessions > 0 {
+		crashFree := 100 * (1 - float64(current.CrashedSessions)/float64(current.Sessions))
+		health.CrashFreeSessionPercent = &crashFree
+	}
+
+	if previous != nil {
+		if previousStats := stats[previous.Version]; previousStats.Errors > 0 || previousStats.Sessions > 0 {
+			previousErrorRate := releaseErrorRate(previousStats, previous, startDate, endDate)
+			health.PreviousErrorRate = &previousErrorRate
+			if previousErrorRate > 0 {
+				change := 100 * (health.ErrorRate - previousErrorRate) / previousErrorRate
+				health.ErrorRateChangePercent = &change
+			}
+		}
+	}
+
+	health.Adoption, err = store.ClickhouseClient.QueryReleaseAdoption(ctx, release.ProjectID, release.Version, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return health, nil
+}
+
+// releaseErrorRate is the number of errors per session of a release, or per hour since the release
+// was first seen for releases without sessions, such as releases of backend services.
+func releaseErrorRate(stats *clickhouse.ReleaseStats, release *model.Release, startDate time.Time, endDate time.Time) float64 {
+	if stats.Sessions > 0 {
+		return float64(stats.Errors) / float64(stats.Sessions)
+	}
+	if release.FirstSeenAt.After(startDate) {
+		startDate = release.FirstSeenAt
+	}
+	// count at least an hour so that releases seen moments ago do not have inflated rates
+	hours := endDate.Sub(startDate).Hours()
+	if hours < 1 {
+		hours = 1
+	}
+	return float64(stats.Errors) / hours
+}
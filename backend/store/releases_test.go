@@ -0,0 +1,71 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleases(t *testing.T) {
+	defer teardown(t)
+
+	project := model.Project{}
+	store.DB.Create(&project)
+
+	first, err := store.UpsertRelease(context.TODO(), project.ID, "1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", first.Version)
+	assert.Nil(t, first.ReleasedAt)
+
+	// seeing the version again keeps the release
+	again, err := store.UpsertRelease(context.TODO(), project.ID, "1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, again.ID)
+
+	_, err = store.CreateRelease(context.TODO(), project.ID, "", nil, nil)
+	assert.Error(t, err)
+
+	releasedAt := first.FirstSeenAt.Add(time.Hour)
+	second, err := store.CreateRelease(context.TODO(), project.ID, "1.1.0", ptr.String("abc123"), &releasedAt)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", *second.CommitSha)
+
+	// creating a release that was seen records its deploy without changing when it was first seen
+	updated, err := store.CreateRelease(context.TODO(), project.ID, "1.0.0", ptr.String("def456"), &releasedAt)
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, updated.ID)
+	assert.Equal(t, "def456", *updated.CommitSha)
+	assert.NotNil(t, updated.ReleasedAt)
+
+	latest, err := store.GetLatestRelease(context.TODO(), project.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, second.ID, latest.ID)
+
+	previous, err := store.GetPreviousRelease(context.TODO(), second)
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, previous.ID)
+
+	previous, err = store.GetPreviousRelease(context.TODO(), first)
+	assert.NoError(t, err)
+	assert.Nil(t, previous)
+
+	releases, err := store.GetReleases(context.TODO(), project.ID)
+	assert.NoError(t, err)
+	assert.Len(t, releases, 2)
+}
+
+func TestReleaseErrorRate(t *testing.T) {
+	now := time.Now()
+	release := &model.Release{FirstSeenAt: now.Add(-30 * time.Minute)}
+
+	assert.Equal(t, 0.5, releaseErrorRate(&clickhouse.ReleaseStats{Errors: 2, Sessions: 4}, release, now.Add(-time.Hour), now))
+	// releases seen less than an hour ago are counted over an hour
+	assert.Equal(t, 3., releaseErrorRate(&clickhouse.ReleaseStats{Errors: 3}, release, now.Add(-time.Hour), now))
+	release.FirstSeenAt = now.Add(-4 * time.Hour)
+	assert.Equal(t, 1.5, releaseErrorRate(&clickhouse.ReleaseStats{Errors: 3}, release, now.Add(-2*time.Hour), now))
+}
@@ -0,0 +1,112 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/highlight-run/highlight/backend/model"
+)
+
+// GetTeams returns the teams of a workspace with their admins.
+func (store *Store) GetTeams(ctx context.Context, workspaceID int) ([]*model.Team, error) {
+	var teams []*model.Team
+	if err := store.DB.WithContext(ctx).
+		Preload("Admins").
+		Where(&model.Team{WorkspaceID: workspaceID}).
+		Order("name").
+		Find(&teams).Error; err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (store *Store) GetTeam(ctx context.Context, workspaceID int, id int) (*model.Team, error) {
+	var team model.Team
+	if err := store.DB.WithContext(ctx).
+		Preload("Admins").
+		Where(&model.Team{Model: model.Model{ID: id}, WorkspaceID: workspaceID}).
+		Take(&team).Error; err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// UpsertTeam creates a team, or updates the name, Slack mention and admins of an existing one.
+// Admins must be members of the workspace.
+func (store *Store) UpsertTeam(ctx context.Context, workspaceID int, id *int, name string, adminIDs []int, slackMentionID *string) (*model.Team, error) {
+	if name == "" {
+		return nil, errors.New("team name is required")
+	}
+	if slackMentionID != nil && *slackMentionID == "" {
+		slackMentionID = nil
+	}
+
+	var admins []model.Admin
+	if len(adminIDs) > 0 {
+		if err := store.DB.WithContext(ctx).
+			Where("id IN (SELECT admin_id FROM workspace_admins WHERE workspace_id = ?)", workspaceID).
+			Where("id IN ?", adminIDs).
+			Find(&admins).Error; err != nil {
+			return nil, err
+		}
+		if len(admins) != len(adminIDs) {
+			return nil, errors.New("team admins must be members of the workspace")
+		}
+	}
+
+	team := &model.Team{WorkspaceID: workspaceID}
+	if err := store.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if id != nil {
+			if err := tx.Where(&model.Team{Model: model.Model{ID: *id}, WorkspaceID: workspaceID}).Take(team).Error; err != nil {
+				return err
+			}
+		}
+		team.Name = name
+		team.SlackMentionID = slackMentionID
+		if err := tx.Omit("Admins").Save(team).Error; err != nil {
+			return err
+		}
+		return tx.Model(team).Association("Admins").Replace(admins)
+	}); err != nil {
+		return nil, err
+	}
+
+	return store.GetTeam(ctx, workspaceID, team.ID)
+}
+
+// DeleteTeam deletes a team, unassigning its error groups and removing its ownership rules.
+func (store *Store) DeleteTeam(ctx context.Context, workspaceID int, id int) error {
+	team, err := store.GetTeam(ctx, workspaceID, id)
+	if err != nil {
+		return err
+	}
+
+	var projectIDs []int
+	if err := store.DB.WithContext(ctx).Model(&model.Project{}).Where(&model.Project{WorkspaceID: workspaceID}).Pluck("id", &projectIDs).Error; err != nil {
+		return err
+	}
+
+	if err := store.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.ErrorGroup{}).Where("assignee_team_id = ?", team.ID).Update("assignee_team_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("team_id = ?", team.ID).Delete(&model.ErrorOwnershipRule{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(team).Association("Admins").Clear(); err != nil {
+			return err
+		}
+		return tx.Delete(team).Error
+	}); err != nil {
+		return err
+	}
+
+	for _, projectID := range projectIDs {
+		if err := store.Redis.Del(ctx, errorOwnershipRulesCacheKey(projectID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpsertTeam(t *testing.T) {
+	ctx := context.TODO()
+	defer teardown(t)
+
+	member := model.Admin{Email: pointy.String("member@highlight.io")}
+	outsider := model.Admin{Email: pointy.String("outsider@highlight.io")}
+	store.DB.Create(&member)
+	store.DB.Create(&outsider)
+	workspace := model.Workspace{Admins: []model.Admin{member}}
+	store.DB.Create(&workspace)
+
+	_, err := store.UpsertTeam(ctx, workspace.ID, nil, "payments", []int{member.ID, outsider.ID}, nil)
+	assert.Error(t, err)
+
+	team, err := store.UpsertTeam(ctx, workspace.ID, nil, "payments", []int{member.ID}, pointy.String("S123"))
+	assert.NoError(t, err)
+	assert.Equal(t, "payments", team.Name)
+	assert.Len(t, team.Admins, 1)
+
+	team, err = store.UpsertTeam(ctx, workspace.ID, &team.ID, "billing", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "billing", team.Name)
+	assert.Empty(t, team.Admins)
+	assert.Nil(t, team.SlackMentionID)
+
+	// teams of other workspaces cannot be updated
+	_, err = store.UpsertTeam(ctx, workspace.ID+1, &team.ID, "billing", nil, nil)
+	assert.Error(t, err)
+
+	errorGroup := model.ErrorGroup{AssigneeTeamID: &team.ID}
+	store.DB.Create(&errorGroup)
+
+	assert.NoError(t, store.DeleteTeam(ctx, workspace.ID, team.ID))
+	teams, err := store.GetTeams(ctx, workspace.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, teams)
+
+	store.DB.Where("id = ?", errorGroup.ID).First(&errorGroup)
+	assert.Nil(t, errorGroup.AssigneeTeamID)
+}
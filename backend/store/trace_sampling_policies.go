@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/redis"
+	"gorm.io/gorm"
+)
+
+func traceSamplingPoliciesCacheKey(projectID int) string {
+	return fmt.Sprintf("trace-sampling-policies-%d", projectID)
+}
+
+// GetTraceSamplingPolicies returns the tail sampling policies of a project in evaluation order.
+func (store *Store) GetTraceSamplingPolicies(ctx context.Context, projectID int, opts ...redis.Option) ([]*model.TraceSamplingPolicy, error) {
+	policies, err := redis.CachedEval(ctx, store.Redis, traceSamplingPoliciesCacheKey(projectID), 250*time.Millisecond, time.Minute, func() (*[]*model.TraceSamplingPolicy, error) {
+		var policies []*model.TraceSamplingPolicy
+		if err := store.DB.WithContext(ctx).
+			Where(&model.TraceSamplingPolicy{ProjectID: projectID}).
+			Order("id").
+			Find(&policies).Error; err != nil {
+			return nil, err
+		}
+		return &policies, nil
+	}, opts...)
+	if err != nil || policies == nil {
+		return nil, err
+	}
+	return *policies, nil
+}
+
+// UpdateTraceSamplingPolicies replaces the tail sampling policies of a project, keeping the provided order.
+func (store *Store) UpdateTraceSamplingPolicies(ctx context.Context, projectID int, inputs []*modelInputs.TraceSamplingPolicyInput) ([]*model.TraceSamplingPolicy, error) {
+	var policies []*model.TraceSamplingPolicy
+	for _, input := range inputs {
+		if input.SamplingRate < 0 || input.SamplingRate > 1 {
+			return nil, fmt.Errorf("invalid sampling rate %f for policy %s", input.SamplingRate, input.Name)
+		}
+		policies = append(policies, &model.TraceSamplingPolicy{
+			ProjectID:    projectID,
+			Name:         input.Name,
+			Query:        input.Query,
+			SamplingRate: input.SamplingRate,
+		})
+	}
+
+	if err := store.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(&model.TraceSamplingPolicy{ProjectID: projectID}).Delete(&model.TraceSamplingPolicy{}).Error; err != nil {
+			return err
+		}
+		if len(policies) == 0 {
+			return nil
+		}
+		return tx.Create(&policies).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	return policies, store.Redis.Del(ctx, traceSamplingPoliciesCacheKey(projectID))
+}
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateTraceSamplingPolicies(t *testing.T) {
+	ctx := context.TODO()
+	defer teardown(t)
+
+	project := model.Project{}
+	store.DB.Create(&project)
+
+	policies, err := store.GetTraceSamplingPolicies(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, policies)
+
+	_, err = store.UpdateTraceSamplingPolicies(ctx, project.ID, []*modelInputs.TraceSamplingPolicyInput{
+		{Name: "errors", Query: "has_errors=true", SamplingRate: 1},
+		{Name: "slow", Query: `parent_span_id="" duration>2s`, SamplingRate: 1},
+	})
+	assert.NoError(t, err)
+
+	policies, err = store.GetTraceSamplingPolicies(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Len(t, policies, 2)
+	assert.Equal(t, "errors", policies[0].Name)
+	assert.Equal(t, "slow", policies[1].Name)
+
+	_, err = store.UpdateTraceSamplingPolicies(ctx, project.ID, []*modelInputs.TraceSamplingPolicyInput{
+		{Name: "invalid", Query: "", SamplingRate: 2},
+	})
+	assert.Error(t, err)
+
+	_, err = store.UpdateTraceSamplingPolicies(ctx, project.ID, nil)
+	assert.NoError(t, err)
+
+	policies, err = store.GetTraceSamplingPolicies(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, policies)
+}
@@ -0,0 +1,25 @@
+package util
+
+import (
+	"regexp"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// the number of user-supplied patterns kept compiled
+const regexpCacheSize = 1_000
+
+var regexps, _ = lru.New[string, *regexp.Regexp](regexpCacheSize)
+
+// CompileRegexp compiles a pattern, reusing the regexps of recently compiled patterns.
+func CompileRegexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := regexps.Get(pattern); ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexps.Add(pattern, re)
+	return re, nil
+}
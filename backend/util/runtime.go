@@ -57,11 +57,13 @@ const (
 	AutoResolveStaleErrors      Handler = "auto-resolve-stale-errors"
 	StartSessionDeleteJob       Handler = "start-session-delete-job"
 	ScheduledTasks              Handler = "scheduled-tasks"
+	ServiceDependencies         Handler = "service-dependencies"
+	ExportJobs                  Handler = "export-jobs"
 )
 
 func (lt Handler) IsValid() bool {
 	switch lt {
-	case ReportStripeUsage, MigrateDB, MetricMonitors, LogAlerts, BackfillStackFrames, RefreshMaterializedViews, PublicWorkerMain, PublicWorkerBatched, PublicWorkerDataSync, PublicWorkerTraces, PublicWorkerMetricSum, PublicWorkerMetricHistogram, PublicWorkerMetricSummary, AutoResolveStaleErrors:
+	case ReportStripeUsage, MigrateDB, MetricMonitors, LogAlerts, BackfillStackFrames, RefreshMaterializedViews, PublicWorkerMain, PublicWorkerBatched, PublicWorkerDataSync, PublicWorkerTraces, PublicWorkerMetricSum, PublicWorkerMetricHistogram, PublicWorkerMetricSummary, AutoResolveStaleErrors, ServiceDependencies, ExportJobs:
 		return true
 	}
 	return false
@@ -13,6 +13,8 @@ import (
 	"github.com/highlight-run/highlight/backend/env"
 	kafka_queue "github.com/highlight-run/highlight/backend/kafka-queue"
 	kafkaqueue "github.com/highlight-run/highlight/backend/kafka-queue"
+	"github.com/highlight-run/highlight/backend/logmetrics"
+	"github.com/highlight-run/highlight/backend/logpatterns"
 	"github.com/highlight-run/highlight/backend/model"
 	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
 	pubgraph "github.com/highlight-run/highlight/backend/public-graph/graph"
@@ -43,6 +45,16 @@ func (k *KafkaWorker) processWorkerError(ctx context.Context, task kafkaqueue.Re
 	task.SetFailures(task.GetFailures() + 1)
 }
 
+// deadLetter preserves a task that will not be retried again for inspection and replay.
+func (k *KafkaWorker) deadLetter(ctx context.Context, task kafkaqueue.RetryableMessage, err error) {
+	if dlqErr := k.KafkaQueue.SubmitDeadLetter(ctx, task, err); dlqErr != nil {
+		log.WithContext(ctx).
+			WithError(dlqErr).
+			WithField("type", task.GetType()).
+			Error("failed to dead-letter task")
+	}
+}
+
 func (k *KafkaWorker) log(ctx context.Context, task kafkaqueue.RetryableMessage, msg ...interface{}) {
 	if task == nil {
 		return
@@ -100,7 +112,8 @@ func (k *KafkaWorker) ProcessMessages() {
 				start := time.Now()
 				publicWorkerMessage, ok := task.(*kafka_queue.Message)
 				if !ok {
-					k.processWorkerError(ctx, task, e.New("failed to cast as publicWorkerMessage"), start)
+					err = e.New("failed to cast as publicWorkerMessage")
+					k.processWorkerError(ctx, task, err, start)
 					break
 				}
 				if err = k.Worker.processPublicWorkerMessage(sCtx, publicWorkerMessage); err != nil {
@@ -109,6 +122,10 @@ func (k *KafkaWorker) ProcessMessages() {
 					break
 				}
 			}
+			// the loop only exits with an error once the task will not be retried
+			if err != nil {
+				k.deadLetter(ctx, task, err)
+			}
 			k.log(ctx, task, "finished processing ", task.GetFailures())
 			s.SetAttribute("taskFailures", task.GetFailures())
 			s2.Finish(err)
@@ -277,7 +294,7 @@ func (k *KafkaBatchWorker) flush(ctx context.Context) error {
 			return err
 		}
 	}
-	if len(traceRows) > 0 {
+	if len(traceRows) > 0 || k.TailSampler.Pending() {
 		if err := k.flushTraces(sCtx, traceRows); err != nil {
 			workSpan.Finish(err)
 			return err
@@ -438,6 +455,12 @@ func (k *KafkaBatchWorker) flushLogs(ctx context.Context, logRows []*clickhouse.
 	wSpan, wCtx := util.StartSpanFromContext(ctx, fmt.Sprintf("worker.kafka.%s.flush.process", k.Name))
 	wSpan.SetAttribute("BatchSize", len(k.messages))
 	wSpan.SetAttribute("NumProjects", len(projectIds))
+
+	var patternRows []*clickhouse.LogPatternRow
+	if k.LogPatterns != nil {
+		patternRows = k.assignLogPatterns(wCtx, filteredRows)
+	}
+
 	for _, projectId := range markBackendSetupProjectIds {
 		err := k.Worker.PublicResolver.MarkBackendSetupImpl(wCtx, int(projectId), model.MarkBackendSetupTypeLogs)
 		if err != nil {
@@ -455,10 +478,99 @@ func (k *KafkaBatchWorker) flushLogs(ctx context.Context, logRows []*clickhouse.
 		log.WithContext(ctxT).WithError(err).Error("failed to batch write logs to clickhouse")
 		return err
 	}
+
+	if err := k.Worker.PublicResolver.Clickhouse.BatchWriteLogPatterns(wCtx, patternRows); err != nil {
+		log.WithContext(wCtx).WithError(err).Error("failed to batch write log patterns to clickhouse")
+	}
+
+	// metrics are derived once the logs are written so that a retried batch is not counted twice
+	if k.LogMetrics != nil {
+		if err := k.submitLogMetrics(wCtx, filteredRows); err != nil {
+			log.WithContext(wCtx).WithError(err).Error("failed to submit log metrics")
+		}
+	}
 	wSpan.Finish()
 	return nil
 }
 
+// assignLogPatterns sets the pattern id of each log row and returns the patterns of the rows.
+func (k *KafkaBatchWorker) assignLogPatterns(ctx context.Context, logRows []*clickhouse.LogRow) []*clickhouse.LogPatternRow {
+	span, _ := util.StartSpanFromContext(ctx, fmt.Sprintf("worker.kafka.%s.flush.logPatterns", k.Name))
+	defer span.Finish()
+
+	patternRows := map[string]*clickhouse.LogPatternRow{}
+	var keys []string
+	for _, logRow := range logRows {
+		pattern := k.LogPatterns.Add(logRow.ProjectId, logRow.ServiceName, logRow.Body)
+		if pattern == nil {
+			continue
+		}
+		logRow.PatternId = pattern.ID
+
+		key := fmt.Sprintf("%d/%s", logRow.ProjectId, pattern.ID)
+		patternRow, ok := patternRows[key]
+		if !ok {
+			patternRow = &clickhouse.LogPatternRow{
+				ProjectId:   logRow.ProjectId,
+				ServiceName: logRow.ServiceName,
+				PatternId:   pattern.ID,
+				FirstSeen:   logRow.Timestamp,
+				LastSeen:    logRow.Timestamp,
+			}
+			patternRows[key] = patternRow
+			keys = append(keys, key)
+		}
+		// the template of a pattern only becomes more general
+		patternRow.Template = pattern.Template
+		if logRow.Timestamp.Before(patternRow.FirstSeen) {
+			patternRow.FirstSeen = logRow.Timestamp
+		}
+		if logRow.Timestamp.After(patternRow.LastSeen) {
+			patternRow.LastSeen = logRow.Timestamp
+		}
+	}
+	span.SetAttribute("NumPatterns", len(keys))
+
+	return lo.Map(keys, func(key string, _ int) *clickhouse.LogPatternRow {
+		return patternRows[key]
+	})
+}
+
+func (k *KafkaBatchWorker) submitLogMetrics(ctx context.Context, logRows []*clickhouse.LogRow) error {
+	span, ctx := util.StartSpanFromContext(ctx, fmt.Sprintf("worker.kafka.%s.flush.logMetrics", k.Name))
+	defer span.Finish()
+
+	var sumMessages, histogramMessages []kafka_queue.RetryableMessage
+	for _, row := range k.LogMetrics.Evaluate(ctx, logRows) {
+		switch metricRow := row.(type) {
+		case *clickhouse.MetricSumRow:
+			sumMessages = append(sumMessages, &kafka_queue.OTeLMetricSumRow{
+				Type:         kafka_queue.PushOTeLMetricSum,
+				MetricSumRow: metricRow,
+			})
+		case *clickhouse.MetricHistogramRow:
+			histogramMessages = append(histogramMessages, &kafka_queue.OTeLMetricHistogramRow{
+				Type:               kafka_queue.PushOTeLMetricHistogram,
+				MetricHistogramRow: metricRow,
+			})
+		}
+	}
+	span.SetAttribute("NumSumRows", len(sumMessages))
+	span.SetAttribute("NumHistogramRows", len(histogramMessages))
+
+	if len(sumMessages) > 0 {
+		if err := k.Worker.PublicResolver.MetricSumQueue.Submit(ctx, "", sumMessages...); err != nil {
+			return err
+		}
+	}
+	if len(histogramMessages) > 0 {
+		if err := k.Worker.PublicResolver.MetricHistogramQueue.Submit(ctx, "", histogramMessages...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (k *KafkaBatchWorker) flushTraces(ctx context.Context, traceRows []*clickhouse.ClickhouseTraceRow) error {
 	markBackendSetupProjectIds := map[uint32]struct{}{}
 	projectIds := map[uint32]struct{}{}
@@ -483,6 +595,13 @@ func (k *KafkaBatchWorker) flushTraces(ctx context.Context, traceRows []*clickho
 		filteredTraceRows = append(filteredTraceRows, trace)
 	}
 
+	if k.TailSampler != nil {
+		now := time.Now()
+		k.TailSampler.Add(ctx, filteredTraceRows, now)
+		k.TailSampler.Decide(ctx, now)
+		filteredTraceRows = k.TailSampler.Ready()
+	}
+
 	span, ctxT := util.StartSpanFromContext(ctx, fmt.Sprintf("worker.kafka.%s.flush.clickhouse.traces", k.Name), util.WithHighlightTracingDisabled(true))
 	span.SetAttribute("NumTraceRows", len(traceRows))
 	span.SetAttribute("PayloadSizeBytes", binary.Size(traceRows))
@@ -493,6 +612,9 @@ func (k *KafkaBatchWorker) flushTraces(ctx context.Context, traceRows []*clickho
 		span.Finish(err)
 		return err
 	}
+	if k.TailSampler != nil {
+		k.TailSampler.Written()
+	}
 
 	for projectId := range markBackendSetupProjectIds {
 		err := k.Worker.PublicResolver.MarkBackendSetupImpl(ctx, int(projectId), model.MarkBackendSetupTypeTraces)
@@ -711,6 +833,12 @@ type KafkaBatchWorker struct {
 	BatchedFlushTimeout time.Duration
 	Name                string
 	TracingDisabled     bool
+	// TailSampler buffers trace rows of tail sampled projects before they are written.
+	TailSampler *TailSampler
+	// LogMetrics derives metrics from the log rows written by the worker.
+	LogMetrics *logmetrics.Evaluator
+	// LogPatterns assigns the log rows written by the worker to the patterns of their service.
+	LogPatterns *logpatterns.Miner
 
 	lastFlush       time.Time
 	messages        []kafkaqueue.RetryableMessage
@@ -0,0 +1,221 @@
+package worker
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/highlight-run/highlight/backend/parser"
+	"github.com/highlight-run/highlight/backend/parser/listener"
+	pubgraph "github.com/highlight-run/highlight/backend/public-graph/graph"
+	"github.com/highlight-run/highlight/backend/redis"
+	hmetric "github.com/highlight/highlight/sdk/highlight-go/metric"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// max spans buffered by a worker before traces are decided ahead of their window
+	tailSamplingMaxBufferedSpans = 250_000
+	// decisions are remembered so that spans arriving after the window follow their trace
+	tailSamplingDecisionCacheSize = 100_000
+	tailSamplingFilterCacheSize   = 1_000
+	tailSamplingDefaultPolicy     = "default"
+)
+
+type tailSamplingStore interface {
+	GetTraceSamplingPolicies(ctx context.Context, projectID int, opts ...redis.Option) ([]*model.TraceSamplingPolicy, error)
+	GetProjectFilterSettings(ctx context.Context, projectID int, opts ...redis.Option) (*model.ProjectFilterSettings, error)
+}
+
+type tailSamplingKey struct {
+	projectID uint32
+	traceID   string
+}
+
+type tailSampledTrace struct {
+	firstSeen time.Time
+	spans     []*clickhouse.ClickhouseTraceRow
+}
+
+// TailSampler keeps or drops complete traces of projects with a model.TraceSamplingPolicy.
+// Spans are buffered per trace until the project's decision window has passed, then the first
+// policy matching any span of the trace decides the sampling rate for all of its spans.
+// Traces are partitioned by trace id, so all spans of a trace reach the same worker. Buffered spans
+// are held in memory only and are lost if the worker restarts or its partitions are reassigned.
+type TailSampler struct {
+	store            tailSamplingStore
+	maxBufferedSpans int
+
+	traces    map[tailSamplingKey]*tailSampledTrace
+	buffered  int
+	decisions *lru.Cache[tailSamplingKey, bool]
+	filters   *lru.Cache[string, listener.Filters]
+	ready     []*clickhouse.ClickhouseTraceRow
+}
+
+func NewTailSampler(store tailSamplingStore) *TailSampler {
+	decisions, _ := lru.New[tailSamplingKey, bool](tailSamplingDecisionCacheSize)
+	filters, _ := lru.New[string, listener.Filters](tailSamplingFilterCacheSize)
+	return &TailSampler{
+		store:            store,
+		maxBufferedSpans: tailSamplingMaxBufferedSpans,
+		traces:           map[tailSamplingKey]*tailSampledTrace{},
+		decisions:        decisions,
+		filters:          filters,
+	}
+}
+
+// Add buffers spans of tail sampled projects. Spans of other projects, or of traces
+// that have already been decided and kept, are ready to be written immediately.
+func (t *TailSampler) Add(ctx context.Context, rows []*clickhouse.ClickhouseTraceRow, now time.Time) {
+	tailSampled := map[uint32]bool{}
+	for _, row := range rows {
+		key := tailSamplingKey{projectID: row.ProjectId, traceID: row.TraceId}
+		if keep, ok := t.decisions.Get(key); ok {
+			if keep {
+				t.ready = append(t.ready, row)
+			}
+			continue
+		}
+
+		enabled, ok := tailSampled[row.ProjectId]
+		if !ok {
+			policies, err := t.store.GetTraceSamplingPolicies(ctx, int(row.ProjectId))
+			if err != nil {
+				log.WithContext(ctx).WithError(err).WithField("project_id", row.ProjectId).Error("failed to get trace sampling policies")
+			}
+			enabled = len(policies) > 0
+			tailSampled[row.ProjectId] = enabled
+		}
+		if !enabled {
+			t.ready = append(t.ready, row)
+			continue
+		}
+
+		trace, ok := t.traces[key]
+		if !ok {
+			trace = &tailSampledTrace{firstSeen: now}
+			t.traces[key] = trace
+		}
+		trace.spans = append(trace.spans, row)
+		t.buffered++
+	}
+}
+
+// Decide evaluates the traces whose decision window has passed.
+// If too many spans are buffered, the oldest traces are decided early.
+func (t *TailSampler) Decide(ctx context.Context, now time.Time) {
+	windows := map[uint32]time.Duration{}
+	var pending []tailSamplingKey
+	for key, trace := range t.traces {
+		window, ok := windows[key.projectID]
+		if !ok {
+			window = t.getWindow(ctx, key.projectID)
+			windows[key.projectID] = window
+		}
+		if now.Sub(trace.firstSeen) >= window {
+			t.decide(ctx, key, trace)
+		} else {
+			pending = append(pending, key)
+		}
+	}
+
+	if t.buffered > t.maxBufferedSpans {
+		sort.Slice(pending, func(i, j int) bool {
+			return t.traces[pending[i]].firstSeen.Before(t.traces[pending[j]].firstSeen)
+		})
+		for _, key := range pending {
+			if t.buffered <= t.maxBufferedSpans {
+				break
+			}
+			t.decide(ctx, key, t.traces[key])
+		}
+	}
+
+	hmetric.Gauge(ctx, "worker.kafka.traces.tailSampling.bufferedSpans", float64(t.buffered), nil, 1)
+}
+
+// Ready returns the spans that should be written.
+func (t *TailSampler) Ready() []*clickhouse.ClickhouseTraceRow {
+	return t.ready
+}
+
+// Written clears the ready spans once they are stored. Until then, they are retried by the next flush.
+func (t *TailSampler) Written() {
+	t.ready = nil
+}
+
+// Pending reports whether spans are buffered or waiting to be written.
+func (t *TailSampler) Pending() bool {
+	if t == nil {
+		return false
+	}
+	return len(t.traces) > 0 || len(t.ready) > 0
+}
+
+func (t *TailSampler) getWindow(ctx context.Context, projectID uint32) time.Duration {
+	settings, err := t.store.GetProjectFilterSettings(ctx, int(projectID))
+	if err != nil {
+		log.WithContext(ctx).WithError(err).WithField("project_id", projectID).Error("failed to get project filter settings")
+		return 0
+	}
+	return time.Duration(settings.TraceTailSamplingWindowSeconds) * time.Second
+}
+
+func (t *TailSampler) decide(ctx context.Context, key tailSamplingKey, trace *tailSampledTrace) {
+	policy, rate := t.evaluate(ctx, key.projectID, trace.spans)
+	// salt the trace id so that the decision is independent of the head sampling of the trace
+	keep := pubgraph.IsIngestedBySample(ctx, "tail-sampling-"+key.traceID, rate)
+	if keep {
+		t.ready = append(t.ready, trace.spans...)
+	}
+
+	t.decisions.Add(key, keep)
+	t.buffered -= len(trace.spans)
+	delete(t.traces, key)
+
+	decision := "dropped"
+	if keep {
+		decision = "kept"
+	}
+	tags := []attribute.KeyValue{
+		attribute.Int("project_id", int(key.projectID)),
+		attribute.String("policy", policy),
+		attribute.String("decision", decision),
+	}
+	hmetric.Incr(ctx, "worker.kafka.traces.tailSampling.decision", tags, 1)
+	hmetric.Histogram(ctx, "worker.kafka.traces.tailSampling.spans", float64(len(trace.spans)), tags, 1)
+}
+
+// evaluate returns the name and sampling rate of the first policy matching any span of the trace.
+func (t *TailSampler) evaluate(ctx context.Context, projectID uint32, spans []*clickhouse.ClickhouseTraceRow) (string, float64) {
+	policies, err := t.store.GetTraceSamplingPolicies(ctx, int(projectID))
+	if err != nil {
+		log.WithContext(ctx).WithError(err).WithField("project_id", projectID).Error("failed to get trace sampling policies")
+		return tailSamplingDefaultPolicy, 1
+	}
+
+	for _, policy := range policies {
+		filters, ok := t.filters.Get(policy.Query)
+		if !ok {
+			filters = parser.Parse(policy.Query, clickhouse.TracesTableNoDefaultConfig)
+			t.filters.Add(policy.Query, filters)
+		}
+		for _, span := range spans {
+			if clickhouse.ClickhouseTraceMatchesQuery(span, filters) {
+				return policy.Name, policy.SamplingRate
+			}
+		}
+	}
+
+	settings, err := t.store.GetProjectFilterSettings(ctx, int(projectID))
+	if err != nil {
+		log.WithContext(ctx).WithError(err).WithField("project_id", projectID).Error("failed to get project filter settings")
+		return tailSamplingDefaultPolicy, 1
+	}
+	return tailSamplingDefaultPolicy, settings.TraceTailSamplingRate
+}
@@ -0,0 +1,115 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/highlight-run/highlight/backend/redis"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTailSamplingStore struct {
+	policies map[int][]*model.TraceSamplingPolicy
+	settings *model.ProjectFilterSettings
+}
+
+func (s *fakeTailSamplingStore) GetTraceSamplingPolicies(_ context.Context, projectID int, _ ...redis.Option) ([]*model.TraceSamplingPolicy, error) {
+	return s.policies[projectID], nil
+}
+
+func (s *fakeTailSamplingStore) GetProjectFilterSettings(_ context.Context, _ int, _ ...redis.Option) (*model.ProjectFilterSettings, error) {
+	return s.settings, nil
+}
+
+func newTailSamplingSpan(projectID uint32, traceID string, parentSpanID string, duration time.Duration, hasErrors bool) *clickhouse.ClickhouseTraceRow {
+	return &clickhouse.ClickhouseTraceRow{
+		ProjectId:    projectID,
+		TraceId:      traceID,
+		SpanId:       fmt.Sprintf("%s-%s", traceID, parentSpanID),
+		ParentSpanId: parentSpanID,
+		Duration:     int64(duration),
+		HasErrors:    hasErrors,
+	}
+}
+
+func TestTailSampler(t *testing.T) {
+	ctx := context.Background()
+	sampler := NewTailSampler(&fakeTailSamplingStore{
+		policies: map[int][]*model.TraceSamplingPolicy{
+			1: {
+				{Name: "errors", Query: "has_errors=true", SamplingRate: 1},
+				{Name: "slow", Query: `parent_span_id="" duration>2s`, SamplingRate: 1},
+			},
+		},
+		settings: &model.ProjectFilterSettings{TraceTailSamplingRate: 0, TraceTailSamplingWindowSeconds: 10},
+	})
+	now := time.Now()
+
+	sampler.Add(ctx, []*clickhouse.ClickhouseTraceRow{
+		// project without policies is not buffered
+		newTailSamplingSpan(2, "untouched", "", time.Second, false),
+		// an error on a child span keeps the whole trace
+		newTailSamplingSpan(1, "error", "", time.Second, false),
+		newTailSamplingSpan(1, "error", "root", time.Second, true),
+		// a slow root span keeps the trace
+		newTailSamplingSpan(1, "slow", "", 3*time.Second, false),
+		newTailSamplingSpan(1, "slow", "root", time.Second, false),
+		// a slow child span does not match the root span policy
+		newTailSamplingSpan(1, "fast", "", time.Second, false),
+		newTailSamplingSpan(1, "fast", "root", 3*time.Second, false),
+	}, now)
+	assert.Len(t, sampler.Ready(), 1)
+	assert.True(t, sampler.Pending())
+
+	// nothing is decided within the window
+	sampler.Decide(ctx, now.Add(5*time.Second))
+	assert.Len(t, sampler.Ready(), 1)
+	sampler.Written()
+
+	sampler.Decide(ctx, now.Add(10*time.Second))
+	traceIDs := lo.Uniq(lo.Map(sampler.Ready(), func(row *clickhouse.ClickhouseTraceRow, _ int) string {
+		return row.TraceId
+	}))
+	assert.ElementsMatch(t, []string{"error", "slow"}, traceIDs)
+	assert.Len(t, sampler.Ready(), 4)
+	sampler.Written()
+	assert.False(t, sampler.Pending())
+
+	// late spans follow the decision of their trace
+	sampler.Add(ctx, []*clickhouse.ClickhouseTraceRow{
+		newTailSamplingSpan(1, "error", "late", time.Second, false),
+		newTailSamplingSpan(1, "fast", "late", time.Second, false),
+	}, now.Add(15*time.Second))
+	assert.Len(t, sampler.Ready(), 1)
+	assert.Equal(t, "error", sampler.Ready()[0].TraceId)
+	assert.False(t, lo.ContainsBy(sampler.Ready(), func(row *clickhouse.ClickhouseTraceRow) bool {
+		return row.TraceId == "fast"
+	}))
+}
+
+func TestTailSamplerMaxBufferedSpans(t *testing.T) {
+	ctx := context.Background()
+	sampler := NewTailSampler(&fakeTailSamplingStore{
+		policies: map[int][]*model.TraceSamplingPolicy{
+			1: {{Name: "all", Query: "", SamplingRate: 1}},
+		},
+		settings: &model.ProjectFilterSettings{TraceTailSamplingRate: 1, TraceTailSamplingWindowSeconds: 10},
+	})
+	sampler.maxBufferedSpans = 2
+	now := time.Now()
+
+	sampler.Add(ctx, []*clickhouse.ClickhouseTraceRow{newTailSamplingSpan(1, "a", "", time.Second, false)}, now)
+	sampler.Add(ctx, []*clickhouse.ClickhouseTraceRow{newTailSamplingSpan(1, "b", "", time.Second, false)}, now.Add(time.Second))
+	sampler.Add(ctx, []*clickhouse.ClickhouseTraceRow{newTailSamplingSpan(1, "c", "", time.Second, false)}, now.Add(2*time.Second))
+
+	// the oldest trace is decided early to stay within the limit
+	sampler.Decide(ctx, now.Add(2*time.Second))
+	assert.Len(t, sampler.Ready(), 1)
+	assert.Equal(t, "a", sampler.Ready()[0].TraceId)
+	assert.True(t, sampler.Pending())
+}
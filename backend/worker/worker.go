@@ -34,14 +34,18 @@ import (
 	parse "github.com/highlight-run/highlight/backend/event-parse"
 	delete_handlers "github.com/highlight-run/highlight/backend/lambda-functions/deleteSessions/handlers"
 
+	"github.com/highlight-run/highlight/backend/exports"
 	log_alerts "github.com/highlight-run/highlight/backend/jobs/log-alerts"
 	metric_alerts "github.com/highlight-run/highlight/backend/jobs/metric-alerts"
 	kafkaqueue "github.com/highlight-run/highlight/backend/kafka-queue"
 	journey_handlers "github.com/highlight-run/highlight/backend/lambda-functions/journeys/handlers"
+	"github.com/highlight-run/highlight/backend/logmetrics"
+	"github.com/highlight-run/highlight/backend/logpatterns"
 	"github.com/highlight-run/highlight/backend/model"
 	"github.com/highlight-run/highlight/backend/payload"
 	"github.com/highlight-run/highlight/backend/phonehome"
 	"github.com/highlight-run/highlight/backend/pricing"
+	"github.com/highlight-run/highlight/backend/redis"
 	mgraph "github.com/highlight-run/highlight/backend/private-graph/graph"
 	backend "github.com/highlight-run/highlight/backend/private-graph/graph/model"
 	pubgraph "github.com/highlight-run/highlight/backend/public-graph/graph"
@@ -528,6 +532,11 @@ func (w *Worker) PublicWorker(ctx context.Context, topic kafkaqueue.TopicType) {
 		if cfg.FlushTimeout == 0 {
 			cfg.FlushTimeout = DefaultBatchedFlushTimeout
 		}
+		topicConfig, err := kafkaqueue.GetTopicConfig(cfg.Topic)
+		if err != nil {
+			log.WithContext(ctx).WithError(err).Fatal("failed to get kafka topic config")
+		}
+		cfg.MessageSizeBytes = topicConfig.MessageSizeBytes
 		wg.Add(cfg.Workers)
 		for i := 0; i < cfg.Workers; i++ {
 			if cfg.Topic == kafkaqueue.TopicTypeDefault {
@@ -551,17 +560,31 @@ func (w *Worker) PublicWorker(ctx context.Context, topic kafkaqueue.TopicType) {
 				}(cfg, i)
 			} else {
 				go func(config WorkerConfig, workerId int) {
+					var tailSampler *TailSampler
+					if config.Topic == kafkaqueue.TopicTypeTraces {
+						tailSampler = NewTailSampler(w.PublicResolver.Store)
+					}
+					var logMetrics *logmetrics.Evaluator
+					var logPatterns *logpatterns.Miner
+					if config.Topic == kafkaqueue.TopicTypeBatched {
+						logMetrics = logmetrics.NewEvaluator(w.PublicResolver.Store, w.PublicResolver.GetProjectMetricRetention)
+						// pattern ids do not depend on the logs seen by a miner, so each worker mines its own logs
+						logPatterns = logpatterns.NewMiner()
+					}
 					k := KafkaBatchWorker{
 						KafkaQueue: kafkaqueue.New(
 							ctx,
 							kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: config.Topic}),
-							kafkaqueue.Consumer, &kafkaqueue.ConfigOverride{QueueCapacity: pointy.Int(config.QueueSize)},
+							kafkaqueue.Consumer, &kafkaqueue.ConfigOverride{QueueCapacity: pointy.Int(config.QueueSize), MessageSizeBytes: config.MessageSizeBytes},
 						),
 						Worker:              w,
 						BatchFlushSize:      config.FlushSize,
 						BatchedFlushTimeout: config.FlushTimeout,
 						Name:                string(config.Topic),
 						TracingDisabled:     config.TracingDisabled,
+						TailSampler:         tailSampler,
+						LogMetrics:          logMetrics,
+						LogPatterns:         logPatterns,
 					}
 					k.ProcessMessages()
 					wg.Done()
@@ -579,6 +602,70 @@ func (w *Worker) AutoResolveStaleErrors(ctx context.Context) {
 	autoResolver.AutoResolveStaleErrors(ctx)
 }
 
+// Derives the edges between services from the spans of windows not yet computed
+func (w *Worker) ComputeServiceDependencies(ctx context.Context) {
+	span, ctx := util.StartSpanFromContext(ctx, "worker.computeServiceDependencies",
+		util.ResourceName("worker.computeServiceDependencies"))
+	defer span.Finish()
+
+	lastWindow, err := w.readServiceDependencyWatermark(ctx)
+	if err != nil {
+		log.WithContext(ctx).WithError(err).Error("failed to read last service dependency window")
+		return
+	}
+
+	for _, window := range clickhouse.ServiceDependencyWindows(lastWindow, time.Now()) {
+		if err := w.computeServiceDependencyWindow(ctx, window); err != nil {
+			log.WithContext(ctx).WithError(err).WithField("window", window).Error("failed to write service dependencies")
+			return
+		}
+	}
+}
+
+// the watermark is the last computed window. before it is first stored,
+// the latest window with stored edges is used.
+func (w *Worker) readServiceDependencyWatermark(ctx context.Context) (time.Time, error) {
+	watermark, err := w.Resolver.Redis.GetServiceDependencyWatermark(ctx)
+	if err != nil || !watermark.IsZero() {
+		return watermark, err
+	}
+	return w.Resolver.ClickhouseClient.ReadLastServiceDependencyWindow(ctx)
+}
+
+// the edges of a window are summed on insert, so a window is written at most once
+// even when the job is rerun or runs on several instances.
+func (w *Worker) computeServiceDependencyWindow(ctx context.Context, window time.Time) error {
+	key := redis.ServiceDependencyWindowLockKey(window)
+	mutex, err := w.Resolver.Redis.AcquireLock(ctx, key, time.Minute)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if _, err := mutex.Unlock(); err != nil {
+			log.WithContext(ctx).WithError(err).WithField("key", key).Error("failed to release lock")
+		}
+	}()
+
+	// recheck the watermark with the lock held, then perform the write
+	watermark, err := w.readServiceDependencyWatermark(ctx)
+	if err != nil {
+		return err
+	}
+	if !window.After(watermark) {
+		return nil
+	}
+
+	if err := w.Resolver.ClickhouseClient.WriteServiceDependencies(ctx, window); err != nil {
+		return err
+	}
+	return w.Resolver.Redis.SetServiceDependencyWatermark(ctx, window)
+}
+
+// Writes the completed hours of the export jobs of all projects to their destination
+func (w *Worker) RunExportJobs(ctx context.Context) {
+	exports.NewExporter(w.Resolver.Store, w.Resolver.ClickhouseClient, w.Resolver.StorageClient).Run(ctx)
+}
+
 func (w *Worker) excludeSession(ctx context.Context, s *model.Session, reason backend.SessionExcludedReason) error {
 	s.Excluded = true
 	s.ExcludedReason = &reason
@@ -1210,7 +1297,7 @@ func (w *Worker) StartLogAlertWatcher(ctx context.Context) {
 }
 
 func (w *Worker) StartMetricAlertWatcher(ctx context.Context) {
-	metric_alerts.WatchMetricAlerts(ctx, w.Resolver.DB, w.Resolver.MailClient, w.Resolver.ClickhouseClient, w.Resolver.LambdaClient)
+	metric_alerts.WatchMetricAlerts(ctx, w.Resolver.DB, w.Resolver.MailClient, w.Resolver.ClickhouseClient, w.Resolver.LambdaClient, w.Resolver.Store)
 }
 
 func (w *Worker) StartSessionDeleteJob(ctx context.Context) {
@@ -1246,6 +1333,18 @@ func (w *Worker) ScheduledTasks(ctx context.Context) {
 			w.AutoResolveStaleErrors(ctx)
 		}
 	}()
+	go func() {
+		w.ComputeServiceDependencies(ctx)
+		for range time.Tick(clickhouse.ServiceDependencyWindow) {
+			w.ComputeServiceDependencies(ctx)
+		}
+	}()
+	go func() {
+		w.RunExportJobs(ctx)
+		for range time.Tick(exports.Interval) {
+			w.RunExportJobs(ctx)
+		}
+	}()
 
 	// block forever
 	select {}
@@ -1456,6 +1555,10 @@ func (w *Worker) GetHandler(ctx context.Context, handlerFlag util.Handler) func(
 		return w.StartSessionDeleteJob
 	case util.ScheduledTasks:
 		return w.ScheduledTasks
+	case util.ServiceDependencies:
+		return w.ComputeServiceDependencies
+	case util.ExportJobs:
+		return w.RunExportJobs
 	case "":
 		// no handler provided defaults to the session worker
 		return w.Start
@@ -1048,3 +1048,34 @@ func TestCalculateOverages(t *testing.T) {
 		}
 	}
 }
+
+func TestComputeServiceDependencyWindow(t *testing.T) {
+	ctx := context.TODO()
+	worker := Worker{
+		Resolver: &graph.Resolver{
+			Redis:            redisClient,
+			ClickhouseClient: chClient,
+		},
+	}
+
+	window := time.Now().Add(-time.Hour).Truncate(clickhouse.ServiceDependencyWindow)
+	now := window.Add(time.Minute)
+	assert.NoError(t, chClient.BatchWriteTraceRows(ctx, []*clickhouse.ClickhouseTraceRow{
+		clickhouse.NewTraceRow(now, 9876).WithTraceId("a").WithSpanId("a1").WithServiceName("frontend").WithSpanKind("Client").AsClickhouseTraceRow(),
+		clickhouse.NewTraceRow(now, 9876).WithTraceId("a").WithSpanId("a2").WithParentSpanId("a1").WithServiceName("api").WithSpanKind("Server").AsClickhouseTraceRow(),
+	}))
+	assert.NoError(t, redisClient.SetServiceDependencyWatermark(ctx, window.Add(-clickhouse.ServiceDependencyWindow)))
+
+	// a rerun of a computed window does not add its edges again
+	assert.NoError(t, worker.computeServiceDependencyWindow(ctx, window))
+	assert.NoError(t, worker.computeServiceDependencyWindow(ctx, window))
+
+	watermark, err := redisClient.GetServiceDependencyWatermark(ctx)
+	assert.NoError(t, err)
+	assert.True(t, window.Equal(watermark))
+
+	dependencies, err := chClient.ReadServiceDependencies(ctx, 9876, nil, model2.DateRangeRequiredInput{StartDate: window, EndDate: window.Add(clickhouse.ServiceDependencyWindow)})
+	assert.NoError(t, err)
+	assert.Len(t, dependencies.Edges, 1)
+	assert.Equal(t, uint64(1), dependencies.Edges[0].CallCount)
+}
@@ -14,9 +14,11 @@ import { Col_search_valueContext } from './SearchGrammarParser.js'
 import { Negated_search_exprContext } from './SearchGrammarParser.js'
 import { Body_search_exprContext } from './SearchGrammarParser.js'
 import { And_search_exprContext } from './SearchGrammarParser.js'
+import { In_search_exprContext } from './SearchGrammarParser.js'
 import { Or_search_exprContext } from './SearchGrammarParser.js'
 import { Implicit_and_search_exprContext } from './SearchGrammarParser.js'
 import { Exists_search_exprContext } from './SearchGrammarParser.js'
+import { Between_search_exprContext } from './SearchGrammarParser.js'
 import { Key_val_search_exprContext } from './SearchGrammarParser.js'
 import { Paren_search_exprContext } from './SearchGrammarParser.js'
 import { Search_keyContext } from './SearchGrammarParser.js'
@@ -24,6 +26,8 @@ import { And_opContext } from './SearchGrammarParser.js'
 import { Implicit_and_opContext } from './SearchGrammarParser.js'
 import { Or_opContext } from './SearchGrammarParser.js'
 import { Exists_opContext } from './SearchGrammarParser.js'
+import { In_opContext } from './SearchGrammarParser.js'
+import { Between_opContext } from './SearchGrammarParser.js'
 import { Negation_opContext } from './SearchGrammarParser.js'
 import { Bin_opContext } from './SearchGrammarParser.js'
 import { Search_valueContext } from './SearchGrammarParser.js'
@@ -175,6 +179,18 @@ export default class SearchGrammarListener extends ParseTreeListener {
 	 * @param ctx the parse tree
 	 */
 	exitAnd_search_expr?: (ctx: And_search_exprContext) => void
+	/**
+	 * Enter a parse tree produced by the `in_search_expr`
+	 * labeled alternative in `SearchGrammarParser.search_expr`.
+	 * @param ctx the parse tree
+	 */
+	enterIn_search_expr?: (ctx: In_search_exprContext) => void
+	/**
+	 * Exit a parse tree produced by the `in_search_expr`
+	 * labeled alternative in `SearchGrammarParser.search_expr`.
+	 * @param ctx the parse tree
+	 */
+	exitIn_search_expr?: (ctx: In_search_exprContext) => void
 	/**
 	 * Enter a parse tree produced by the `or_search_expr`
 	 * labeled alternative in `SearchGrammarParser.search_expr`.
@@ -215,6 +231,18 @@ export default class SearchGrammarListener extends ParseTreeListener {
 	 * @param ctx the parse tree
 	 */
 	exitExists_search_expr?: (ctx: Exists_search_exprContext) => void
+	/**
+	 * Enter a parse tree produced by the `between_search_expr`
+	 * labeled alternative in `SearchGrammarParser.search_expr`.
+	 * @param ctx the parse tree
+	 */
+	enterBetween_search_expr?: (ctx: Between_search_exprContext) => void
+	/**
+	 * Exit a parse tree produced by the `between_search_expr`
+	 * labeled alternative in `SearchGrammarParser.search_expr`.
+	 * @param ctx the parse tree
+	 */
+	exitBetween_search_expr?: (ctx: Between_search_exprContext) => void
 	/**
 	 * Enter a parse tree produced by the `key_val_search_expr`
 	 * labeled alternative in `SearchGrammarParser.search_expr`.
@@ -289,6 +317,26 @@ export default class SearchGrammarListener extends ParseTreeListener {
 	 * @param ctx the parse tree
 	 */
 	exitExists_op?: (ctx: Exists_opContext) => void
+	/**
+	 * Enter a parse tree produced by `SearchGrammarParser.in_op`.
+	 * @param ctx the parse tree
+	 */
+	enterIn_op?: (ctx: In_opContext) => void
+	/**
+	 * Exit a parse tree produced by `SearchGrammarParser.in_op`.
+	 * @param ctx the parse tree
+	 */
+	exitIn_op?: (ctx: In_opContext) => void
+	/**
+	 * Enter a parse tree produced by `SearchGrammarParser.between_op`.
+	 * @param ctx the parse tree
+	 */
+	enterBetween_op?: (ctx: Between_opContext) => void
+	/**
+	 * Exit a parse tree produced by `SearchGrammarParser.between_op`.
+	 * @param ctx the parse tree
+	 */
+	exitBetween_op?: (ctx: Between_opContext) => void
 	/**
 	 * Enter a parse tree produced by `SearchGrammarParser.negation_op`.
 	 * @param ctx the parse tree
@@ -33,21 +33,25 @@ export default class SearchGrammarParser extends Parser {
 	public static readonly OR = 2
 	public static readonly NOT = 3
 	public static readonly EXISTS = 4
-	public static readonly BANG = 5
-	public static readonly EQ = 6
-	public static readonly NEQ = 7
-	public static readonly LT = 8
-	public static readonly LTE = 9
-	public static readonly GT = 10
-	public static readonly GTE = 11
-	public static readonly LPAREN = 12
-	public static readonly RPAREN = 13
-	public static readonly COLON = 14
-	public static readonly ID = 15
-	public static readonly STRING = 16
-	public static readonly VALUE = 17
-	public static readonly WS = 18
-	public static readonly ERROR_CHARACTERS = 19
+	public static readonly IN = 5
+	public static readonly BETWEEN = 6
+	public static readonly BANG = 7
+	public static readonly EQ = 8
+	public static readonly NEQ = 9
+	public static readonly REGEX_MATCH = 10
+	public static readonly NOT_REGEX_MATCH = 11
+	public static readonly LT = 12
+	public static readonly LTE = 13
+	public static readonly GT = 14
+	public static readonly GTE = 15
+	public static readonly LPAREN = 16
+	public static readonly RPAREN = 17
+	public static readonly COLON = 18
+	public static readonly ID = 19
+	public static readonly STRING = 20
+	public static readonly VALUE = 21
+	public static readonly WS = 22
+	public static readonly ERROR_CHARACTERS = 23
 	public static override readonly EOF = Token.EOF
 	public static readonly RULE_search_query = 0
 	public static readonly RULE_top_col_expr = 1
@@ -58,18 +62,24 @@ export default class SearchGrammarParser extends Parser {
 	public static readonly RULE_implicit_and_op = 6
 	public static readonly RULE_or_op = 7
 	public static readonly RULE_exists_op = 8
-	public static readonly RULE_negation_op = 9
-	public static readonly RULE_bin_op = 10
-	public static readonly RULE_search_value = 11
+	public static readonly RULE_in_op = 9
+	public static readonly RULE_between_op = 10
+	public static readonly RULE_negation_op = 11
+	public static readonly RULE_bin_op = 12
+	public static readonly RULE_search_value = 13
 	public static readonly literalNames: (string | null)[] = [
 		null,
 		"'AND'",
 		"'OR'",
 		"'NOT'",
 		"'EXISTS'",
+		"'IN'",
+		"'BETWEEN'",
 		"'!'",
 		"'='",
 		"'!='",
+		"'=~'",
+		"'!~'",
 		"'<'",
 		"'<='",
 		"'>'",
@@ -84,9 +94,13 @@ export default class SearchGrammarParser extends Parser {
 		'OR',
 		'NOT',
 		'EXISTS',
+		'IN',
+		'BETWEEN',
 		'BANG',
 		'EQ',
 		'NEQ',
+		'REGEX_MATCH',
+		'NOT_REGEX_MATCH',
 		'LT',
 		'LTE',
 		'GT',
@@ -111,6 +125,8 @@ export default class SearchGrammarParser extends Parser {
 		'implicit_and_op',
 		'or_op',
 		'exists_op',
+		'in_op',
+		'between_op',
 		'negation_op',
 		'bin_op',
 		'search_value',
@@ -156,26 +172,28 @@ export default class SearchGrammarParser extends Parser {
 		)
 		this.enterRule(localctx, 0, SearchGrammarParser.RULE_search_query)
 		try {
-			this.state = 28
+			this.state = 32
 			this._errHandler.sync(this)
 			switch (this._input.LA(1)) {
 				case -1:
 					this.enterOuterAlt(localctx, 1)
 					{
-						this.state = 24
+						this.state = 28
 						this.match(SearchGrammarParser.EOF)
 					}
 					break
 				case 3:
-				case 12:
-				case 15:
+				case 5:
+				case 6:
 				case 16:
-				case 17:
+				case 19:
+				case 20:
+				case 21:
 					this.enterOuterAlt(localctx, 2)
 					{
-						this.state = 25
+						this.state = 29
 						this.search_expr(0)
-						this.state = 26
+						this.state = 30
 						this.match(SearchGrammarParser.EOF)
 					}
 					break
@@ -204,18 +222,18 @@ export default class SearchGrammarParser extends Parser {
 		)
 		this.enterRule(localctx, 2, SearchGrammarParser.RULE_top_col_expr)
 		try {
-			this.state = 38
+			this.state = 42
 			this._errHandler.sync(this)
 			switch (this._input.LA(1)) {
-				case 12:
+				case 16:
 					localctx = new Top_paren_col_exprContext(this, localctx)
 					this.enterOuterAlt(localctx, 1)
 					{
-						this.state = 30
+						this.state = 34
 						this.match(SearchGrammarParser.LPAREN)
-						this.state = 31
+						this.state = 35
 						this.col_expr(0)
-						this.state = 32
+						this.state = 36
 						this.match(SearchGrammarParser.RPAREN)
 					}
 					break
@@ -223,19 +241,21 @@ export default class SearchGrammarParser extends Parser {
 					localctx = new Negated_top_col_exprContext(this, localctx)
 					this.enterOuterAlt(localctx, 2)
 					{
-						this.state = 34
+						this.state = 38
 						this.negation_op()
-						this.state = 35
+						this.state = 39
 						this.top_col_expr()
 					}
 					break
-				case 15:
-				case 16:
-				case 17:
+				case 5:
+				case 6:
+				case 19:
+				case 20:
+				case 21:
 					localctx = new Top_col_search_valueContext(this, localctx)
 					this.enterOuterAlt(localctx, 3)
 					{
-						this.state = 37
+						this.state = 41
 						this.search_value()
 					}
 					break
@@ -283,20 +303,20 @@ export default class SearchGrammarParser extends Parser {
 			let _alt: number
 			this.enterOuterAlt(localctx, 1)
 			{
-				this.state = 49
+				this.state = 53
 				this._errHandler.sync(this)
 				switch (this._input.LA(1)) {
-					case 12:
+					case 16:
 						{
 							localctx = new Col_paren_exprContext(this, localctx)
 							this._ctx = localctx
 							_prevctx = localctx
 
-							this.state = 41
+							this.state = 45
 							this.match(SearchGrammarParser.LPAREN)
-							this.state = 42
+							this.state = 46
 							this.col_expr(0)
-							this.state = 43
+							this.state = 47
 							this.match(SearchGrammarParser.RPAREN)
 						}
 						break
@@ -308,15 +328,17 @@ export default class SearchGrammarParser extends Parser {
 							)
 							this._ctx = localctx
 							_prevctx = localctx
-							this.state = 45
+							this.state = 49
 							this.negation_op()
-							this.state = 46
+							this.state = 50
 							this.col_expr(4)
 						}
 						break
-					case 15:
-					case 16:
-					case 17:
+					case 5:
+					case 6:
+					case 19:
+					case 20:
+					case 21:
 						{
 							localctx = new Col_search_valueContext(
 								this,
@@ -324,7 +346,7 @@ export default class SearchGrammarParser extends Parser {
 							)
 							this._ctx = localctx
 							_prevctx = localctx
-							this.state = 48
+							this.state = 52
 							this.search_value()
 						}
 						break
@@ -332,7 +354,7 @@ export default class SearchGrammarParser extends Parser {
 						throw new NoViableAltException(this)
 				}
 				this._ctx.stop = this._input.LT(-1)
-				this.state = 59
+				this.state = 63
 				this._errHandler.sync(this)
 				_alt = this._interp.adaptivePredict(this._input, 4, this._ctx)
 				while (_alt !== 2 && _alt !== ATN.INVALID_ALT_NUMBER) {
@@ -342,7 +364,7 @@ export default class SearchGrammarParser extends Parser {
 						}
 						_prevctx = localctx
 						{
-							this.state = 57
+							this.state = 61
 							this._errHandler.sync(this)
 							switch (
 								this._interp.adaptivePredict(
@@ -366,15 +388,15 @@ export default class SearchGrammarParser extends Parser {
 											_startState,
 											SearchGrammarParser.RULE_col_expr,
 										)
-										this.state = 51
+										this.state = 55
 										if (!this.precpred(this._ctx, 3)) {
 											throw this.createFailedPredicateException(
 												'this.precpred(this._ctx, 3)',
 											)
 										}
-										this.state = 52
+										this.state = 56
 										this.match(SearchGrammarParser.AND)
-										this.state = 53
+										this.state = 57
 										this.col_expr(4)
 									}
 									break
@@ -393,22 +415,22 @@ export default class SearchGrammarParser extends Parser {
 											_startState,
 											SearchGrammarParser.RULE_col_expr,
 										)
-										this.state = 54
+										this.state = 58
 										if (!this.precpred(this._ctx, 2)) {
 											throw this.createFailedPredicateException(
 												'this.precpred(this._ctx, 2)',
 											)
 										}
-										this.state = 55
+										this.state = 59
 										this.match(SearchGrammarParser.OR)
-										this.state = 56
+										this.state = 60
 										this.col_expr(3)
 									}
 									break
 							}
 						}
 					}
-					this.state = 61
+					this.state = 65
 					this._errHandler.sync(this)
 					_alt = this._interp.adaptivePredict(
 						this._input,
@@ -454,14 +476,15 @@ export default class SearchGrammarParser extends Parser {
 			SearchGrammarParser.RULE_search_expr,
 			_p,
 		)
+		let _la: number
 		try {
 			let _alt: number
 			this.enterOuterAlt(localctx, 1)
 			{
-				this.state = 79
+				this.state = 99
 				this._errHandler.sync(this)
 				switch (
-					this._interp.adaptivePredict(this._input, 6, this._ctx)
+					this._interp.adaptivePredict(this._input, 7, this._ctx)
 				) {
 					case 1:
 						{
@@ -472,11 +495,11 @@ export default class SearchGrammarParser extends Parser {
 							this._ctx = localctx
 							_prevctx = localctx
 
-							this.state = 63
+							this.state = 67
 							this.match(SearchGrammarParser.LPAREN)
-							this.state = 64
+							this.state = 68
 							this.search_expr(0)
-							this.state = 65
+							this.state = 69
 							this.match(SearchGrammarParser.RPAREN)
 						}
 						break
@@ -488,13 +511,65 @@ export default class SearchGrammarParser extends Parser {
 							)
 							this._ctx = localctx
 							_prevctx = localctx
-							this.state = 67
+							this.state = 71
 							this.negation_op()
-							this.state = 68
-							this.search_expr(7)
+							this.state = 72
+							this.search_expr(9)
 						}
 						break
 					case 3:
+						{
+							localctx = new In_search_exprContext(this, localctx)
+							this._ctx = localctx
+							_prevctx = localctx
+							this.state = 74
+							this.search_key()
+							this.state = 75
+							this.in_op()
+							this.state = 76
+							this.match(SearchGrammarParser.LPAREN)
+							this.state = 78
+							this._errHandler.sync(this)
+							_la = this._input.LA(1)
+							do {
+								{
+									{
+										this.state = 77
+										this.search_value()
+									}
+								}
+								this.state = 80
+								this._errHandler.sync(this)
+								_la = this._input.LA(1)
+							} while (
+								(_la & ~0x1f) === 0 &&
+								((1 << _la) & 3670112) !== 0
+							)
+							this.state = 82
+							this.match(SearchGrammarParser.RPAREN)
+						}
+						break
+					case 4:
+						{
+							localctx = new Between_search_exprContext(
+								this,
+								localctx,
+							)
+							this._ctx = localctx
+							_prevctx = localctx
+							this.state = 84
+							this.search_key()
+							this.state = 85
+							this.between_op()
+							this.state = 86
+							this.search_value()
+							this.state = 87
+							this.match(SearchGrammarParser.AND)
+							this.state = 88
+							this.search_value()
+						}
+						break
+					case 5:
 						{
 							localctx = new Key_val_search_exprContext(
 								this,
@@ -502,29 +577,29 @@ export default class SearchGrammarParser extends Parser {
 							)
 							this._ctx = localctx
 							_prevctx = localctx
-							this.state = 70
+							this.state = 90
 							this.search_key()
-							this.state = 71
+							this.state = 91
 							this.bin_op()
-							this.state = 73
+							this.state = 93
 							this._errHandler.sync(this)
 							switch (
 								this._interp.adaptivePredict(
 									this._input,
-									5,
+									6,
 									this._ctx,
 								)
 							) {
 								case 1:
 									{
-										this.state = 72
+										this.state = 92
 										this.top_col_expr()
 									}
 									break
 							}
 						}
 						break
-					case 4:
+					case 6:
 						{
 							localctx = new Exists_search_exprContext(
 								this,
@@ -532,13 +607,13 @@ export default class SearchGrammarParser extends Parser {
 							)
 							this._ctx = localctx
 							_prevctx = localctx
-							this.state = 75
+							this.state = 95
 							this.search_key()
-							this.state = 76
+							this.state = 96
 							this.exists_op()
 						}
 						break
-					case 5:
+					case 7:
 						{
 							localctx = new Body_search_exprContext(
 								this,
@@ -546,15 +621,15 @@ export default class SearchGrammarParser extends Parser {
 							)
 							this._ctx = localctx
 							_prevctx = localctx
-							this.state = 78
+							this.state = 98
 							this.top_col_expr()
 						}
 						break
 				}
 				this._ctx.stop = this._input.LT(-1)
-				this.state = 95
+				this.state = 115
 				this._errHandler.sync(this)
-				_alt = this._interp.adaptivePredict(this._input, 8, this._ctx)
+				_alt = this._interp.adaptivePredict(this._input, 9, this._ctx)
 				while (_alt !== 2 && _alt !== ATN.INVALID_ALT_NUMBER) {
 					if (_alt === 1) {
 						if (this._parseListeners != null) {
@@ -562,12 +637,12 @@ export default class SearchGrammarParser extends Parser {
 						}
 						_prevctx = localctx
 						{
-							this.state = 93
+							this.state = 113
 							this._errHandler.sync(this)
 							switch (
 								this._interp.adaptivePredict(
 									this._input,
-									7,
+									8,
 									this._ctx,
 								)
 							) {
@@ -586,16 +661,16 @@ export default class SearchGrammarParser extends Parser {
 											_startState,
 											SearchGrammarParser.RULE_search_expr,
 										)
-										this.state = 81
-										if (!this.precpred(this._ctx, 6)) {
+										this.state = 101
+										if (!this.precpred(this._ctx, 8)) {
 											throw this.createFailedPredicateException(
-												'this.precpred(this._ctx, 6)',
+												'this.precpred(this._ctx, 8)',
 											)
 										}
-										this.state = 82
+										this.state = 102
 										this.and_op()
-										this.state = 83
-										this.search_expr(7)
+										this.state = 103
+										this.search_expr(9)
 									}
 									break
 								case 2:
@@ -613,16 +688,16 @@ export default class SearchGrammarParser extends Parser {
 											_startState,
 											SearchGrammarParser.RULE_search_expr,
 										)
-										this.state = 85
-										if (!this.precpred(this._ctx, 5)) {
+										this.state = 105
+										if (!this.precpred(this._ctx, 7)) {
 											throw this.createFailedPredicateException(
-												'this.precpred(this._ctx, 5)',
+												'this.precpred(this._ctx, 7)',
 											)
 										}
-										this.state = 86
+										this.state = 106
 										this.or_op()
-										this.state = 87
-										this.search_expr(6)
+										this.state = 107
+										this.search_expr(8)
 									}
 									break
 								case 3:
@@ -641,26 +716,26 @@ export default class SearchGrammarParser extends Parser {
 											_startState,
 											SearchGrammarParser.RULE_search_expr,
 										)
-										this.state = 89
-										if (!this.precpred(this._ctx, 4)) {
+										this.state = 109
+										if (!this.precpred(this._ctx, 6)) {
 											throw this.createFailedPredicateException(
-												'this.precpred(this._ctx, 4)',
+												'this.precpred(this._ctx, 6)',
 											)
 										}
-										this.state = 90
+										this.state = 110
 										this.implicit_and_op()
-										this.state = 91
-										this.search_expr(5)
+										this.state = 111
+										this.search_expr(7)
 									}
 									break
 							}
 						}
 					}
-					this.state = 97
+					this.state = 117
 					this._errHandler.sync(this)
 					_alt = this._interp.adaptivePredict(
 						this._input,
-						8,
+						9,
 						this._ctx,
 					)
 				}
@@ -689,7 +764,7 @@ export default class SearchGrammarParser extends Parser {
 		try {
 			this.enterOuterAlt(localctx, 1)
 			{
-				this.state = 98
+				this.state = 118
 				this.match(SearchGrammarParser.ID)
 			}
 		} catch (re) {
@@ -716,7 +791,7 @@ export default class SearchGrammarParser extends Parser {
 		try {
 			this.enterOuterAlt(localctx, 1)
 			{
-				this.state = 100
+				this.state = 120
 				this.match(SearchGrammarParser.AND)
 			}
 		} catch (re) {
@@ -769,7 +844,7 @@ export default class SearchGrammarParser extends Parser {
 		try {
 			this.enterOuterAlt(localctx, 1)
 			{
-				this.state = 104
+				this.state = 124
 				this.match(SearchGrammarParser.OR)
 			}
 		} catch (re) {
@@ -794,22 +869,22 @@ export default class SearchGrammarParser extends Parser {
 		)
 		this.enterRule(localctx, 16, SearchGrammarParser.RULE_exists_op)
 		try {
-			this.state = 109
+			this.state = 129
 			this._errHandler.sync(this)
 			switch (this._input.LA(1)) {
 				case 4:
 					this.enterOuterAlt(localctx, 1)
 					{
-						this.state = 106
+						this.state = 126
 						this.match(SearchGrammarParser.EXISTS)
 					}
 					break
 				case 3:
 					this.enterOuterAlt(localctx, 2)
 					{
-						this.state = 107
+						this.state = 127
 						this.match(SearchGrammarParser.NOT)
-						this.state = 108
+						this.state = 128
 						this.match(SearchGrammarParser.EXISTS)
 					}
 					break
@@ -830,17 +905,105 @@ export default class SearchGrammarParser extends Parser {
 		return localctx
 	}
 	// @RuleVersion(0)
+	public in_op(): In_opContext {
+		let localctx: In_opContext = new In_opContext(
+			this,
+			this._ctx,
+			this.state,
+		)
+		this.enterRule(localctx, 18, SearchGrammarParser.RULE_in_op)
+		try {
+			this.state = 134
+			this._errHandler.sync(this)
+			switch (this._input.LA(1)) {
+				case 5:
+					this.enterOuterAlt(localctx, 1)
+					{
+						this.state = 131
+						this.match(SearchGrammarParser.IN)
+					}
+					break
+				case 3:
+					this.enterOuterAlt(localctx, 2)
+					{
+						this.state = 132
+						this.match(SearchGrammarParser.NOT)
+						this.state = 133
+						this.match(SearchGrammarParser.IN)
+					}
+					break
+				default:
+					throw new NoViableAltException(this)
+			}
+		} catch (re) {
+			if (re instanceof RecognitionException) {
+				localctx.exception = re
+				this._errHandler.reportError(this, re)
+				this._errHandler.recover(this, re)
+			} else {
+				throw re
+			}
+		} finally {
+			this.exitRule()
+		}
+		return localctx
+	}
+	// @RuleVersion(0)
+	public between_op(): Between_opContext {
+		let localctx: Between_opContext = new Between_opContext(
+			this,
+			this._ctx,
+			this.state,
+		)
+		this.enterRule(localctx, 20, SearchGrammarParser.RULE_between_op)
+		try {
+			this.state = 139
+			this._errHandler.sync(this)
+			switch (this._input.LA(1)) {
+				case 6:
+					this.enterOuterAlt(localctx, 1)
+					{
+						this.state = 136
+						this.match(SearchGrammarParser.BETWEEN)
+					}
+					break
+				case 3:
+					this.enterOuterAlt(localctx, 2)
+					{
+						this.state = 137
+						this.match(SearchGrammarParser.NOT)
+						this.state = 138
+						this.match(SearchGrammarParser.BETWEEN)
+					}
+					break
+				default:
+					throw new NoViableAltException(this)
+			}
+		} catch (re) {
+			if (re instanceof RecognitionException) {
+				localctx.exception = re
+				this._errHandler.reportError(this, re)
+				this._errHandler.recover(this, re)
+			} else {
+				throw re
+			}
+		} finally {
+			this.exitRule()
+		}
+		return localctx
+	}
+	// @RuleVersion(0)
 	public negation_op(): Negation_opContext {
 		let localctx: Negation_opContext = new Negation_opContext(
 			this,
 			this._ctx,
 			this.state,
 		)
-		this.enterRule(localctx, 18, SearchGrammarParser.RULE_negation_op)
+		this.enterRule(localctx, 22, SearchGrammarParser.RULE_negation_op)
 		try {
 			this.enterOuterAlt(localctx, 1)
 			{
-				this.state = 111
+				this.state = 141
 				this.match(SearchGrammarParser.NOT)
 			}
 		} catch (re) {
@@ -863,51 +1026,51 @@ export default class SearchGrammarParser extends Parser {
 			this._ctx,
 			this.state,
 		)
-		this.enterRule(localctx, 20, SearchGrammarParser.RULE_bin_op)
+		this.enterRule(localctx, 24, SearchGrammarParser.RULE_bin_op)
 		let _la: number
 		try {
 			let _alt: number
 			this.enterOuterAlt(localctx, 1)
 			{
-				this.state = 116
+				this.state = 146
 				this._errHandler.sync(this)
 				_la = this._input.LA(1)
-				while (_la === 18) {
+				while (_la === 22) {
 					{
 						{
-							this.state = 113
+							this.state = 143
 							this.match(SearchGrammarParser.WS)
 						}
 					}
-					this.state = 118
+					this.state = 148
 					this._errHandler.sync(this)
 					_la = this._input.LA(1)
 				}
-				this.state = 119
+				this.state = 149
 				_la = this._input.LA(1)
-				if (!((_la & ~0x1f) === 0 && ((1 << _la) & 20448) !== 0)) {
+				if (!((_la & ~0x1f) === 0 && ((1 << _la) & 327552) !== 0)) {
 					this._errHandler.recoverInline(this)
 				} else {
 					this._errHandler.reportMatch(this)
 					this.consume()
 				}
-				this.state = 123
+				this.state = 153
 				this._errHandler.sync(this)
-				_alt = this._interp.adaptivePredict(this._input, 11, this._ctx)
+				_alt = this._interp.adaptivePredict(this._input, 14, this._ctx)
 				while (_alt !== 2 && _alt !== ATN.INVALID_ALT_NUMBER) {
 					if (_alt === 1) {
 						{
 							{
-								this.state = 120
+								this.state = 150
 								this.match(SearchGrammarParser.WS)
 							}
 						}
 					}
-					this.state = 125
+					this.state = 155
 					this._errHandler.sync(this)
 					_alt = this._interp.adaptivePredict(
 						this._input,
-						11,
+						14,
 						this._ctx,
 					)
 				}
@@ -932,14 +1095,14 @@ export default class SearchGrammarParser extends Parser {
 			this._ctx,
 			this.state,
 		)
-		this.enterRule(localctx, 22, SearchGrammarParser.RULE_search_value)
+		this.enterRule(localctx, 26, SearchGrammarParser.RULE_search_value)
 		let _la: number
 		try {
 			this.enterOuterAlt(localctx, 1)
 			{
-				this.state = 126
+				this.state = 156
 				_la = this._input.LA(1)
-				if (!((_la & ~0x1f) === 0 && ((1 << _la) & 229376) !== 0)) {
+				if (!((_la & ~0x1f) === 0 && ((1 << _la) & 3670112) !== 0)) {
 					this._errHandler.recoverInline(this)
 				} else {
 					this._errHandler.reportMatch(this)
@@ -997,69 +1160,83 @@ export default class SearchGrammarParser extends Parser {
 	): boolean {
 		switch (predIndex) {
 			case 2:
-				return this.precpred(this._ctx, 6)
+				return this.precpred(this._ctx, 8)
 			case 3:
-				return this.precpred(this._ctx, 5)
+				return this.precpred(this._ctx, 7)
 			case 4:
-				return this.precpred(this._ctx, 4)
+				return this.precpred(this._ctx, 6)
 		}
 		return true
 	}
 
 	public static readonly _serializedATN: number[] = [
-		4, 1, 19, 129, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 1, 23, 159, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
 		4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2, 10, 7,
-		10, 2, 11, 7, 11, 1, 0, 1, 0, 1, 0, 1, 0, 3, 0, 29, 8, 0, 1, 1, 1, 1, 1,
-		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 39, 8, 1, 1, 2, 1, 2, 1, 2, 1, 2,
-		1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 3, 2, 50, 8, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1,
-		2, 1, 2, 5, 2, 58, 8, 2, 10, 2, 12, 2, 61, 9, 2, 1, 3, 1, 3, 1, 3, 1, 3,
-		1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 3, 3, 74, 8, 3, 1, 3, 1, 3, 1,
-		3, 1, 3, 3, 3, 80, 8, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3,
-		1, 3, 1, 3, 1, 3, 1, 3, 5, 3, 94, 8, 3, 10, 3, 12, 3, 97, 9, 3, 1, 4, 1,
-		4, 1, 5, 1, 5, 1, 6, 1, 6, 1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 3, 8, 110, 8,
-		8, 1, 9, 1, 9, 1, 10, 5, 10, 115, 8, 10, 10, 10, 12, 10, 118, 9, 10, 1,
-		10, 1, 10, 5, 10, 122, 8, 10, 10, 10, 12, 10, 125, 9, 10, 1, 11, 1, 11,
-		1, 11, 0, 2, 4, 6, 12, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 0, 2,
-		2, 0, 5, 11, 14, 14, 1, 0, 15, 17, 134, 0, 28, 1, 0, 0, 0, 2, 38, 1, 0,
-		0, 0, 4, 49, 1, 0, 0, 0, 6, 79, 1, 0, 0, 0, 8, 98, 1, 0, 0, 0, 10, 100,
-		1, 0, 0, 0, 12, 102, 1, 0, 0, 0, 14, 104, 1, 0, 0, 0, 16, 109, 1, 0, 0,
-		0, 18, 111, 1, 0, 0, 0, 20, 116, 1, 0, 0, 0, 22, 126, 1, 0, 0, 0, 24,
-		29, 5, 0, 0, 1, 25, 26, 3, 6, 3, 0, 26, 27, 5, 0, 0, 1, 27, 29, 1, 0, 0,
-		0, 28, 24, 1, 0, 0, 0, 28, 25, 1, 0, 0, 0, 29, 1, 1, 0, 0, 0, 30, 31, 5,
-		12, 0, 0, 31, 32, 3, 4, 2, 0, 32, 33, 5, 13, 0, 0, 33, 39, 1, 0, 0, 0,
-		34, 35, 3, 18, 9, 0, 35, 36, 3, 2, 1, 0, 36, 39, 1, 0, 0, 0, 37, 39, 3,
-		22, 11, 0, 38, 30, 1, 0, 0, 0, 38, 34, 1, 0, 0, 0, 38, 37, 1, 0, 0, 0,
-		39, 3, 1, 0, 0, 0, 40, 41, 6, 2, -1, 0, 41, 42, 5, 12, 0, 0, 42, 43, 3,
-		4, 2, 0, 43, 44, 5, 13, 0, 0, 44, 50, 1, 0, 0, 0, 45, 46, 3, 18, 9, 0,
-		46, 47, 3, 4, 2, 4, 47, 50, 1, 0, 0, 0, 48, 50, 3, 22, 11, 0, 49, 40, 1,
-		0, 0, 0, 49, 45, 1, 0, 0, 0, 49, 48, 1, 0, 0, 0, 50, 59, 1, 0, 0, 0, 51,
-		52, 10, 3, 0, 0, 52, 53, 5, 1, 0, 0, 53, 58, 3, 4, 2, 4, 54, 55, 10, 2,
-		0, 0, 55, 56, 5, 2, 0, 0, 56, 58, 3, 4, 2, 3, 57, 51, 1, 0, 0, 0, 57,
-		54, 1, 0, 0, 0, 58, 61, 1, 0, 0, 0, 59, 57, 1, 0, 0, 0, 59, 60, 1, 0, 0,
-		0, 60, 5, 1, 0, 0, 0, 61, 59, 1, 0, 0, 0, 62, 63, 6, 3, -1, 0, 63, 64,
-		5, 12, 0, 0, 64, 65, 3, 6, 3, 0, 65, 66, 5, 13, 0, 0, 66, 80, 1, 0, 0,
-		0, 67, 68, 3, 18, 9, 0, 68, 69, 3, 6, 3, 7, 69, 80, 1, 0, 0, 0, 70, 71,
-		3, 8, 4, 0, 71, 73, 3, 20, 10, 0, 72, 74, 3, 2, 1, 0, 73, 72, 1, 0, 0,
-		0, 73, 74, 1, 0, 0, 0, 74, 80, 1, 0, 0, 0, 75, 76, 3, 8, 4, 0, 76, 77,
-		3, 16, 8, 0, 77, 80, 1, 0, 0, 0, 78, 80, 3, 2, 1, 0, 79, 62, 1, 0, 0, 0,
-		79, 67, 1, 0, 0, 0, 79, 70, 1, 0, 0, 0, 79, 75, 1, 0, 0, 0, 79, 78, 1,
-		0, 0, 0, 80, 95, 1, 0, 0, 0, 81, 82, 10, 6, 0, 0, 82, 83, 3, 10, 5, 0,
-		83, 84, 3, 6, 3, 7, 84, 94, 1, 0, 0, 0, 85, 86, 10, 5, 0, 0, 86, 87, 3,
-		14, 7, 0, 87, 88, 3, 6, 3, 6, 88, 94, 1, 0, 0, 0, 89, 90, 10, 4, 0, 0,
-		90, 91, 3, 12, 6, 0, 91, 92, 3, 6, 3, 5, 92, 94, 1, 0, 0, 0, 93, 81, 1,
-		0, 0, 0, 93, 85, 1, 0, 0, 0, 93, 89, 1, 0, 0, 0, 94, 97, 1, 0, 0, 0, 95,
-		93, 1, 0, 0, 0, 95, 96, 1, 0, 0, 0, 96, 7, 1, 0, 0, 0, 97, 95, 1, 0, 0,
-		0, 98, 99, 5, 15, 0, 0, 99, 9, 1, 0, 0, 0, 100, 101, 5, 1, 0, 0, 101,
-		11, 1, 0, 0, 0, 102, 103, 1, 0, 0, 0, 103, 13, 1, 0, 0, 0, 104, 105, 5,
-		2, 0, 0, 105, 15, 1, 0, 0, 0, 106, 110, 5, 4, 0, 0, 107, 108, 5, 3, 0,
-		0, 108, 110, 5, 4, 0, 0, 109, 106, 1, 0, 0, 0, 109, 107, 1, 0, 0, 0,
-		110, 17, 1, 0, 0, 0, 111, 112, 5, 3, 0, 0, 112, 19, 1, 0, 0, 0, 113,
-		115, 5, 18, 0, 0, 114, 113, 1, 0, 0, 0, 115, 118, 1, 0, 0, 0, 116, 114,
-		1, 0, 0, 0, 116, 117, 1, 0, 0, 0, 117, 119, 1, 0, 0, 0, 118, 116, 1, 0,
-		0, 0, 119, 123, 7, 0, 0, 0, 120, 122, 5, 18, 0, 0, 121, 120, 1, 0, 0, 0,
-		122, 125, 1, 0, 0, 0, 123, 121, 1, 0, 0, 0, 123, 124, 1, 0, 0, 0, 124,
-		21, 1, 0, 0, 0, 125, 123, 1, 0, 0, 0, 126, 127, 7, 1, 0, 0, 127, 23, 1,
-		0, 0, 0, 12, 28, 38, 49, 57, 59, 73, 79, 93, 95, 109, 116, 123,
+		10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 1, 0, 1, 0, 1, 0, 1, 0, 3,
+		0, 33, 8, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 43,
+		8, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 3, 2, 54, 8,
+		2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 5, 2, 62, 8, 2, 10, 2, 12, 2, 65,
+		9, 2, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3,
+		1, 3, 4, 3, 79, 8, 3, 11, 3, 12, 3, 80, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1,
+		3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 3, 3, 94, 8, 3, 1, 3, 1, 3, 1, 3, 1, 3,
+		3, 3, 100, 8, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3,
+		1, 3, 1, 3, 1, 3, 5, 3, 114, 8, 3, 10, 3, 12, 3, 117, 9, 3, 1, 4, 1, 4,
+		1, 5, 1, 5, 1, 6, 1, 6, 1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 3, 8, 130, 8, 8,
+		1, 9, 1, 9, 1, 9, 3, 9, 135, 8, 9, 1, 10, 1, 10, 1, 10, 3, 10, 140, 8,
+		10, 1, 11, 1, 11, 1, 12, 5, 12, 145, 8, 12, 10, 12, 12, 12, 148, 9, 12,
+		1, 12, 1, 12, 5, 12, 152, 8, 12, 10, 12, 12, 12, 155, 9, 12, 1, 13, 1,
+		13, 1, 13, 0, 2, 4, 6, 14, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22,
+		24, 26, 0, 2, 2, 0, 7, 15, 18, 18, 2, 0, 5, 6, 19, 21, 167, 0, 32, 1, 0,
+		0, 0, 2, 42, 1, 0, 0, 0, 4, 53, 1, 0, 0, 0, 6, 99, 1, 0, 0, 0, 8, 118,
+		1, 0, 0, 0, 10, 120, 1, 0, 0, 0, 12, 122, 1, 0, 0, 0, 14, 124, 1, 0, 0,
+		0, 16, 129, 1, 0, 0, 0, 18, 134, 1, 0, 0, 0, 20, 139, 1, 0, 0, 0, 22,
+		141, 1, 0, 0, 0, 24, 146, 1, 0, 0, 0, 26, 156, 1, 0, 0, 0, 28, 33, 5, 0,
+		0, 1, 29, 30, 3, 6, 3, 0, 30, 31, 5, 0, 0, 1, 31, 33, 1, 0, 0, 0, 32,
+		28, 1, 0, 0, 0, 32, 29, 1, 0, 0, 0, 33, 1, 1, 0, 0, 0, 34, 35, 5, 16, 0,
+		0, 35, 36, 3, 4, 2, 0, 36, 37, 5, 17, 0, 0, 37, 43, 1, 0, 0, 0, 38, 39,
+		3, 22, 11, 0, 39, 40, 3, 2, 1, 0, 40, 43, 1, 0, 0, 0, 41, 43, 3, 26, 13,
+		0, 42, 34, 1, 0, 0, 0, 42, 38, 1, 0, 0, 0, 42, 41, 1, 0, 0, 0, 43, 3, 1,
+		0, 0, 0, 44, 45, 6, 2, -1, 0, 45, 46, 5, 16, 0, 0, 46, 47, 3, 4, 2, 0,
+		47, 48, 5, 17, 0, 0, 48, 54, 1, 0, 0, 0, 49, 50, 3, 22, 11, 0, 50, 51,
+		3, 4, 2, 4, 51, 54, 1, 0, 0, 0, 52, 54, 3, 26, 13, 0, 53, 44, 1, 0, 0,
+		0, 53, 49, 1, 0, 0, 0, 53, 52, 1, 0, 0, 0, 54, 63, 1, 0, 0, 0, 55, 56,
+		10, 3, 0, 0, 56, 57, 5, 1, 0, 0, 57, 62, 3, 4, 2, 4, 58, 59, 10, 2, 0,
+		0, 59, 60, 5, 2, 0, 0, 60, 62, 3, 4, 2, 3, 61, 55, 1, 0, 0, 0, 61, 58,
+		1, 0, 0, 0, 62, 65, 1, 0, 0, 0, 63, 61, 1, 0, 0, 0, 63, 64, 1, 0, 0, 0,
+		64, 5, 1, 0, 0, 0, 65, 63, 1, 0, 0, 0, 66, 67, 6, 3, -1, 0, 67, 68, 5,
+		16, 0, 0, 68, 69, 3, 6, 3, 0, 69, 70, 5, 17, 0, 0, 70, 100, 1, 0, 0, 0,
+		71, 72, 3, 22, 11, 0, 72, 73, 3, 6, 3, 9, 73, 100, 1, 0, 0, 0, 74, 75,
+		3, 8, 4, 0, 75, 76, 3, 18, 9, 0, 76, 78, 5, 16, 0, 0, 77, 79, 3, 26, 13,
+		0, 78, 77, 1, 0, 0, 0, 79, 80, 1, 0, 0, 0, 80, 78, 1, 0, 0, 0, 80, 81,
+		1, 0, 0, 0, 81, 82, 1, 0, 0, 0, 82, 83, 5, 17, 0, 0, 83, 100, 1, 0, 0,
+		0, 84, 85, 3, 8, 4, 0, 85, 86, 3, 20, 10, 0, 86, 87, 3, 26, 13, 0, 87,
+		88, 5, 1, 0, 0, 88, 89, 3, 26, 13, 0, 89, 100, 1, 0, 0, 0, 90, 91, 3, 8,
+		4, 0, 91, 93, 3, 24, 12, 0, 92, 94, 3, 2, 1, 0, 93, 92, 1, 0, 0, 0, 93,
+		94, 1, 0, 0, 0, 94, 100, 1, 0, 0, 0, 95, 96, 3, 8, 4, 0, 96, 97, 3, 16,
+		8, 0, 97, 100, 1, 0, 0, 0, 98, 100, 3, 2, 1, 0, 99, 66, 1, 0, 0, 0, 99,
+		71, 1, 0, 0, 0, 99, 74, 1, 0, 0, 0, 99, 84, 1, 0, 0, 0, 99, 90, 1, 0, 0,
+		0, 99, 95, 1, 0, 0, 0, 99, 98, 1, 0, 0, 0, 100, 115, 1, 0, 0, 0, 101,
+		102, 10, 8, 0, 0, 102, 103, 3, 10, 5, 0, 103, 104, 3, 6, 3, 9, 104, 114,
+		1, 0, 0, 0, 105, 106, 10, 7, 0, 0, 106, 107, 3, 14, 7, 0, 107, 108, 3,
+		6, 3, 8, 108, 114, 1, 0, 0, 0, 109, 110, 10, 6, 0, 0, 110, 111, 3, 12,
+		6, 0, 111, 112, 3, 6, 3, 7, 112, 114, 1, 0, 0, 0, 113, 101, 1, 0, 0, 0,
+		113, 105, 1, 0, 0, 0, 113, 109, 1, 0, 0, 0, 114, 117, 1, 0, 0, 0, 115,
+		113, 1, 0, 0, 0, 115, 116, 1, 0, 0, 0, 116, 7, 1, 0, 0, 0, 117, 115, 1,
+		0, 0, 0, 118, 119, 5, 19, 0, 0, 119, 9, 1, 0, 0, 0, 120, 121, 5, 1, 0,
+		0, 121, 11, 1, 0, 0, 0, 122, 123, 1, 0, 0, 0, 123, 13, 1, 0, 0, 0, 124,
+		125, 5, 2, 0, 0, 125, 15, 1, 0, 0, 0, 126, 130, 5, 4, 0, 0, 127, 128, 5,
+		3, 0, 0, 128, 130, 5, 4, 0, 0, 129, 126, 1, 0, 0, 0, 129, 127, 1, 0, 0,
+		0, 130, 17, 1, 0, 0, 0, 131, 135, 5, 5, 0, 0, 132, 133, 5, 3, 0, 0, 133,
+		135, 5, 5, 0, 0, 134, 131, 1, 0, 0, 0, 134, 132, 1, 0, 0, 0, 135, 19, 1,
+		0, 0, 0, 136, 140, 5, 6, 0, 0, 137, 138, 5, 3, 0, 0, 138, 140, 5, 6, 0,
+		0, 139, 136, 1, 0, 0, 0, 139, 137, 1, 0, 0, 0, 140, 21, 1, 0, 0, 0, 141,
+		142, 5, 3, 0, 0, 142, 23, 1, 0, 0, 0, 143, 145, 5, 22, 0, 0, 144, 143,
+		1, 0, 0, 0, 145, 148, 1, 0, 0, 0, 146, 144, 1, 0, 0, 0, 146, 147, 1, 0,
+		0, 0, 147, 149, 1, 0, 0, 0, 148, 146, 1, 0, 0, 0, 149, 153, 7, 0, 0, 0,
+		150, 152, 5, 22, 0, 0, 151, 150, 1, 0, 0, 0, 152, 155, 1, 0, 0, 0, 153,
+		151, 1, 0, 0, 0, 153, 154, 1, 0, 0, 0, 154, 25, 1, 0, 0, 0, 155, 153, 1,
+		0, 0, 0, 156, 157, 7, 1, 0, 0, 157, 27, 1, 0, 0, 0, 15, 32, 42, 53, 61,
+		63, 80, 93, 99, 113, 115, 129, 134, 139, 146, 153,
 	]
 
 	private static __ATN: ATN
@@ -1438,6 +1615,48 @@ export class And_search_exprContext extends Search_exprContext {
 		}
 	}
 }
+export class In_search_exprContext extends Search_exprContext {
+	constructor(parser: SearchGrammarParser, ctx: Search_exprContext) {
+		super(parser, ctx.parentCtx, ctx.invokingState)
+		super.copyFrom(ctx)
+	}
+	public search_key(): Search_keyContext {
+		return this.getTypedRuleContext(
+			Search_keyContext,
+			0,
+		) as Search_keyContext
+	}
+	public in_op(): In_opContext {
+		return this.getTypedRuleContext(In_opContext, 0) as In_opContext
+	}
+	public LPAREN(): TerminalNode {
+		return this.getToken(SearchGrammarParser.LPAREN, 0)
+	}
+	public RPAREN(): TerminalNode {
+		return this.getToken(SearchGrammarParser.RPAREN, 0)
+	}
+	public search_value_list(): Search_valueContext[] {
+		return this.getTypedRuleContexts(
+			Search_valueContext,
+		) as Search_valueContext[]
+	}
+	public search_value(i: number): Search_valueContext {
+		return this.getTypedRuleContext(
+			Search_valueContext,
+			i,
+		) as Search_valueContext
+	}
+	public enterRule(listener: SearchGrammarListener): void {
+		if (listener.enterIn_search_expr) {
+			listener.enterIn_search_expr(this)
+		}
+	}
+	public exitRule(listener: SearchGrammarListener): void {
+		if (listener.exitIn_search_expr) {
+			listener.exitIn_search_expr(this)
+		}
+	}
+}
 export class Or_search_exprContext extends Search_exprContext {
 	constructor(parser: SearchGrammarParser, ctx: Search_exprContext) {
 		super(parser, ctx.parentCtx, ctx.invokingState)
@@ -1526,6 +1745,48 @@ export class Exists_search_exprContext extends Search_exprContext {
 		}
 	}
 }
+export class Between_search_exprContext extends Search_exprContext {
+	constructor(parser: SearchGrammarParser, ctx: Search_exprContext) {
+		super(parser, ctx.parentCtx, ctx.invokingState)
+		super.copyFrom(ctx)
+	}
+	public search_key(): Search_keyContext {
+		return this.getTypedRuleContext(
+			Search_keyContext,
+			0,
+		) as Search_keyContext
+	}
+	public between_op(): Between_opContext {
+		return this.getTypedRuleContext(
+			Between_opContext,
+			0,
+		) as Between_opContext
+	}
+	public search_value_list(): Search_valueContext[] {
+		return this.getTypedRuleContexts(
+			Search_valueContext,
+		) as Search_valueContext[]
+	}
+	public search_value(i: number): Search_valueContext {
+		return this.getTypedRuleContext(
+			Search_valueContext,
+			i,
+		) as Search_valueContext
+	}
+	public AND(): TerminalNode {
+		return this.getToken(SearchGrammarParser.AND, 0)
+	}
+	public enterRule(listener: SearchGrammarListener): void {
+		if (listener.enterBetween_search_expr) {
+			listener.enterBetween_search_expr(this)
+		}
+	}
+	public exitRule(listener: SearchGrammarListener): void {
+		if (listener.exitBetween_search_expr) {
+			listener.exitBetween_search_expr(this)
+		}
+	}
+}
 export class Key_val_search_exprContext extends Search_exprContext {
 	constructor(parser: SearchGrammarParser, ctx: Search_exprContext) {
 		super(parser, ctx.parentCtx, ctx.invokingState)
@@ -1721,6 +1982,66 @@ export class Exists_opContext extends ParserRuleContext {
 	}
 }
 
+export class In_opContext extends ParserRuleContext {
+	constructor(
+		parser?: SearchGrammarParser,
+		parent?: ParserRuleContext,
+		invokingState?: number,
+	) {
+		super(parent, invokingState)
+		this.parser = parser
+	}
+	public IN(): TerminalNode {
+		return this.getToken(SearchGrammarParser.IN, 0)
+	}
+	public NOT(): TerminalNode {
+		return this.getToken(SearchGrammarParser.NOT, 0)
+	}
+	public get ruleIndex(): number {
+		return SearchGrammarParser.RULE_in_op
+	}
+	public enterRule(listener: SearchGrammarListener): void {
+		if (listener.enterIn_op) {
+			listener.enterIn_op(this)
+		}
+	}
+	public exitRule(listener: SearchGrammarListener): void {
+		if (listener.exitIn_op) {
+			listener.exitIn_op(this)
+		}
+	}
+}
+
+export class Between_opContext extends ParserRuleContext {
+	constructor(
+		parser?: SearchGrammarParser,
+		parent?: ParserRuleContext,
+		invokingState?: number,
+	) {
+		super(parent, invokingState)
+		this.parser = parser
+	}
+	public BETWEEN(): TerminalNode {
+		return this.getToken(SearchGrammarParser.BETWEEN, 0)
+	}
+	public NOT(): TerminalNode {
+		return this.getToken(SearchGrammarParser.NOT, 0)
+	}
+	public get ruleIndex(): number {
+		return SearchGrammarParser.RULE_between_op
+	}
+	public enterRule(listener: SearchGrammarListener): void {
+		if (listener.enterBetween_op) {
+			listener.enterBetween_op(this)
+		}
+	}
+	public exitRule(listener: SearchGrammarListener): void {
+		if (listener.exitBetween_op) {
+			listener.exitBetween_op(this)
+		}
+	}
+}
+
 export class Negation_opContext extends ParserRuleContext {
 	constructor(
 		parser?: SearchGrammarParser,
@@ -1766,6 +2087,12 @@ export class Bin_opContext extends ParserRuleContext {
 	public NEQ(): TerminalNode {
 		return this.getToken(SearchGrammarParser.NEQ, 0)
 	}
+	public REGEX_MATCH(): TerminalNode {
+		return this.getToken(SearchGrammarParser.REGEX_MATCH, 0)
+	}
+	public NOT_REGEX_MATCH(): TerminalNode {
+		return this.getToken(SearchGrammarParser.NOT_REGEX_MATCH, 0)
+	}
 	public GT(): TerminalNode {
 		return this.getToken(SearchGrammarParser.GT, 0)
 	}
@@ -1820,6 +2147,12 @@ export class Search_valueContext extends ParserRuleContext {
 	public VALUE(): TerminalNode {
 		return this.getToken(SearchGrammarParser.VALUE, 0)
 	}
+	public IN(): TerminalNode {
+		return this.getToken(SearchGrammarParser.IN, 0)
+	}
+	public BETWEEN(): TerminalNode {
+		return this.getToken(SearchGrammarParser.BETWEEN, 0)
+	}
 	public get ruleIndex(): number {
 		return SearchGrammarParser.RULE_search_value
 	}
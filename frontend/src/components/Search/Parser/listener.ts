@@ -3,10 +3,14 @@ import { ErrorListener, RecognitionException, Recognizer, Token } from 'antlr4'
 import SearchGrammarListener from '@/components/Search/Parser/antlr/SearchGrammarListener'
 import {
 	And_opContext,
+	Between_opContext,
+	Between_search_exprContext,
 	Bin_opContext,
 	Body_search_exprContext,
 	Exists_opContext,
 	Exists_search_exprContext,
+	In_opContext,
+	In_search_exprContext,
 	Key_val_search_exprContext,
 	Or_opContext,
 	Search_keyContext,
@@ -85,6 +89,20 @@ export class SearchListener extends SearchGrammarListener {
 		this.currentExpression = { start, stop, text } as SearchExpression
 	}
 
+	enterIn_search_expr = (ctx: In_search_exprContext) => {
+		const start = ctx.start.start
+		const stop = ctx.stop ? ctx.stop.stop : ctx.start.stop
+		const text = this.queryString.substring(start, stop + 1)
+		this.currentExpression = { start, stop, text } as SearchExpression
+	}
+
+	enterBetween_search_expr = (ctx: Between_search_exprContext) => {
+		const start = ctx.start.start
+		const stop = ctx.stop ? ctx.stop.stop : ctx.start.stop
+		const text = this.queryString.substring(start, stop + 1)
+		this.currentExpression = { start, stop, text } as SearchExpression
+	}
+
 	enterBody_search_expr = (ctx: Body_search_exprContext) => {
 		const start = ctx.start.start
 		const stop = ctx.stop ? ctx.stop.stop : ctx.start.stop
@@ -106,6 +124,14 @@ export class SearchListener extends SearchGrammarListener {
 		this.currentExpression.value = ''
 	}
 
+	enterIn_op = (ctx: In_opContext) => {
+		this.currentExpression.operator = this.operatorText(ctx)
+	}
+
+	enterBetween_op = (ctx: Between_opContext) => {
+		this.currentExpression.operator = this.operatorText(ctx)
+	}
+
 	enterBin_op = (ctx: Bin_opContext) => {
 		this.currentExpression.operator = ctx.getText() as SearchOperator
 	}
@@ -156,11 +182,47 @@ export class SearchListener extends SearchGrammarListener {
 		this.currentExpression = { ...DEFAULT_EXPRESSION }
 	}
 
+	exitIn_search_expr = (ctx: In_search_exprContext) => {
+		// the parenthesized list of values, e.g. `(api, worker)`
+		const start = ctx.LPAREN().symbol.start
+		const stop = ctx.RPAREN().symbol.stop
+		this.currentExpression.value = this.queryString.substring(
+			start,
+			stop + 1,
+		)
+		this.expressions.push(this.currentExpression)
+		this.currentExpression = { ...DEFAULT_EXPRESSION }
+	}
+
+	exitBetween_search_expr = (ctx: Between_search_exprContext) => {
+		// the range of values, e.g. `100ms AND 2s`
+		const values = ctx.search_value_list()
+		const start = values[0].start.start
+		const last = values[values.length - 1]
+		const stop = last.stop ? last.stop.stop : last.start.stop
+		this.currentExpression.value = this.queryString.substring(
+			start,
+			stop + 1,
+		)
+		this.expressions.push(this.currentExpression)
+		this.currentExpression = { ...DEFAULT_EXPRESSION }
+	}
+
 	exitBody_search_expr = (_ctx: Body_search_exprContext) => {
 		this.currentExpression.value = this.currentExpression.text
 		this.expressions.push(this.currentExpression)
 		this.currentExpression = { ...DEFAULT_EXPRESSION }
 	}
+
+	// Multi-word operators like `NOT IN` are normalized to a single space.
+	private operatorText = (ctx: In_opContext | Between_opContext) => {
+		const start = ctx.start.start
+		const stop = ctx.stop ? ctx.stop.stop : ctx.start.stop
+		return this.queryString
+			.substring(start, stop + 1)
+			.toUpperCase()
+			.replace(/\s+/g, ' ') as SearchOperator
+	}
 }
 
 export type SearchError = {
@@ -81,12 +81,16 @@ const EQUAL_OPERATOR = ['='] as const
 const BOOLEAN_OPERATORS = ['=', '!='] as const
 const CONTAINS_OPERATOR = ['="**"', '!="**"'] as const
 const MATCHES_OPERATOR = ['="//"', '!="//"'] as const
+const REGEX_OPERATORS = ['=~', '!~'] as const
+const LIST_OPERATORS = ['IN', 'NOT IN', 'BETWEEN', 'NOT BETWEEN'] as const
 export const SEARCH_OPERATORS = [
 	...BOOLEAN_OPERATORS,
 	...NUMERIC_OPERATORS,
 	...EXISTS_OPERATORS,
 	...CONTAINS_OPERATOR,
 	...MATCHES_OPERATOR,
+	...REGEX_OPERATORS,
+	...LIST_OPERATORS,
 ] as const
 export type SearchOperator = (typeof SEARCH_OPERATORS)[number]
 
@@ -1257,6 +1261,18 @@ const getSearchResultBadgeText = (key: SearchResult) => {
 				return 'matches'
 			case '!="//"':
 				return 'does not match'
+			case '=~':
+				return 'matches regex'
+			case '!~':
+				return 'does not match regex'
+			case 'IN':
+				return 'is one of'
+			case 'NOT IN':
+				return 'is not one of'
+			case 'BETWEEN':
+				return 'between'
+			case 'NOT BETWEEN':
+				return 'not between'
 		}
 	} else if (key.type === 'Value') {
 		return undefined
@@ -1,3 +1,4 @@
+import { BODY_KEY } from '@/components/Search/SearchForm/utils'
 import { parseSearch } from '@/components/Search/utils'
 
 describe('parseSearch', () => {
@@ -47,6 +48,55 @@ describe('parseSearch', () => {
 		])
 	})
 
+	it('parses IN and BETWEEN expressions', () => {
+		const queryString =
+			'service_name NOT IN (api, worker) duration BETWEEN 100ms AND 2s'
+		const { queryParts } = parseSearch(queryString)
+
+		expect(queryParts).toEqual([
+			{
+				key: 'service_name',
+				operator: 'NOT IN',
+				value: '(api, worker)',
+				text: 'service_name NOT IN (api, worker)',
+				start: 0,
+				stop: 32,
+			},
+			{
+				key: 'duration',
+				operator: 'BETWEEN',
+				value: '100ms AND 2s',
+				text: 'duration BETWEEN 100ms AND 2s',
+				start: 34,
+				stop: 62,
+			},
+		])
+	})
+
+	it('keeps commas in free text', () => {
+		const queryString = 'hello, world'
+		const { queryParts } = parseSearch(queryString)
+
+		expect(queryParts).toEqual([
+			{
+				key: BODY_KEY,
+				operator: '=',
+				value: 'hello,',
+				text: 'hello,',
+				start: 0,
+				stop: 5,
+			},
+			{
+				key: BODY_KEY,
+				operator: '=',
+				value: 'world',
+				text: 'world',
+				start: 7,
+				stop: 11,
+			},
+		])
+	})
+
 	it('parses a query using the same key multiple times', () => {
 		const queryString =
 			'span_name=gorm.Query span_name=KafkaWorkerDoingWork'
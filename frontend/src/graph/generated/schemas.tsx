@@ -1125,6 +1125,7 @@ export type MetricBucket = {
 	group: Array<Scalars['String']>
 	metric_type: MetricAggregator
 	metric_value?: Maybe<Scalars['Float']>
+	yhat?: Maybe<Scalars['Float']>
 	yhat_lower?: Maybe<Scalars['Float']>
 	yhat_upper?: Maybe<Scalars['Float']>
 }
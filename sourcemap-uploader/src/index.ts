@@ -10,6 +10,10 @@ program
   .command("upload")
   .requiredOption("-k, --apiKey <string>", "The Highlight api key")
   .option("-av, --appVersion [string]", "The current version of your deploy")
+  .option(
+    "-cs, --commitSha [string]",
+    "An optional commit sha recorded with the release of the version",
+  )
   .option(
     "-p, --path [string]",
     "Sets the directory of where the sourcemaps are",
@@ -16,9 +16,18 @@ const GET_SOURCE_MAP_URLS_QUERY = `
   }
 `;
 
+const CREATE_RELEASE_MUTATION = `
+  mutation CreateRelease($api_key: String!, $version: String!, $commit_sha: String) {
+    createRelease(api_key: $api_key, version: $version, commit_sha: $commit_sha) {
+      id
+    }
+  }
+`;
+
 export const uploadSourcemaps = async ({
   apiKey,
   appVersion,
+  commitSha,
   path,
   basePath,
   backendUrl,
@@ -26,6 +35,7 @@ export const uploadSourcemaps = async ({
 }: {
   apiKey: string;
   appVersion: string;
+  commitSha?: string;
   path: string;
   basePath?: string;
   backendUrl?: string;
@@ -129,8 +139,45 @@ export const uploadSourcemaps = async ({
       uploadFile(path, uploadUrls[idx], name),
     ),
   );
+
+  if (appVersion) {
+    await createRelease(backend, apiKey, appVersion, commitSha);
+  }
 };
 
+// records the deploy of the version so its errors and sessions are reported as a release
+async function createRelease(
+  backend: string,
+  apiKey: string,
+  version: string,
+  commitSha?: string,
+) {
+  const releaseRes = await fetch(backend, {
+    method: "post",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    body: JSON.stringify({
+      query: CREATE_RELEASE_MUTATION,
+      variables: {
+        api_key: apiKey,
+        version,
+        commit_sha: commitSha,
+      },
+    }),
+  })
+    .then((response) => response.json())
+    .catch((e) => {
+      console.log(e);
+    });
+
+  if (!releaseRes || !releaseRes.data || !releaseRes.data.createRelease) {
+    console.error("Error: Unable to create release.", releaseRes);
+    return;
+  }
+  console.log(`[Highlight] Created release ${version}`);
+}
+
 const NextRouteGroupPattern = new RegExp(/(\(.+?\))\//gm);
 
 async function getAllSourceMapFiles(